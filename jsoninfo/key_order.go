@@ -0,0 +1,182 @@
+package jsoninfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// KeyOrder records the order in which the keys of a JSON object appeared,
+// so that encoding can reproduce it.
+type KeyOrder struct {
+	// Keys lists the keys of the object itself.
+	Keys []string
+
+	// Fields lists, for map-valued struct fields, the keys of the map.
+	Fields map[string][]string
+}
+
+// ObjectKeys returns the keys of the JSON object in data in the order they appear.
+// Duplicate keys are reported once.
+func ObjectKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+	var keys []string
+	seen := make(map[string]struct{})
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON object key, got %v", tok)
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// ReorderObject re-encodes the JSON object in data so that the keys listed
+// in order come first, in that order. Other keys follow, sorted.
+func ReorderObject(data []byte, order []string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return data, nil
+	}
+	return marshalOrdered(fields, order)
+}
+
+func marshalOrdered(fields map[string]json.RawMessage, order []string) ([]byte, error) {
+	if len(order) == 0 {
+		return json.Marshal(fields)
+	}
+	keys := make([]string, 0, len(fields))
+	written := make(map[string]struct{}, len(fields))
+	for _, k := range order {
+		if _, ok := fields[k]; !ok {
+			continue
+		}
+		if _, ok := written[k]; ok {
+			continue
+		}
+		written[k] = struct{}{}
+		keys = append(keys, k)
+	}
+	rest := make([]string, 0, len(fields)-len(keys))
+	for k := range fields {
+		if _, ok := written[k]; !ok {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyData, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		valueData, err := json.Marshal(fields[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueData)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// DecodeKeyOrder returns the key order of the decoded object and of its map-valued fields.
+// It must be called before DecodeStructFieldsAndExtensions consumes the fields.
+func (decoder *ObjectDecoder) DecodeKeyOrder(value interface{}) (*KeyOrder, error) {
+	keys, err := ObjectKeys(decoder.Data)
+	if err != nil {
+		return nil, err
+	}
+	return keyOrderOf(keys, decoder.remainingFields, value), nil
+}
+
+// KeyOrderOf returns the key order of the JSON object in data and of the fields
+// of that object that are maps in value, a pointer to the struct data decodes into.
+func KeyOrderOf(data []byte, value interface{}) (*KeyOrder, error) {
+	keys, err := ObjectKeys(data)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return keyOrderOf(keys, fields, value), nil
+}
+
+func keyOrderOf(keys []string, fields map[string]json.RawMessage, value interface{}) *KeyOrder {
+	order := &KeyOrder{Keys: keys}
+
+	reflectionType := reflect.TypeOf(value)
+	for reflectionType.Kind() == reflect.Ptr {
+		reflectionType = reflectionType.Elem()
+	}
+	if reflectionType.Kind() != reflect.Struct {
+		return order
+	}
+	for _, field := range GetTypeInfo(reflectionType).Fields {
+		if !field.HasJSONTag {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Map {
+			continue
+		}
+		fieldData, ok := fields[field.JSONName]
+		if !ok {
+			continue
+		}
+		fieldKeys, err := ObjectKeys(fieldData)
+		if err != nil || len(fieldKeys) < 2 {
+			// null or unexpected JSON: decoding the field reports errors
+			continue
+		}
+		if order.Fields == nil {
+			order.Fields = make(map[string][]string)
+		}
+		order.Fields[field.JSONName] = fieldKeys
+	}
+	return order
+}
+
+// EncodeKeyOrder makes Bytes() write keys in the given order.
+// Keys absent from the order are written afterwards, sorted.
+func (encoder *ObjectEncoder) EncodeKeyOrder(order *KeyOrder) error {
+	encoder.order = order
+	return nil
+}
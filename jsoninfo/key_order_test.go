@@ -0,0 +1,78 @@
+package jsoninfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKeys(t *testing.T) {
+	keys, err := ObjectKeys([]byte(`{"z": 1, "a": {"y": 2, "b": 3}, "m": [1, 2], "a": null}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"z", "a", "m"}, keys)
+
+	_, err = ObjectKeys([]byte(`[1, 2]`))
+	require.Error(t, err)
+}
+
+func TestReorderObject(t *testing.T) {
+	data, err := ReorderObject([]byte(`{"a": 1, "c": 3, "b": {"y": 2, "x": 1}, "d": 4}`), []string{"c", "missing", "b"})
+	require.NoError(t, err)
+	require.Equal(t, `{"c":3,"b":{"y":2,"x":1},"a":1,"d":4}`, string(data))
+
+	data, err = ReorderObject([]byte(`{"b": 2, "a": 1}`), nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1,"b":2}`, string(data))
+}
+
+type orderedStruct struct {
+	order  *KeyOrder
+	Name   string            `json:"name,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (value *orderedStruct) EncodeWith(encoder *ObjectEncoder, v interface{}) error {
+	if err := encoder.EncodeKeyOrder(value.order); err != nil {
+		return err
+	}
+	return encoder.EncodeStructFieldsAndExtensions(v)
+}
+
+func (value *orderedStruct) DecodeWith(decoder *ObjectDecoder, v interface{}) error {
+	order, err := decoder.DecodeKeyOrder(v)
+	if err != nil {
+		return err
+	}
+	value.order = order
+	return decoder.DecodeStructFieldsAndExtensions(v)
+}
+
+func TestKeyOrderRoundTrip(t *testing.T) {
+	data := []byte(`{"labels":{"zeta":"1","alpha":"2","mu":"3"},"name":"x"}`)
+
+	var value orderedStruct
+	err := UnmarshalStrictStruct(data, &value)
+	require.NoError(t, err)
+	require.Equal(t, []string{"labels", "name"}, value.order.Keys)
+	require.Equal(t, []string{"zeta", "alpha", "mu"}, value.order.Fields["labels"])
+
+	got, err := MarshalStrictStruct(&value)
+	require.NoError(t, err)
+	require.Equal(t, string(data), string(got))
+
+	// New keys go after the known ones, sorted
+	value.Labels["beta"] = "4"
+	got, err = MarshalStrictStruct(&value)
+	require.NoError(t, err)
+	require.Equal(t, `{"labels":{"zeta":"1","alpha":"2","mu":"3","beta":"4"},"name":"x"}`, string(got))
+}
+
+func TestKeyOrderOf(t *testing.T) {
+	order, err := KeyOrderOf([]byte(`{"name":"x","labels":{"zeta":"1","alpha":"2"}}`), &orderedStruct{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"name", "labels"}, order.Keys)
+	require.Equal(t, map[string][]string{"labels": {"zeta", "alpha"}}, order.Fields)
+
+	_, err = KeyOrderOf([]byte(`"x"`), &orderedStruct{})
+	require.Error(t, err)
+}
@@ -19,6 +19,7 @@ func MarshalStrictStruct(value StrictStruct) ([]byte, error) {
 
 type ObjectEncoder struct {
 	result map[string]json.RawMessage
+	order  *KeyOrder
 }
 
 func NewObjectEncoder() *ObjectEncoder {
@@ -29,7 +30,23 @@ func NewObjectEncoder() *ObjectEncoder {
 
 // Bytes returns the result of encoding.
 func (encoder *ObjectEncoder) Bytes() ([]byte, error) {
-	return json.Marshal(encoder.result)
+	order := encoder.order
+	if order == nil {
+		return json.Marshal(encoder.result)
+	}
+	for name, keys := range order.Fields {
+		data, ok := encoder.result[name]
+		if !ok {
+			continue
+		}
+		reordered, err := ReorderObject(data, keys)
+		if err != nil {
+			// Not an object (e.g. the field was changed to null): keep as is
+			continue
+		}
+		encoder.result[name] = reordered
+	}
+	return marshalOrdered(encoder.result, order.Keys)
 }
 
 // EncodeExtension adds a key/value to the current JSON object.
@@ -93,6 +110,9 @@ iteration:
 				result[field.JSONName] = []byte("null")
 				continue
 			}
+			if z, ok := v.(interface{ IsZero() bool }); ok && field.JSONOmitEmpty && z.IsZero() {
+				continue iteration
+			}
 			fieldData, err := v.MarshalJSON()
 			if err != nil {
 				return err
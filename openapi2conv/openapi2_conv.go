@@ -479,8 +479,8 @@ func ToV3SchemaRef(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
 	for k, v := range schema.Value.Properties {
 		schema.Value.Properties[k] = ToV3SchemaRef(v)
 	}
-	if v := schema.Value.AdditionalProperties; v != nil {
-		schema.Value.AdditionalProperties = ToV3SchemaRef(v)
+	if v := schema.Value.AdditionalProperties.Schema; v != nil {
+		schema.Value.AdditionalProperties.Schema = ToV3SchemaRef(v)
 	}
 	for i, v := range schema.Value.AllOf {
 		schema.Value.AllOf[i] = ToV3SchemaRef(v)
@@ -828,8 +828,8 @@ func FromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components
 	for _, key := range keys {
 		schema.Value.Properties[key], _ = FromV3SchemaRef(schema.Value.Properties[key], components)
 	}
-	if v := schema.Value.AdditionalProperties; v != nil {
-		schema.Value.AdditionalProperties, _ = FromV3SchemaRef(v, components)
+	if v := schema.Value.AdditionalProperties.Schema; v != nil {
+		schema.Value.AdditionalProperties.Schema, _ = FromV3SchemaRef(v, components)
 	}
 	for i, v := range schema.Value.AllOf {
 		schema.Value.AllOf[i], _ = FromV3SchemaRef(v, components)
@@ -392,6 +392,35 @@ const exampleV2 = `
 			"default_security_1": []
 		}
 	],
+	"securityDefinitions": {
+		"default_security_0": {
+			"type": "oauth2",
+			"flow": "accessCode",
+			"authorizationUrl": "https://example.com/oauth/authorize",
+			"tokenUrl": "https://example.com/oauth/token",
+			"scopes": {
+				"scope0": "Scope 0",
+				"scope1": "Scope 1"
+			}
+		},
+		"default_security_1": {
+			"type": "apiKey",
+			"in": "header",
+			"name": "X-Api-Key"
+		},
+		"get_security_0": {
+			"type": "oauth2",
+			"flow": "application",
+			"tokenUrl": "https://example.com/oauth/token",
+			"scopes": {
+				"scope0": "Scope 0",
+				"scope1": "Scope 1"
+			}
+		},
+		"get_security_1": {
+			"type": "basic"
+		}
+	},
 	"swagger": "2.0",
 	"tags": [
 		{
@@ -407,6 +436,42 @@ const exampleV2 = `
 const exampleV3 = `
 {
 	"components": {
+		"securitySchemes": {
+			"default_security_0": {
+				"type": "oauth2",
+				"flows": {
+					"authorizationCode": {
+						"authorizationUrl": "https://example.com/oauth/authorize",
+						"tokenUrl": "https://example.com/oauth/token",
+						"scopes": {
+							"scope0": "Scope 0",
+							"scope1": "Scope 1"
+						}
+					}
+				}
+			},
+			"default_security_1": {
+				"type": "apiKey",
+				"in": "header",
+				"name": "X-Api-Key"
+			},
+			"get_security_0": {
+				"type": "oauth2",
+				"flows": {
+					"clientCredentials": {
+						"tokenUrl": "https://example.com/oauth/token",
+						"scopes": {
+							"scope0": "Scope 0",
+							"scope1": "Scope 1"
+						}
+					}
+				}
+			},
+			"get_security_1": {
+				"type": "http",
+				"scheme": "basic"
+			}
+		},
 		"parameters": {
 			"banana": {
 				"in": "path",
@@ -0,0 +1,188 @@
+package openapi3
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BatchValidator loads and validates the documents of a file system concurrently,
+// e.g. all the specifications of a repository.
+type BatchValidator struct {
+	// Concurrency is the number of documents loaded and validated at once,
+	// runtime.GOMAXPROCS(0) if not positive.
+	Concurrency int
+
+	// NewLoader returns the loader of each document, a loader with IsExternalRefsAllowed set if nil.
+	// The files the documents refer to are read from the file system validated, and the other
+	// references with the ReadFromURIFunc of the loader, ReadFromHTTP(http.DefaultClient) if nil,
+	// which must be safe for concurrent use. What it reads is cached across the documents.
+	NewLoader func() *Loader
+
+	// Match tells whether the file at path, in the file system validated, is a document to validate
+	// rather than a part of others. By default the files with a .json, .yaml or .yml extension
+	// and an openapi field are.
+	Match func(path string, data []byte) bool
+
+	// ValidationOptions are the options documents are validated with.
+	ValidationOptions []ValidationOption
+}
+
+// BatchResult is the outcome of loading and validating a document with a BatchValidator.
+type BatchResult struct {
+	// Path is the path of the document in the file system validated.
+	Path string
+	// Doc is the document loaded, nil if it failed to load.
+	Doc *T
+	// Err is the error loading or validating the document, nil if it is valid.
+	Err error
+}
+
+// ValidateDir loads and validates the documents of the directory tree rooted at dir,
+// see ValidateFS.
+func (v *BatchValidator) ValidateDir(ctx context.Context, dir string) ([]BatchResult, error) {
+	return v.ValidateFS(ctx, os.DirFS(dir))
+}
+
+// ValidateFS loads and validates the documents of fsys, returning their results sorted by path.
+// The returned error is that of walking fsys: the errors of the documents are in their results.
+// The documents not processed yet when ctx is done have its error as theirs.
+func (v *BatchValidator) ValidateFS(ctx context.Context, fsys fs.FS) ([]BatchResult, error) {
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	concurrency := v.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	cache := &batchCache{entries: make(map[string]*batchCacheEntry)}
+	results := make([]*BatchResult, len(paths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = v.validate(ctx, fsys, cache, paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	batch := make([]BatchResult, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			batch = append(batch, *result)
+		}
+	}
+	return batch, nil
+}
+
+// validate returns the result of the document at p in fsys, nil if the file is not a document.
+func (v *BatchValidator) validate(ctx context.Context, fsys fs.FS, cache *batchCache, p string) *BatchResult {
+	result := &BatchResult{Path: p}
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	match := v.Match
+	if match == nil {
+		match = isBatchDocument
+	}
+	if !match(p, data) {
+		return nil
+	}
+	if result.Err = ctx.Err(); result.Err != nil {
+		return result
+	}
+
+	var loader *Loader
+	if v.NewLoader != nil {
+		loader = v.NewLoader()
+	} else {
+		loader = NewLoader()
+		loader.IsExternalRefsAllowed = true
+	}
+	loader.ReadFromURIFunc = cache.reader(fsys, loader.ReadFromURIFunc)
+
+	if result.Doc, result.Err = loader.LoadFromDataWithPath(data, &url.URL{Path: p}); result.Err != nil {
+		return result
+	}
+	result.Err = result.Doc.Validate(ctx, v.ValidationOptions...)
+	return result
+}
+
+// isBatchDocument is the default BatchValidator.Match.
+func isBatchDocument(p string, data []byte) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".json", ".yaml", ".yml":
+	default:
+		return false
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+	}
+	return NewLoader().unmarshal(data, &doc) == nil && doc.OpenAPI != ""
+}
+
+// batchCache caches the contents read by the loaders of a BatchValidator, by URI.
+type batchCache struct {
+	mu      sync.Mutex
+	entries map[string]*batchCacheEntry
+}
+
+type batchCacheEntry struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// reader returns a ReadFromURIFunc reading local files from fsys, and other URIs
+// with read the first time they are read.
+func (cache *batchCache) reader(fsys fs.FS, read ReadFromURIFunc) ReadFromURIFunc {
+	if read == nil {
+		read = ReadFromHTTP(http.DefaultClient)
+	}
+	return func(loader *Loader, location *url.URL) ([]byte, error) {
+		if location.Host == "" && (location.Scheme == "" || location.Scheme == "file") {
+			return fs.ReadFile(fsys, strings.TrimPrefix(path.Clean(location.Path), "/"))
+		}
+		uri := location.String()
+		cache.mu.Lock()
+		entry, ok := cache.entries[uri]
+		if !ok {
+			entry = &batchCacheEntry{}
+			cache.entries[uri] = entry
+		}
+		cache.mu.Unlock()
+		entry.once.Do(func() {
+			entry.data, entry.err = read(loader, location)
+		})
+		return entry.data, entry.err
+	}
+}
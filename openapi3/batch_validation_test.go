@@ -0,0 +1,95 @@
+package openapi3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchValidator(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`
+Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`))
+	}))
+	defer ts.Close()
+
+	spec := func(title, schemaRef string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(`
+openapi: 3.0.0
+info:
+  title: ` + title + `
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: "` + schemaRef + `"
+`)}
+	}
+	fsys := fstest.MapFS{
+		"pets/openapi.yaml":   spec("pets", "../common/schemas.yaml#/Pet"),
+		"stores/openapi.yml":  spec("stores", ts.URL+"/schemas.yaml#/Pet"),
+		"owners/openapi.json": spec("owners", ts.URL+"/schemas.yaml#/Pet"),
+		"toys/openapi.yaml":   spec("", "../common/schemas.yaml#/Pet"),
+		"food/openapi.yaml":   spec("food", "../common/schemas.yaml#/Food"),
+		"common/schemas.yaml": {Data: []byte(`
+Pet:
+  type: object
+`)},
+		"README.md": {Data: []byte(`# openapi: 3.0.0`)},
+	}
+
+	v := &BatchValidator{Concurrency: 2}
+	results, err := v.ValidateFS(context.Background(), fsys)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		paths = append(paths, result.Path)
+	}
+	require.Equal(t, []string{
+		"food/openapi.yaml",
+		"owners/openapi.json",
+		"pets/openapi.yaml",
+		"stores/openapi.yml",
+		"toys/openapi.yaml",
+	}, paths)
+
+	require.Error(t, results[0].Err)
+	require.Nil(t, results[0].Doc)
+	require.NoError(t, results[1].Err)
+	require.Equal(t, "object", results[1].Doc.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
+	require.NoError(t, results[2].Err)
+	require.NoError(t, results[3].Err)
+	require.EqualError(t, results[4].Err, "invalid info: value of title must be a non-empty string")
+	require.NotNil(t, results[4].Doc)
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = v.ValidateFS(ctx, fsys)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	for _, result := range results {
+		require.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
@@ -2,8 +2,10 @@ package openapi3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 )
@@ -25,6 +27,42 @@ func (c Callbacks) JSONLookup(token string) (interface{}, error) {
 	return ref.Value, nil
 }
 
+// Validate returns an error if Callbacks does not comply with the OpenAPI spec.
+// Each callback is validated once, as callbacks may refer to themselves through
+// the operations of their path items.
+func (callbacks Callbacks) Validate(ctx context.Context, opts ...ValidationOption) error {
+	ctx = WithValidationOptions(ctx, opts...)
+	vo := getValidationOptions(ctx)
+	if vo.validatedCallbacks == nil {
+		copied := *vo
+		copied.validatedCallbacks = make(map[*Callback]struct{})
+		ctx = context.WithValue(ctx, validationOptionsKey{}, &copied)
+		vo = &copied
+	}
+
+	names := make([]string, 0, len(callbacks))
+	for name := range callbacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := callbacks[name]
+		if ref == nil {
+			return fmt.Errorf("callback %q: value MUST be an object", name)
+		}
+		if v := ref.Value; v != nil {
+			if _, ok := vo.validatedCallbacks[v]; ok {
+				continue
+			}
+			vo.validatedCallbacks[v] = struct{}{}
+		}
+		if err := ref.Validate(ctx); err != nil {
+			return fmt.Errorf("callback %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // Callback is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#callbackObject
 type Callback map[string]*PathItem
@@ -39,10 +77,26 @@ func (callback Callback) Validate(ctx context.Context, opts ...ValidationOption)
 	}
 	sort.Strings(keys)
 	for _, key := range keys {
+		if err := validateCallbackExpression(key); err != nil {
+			return fmt.Errorf("invalid callback expression %q: %w", key, err)
+		}
+		wrap := func(e error) error { return fmt.Errorf("invalid path item of %q: %w", key, e) }
 		v := callback[key]
+		if v == nil {
+			return wrap(errors.New("value MUST be an object"))
+		}
 		if err := v.Validate(ctx); err != nil {
-			return err
+			return wrap(err)
 		}
 	}
 	return nil
 }
+
+// validateCallbackExpression returns an error if key, of a Callback, is neither a runtime expression
+// nor a URL embedding runtime expressions between braces.
+func validateCallbackExpression(key string) error {
+	if !strings.HasPrefix(key, "$") && !strings.Contains(key, "{$") {
+		return errors.New("must contain a runtime expression")
+	}
+	return validateRuntimeExpressions(key)
+}
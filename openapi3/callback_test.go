@@ -0,0 +1,113 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbacksResolution(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Subscriptions
+  version: 1.0.0
+paths:
+  /pets/subscriptions:
+    post:
+      responses:
+        "201":
+          description: subscribed
+      callbacks:
+        onPet:
+          "{$request.body#/url}":
+            post:
+              requestBody:
+                $ref: "#/components/requestBodies/Pet"
+              responses:
+                "200":
+                  description: received
+  /stores/subscriptions:
+    post:
+      responses:
+        "201":
+          description: subscribed
+      callbacks:
+        onStore:
+          "{$request.body#/url}":
+            post:
+              requestBody:
+                $ref: "#/components/requestBodies/Store"
+              responses:
+                "200":
+                  description: received
+              callbacks:
+                onAck:
+                  $ref: "#/components/callbacks/Ack"
+components:
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Pet"
+    Store:
+      content:
+        application/json:
+          schema:
+            type: object
+  schemas:
+    Pet:
+      type: object
+  callbacks:
+    Ack:
+      "$request.header.X-Ack-URL":
+        post:
+          requestBody:
+            $ref: "#/components/requestBodies/Pet"
+          responses:
+            "200":
+              description: acknowledged
+          callbacks:
+            onAck:
+              $ref: "#/components/callbacks/Ack"
+`[1:]
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	pets := (*doc.Paths["/pets/subscriptions"].Post.Callbacks["onPet"].Value)["{$request.body#/url}"]
+	require.Equal(t, "object", pets.Post.RequestBody.Value.Content["application/json"].Schema.Value.Type)
+	stores := (*doc.Paths["/stores/subscriptions"].Post.Callbacks["onStore"].Value)["{$request.body#/url}"]
+	require.Equal(t, "object", stores.Post.RequestBody.Value.Content["application/json"].Schema.Value.Type)
+	ack := (*stores.Post.Callbacks["onAck"].Value)["$request.header.X-Ack-URL"]
+	require.Equal(t, "object", ack.Post.RequestBody.Value.Content["application/json"].Schema.Value.Type)
+	require.Same(t, doc.Components.Callbacks["Ack"].Value, ack.Post.Callbacks["onAck"].Value)
+}
+
+func TestCallbackValidation(t *testing.T) {
+	response := &ResponseRef{Value: NewResponse().WithDescription("received")}
+	pathItem := &PathItem{Post: &Operation{Responses: Responses{"200": response}}}
+
+	for _, key := range []string{
+		"{$request.body#/url}",
+		"$request.query.callbackUrl",
+		"https://example.com/events?id={$request.body#/id}&status={$statusCode}",
+	} {
+		require.NoError(t, Callback{key: pathItem}.Validate(context.Background()), key)
+	}
+
+	err := Callback{"{$request.body.url}": pathItem}.Validate(context.Background())
+	require.EqualError(t, err, `invalid callback expression "{$request.body.url}": invalid runtime expression "$request.body.url": source must be header.<token>, query.<name>, path.<name>, body or body#<json-pointer>`)
+
+	err = Callback{"https://example.com/events": pathItem}.Validate(context.Background())
+	require.EqualError(t, err, `invalid callback expression "https://example.com/events": must contain a runtime expression`)
+
+	err = Callback{"{$request.body#/url}": nil}.Validate(context.Background())
+	require.EqualError(t, err, `invalid path item of "{$request.body#/url}": value MUST be an object`)
+
+	err = Callback{"{$request.body#/url}": &PathItem{Post: &Operation{}}}.Validate(context.Background())
+	require.EqualError(t, err, `invalid path item of "{$request.body#/url}": invalid operation POST: value of responses must be an object`)
+}
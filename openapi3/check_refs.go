@@ -0,0 +1,223 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BrokenRefError is a $ref that does not resolve, found by CheckRefs.
+type BrokenRefError struct {
+	// Location is the URL of the document with the $ref, empty for a document checked
+	// without a location.
+	Location string
+	// Pointer is the JSON pointer to the object with the $ref within its document.
+	Pointer string
+	Ref     string
+	Err     error
+}
+
+func (e *BrokenRefError) Error() string {
+	return fmt.Sprintf("%s#%s: broken ref %q: %v", e.Location, e.Pointer, e.Ref, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BrokenRefError) Unwrap() error {
+	return e.Err
+}
+
+// CheckRefs checks that every $ref of the document data, located at location which may be nil,
+// points to a value, without loading the document, and returns a MultiError
+// of a *BrokenRefError for each one that does not, or nil if they all resolve.
+// Unlike loading, it does not stop at the first broken reference.
+//
+// The references to other documents, along with theirs, are only checked when
+// IsExternalRefsAllowed is set, reading them with ReadFromURIFunc.
+// The values of examples and extensions are not checked, as they may contain
+// $ref fields that are not references.
+func (loader *Loader) CheckRefs(data []byte, location *url.URL) error {
+	checker := &refChecker{
+		loader:    loader,
+		documents: make(map[string]interface{}),
+		errs:      make(map[string]error),
+	}
+	var root interface{}
+	if err := loader.unmarshal(data, &root); err != nil {
+		return err
+	}
+	uri := ""
+	if location != nil {
+		uri = location.String()
+	}
+	checker.documents[uri] = root
+	checker.check(root, location, "", "")
+	if len(checker.broken) == 0 {
+		return nil
+	}
+	return checker.broken
+}
+
+type refChecker struct {
+	loader *Loader
+	// documents are the documents read, by URL
+	documents map[string]interface{}
+	// errs are the errors reading documents, by URL
+	errs   map[string]error
+	broken MultiError
+}
+
+// refCheckerNameMaps are the keys of the objects whose keys are names rather than fields.
+var refCheckerNameMaps = map[string]bool{
+	"callbacks":         true,
+	"content":           true,
+	"encoding":          true,
+	"headers":           true,
+	"links":             true,
+	"parameters":        true,
+	"paths":             true,
+	"patternProperties": true,
+	"properties":        true,
+	"requestBodies":     true,
+	"responses":         true,
+	"schemas":           true,
+	"securitySchemes":   true,
+	"variables":         true,
+	"webhooks":          true,
+}
+
+// check checks the references of value, found at pointer in the document at location
+// as the value of key.
+func (c *refChecker) check(value interface{}, location *url.URL, pointer, key string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			c.checkRef(ref, location, pointer)
+		}
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if refCheckerNameMaps[key] {
+				c.check(value[k], location, childPointer(pointer, k), k)
+				continue
+			}
+			switch {
+			case k == "example" || strings.HasPrefix(k, "x-"):
+			case k == "examples":
+				// Example objects, or the examples of a schema as of OpenAPI 3.1
+				examples, _ := value[k].(map[string]interface{})
+				for _, name := range sortedMapKeys(examples) {
+					example, _ := examples[name].(map[string]interface{})
+					if ref, ok := example["$ref"].(string); ok {
+						c.checkRef(ref, location, childPointer(pointer, k, name))
+					}
+				}
+			default:
+				c.check(value[k], location, childPointer(pointer, k), k)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			c.check(item, location, childPointer(pointer, strconv.Itoa(i)), "")
+		}
+	}
+}
+
+// checkRef records ref, found at pointer in the document at location, if it does not resolve.
+func (c *refChecker) checkRef(ref string, location *url.URL, pointer string) {
+	if err := c.resolve(ref, location); err != nil {
+		brokenRef := &BrokenRefError{Pointer: pointer, Ref: ref, Err: err}
+		if location != nil {
+			brokenRef.Location = location.String()
+		}
+		c.broken = append(c.broken, brokenRef)
+	}
+}
+
+// resolve returns why ref, found in the document at location, does not resolve.
+func (c *refChecker) resolve(ref string, location *url.URL) error {
+	documentRef, fragment := ref, ""
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		documentRef, fragment = ref[:i], ref[i+1:]
+	}
+
+	documentLocation := location
+	if documentRef != "" {
+		if !c.loader.IsExternalRefsAllowed {
+			return nil
+		}
+		parsed, err := url.Parse(documentRef)
+		if err != nil {
+			return err
+		}
+		if documentLocation, err = resolvePath(location, parsed); err != nil {
+			return err
+		}
+	}
+	document, err := c.document(documentLocation)
+	if err != nil {
+		return err
+	}
+
+	if fragment == "" {
+		return nil
+	}
+	if fragment[0] != '/' {
+		return fmt.Errorf("fragment %q is not a JSON pointer", fragment)
+	}
+	value := document
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = unescapeRefString(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[token]; !ok {
+				return fmt.Errorf("no %q in the document", token)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return fmt.Errorf("no %q in the document", token)
+			}
+			value = v[i]
+		default:
+			return fmt.Errorf("no %q in the document", token)
+		}
+	}
+	return nil
+}
+
+// document returns the document at location, reading it and checking its references
+// the first time.
+func (c *refChecker) document(location *url.URL) (interface{}, error) {
+	uri := ""
+	if location != nil {
+		uri = location.String()
+	}
+	if document, ok := c.documents[uri]; ok {
+		return document, nil
+	}
+	if err, ok := c.errs[uri]; ok {
+		return nil, err
+	}
+	if location == nil {
+		return nil, errors.New("no document location")
+	}
+	data, err := c.loader.readURL(location)
+	var document interface{}
+	if err == nil {
+		err = c.loader.unmarshal(data, &document)
+	}
+	if err != nil {
+		c.errs[uri] = err
+		return nil, err
+	}
+	c.documents[uri] = document
+	c.check(document, location, "", "")
+	return document, nil
+}
@@ -0,0 +1,94 @@
+package openapi3
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRefs(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: References
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - $ref: 'common.yaml#/parameters/offset'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+              examples:
+                pets: {$ref: '#/components/examples/pets'}
+                raw:
+                  value: {$ref: 'not a reference'}
+        default:
+          $ref: 'common.yaml#/responses/Error'
+components:
+  schemas:
+    Pet:
+      type: object
+      x-generator: {$ref: 'not a reference'}
+      example: {$ref: 'not a reference'}
+      properties:
+        example: {$ref: '#/components/schemas/Tag'}
+        owner: {$ref: 'people.yaml#/Owner'}
+  examples:
+    pets: {value: []}
+`)
+	files := map[string]string{
+		"/specs/common.yaml": `
+parameters:
+  offset: {name: offset, in: query, schema: {$ref: '#/schemas/Offset'}}
+responses: {}
+`,
+	}
+	loader := NewLoader()
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		if data, ok := files[location.String()]; ok {
+			return []byte(data), nil
+		}
+		return nil, fmt.Errorf("not found: %s", location)
+	}
+	location := &url.URL{Path: "/specs/openapi.yaml"}
+
+	// Only internal references
+	err := loader.CheckRefs(spec, location)
+	require.Error(t, err)
+	var broken []string
+	for _, e := range err.(MultiError) {
+		broken = append(broken, e.Error())
+	}
+	require.Equal(t, []string{
+		`/specs/openapi.yaml#/components/schemas/Pet/properties/example: broken ref "#/components/schemas/Tag": no "Tag" in the document`,
+		`/specs/openapi.yaml#/paths/~1pets/get/parameters/0: broken ref "#/components/parameters/limit": no "parameters" in the document`,
+		`/specs/openapi.yaml#/paths/~1pets/get/responses/200/content/application~1json/schema: broken ref "#/components/schemas/Pets": no "Pets" in the document`,
+	}, broken)
+
+	loader.IsExternalRefsAllowed = true
+	err = loader.CheckRefs(spec, location)
+	require.Error(t, err)
+	broken = nil
+	for _, e := range err.(MultiError) {
+		broken = append(broken, e.(*BrokenRefError).Location+"#"+e.(*BrokenRefError).Pointer+" "+e.(*BrokenRefError).Ref)
+	}
+	require.Equal(t, []string{
+		"/specs/openapi.yaml#/components/schemas/Pet/properties/example #/components/schemas/Tag",
+		"/specs/openapi.yaml#/components/schemas/Pet/properties/owner people.yaml#/Owner",
+		"/specs/openapi.yaml#/paths/~1pets/get/parameters/0 #/components/parameters/limit",
+		"/specs/common.yaml#/parameters/offset/schema #/schemas/Offset",
+		"/specs/openapi.yaml#/paths/~1pets/get/responses/200/content/application~1json/schema #/components/schemas/Pets",
+		"/specs/openapi.yaml#/paths/~1pets/get/responses/default common.yaml#/responses/Error",
+	}, broken)
+
+	require.NoError(t, NewLoader().CheckRefs([]byte(`{"openapi": "3.0.0", "paths": {}}`), nil))
+}
@@ -0,0 +1,300 @@
+package openapi3
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ComponentGraph is the graph of references between the components of a document.
+// Components are identified by their local reference, e.g. "#/components/schemas/Pet".
+type ComponentGraph struct {
+	components   map[string]struct{}
+	dependencies map[string]map[string]struct{}
+	dependents   map[string]map[string]struct{}
+	// components referenced from outside the components section
+	fromDocument map[string]struct{}
+}
+
+// NewComponentGraph builds the graph of references between the components of doc.
+//
+// A component depends on another when it contains a reference to it, directly or
+// through inline values. References are matched by their local $ref or, for
+// references to other files, by their resolved value being that of a component.
+// Security requirements depend on the security schemes they name.
+func NewComponentGraph(doc *T) *ComponentGraph {
+	g := &ComponentGraph{
+		components:   make(map[string]struct{}),
+		dependencies: make(map[string]map[string]struct{}),
+		dependents:   make(map[string]map[string]struct{}),
+		fromDocument: make(map[string]struct{}),
+	}
+
+	target := componentRefTarget(doc, g.components)
+
+	owner := ""
+	w := &walker{walked: make(map[interface{}]struct{})}
+	w.walkFunc = func(pointer string, value, parent interface{}) error {
+		if _, ok := value.(*Components); ok {
+			return nil
+		}
+
+		from := ""
+		if tokens := strings.SplitN(pointer, "/", 5); len(tokens) >= 4 && tokens[1] == "components" {
+			from = "#" + strings.Join(tokens[:4], "/")
+		}
+		if from != owner {
+			// Values shared by different components are walked for each of them
+			owner = from
+			w.walked = make(map[interface{}]struct{})
+		}
+
+		if requirement, ok := value.(SecurityRequirement); ok {
+			for name := range requirement {
+				g.addEdge(from, "#"+childPointer("/components/securitySchemes", name))
+			}
+			return nil
+		}
+		if to, ok := target(value); ok {
+			g.addEdge(from, to)
+			return ErrSkipChildren
+		}
+		return nil
+	}
+	// The walk function never fails
+	_ = w.walkT(doc)
+	return g
+}
+
+// componentRefTarget returns a function telling which component an XxxRef or a PathItem references.
+// References are matched by their local $ref or, for references to other files,
+// by their resolved value being that of a component. Path items referencing other files
+// are resolved into copies and so are not matched.
+// The references of the components of doc are added to components, if not nil.
+func componentRefTarget(doc *T, components map[string]struct{}) func(value interface{}) (string, bool) {
+	byValue := make(map[interface{}]string)
+	for _, kind := range []struct {
+		name   string
+		values map[string]interface{}
+	}{
+		{"schemas", componentValues(doc.Components.Schemas)},
+		{"parameters", componentValues(doc.Components.Parameters)},
+		{"headers", componentValues(doc.Components.Headers)},
+		{"requestBodies", componentValues(doc.Components.RequestBodies)},
+		{"responses", componentValues(doc.Components.Responses)},
+		{"securitySchemes", componentValues(doc.Components.SecuritySchemes)},
+		{"examples", componentValues(doc.Components.Examples)},
+		{"links", componentValues(doc.Components.Links)},
+		{"callbacks", componentValues(doc.Components.Callbacks)},
+		{"pathItems", componentValues(doc.Components.PathItems)},
+	} {
+		for name, value := range kind.values {
+			ref := "#" + childPointer("/components", kind.name, name)
+			if components != nil {
+				components[ref] = struct{}{}
+			}
+			if value != nil {
+				if _, ok := byValue[value]; !ok {
+					byValue[value] = ref
+				}
+			}
+		}
+	}
+	return func(value interface{}) (string, bool) {
+		ref, refValue, ok := refAndValue(value)
+		if pathItem, isPathItem := value.(*PathItem); isPathItem && pathItem != nil {
+			ref, ok = pathItem.Ref, true
+		}
+		if !ok || ref == "" {
+			return "", false
+		}
+		if strings.HasPrefix(ref, "#/components/") {
+			return ref, true
+		}
+		if refValue != nil {
+			if component, ok := byValue[refValue]; ok {
+				return component, true
+			}
+		}
+		return "", false
+	}
+}
+
+// componentValues returns the values of the XxxRef in a components map, by name,
+// which are nil for path items.
+func componentValues(m interface{}) map[string]interface{} {
+	mv := reflect.ValueOf(m)
+	values := make(map[string]interface{}, mv.Len())
+	for _, name := range sortedMapKeys(m) {
+		_, value, _ := refAndValue(mv.MapIndex(reflect.ValueOf(name).Convert(mv.Type().Key())).Interface())
+		values[name] = value
+	}
+	return values
+}
+
+// refAndValue returns the $ref and value of an XxxRef, or false if value is not one.
+func refAndValue(value interface{}) (string, interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || !isRefType(v.Type().Elem()) {
+		return "", nil, false
+	}
+	if v.IsNil() {
+		return "", nil, true
+	}
+	ref, refValue := v.Elem().Field(0).String(), v.Elem().Field(1)
+	if refValue.IsNil() {
+		return ref, nil, true
+	}
+	return ref, refValue.Interface(), true
+}
+
+func (g *ComponentGraph) addEdge(from, to string) {
+	// References to undefined components still appear in the graph
+	g.components[to] = struct{}{}
+	if from == "" {
+		g.fromDocument[to] = struct{}{}
+		return
+	}
+	if g.dependencies[from] == nil {
+		g.dependencies[from] = make(map[string]struct{})
+	}
+	g.dependencies[from][to] = struct{}{}
+	if g.dependents[to] == nil {
+		g.dependents[to] = make(map[string]struct{})
+	}
+	g.dependents[to][from] = struct{}{}
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Components returns the components of the graph, sorted.
+// This includes referenced components that are not defined.
+func (g *ComponentGraph) Components() []string {
+	return sortedSet(g.components)
+}
+
+// Dependencies returns the components that ref references directly, sorted.
+func (g *ComponentGraph) Dependencies(ref string) []string {
+	return sortedSet(g.dependencies[ref])
+}
+
+// Dependents returns the components that reference ref directly, sorted.
+func (g *ComponentGraph) Dependents(ref string) []string {
+	return sortedSet(g.dependents[ref])
+}
+
+// DocumentDependencies returns the components referenced directly from outside
+// the components section (paths, security requirements, ...), sorted.
+func (g *ComponentGraph) DocumentDependencies() []string {
+	return sortedSet(g.fromDocument)
+}
+
+// TransitiveDependencies returns the components that refs reference directly
+// or indirectly, sorted. refs are included only when part of a cycle.
+func (g *ComponentGraph) TransitiveDependencies(refs ...string) []string {
+	return sortedSet(g.closure(g.dependencies, refs))
+}
+
+// TransitiveDependents returns the components that reference refs directly
+// or indirectly, sorted. refs are included only when part of a cycle.
+func (g *ComponentGraph) TransitiveDependents(refs ...string) []string {
+	return sortedSet(g.closure(g.dependents, refs))
+}
+
+func (g *ComponentGraph) closure(edges map[string]map[string]struct{}, refs []string) map[string]struct{} {
+	reached := make(map[string]struct{})
+	stack := append([]string(nil), refs...)
+	for len(stack) != 0 {
+		ref := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for next := range edges[ref] {
+			if _, ok := reached[next]; !ok {
+				reached[next] = struct{}{}
+				stack = append(stack, next)
+			}
+		}
+	}
+	return reached
+}
+
+// Unreachable returns the components that are not used by the document,
+// directly or through other components, sorted.
+func (g *ComponentGraph) Unreachable() []string {
+	used := g.closure(g.dependencies, g.DocumentDependencies())
+	for ref := range g.fromDocument {
+		used[ref] = struct{}{}
+	}
+	unused := make(map[string]struct{})
+	for ref := range g.components {
+		if _, ok := used[ref]; !ok {
+			unused[ref] = struct{}{}
+		}
+	}
+	return sortedSet(unused)
+}
+
+// Cycles returns the strongly connected components of the graph that form cycles:
+// groups of components that all reference each other, directly or indirectly,
+// and components referencing themselves. Each cycle is sorted, as is the list.
+func (g *ComponentGraph) Cycles() [][]string {
+	// Tarjan's algorithm
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(ref string)
+	strongConnect = func(ref string) {
+		index[ref] = len(index)
+		lowLink[ref] = index[ref]
+		stack = append(stack, ref)
+		onStack[ref] = true
+
+		for _, next := range g.Dependencies(ref) {
+			if _, ok := index[next]; !ok {
+				strongConnect(next)
+				if lowLink[next] < lowLink[ref] {
+					lowLink[ref] = lowLink[next]
+				}
+			} else if onStack[next] && index[next] < lowLink[ref] {
+				lowLink[ref] = index[next]
+			}
+		}
+
+		if lowLink[ref] != index[ref] {
+			return
+		}
+		var scc []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			scc = append(scc, last)
+			if last == ref {
+				break
+			}
+		}
+		if _, self := g.dependencies[ref][ref]; len(scc) > 1 || self {
+			sort.Strings(scc)
+			cycles = append(cycles, scc)
+		}
+	}
+	for _, ref := range g.Components() {
+		if _, ok := index[ref]; !ok {
+			strongConnect(ref)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
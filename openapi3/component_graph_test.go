@@ -0,0 +1,169 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentGraph(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Graph
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-Key}
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        $ref: '#/components/schemas/Int'
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/ErrorResponse'
+  schemas:
+    Int: {type: integer}
+    ErrorResponse:
+      type: object
+      properties:
+        code:
+          $ref: '#/components/schemas/Int'
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Person'
+    Person:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+        friend:
+          $ref: '#/components/schemas/Person'
+    Unused:
+      type: object
+      properties:
+        error:
+          $ref: '#/components/schemas/ErrorResponse'
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	g := NewComponentGraph(doc)
+	require.Len(t, g.Components(), 8)
+	require.Equal(t, []string{"#/components/schemas/Int"}, g.Dependencies("#/components/schemas/ErrorResponse"))
+	require.Equal(t, []string{"#/components/responses/Error", "#/components/schemas/Unused"},
+		g.Dependents("#/components/schemas/ErrorResponse"))
+	require.Equal(t, []string{"#/components/parameters/Limit", "#/components/schemas/ErrorResponse"},
+		g.Dependents("#/components/schemas/Int"))
+	require.Equal(t, []string{
+		"#/components/parameters/Limit",
+		"#/components/responses/Error",
+		"#/components/schemas/Pet",
+		"#/components/securitySchemes/apiKey",
+	}, g.DocumentDependencies())
+	require.Equal(t, []string{"#/components/responses/Error", "#/components/schemas/Unused"},
+		g.TransitiveDependents("#/components/schemas/ErrorResponse"))
+	require.Equal(t, []string{"#/components/schemas/Int"},
+		g.TransitiveDependencies("#/components/parameters/Limit"))
+	require.Equal(t, []string{"#/components/schemas/Unused"}, g.Unreachable())
+	require.Equal(t, [][]string{
+		{"#/components/schemas/Person", "#/components/schemas/Pet"},
+	}, g.Cycles())
+
+	require.Contains(t, g.Dependencies("#/components/schemas/Person"), "#/components/schemas/Person")
+}
+
+func TestComponentGraphSelfReference(t *testing.T) {
+	doc := &T{
+		Components: Components{
+			Schemas: Schemas{
+				"Node": NewSchemaRef("", &Schema{
+					Properties: Schemas{"next": NewSchemaRef("#/components/schemas/Node", nil)},
+				}),
+				"Alias": NewSchemaRef("#/components/schemas/Missing", nil),
+			},
+		},
+	}
+	g := NewComponentGraph(doc)
+	require.Equal(t, []string{
+		"#/components/schemas/Alias",
+		"#/components/schemas/Missing",
+		"#/components/schemas/Node",
+	}, g.Components())
+	require.Equal(t, []string{"#/components/schemas/Missing"}, g.Dependencies("#/components/schemas/Alias"))
+	require.Equal(t, [][]string{{"#/components/schemas/Node"}}, g.Cycles())
+}
+
+func TestComponentGraphPathItems(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+info:
+  title: Graph
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Pets'
+components:
+  pathItems:
+    Pets:
+      get:
+        responses:
+          '200':
+            description: ok
+            content:
+              application/json:
+                schema:
+                  $ref: '#/components/schemas/Pet'
+    AllPets:
+      $ref: '#/components/pathItems/Pets'
+    Unused:
+      get:
+        responses:
+          '200':
+            description: ok
+  schemas:
+    Pet: {type: object}
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	g := NewComponentGraph(doc)
+	require.Len(t, g.Components(), 4)
+	require.Equal(t, []string{"#/components/schemas/Pet"}, g.Dependencies("#/components/pathItems/Pets"))
+	require.Equal(t, []string{"#/components/pathItems/Pets"}, g.Dependencies("#/components/pathItems/AllPets"))
+	require.Equal(t, []string{"#/components/pathItems/Pets"}, g.DocumentDependencies())
+	require.Equal(t, []string{"#/components/pathItems/AllPets", "#/components/pathItems/Unused"}, g.Unreachable())
+
+	require.Equal(t, []string{"/components/pathItems/AllPets", "/paths/~1pets"},
+		doc.FindReferences("#/components/pathItems/Pets"))
+}
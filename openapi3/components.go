@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -23,6 +24,7 @@ type Components struct {
 	Examples        Examples        `json:"examples,omitempty" yaml:"examples,omitempty"`
 	Links           Links           `json:"links,omitempty" yaml:"links,omitempty"`
 	Callbacks       Callbacks       `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
+	PathItems       PathItems       `json:"pathItems,omitempty" yaml:"pathItems,omitempty"` // OpenAPI 3.1
 }
 
 func NewComponents() Components {
@@ -42,6 +44,7 @@ func (components *Components) UnmarshalJSON(data []byte) error {
 // Validate returns an error if Components does not comply with the OpenAPI spec.
 func (components *Components) Validate(ctx context.Context, opts ...ValidationOption) (err error) {
 	ctx = WithValidationOptions(ctx, opts...)
+	errs := newValidationErrors(ctx)
 
 	schemas := make([]string, 0, len(components.Schemas))
 	for name := range components.Schemas {
@@ -50,11 +53,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(schemas)
 	for _, k := range schemas {
 		v := components.Schemas[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("schema %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("schema %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("schema %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -65,11 +69,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(parameters)
 	for _, k := range parameters {
 		v := components.Parameters[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("parameter %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("parameter %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("parameter %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -80,11 +85,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(requestBodies)
 	for _, k := range requestBodies {
 		v := components.RequestBodies[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("request body %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("request body %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("request body %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -95,11 +101,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(responses)
 	for _, k := range responses {
 		v := components.Responses[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("response %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("response %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("response %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -110,11 +117,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(headers)
 	for _, k := range headers {
 		v := components.Headers[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("header %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("header %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("header %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -125,11 +133,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(securitySchemes)
 	for _, k := range securitySchemes {
 		v := components.SecuritySchemes[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("security scheme %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("security scheme %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("security scheme %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -140,11 +149,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(examples)
 	for _, k := range examples {
 		v := components.Examples[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("example %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("example %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("example %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -155,11 +165,12 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(links)
 	for _, k := range links {
 		v := components.Links[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("link %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("link %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("link %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
@@ -170,15 +181,36 @@ func (components *Components) Validate(ctx context.Context, opts ...ValidationOp
 	sort.Strings(callbacks)
 	for _, k := range callbacks {
 		v := components.Callbacks[k]
-		if err = ValidateIdentifier(k); err != nil {
-			return fmt.Errorf("callback %q: %w", k, err)
+		wrap := func(e error) error { return fmt.Errorf("callback %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			err = v.Validate(ctx)
 		}
-		if err = v.Validate(ctx); err != nil {
-			return fmt.Errorf("callback %q: %w", k, err)
+		if err = errs.add(err, wrap); err != nil {
+			return
 		}
 	}
 
-	return
+	pathItems := make([]string, 0, len(components.PathItems))
+	for name := range components.PathItems {
+		pathItems = append(pathItems, name)
+	}
+	sort.Strings(pathItems)
+	for _, k := range pathItems {
+		v := components.PathItems[k]
+		wrap := func(e error) error { return fmt.Errorf("path item %q: %w", k, e) }
+		if err = ValidateIdentifier(k); err == nil {
+			if v == nil {
+				err = errors.New("value MUST be an object")
+			} else {
+				err = v.Validate(ctx)
+			}
+		}
+		if err = errs.add(err, wrap); err != nil {
+			return
+		}
+	}
+
+	return errs.err()
 }
 
 const identifierPattern = `^[a-zA-Z0-9._-]+$`
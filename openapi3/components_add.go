@@ -0,0 +1,147 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ErrComponentExists is returned by the Components.AddXxx methods when a component
+// of the same name already exists and the CollisionPolicy rejects it.
+var ErrComponentExists = errors.New("component already exists")
+
+// CollisionPolicy tells the Components.AddXxx methods how to handle a name already in use.
+type CollisionPolicy int
+
+const (
+	// CollisionReject fails with ErrComponentExists.
+	CollisionReject CollisionPolicy = iota
+	// CollisionReplace replaces the existing component.
+	CollisionReplace
+	// CollisionRename adds the component under the name suffixed by the first available number,
+	// starting at 2, e.g. "Pet2".
+	CollisionRename
+	// CollisionReuse keeps the existing component if it is equal to the added one,
+	// and fails with ErrComponentExists otherwise.
+	CollisionReuse
+)
+
+// AddSchema adds a schema to components.Schemas under name according to policy,
+// and returns the reference to it, e.g. "#/components/schemas/Pet".
+func (components *Components) AddSchema(name string, schema *Schema, policy CollisionPolicy) (string, error) {
+	if components.Schemas == nil {
+		components.Schemas = make(Schemas)
+	}
+	return addComponent("schemas", name, schema, policy,
+		func(name string) (interface{}, bool) {
+			if ref := components.Schemas[name]; ref != nil {
+				return ref.Value, true
+			}
+			return nil, false
+		},
+		func(name string) { components.Schemas[name] = &SchemaRef{Value: schema} })
+}
+
+// AddParameter adds a parameter to components.Parameters under name according to policy,
+// and returns the reference to it, e.g. "#/components/parameters/limit".
+func (components *Components) AddParameter(name string, parameter *Parameter, policy CollisionPolicy) (string, error) {
+	if components.Parameters == nil {
+		components.Parameters = make(ParametersMap)
+	}
+	return addComponent("parameters", name, parameter, policy,
+		func(name string) (interface{}, bool) {
+			if ref := components.Parameters[name]; ref != nil {
+				return ref.Value, true
+			}
+			return nil, false
+		},
+		func(name string) { components.Parameters[name] = &ParameterRef{Value: parameter} })
+}
+
+// AddResponse adds a response to components.Responses under name according to policy,
+// and returns the reference to it, e.g. "#/components/responses/NotFound".
+func (components *Components) AddResponse(name string, response *Response, policy CollisionPolicy) (string, error) {
+	if components.Responses == nil {
+		components.Responses = make(Responses)
+	}
+	return addComponent("responses", name, response, policy,
+		func(name string) (interface{}, bool) {
+			if ref := components.Responses[name]; ref != nil {
+				return ref.Value, true
+			}
+			return nil, false
+		},
+		func(name string) { components.Responses[name] = &ResponseRef{Value: response} })
+}
+
+// AddSecurityScheme adds a security scheme to components.SecuritySchemes under name according to policy,
+// and returns the reference to it, e.g. "#/components/securitySchemes/apiKey".
+// Security requirements refer to it by the name at the end of the reference.
+func (components *Components) AddSecurityScheme(name string, securityScheme *SecurityScheme, policy CollisionPolicy) (string, error) {
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = make(SecuritySchemes)
+	}
+	return addComponent("securitySchemes", name, securityScheme, policy,
+		func(name string) (interface{}, bool) {
+			if ref := components.SecuritySchemes[name]; ref != nil {
+				return ref.Value, true
+			}
+			return nil, false
+		},
+		func(name string) { components.SecuritySchemes[name] = &SecuritySchemeRef{Value: securityScheme} })
+}
+
+// addComponent adds value under name in the components of the given kind,
+// getting existing components with get and adding with set.
+func addComponent(kind, name string, value interface{}, policy CollisionPolicy,
+	get func(name string) (interface{}, bool), set func(name string)) (string, error) {
+	if err := ValidateIdentifier(name); err != nil {
+		return "", err
+	}
+	prefix := "#/components/" + kind + "/"
+	existing, ok := get(name)
+	if !ok {
+		set(name)
+		return prefix + name, nil
+	}
+
+	switch policy {
+	case CollisionReplace:
+		set(name)
+		return prefix + name, nil
+	case CollisionRename:
+		for i := 2; ; i++ {
+			renamed := name + strconv.Itoa(i)
+			if _, ok := get(renamed); !ok {
+				set(renamed)
+				return prefix + renamed, nil
+			}
+		}
+	case CollisionReuse:
+		if equalComponents(existing, value) {
+			return prefix + name, nil
+		}
+	}
+	return "", fmt.Errorf("%s%s: %w", prefix, name, ErrComponentExists)
+}
+
+func equalComponents(a, b interface{}) bool {
+	if a == b {
+		return true
+	}
+	dataA, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	dataB, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	var valueA, valueB interface{}
+	if json.Unmarshal(dataA, &valueA) != nil || json.Unmarshal(dataB, &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
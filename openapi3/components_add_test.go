@@ -0,0 +1,60 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentsAdd(t *testing.T) {
+	doc := &T{
+		OpenAPI: "3.0.0",
+		Info:    &Info{Title: "Pets", Version: "1.0.0"},
+		Paths:   Paths{},
+	}
+
+	ref, err := doc.Components.AddSchema("Pet", NewObjectSchema().WithProperty("name", NewStringSchema()), CollisionReject)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Pet", ref)
+
+	_, err = doc.Components.AddSchema("Pet", NewStringSchema(), CollisionReject)
+	require.True(t, errors.Is(err, ErrComponentExists))
+	require.EqualError(t, err, "#/components/schemas/Pet: component already exists")
+
+	ref, err = doc.Components.AddSchema("Pet", NewObjectSchema().WithProperty("name", NewStringSchema()), CollisionReuse)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Pet", ref)
+	_, err = doc.Components.AddSchema("Pet", NewStringSchema(), CollisionReuse)
+	require.True(t, errors.Is(err, ErrComponentExists))
+
+	ref, err = doc.Components.AddSchema("Pet", NewStringSchema(), CollisionRename)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Pet2", ref)
+	ref, err = doc.Components.AddSchema("Pet", NewIntegerSchema(), CollisionRename)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Pet3", ref)
+
+	ref, err = doc.Components.AddSchema("Pet2", NewBoolSchema(), CollisionReplace)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Pet2", ref)
+	require.Equal(t, "boolean", doc.Components.Schemas["Pet2"].Value.Type)
+
+	_, err = doc.Components.AddSchema("a pet", NewStringSchema(), CollisionReject)
+	require.Error(t, err)
+
+	ref, err = doc.Components.AddParameter("limit", NewQueryParameter("limit").WithSchema(NewIntegerSchema()), CollisionReject)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/parameters/limit", ref)
+
+	ref, err = doc.Components.AddResponse("NotFound", NewResponse().WithDescription("not found"), CollisionReject)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/responses/NotFound", ref)
+
+	ref, err = doc.Components.AddSecurityScheme("apiKey", &SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}, CollisionReject)
+	require.NoError(t, err)
+	require.Equal(t, "#/components/securitySchemes/apiKey", ref)
+
+	require.NoError(t, doc.Validate(context.Background()))
+}
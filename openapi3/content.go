@@ -2,6 +2,8 @@ package openapi3
 
 import (
 	"context"
+	"fmt"
+	"mime"
 	"sort"
 	"strings"
 )
@@ -62,47 +64,154 @@ func NewContentWithFormDataSchemaRef(schema *SchemaRef) Content {
 	}
 }
 
+// Get returns the media type of content that best matches the Content-Type mime,
+// or nil if none matches. See BestMatch.
 func (content Content) Get(mime string) *MediaType {
-	// If the mime is empty then short-circuit to the wildcard.
-	// We do this here so that we catch only the specific case of
-	// and empty mime rather than a present, but invalid, mime type.
+	if best, _ := content.BestMatch(mime); best != nil {
+		return best.MediaType
+	}
+	return nil
+}
+
+// MediaTypeRank is how specifically a media type range of a Content matches a Content-Type.
+type MediaTypeRank int
+
+const (
+	// RankAny is the rank of */*, which matches every Content-Type.
+	RankAny MediaTypeRank = iota + 1
+	// RankSubtypeWildcard is the rank of type/*, e.g. application/* for application/xml.
+	RankSubtypeWildcard
+	// RankSuffix is the rank of the type a structured syntax suffix stands for,
+	// e.g. application/json for application/problem+json.
+	RankSuffix
+	// RankExact is the rank of the same type and subtype, whatever their parameters.
+	RankExact
+)
+
+// MediaTypeMatch is a media type of a Content that matches a Content-Type.
+type MediaTypeMatch struct {
+	// Key is the media type range as it is declared in the Content.
+	Key       string
+	MediaType *MediaType
+	Rank      MediaTypeRank
+	// Parameters is the number of parameters of Key, all of which the Content-Type has
+	// with the same values. Among matches of the same rank, those with more parameters
+	// are more specific.
+	Parameters int
+}
+
+// Match returns the media types of content that match the Content-Type mime, the most
+// specific first: by rank, then number of parameters, then key.
+// Media type ranges with parameters only match Content-Types with the same parameters,
+// e.g. "application/json; charset=utf-8" does not match "application/json".
+// An empty mime only matches */*, and an invalid one nothing.
+func (content Content) Match(mime string) []MediaTypeMatch {
+	var matches []MediaTypeMatch
 	if mime == "" {
-		return content["*/*"]
-	}
-	// Start by making the most specific match possible
-	// by using the mime type in full.
-	if v := content[mime]; v != nil {
-		return v
-	}
-	// If an exact match is not found then we strip all
-	// metadata from the mime type and only use the x/y
-	// portion.
-	i := strings.IndexByte(mime, ';')
-	if i < 0 {
-		// If there is no metadata then preserve the full mime type
-		// string for later wildcard searches.
-		i = len(mime)
-	}
-	mime = mime[:i]
-	if v := content[mime]; v != nil {
-		return v
-	}
-	// If the x/y pattern has no specific match then we
-	// try the x/* pattern.
-	i = strings.IndexByte(mime, '/')
-	if i < 0 {
-		// In the case that the given mime type is not valid because it is
-		// missing the subtype we return nil so that this does not accidentally
-		// resolve with the wildcard.
+		if v := content["*/*"]; v != nil {
+			matches = append(matches, MediaTypeMatch{Key: "*/*", MediaType: v, Rank: RankAny})
+		}
+		return matches
+	}
+	typ, subtype, params, ok := parseContentType(mime)
+	if !ok {
 		return nil
 	}
-	mime = mime[:i] + "/*"
-	if v := content[mime]; v != nil {
-		return v
+	suffix := ""
+	if i := strings.LastIndexByte(subtype, '+'); i >= 0 {
+		suffix = subtype[i+1:]
+	}
+	for key, v := range content {
+		if v == nil {
+			continue
+		}
+		keyType, keySubtype, keyParams, ok := parseContentType(key)
+		if !ok {
+			continue
+		}
+		var rank MediaTypeRank
+		switch {
+		case keyType == "*" && keySubtype == "*":
+			rank = RankAny
+		case keyType != typ:
+			continue
+		case keySubtype == "*":
+			rank = RankSubtypeWildcard
+		case keySubtype == subtype:
+			rank = RankExact
+		case suffix != "" && keySubtype == suffix:
+			rank = RankSuffix
+		default:
+			continue
+		}
+		if !matchParameters(keyParams, params) {
+			continue
+		}
+		matches = append(matches, MediaTypeMatch{Key: key, MediaType: v, Rank: rank, Parameters: len(keyParams)})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if a.Parameters != b.Parameters {
+			return a.Parameters > b.Parameters
+		}
+		return a.Key < b.Key
+	})
+	return matches
+}
+
+// BestMatch returns the most specific media type of content that matches the Content-Type
+// mime, or nil if none matches, along with the other matches that are as specific,
+// e.g. both "application/json; charset=utf-8" and "application/json; version=2"
+// for "application/json; charset=utf-8; version=2". See Match.
+func (content Content) BestMatch(mime string) (best *MediaTypeMatch, ties []MediaTypeMatch) {
+	matches := content.Match(mime)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	best = &matches[0]
+	for _, match := range matches[1:] {
+		if match.Rank != best.Rank || match.Parameters != best.Parameters {
+			break
+		}
+		ties = append(ties, match)
 	}
-	// Finally, the most generic match of */* is returned
-	// as a catch-all.
-	return content["*/*"]
+	return best, ties
+}
+
+// parseContentType returns the lowercased type and subtype of a media type or range,
+// along with its parameters, none if they are invalid.
+func parseContentType(value string) (typ, subtype string, params map[string]string, ok bool) {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil && err != mime.ErrInvalidMediaParameter {
+		return
+	}
+	i := strings.IndexByte(mediaType, '/')
+	if i <= 0 || i == len(mediaType)-1 {
+		return
+	}
+	return mediaType[:i], mediaType[i+1:], params, true
+}
+
+// matchParameters returns whether params has every parameter of want, with the same value.
+func matchParameters(want, params map[string]string) bool {
+	for name, value := range want {
+		got, ok := params[name]
+		if !ok {
+			return false
+		}
+		if name == "charset" {
+			// Charsets are case-insensitive
+			if !strings.EqualFold(got, value) {
+				return false
+			}
+		} else if got != value {
+			return false
+		}
+	}
+	return true
 }
 
 // Validate returns an error if Content does not comply with the OpenAPI spec.
@@ -119,6 +228,9 @@ func (content Content) Validate(ctx context.Context, opts ...ValidationOption) e
 		if err := v.Validate(ctx); err != nil {
 			return err
 		}
+		if err := v.validateEncodings(ctx, k); err != nil {
+			return fmt.Errorf("media type %q: %w", k, err)
+		}
 	}
 	return nil
 }
@@ -111,3 +111,82 @@ func TestContent_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_Match(t *testing.T) {
+	content := Content{
+		"*/*":                             NewMediaType(),
+		"application/*":                   NewMediaType(),
+		"application/json":                NewMediaType(),
+		"application/json; charset=utf-8": NewMediaType(),
+		"application/json; version=2":     NewMediaType(),
+		"text/plain":                      NewMediaType(),
+	}
+	keys := func(matches []MediaTypeMatch) []string {
+		var keys []string
+		for _, match := range matches {
+			keys = append(keys, match.Key)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		mime string
+		want []string
+		ties []string
+	}{
+		{
+			mime: "application/json",
+			want: []string{"application/json", "application/*", "*/*"},
+		},
+		{
+			mime: "application/json;charset=UTF-8",
+			want: []string{"application/json; charset=utf-8", "application/json", "application/*", "*/*"},
+		},
+		{
+			mime: "application/json; charset=utf-8; version=2",
+			want: []string{"application/json; charset=utf-8", "application/json; version=2", "application/json", "application/*", "*/*"},
+			ties: []string{"application/json; version=2"},
+		},
+		{
+			mime: "application/problem+json",
+			want: []string{"application/json", "application/*", "*/*"},
+		},
+		{
+			mime: "Text/Plain",
+			want: []string{"text/plain", "*/*"},
+		},
+		{
+			mime: "image/png",
+			want: []string{"*/*"},
+		},
+		{
+			mime: "",
+			want: []string{"*/*"},
+		},
+		{
+			mime: "text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mime, func(t *testing.T) {
+			matches := content.Match(tt.mime)
+			require.Equal(t, tt.want, keys(matches))
+
+			best, ties := content.BestMatch(tt.mime)
+			if len(tt.want) == 0 {
+				require.Nil(t, best)
+				require.Empty(t, ties)
+				return
+			}
+			require.Equal(t, tt.want[0], best.Key)
+			require.True(t, content[tt.want[0]] == best.MediaType)
+			require.Equal(t, tt.ties, keys(ties))
+		})
+	}
+
+	best, _ := content.BestMatch("application/problem+json")
+	require.Equal(t, RankSuffix, best.Rank)
+	best, _ = content.BestMatch("application/json; charset=utf-8")
+	require.Equal(t, RankExact, best.Rank)
+	require.Equal(t, 1, best.Parameters)
+}
@@ -0,0 +1,94 @@
+package openapi3
+
+import (
+	"reflect"
+)
+
+// deepCopy returns a copy of value that shares no pointers, maps or slices with it.
+// Pointer cycles (e.g. recursive schemas) are reproduced in the copy.
+// Unexported fields are copied shallowly.
+func deepCopy(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	src := reflect.ValueOf(value)
+	dst := reflect.New(src.Type()).Elem()
+	c := copier{copies: make(map[copiedKey]reflect.Value)}
+	c.copy(dst, src)
+	return dst.Interface()
+}
+
+type copiedKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+type copier struct {
+	copies map[copiedKey]reflect.Value
+}
+
+func (c *copier) copy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := copiedKey{typ: src.Type(), ptr: src.Pointer()}
+		if copied, ok := c.copies[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		c.copies[key] = copied
+		c.copy(copied.Elem(), src.Elem())
+		dst.Set(copied)
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := copiedKey{typ: src.Type(), ptr: src.Pointer()}
+		if copied, ok := c.copies[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.copies[key] = copied
+		elemType := src.Type().Elem()
+		for iter := src.MapRange(); iter.Next(); {
+			elem := reflect.New(elemType).Elem()
+			c.copy(elem, iter.Value())
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+		dst.Set(copied)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			c.copy(copied.Index(i), src.Index(i))
+		}
+		dst.Set(copied)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		c.copy(elem, src.Elem())
+		dst.Set(elem)
+
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if field := dst.Field(i); field.CanSet() {
+				c.copy(field, src.Field(i))
+			}
+		}
+
+	default:
+		dst.Set(src)
+	}
+}
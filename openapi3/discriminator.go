@@ -2,6 +2,10 @@ package openapi3
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -29,5 +33,224 @@ func (discriminator *Discriminator) UnmarshalJSON(data []byte) error {
 func (discriminator *Discriminator) Validate(ctx context.Context, opts ...ValidationOption) error {
 	// ctx = WithValidationOptions(ctx, opts...)
 
+	if discriminator.PropertyName == "" {
+		return errors.New("value of propertyName must be a non-empty string")
+	}
+	for _, key := range sortedMapKeys(discriminator.Mapping) {
+		if discriminator.Mapping[key] == "" {
+			return fmt.Errorf("mapping %q must name a schema", key)
+		}
+	}
+	return nil
+}
+
+// target returns the reference to the schema that value of the discriminator property
+// selects, and the name that schema may be known by.
+func (discriminator *Discriminator) target(value string) (ref, name string) {
+	if mapped, ok := discriminator.Mapping[value]; ok {
+		if !strings.ContainsAny(mapped, "#/") {
+			return "#/components/schemas/" + mapped, mapped
+		}
+		return mapped, mapped[strings.LastIndex(mapped, "/")+1:]
+	}
+	return "#/components/schemas/" + value, value
+}
+
+// selects tells whether ref, a reference in oneOf or anyOf, is the schema
+// the discriminator property value selects.
+func (discriminator *Discriminator) selects(ref, value string) bool {
+	if ref == "" {
+		return false
+	}
+	target, name := discriminator.target(value)
+	if ref == target {
+		return true
+	}
+	if _, ok := discriminator.Mapping[value]; ok && strings.Contains(target, "/") {
+		// An explicit reference must match exactly
+		return false
+	}
+	return ref[strings.LastIndex(ref, "/")+1:] == name
+}
+
+// ResolveDiscriminator returns the schema of the oneOf or anyOf of schema that the
+// discriminator of schema selects for value, an object, using the discriminator
+// mapping or, for values it does not map, the name of the schema.
+func (schema *Schema) ResolveDiscriminator(value interface{}) (*SchemaRef, error) {
+	discriminator := schema.Discriminator
+	if discriminator == nil {
+		return nil, errors.New("schema has no discriminator")
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("value is not an object")
+	}
+	propertyValue, ok := object[discriminator.PropertyName]
+	if !ok {
+		return nil, fmt.Errorf("value does not contain the discriminator property %q", discriminator.PropertyName)
+	}
+	name, ok := propertyValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("value of the discriminator property %q is not a string", discriminator.PropertyName)
+	}
+	for _, refs := range []SchemaRefs{schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if discriminator.selects(ref.Ref, name) {
+				return ref, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no schema matches the discriminator value %q", name)
+}
+
+// discriminatedBranch returns the index in branches, the oneOf or anyOf of schema,
+// of the only branch value is validated against: the branch the discriminator of
+// schema selects. It returns -1 when value is not an object, or when schema has
+// no discriminator or branches no reference it could select.
+func (schema *Schema) discriminatedBranch(settings *schemaValidationSettings, branches SchemaRefs, value interface{}) (int, error) {
+	discriminator := schema.Discriminator
+	if discriminator == nil {
+		return -1, nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return -1, nil
+	}
+	referenced := false
+	for _, branch := range branches {
+		referenced = referenced || branch.Ref != ""
+	}
+	if !referenced {
+		return -1, nil
+	}
+
+	var reason string
+	if propertyValue, ok := object[discriminator.PropertyName]; !ok {
+		reason = "input does not contain the discriminator property"
+	} else if name, ok := propertyValue.(string); !ok {
+		reason = "descriminator value is not a string"
+	} else {
+		for i, branch := range branches {
+			if discriminator.selects(branch.Ref, name) {
+				return i, nil
+			}
+		}
+		reason = "input does not contain a valid discriminator value"
+	}
+	if settings.failfast {
+		return -1, errSchema
+	}
+	return -1, &SchemaError{
+		Value:                 value,
+		Schema:                schema,
+		SchemaField:           "discriminator",
+		Origin:                errors.New(reason),
+		customizeMessageError: settings.customizeMessageError,
+	}
+}
+
+// validateDiscriminator returns an error if the discriminator mapping of schema names
+// schemas that are not among its oneOf and anyOf, and, when discriminator property
+// validation is enabled, if the schemas the discriminator selects from do not declare
+// and require the discriminator property.
+func (schema *Schema) validateDiscriminator(ctx context.Context) error {
+	discriminator := schema.Discriminator
+	if err := discriminator.Validate(ctx); err != nil {
+		return fmt.Errorf("invalid discriminator: %w", err)
+	}
+
+	candidates := append(append(SchemaRefs(nil), schema.OneOf...), schema.AnyOf...)
+	if len(candidates) != 0 {
+		for _, key := range sortedMapKeys(discriminator.Mapping) {
+			found := false
+			for _, candidate := range candidates {
+				if discriminator.selects(candidate.Ref, key) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("invalid discriminator: mapping %q targets %q which is not in oneOf or anyOf", key, discriminator.Mapping[key])
+			}
+		}
+	}
+
+	if !getValidationOptions(ctx).DiscriminatorPropertyValidationEnabled {
+		return nil
+	}
+	if len(candidates) == 0 {
+		candidates = SchemaRefs{{Value: schema}}
+	}
+	name := discriminator.PropertyName
+	for _, candidate := range candidates {
+		if candidate.Value == nil {
+			continue
+		}
+		declared, required := declaresProperty(candidate.Value, name, make(map[*Schema]struct{}))
+		what := "schema"
+		if candidate.Ref != "" {
+			what = fmt.Sprintf("schema %q", candidate.Ref)
+		}
+		if !declared {
+			return fmt.Errorf("invalid discriminator: %s does not declare property %q", what, name)
+		}
+		if !required {
+			return fmt.Errorf("invalid discriminator: %s does not require property %q", what, name)
+		}
+	}
+	return nil
+}
+
+// declaresProperty tells whether schema, or the schemas of its allOf, declare and require
+// the property name.
+func declaresProperty(schema *Schema, name string, visited map[*Schema]struct{}) (declared, required bool) {
+	if _, ok := visited[schema]; ok {
+		return
+	}
+	visited[schema] = struct{}{}
+	_, declared = schema.Properties[name]
+	for _, r := range schema.Required {
+		if r == name {
+			required = true
+		}
+	}
+	for _, ref := range schema.AllOf {
+		if ref.Value != nil {
+			d, r := declaresProperty(ref.Value, name, visited)
+			declared, required = declared || d, required || r
+		}
+	}
+	return
+}
+
+// validateDiscriminatorMappings returns an error if a discriminator mapping of doc names a
+// schema of its components that does not exist, mentioning the top-level key it is found under.
+func (doc *T) validateDiscriminatorMappings() error {
+	var errs []string
+	// The walk function never fails
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		discriminator, ok := value.(*Discriminator)
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedMapKeys(discriminator.Mapping) {
+			ref, _ := discriminator.target(key)
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			if name == ref || strings.Contains(name, "/") {
+				// External or not a component
+				continue
+			}
+			if _, ok := doc.Components.Schemas[unescapeRefString(name)]; !ok {
+				tokens := strings.SplitN(strings.TrimPrefix(pointer, "/"), "/", 2)
+				errs = append(errs, fmt.Sprintf("invalid %s: invalid discriminator at %s: mapping %q targets undefined schema %q",
+					tokens[0], pointer, key, discriminator.Mapping[key]))
+			}
+		}
+		return nil
+	})
+	if len(errs) != 0 {
+		sort.Strings(errs)
+		return errors.New(errs[0])
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package openapi3
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -54,3 +56,139 @@ func TestParsingDiscriminator(t *testing.T) {
 
 	require.Equal(t, 2, len(doc.Components.Schemas["MyResponseType"].Value.Discriminator.Mapping))
 }
+
+var discriminatorSpec = `
+openapi: 3.0.0
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              oneOf:
+                - $ref: "#/components/schemas/Cat"
+                - $ref: "#/components/schemas/Dog"
+                - $ref: "#/components/schemas/Lizard"
+              discriminator:
+                propertyName: petType
+                mapping:
+                  kitty: Cat
+                  dog: "#/components/schemas/Dog"
+      responses:
+        '204':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [petType]
+      properties:
+        petType: {type: string}
+    Cat:
+      allOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          properties:
+            hunts: {type: boolean}
+    Dog:
+      allOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          properties:
+            barks: {type: boolean}
+    Lizard:
+      type: object
+      properties:
+        petType: {type: string}
+`
+
+func TestResolveDiscriminator(t *testing.T) {
+	doc, err := NewLoader().LoadFromData([]byte(discriminatorSpec))
+	require.NoError(t, err)
+	err = doc.Validate(context.Background())
+	require.NoError(t, err)
+
+	schema := doc.Paths["/pets"].Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+	for value, expected := range map[string]string{
+		"kitty":  "#/components/schemas/Cat",
+		"dog":    "#/components/schemas/Dog",
+		"Lizard": "#/components/schemas/Lizard",
+	} {
+		ref, err := schema.ResolveDiscriminator(map[string]interface{}{"petType": value})
+		require.NoError(t, err, value)
+		require.Equal(t, expected, ref.Ref, value)
+		require.Same(t, doc.Components.Schemas[strings.TrimPrefix(expected, "#/components/schemas/")].Value, ref.Value)
+	}
+
+	_, err = schema.ResolveDiscriminator(map[string]interface{}{"petType": "Cat"})
+	require.NoError(t, err, "mapping does not hide schema names")
+	_, err = schema.ResolveDiscriminator(map[string]interface{}{"petType": "snake"})
+	require.EqualError(t, err, `no schema matches the discriminator value "snake"`)
+	_, err = schema.ResolveDiscriminator(map[string]interface{}{})
+	require.EqualError(t, err, `value does not contain the discriminator property "petType"`)
+	_, err = schema.ResolveDiscriminator(map[string]interface{}{"petType": 1})
+	require.EqualError(t, err, `value of the discriminator property "petType" is not a string`)
+	_, err = schema.ResolveDiscriminator("kitty")
+	require.EqualError(t, err, "value is not an object")
+	_, err = NewObjectSchema().ResolveDiscriminator(map[string]interface{}{})
+	require.EqualError(t, err, "schema has no discriminator")
+}
+
+func TestDiscriminatorValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		opts    []ValidationOption
+		err     string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:    "empty property name",
+			replace: [2]string{"propertyName: petType", `propertyName: ""`},
+			err:     "value of propertyName must be a non-empty string",
+		},
+		{
+			name:    "mapping outside oneOf",
+			replace: [2]string{`dog: "#/components/schemas/Dog"`, `dog: "#/components/schemas/Pet"`},
+			err:     `invalid discriminator: mapping "dog" targets "#/components/schemas/Pet" which is not in oneOf or anyOf`,
+		},
+		{
+			name:    "mapping to undefined schema",
+			replace: [2]string{"    Lizard:\n", "    Lizard:\n      discriminator:\n        propertyName: petType\n        mapping: {gecko: Gecko}\n"},
+			err:     `invalid components: invalid discriminator at /components/schemas/Lizard/discriminator: mapping "gecko" targets undefined schema "Gecko"`,
+		},
+		{
+			name: "property not required",
+			opts: []ValidationOption{EnableDiscriminatorPropertyValidation()},
+			err:  `invalid discriminator: schema "#/components/schemas/Lizard" does not require property "petType"`,
+		},
+		{
+			name:    "property not declared",
+			replace: [2]string{"petType: {type: string}\n", "name: {type: string}\n"},
+			opts:    []ValidationOption{EnableDiscriminatorPropertyValidation()},
+			err:     `invalid discriminator: schema "#/components/schemas/Cat" does not declare property "petType"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := discriminatorSpec
+			if test.replace[0] != "" {
+				require.Contains(t, spec, test.replace[0])
+				spec = strings.Replace(spec, test.replace[0], test.replace[1], 1)
+			}
+			doc, err := NewLoader().LoadFromData([]byte(spec))
+			require.NoError(t, err)
+			err = doc.Validate(context.Background(), test.opts...)
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.err)
+		})
+	}
+}
@@ -2,8 +2,11 @@ package openapi3
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"mime"
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -57,6 +60,7 @@ func (encoding *Encoding) SerializationMethod() *SerializationMethod {
 	if encoding != nil {
 		if encoding.Style != "" {
 			sm.Style = encoding.Style
+			sm.Explode = defaultExplode(sm.Style)
 		}
 		if encoding.Explode != nil {
 			sm.Explode = *encoding.Explode
@@ -81,10 +85,10 @@ func (encoding *Encoding) Validate(ctx context.Context, opts ...ValidationOption
 	for _, k := range headers {
 		v := encoding.Headers[k]
 		if err := ValidateIdentifier(k); err != nil {
-			return nil
+			return err
 		}
 		if err := v.Validate(ctx); err != nil {
-			return nil
+			return fmt.Errorf("header %q: %w", k, err)
 		}
 	}
 
@@ -104,3 +108,87 @@ func (encoding *Encoding) Validate(ctx context.Context, opts ...ValidationOption
 
 	return nil
 }
+
+// validateEncodings returns an error if the encodings of mediaType, named mediaTypeName,
+// do not apply to it: headers only apply to multipart media types, style and explode
+// only to application/x-www-form-urlencoded, and encoded properties must be declared
+// by the media type's schema.
+func (mediaType *MediaType) validateEncodings(ctx context.Context, mediaTypeName string) error {
+	if mediaType == nil || len(mediaType.Encoding) == 0 {
+		return nil
+	}
+	parsed, _, err := mime.ParseMediaType(mediaTypeName)
+	if err != nil {
+		parsed = strings.ToLower(mediaTypeName)
+	}
+	multipart := strings.HasPrefix(parsed, "multipart/")
+	urlencoded := parsed == "application/x-www-form-urlencoded"
+
+	var properties map[string]struct{}
+	if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+		properties = make(map[string]struct{})
+		if !collectPropertyNames(mediaType.Schema.Value, properties, make(map[*Schema]struct{})) {
+			// Any property is allowed
+			properties = nil
+		}
+	}
+
+	names := make([]string, 0, len(mediaType.Encoding))
+	for name := range mediaType.Encoding {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		encoding := mediaType.Encoding[name]
+		if encoding == nil {
+			continue
+		}
+		wrap := func(err error) error { return fmt.Errorf("invalid encoding of property %q: %w", name, err) }
+		if properties != nil {
+			if _, ok := properties[name]; !ok {
+				return wrap(errors.New("property is not declared by the schema"))
+			}
+		}
+		if len(encoding.Headers) != 0 && !multipart {
+			return wrap(fmt.Errorf("headers only apply to multipart media types, not %q", parsed))
+		}
+		if (encoding.Style != "" || encoding.Explode != nil) && !urlencoded {
+			return wrap(fmt.Errorf("style and explode only apply to application/x-www-form-urlencoded, not %q", parsed))
+		}
+		for _, contentType := range strings.Split(encoding.ContentType, ",") {
+			if contentType = strings.TrimSpace(contentType); contentType == "" {
+				continue
+			}
+			if _, _, err := mime.ParseMediaType(contentType); err != nil && !strings.HasSuffix(contentType, "/*") {
+				return wrap(fmt.Errorf("invalid content type %q: %v", contentType, err))
+			}
+		}
+		if err := encoding.Validate(ctx); err != nil {
+			return wrap(err)
+		}
+	}
+	return nil
+}
+
+// collectPropertyNames adds the names of the properties schema declares, looking
+// into allOf, oneOf and anyOf, and returns false if schema allows other properties.
+func collectPropertyNames(schema *Schema, names map[string]struct{}, visited map[*Schema]struct{}) bool {
+	if _, ok := visited[schema]; ok {
+		return true
+	}
+	visited[schema] = struct{}{}
+	for name := range schema.Properties {
+		names[name] = struct{}{}
+	}
+	closed := schema.AdditionalProperties.Schema == nil &&
+		(schema.AdditionalProperties.Has == nil || !*schema.AdditionalProperties.Has) &&
+		(len(schema.Properties) != 0 || len(schema.AllOf) != 0 || len(schema.OneOf) != 0 || len(schema.AnyOf) != 0)
+	for _, refs := range []SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref != nil && ref.Value != nil && !collectPropertyNames(ref.Value, names, visited) {
+				closed = false
+			}
+		}
+	}
+	return closed
+}
@@ -36,7 +36,9 @@ var encodingJSON = []byte(`
 {
   "contentType": "application/json",
   "headers": {
-    "someHeader": {}
+    "someHeader": {
+      "schema": {"type": "string"}
+    }
   },
   "style": "form",
   "explode": true,
@@ -50,7 +52,7 @@ func encoding() *Encoding {
 		ContentType: "application/json",
 		Headers: map[string]*HeaderRef{
 			"someHeader": {
-				Value: &Header{},
+				Value: &Header{Parameter: Parameter{Schema: NewStringSchema().NewRef()}},
 			},
 		},
 		Style:         "form",
@@ -73,7 +75,12 @@ func TestEncodingSerializationMethod(t *testing.T) {
 		{
 			name: "encoding with style",
 			enc:  &Encoding{Style: SerializationSpaceDelimited},
-			want: &SerializationMethod{Style: SerializationSpaceDelimited, Explode: true},
+			want: &SerializationMethod{Style: SerializationSpaceDelimited, Explode: false},
+		},
+		{
+			name: "encoding with deepObject style",
+			enc:  &Encoding{Style: SerializationDeepObject},
+			want: &SerializationMethod{Style: SerializationDeepObject, Explode: true},
 		},
 		{
 			name: "encoding with explode",
@@ -98,3 +105,138 @@ func TestEncodingSerializationMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodingValidationInDocument(t *testing.T) {
+	spec := func(mediaType, encoding string) []byte {
+		return []byte(`
+openapi: 3.0.0
+info: {title: Encodings, version: 1.0.0}
+paths:
+  /upload:
+    post:
+      requestBody:
+        content:
+          ` + mediaType + `:
+            schema:
+              type: object
+              properties:
+                id: {type: string}
+                tags:
+                  type: array
+                  items: {type: string}
+            encoding:
+` + encoding + `
+      responses:
+        '204':
+          description: No content
+`)
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		encoding  string
+		err       string
+	}{
+		{
+			name:      "multipart with headers",
+			mediaType: "multipart/form-data",
+			encoding: `
+              id:
+                contentType: text/plain
+                headers:
+                  X-Rate-Limit:
+                    schema: {type: integer}`,
+		},
+		{
+			name:      "urlencoded with style",
+			mediaType: "application/x-www-form-urlencoded",
+			encoding: `
+              tags:
+                style: pipeDelimited
+                explode: false`,
+		},
+		{
+			name:      "undeclared property",
+			mediaType: "multipart/form-data",
+			encoding: `
+              name:
+                contentType: text/plain`,
+			err: `invalid encoding of property "name": property is not declared by the schema`,
+		},
+		{
+			name:      "headers outside multipart",
+			mediaType: "application/x-www-form-urlencoded",
+			encoding: `
+              id:
+                headers:
+                  X-Rate-Limit:
+                    schema: {type: integer}`,
+			err: `headers only apply to multipart media types, not "application/x-www-form-urlencoded"`,
+		},
+		{
+			name:      "style outside urlencoded",
+			mediaType: "multipart/form-data",
+			encoding: `
+              tags:
+                style: form`,
+			err: `style and explode only apply to application/x-www-form-urlencoded, not "multipart/form-data"`,
+		},
+		{
+			name:      "unsupported style and explode",
+			mediaType: "application/x-www-form-urlencoded",
+			encoding: `
+              tags:
+                style: deepObject
+                explode: false`,
+			err: `serialization method with style="deepObject" and explode=false is not supported by media type`,
+		},
+		{
+			name:      "invalid content type",
+			mediaType: "multipart/form-data",
+			encoding: `
+              id:
+                contentType: "text/"`,
+			err: `invalid content type "text/"`,
+		},
+		{
+			name:      "invalid header",
+			mediaType: "multipart/form-data",
+			encoding: `
+              id:
+                headers:
+                  X-Rate-Limit:
+                    schema: {type: integer}
+                    content:
+                      text/plain: {schema: {type: integer}}`,
+			err: `header "X-Rate-Limit": `,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := NewLoader().LoadFromData(spec(test.mediaType, test.encoding))
+			require.NoError(t, err)
+			err = doc.Validate(context.Background())
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestEncodingValidationOpenSchema(t *testing.T) {
+	additionalProperties := true
+	mediaType := NewMediaType().
+		WithSchema(&Schema{Type: "object", AdditionalProperties: AdditionalProperties{Has: &additionalProperties}}).
+		WithEncoding("anything", &Encoding{ContentType: "image/png"})
+	err := Content{"multipart/form-data": mediaType}.Validate(context.Background())
+	require.NoError(t, err)
+
+	mediaType.Schema.Value.AdditionalProperties = AdditionalProperties{}
+	mediaType.Schema.Value.AllOf = SchemaRefs{{Value: NewObjectSchema().WithProperty("anything", NewStringSchema())}}
+	err = Content{"multipart/form-data": mediaType}.Validate(context.Background())
+	require.NoError(t, err)
+}
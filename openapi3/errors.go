@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"bytes"
+	"context"
 	"errors"
 )
 
@@ -13,6 +14,44 @@ func (me MultiError) Error() string {
 	return spliceErr(" | ", me)
 }
 
+// validationErrors collects the errors of the validation of the parts of a document
+// when multi-error validation is enabled. See EnableMultiErrorValidation.
+type validationErrors struct {
+	multi bool
+	errs  MultiError
+}
+
+func newValidationErrors(ctx context.Context) *validationErrors {
+	return &validationErrors{multi: getValidationOptions(ctx).MultiErrorEnabled}
+}
+
+// add returns err, if not nil, wrapped by wrap, unless it collects it, or each of
+// the errors of a MultiError, so that validation goes on.
+func (v *validationErrors) add(err error, wrap func(error) error) error {
+	if err == nil {
+		return nil
+	}
+	if !v.multi {
+		return wrap(err)
+	}
+	if me, ok := err.(MultiError); ok {
+		for _, e := range me {
+			v.errs = append(v.errs, wrap(e))
+		}
+	} else {
+		v.errs = append(v.errs, wrap(err))
+	}
+	return nil
+}
+
+// err returns the errors collected, or nil.
+func (v *validationErrors) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
 func spliceErr(sep string, errs []error) string {
 	buff := &bytes.Buffer{}
 	for i, e := range errs {
@@ -1,16 +1,133 @@
 package openapi3
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
 
-func validateExampleValue(ctx context.Context, input interface{}, schema *Schema) error {
-	opts := make([]SchemaValidationOption, 0, 2)
+	"github.com/invopop/yaml"
+)
+
+// validateExamples validates example or examples, which are mutually exclusive, against schema,
+// the errors of examples being prefixed with prefix and the name of the example.
+func validateExamples(ctx context.Context, example interface{}, examples Examples, schema *Schema, prefix string) error {
+	if example != nil {
+		if err := validateExampleValue(ctx, example, schema); err != nil {
+			return fmt.Errorf("invalid example: %w", err)
+		}
+	}
+
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		v := examples[k]
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, k, err)
+		}
+		if err := validateExampleRef(ctx, v, schema); err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, k, err)
+		}
+	}
+	return nil
+}
+
+// validatedExample is an example found valid against a schema, in requests or responses.
+type validatedExample struct {
+	example      *Example
+	schema       *Schema
+	asReq, asRes bool
+}
+
+// validateExampleRef validates the example of ref against schema, unless it is a reference
+// and component examples validation is disabled.
+// The examples of components, which may be referred to from many places, are validated
+// once against each schema during the validation of a document.
+func validateExampleRef(ctx context.Context, ref *ExampleRef, schema *Schema) error {
+	vo := getValidationOptions(ctx)
+	if ref.Ref == "" {
+		return validateExample(ctx, ref.Value, schema)
+	}
+	if vo.ComponentExamplesValidationDisabled {
+		return nil
+	}
+	key := validatedExample{example: ref.Value, schema: schema, asReq: vo.examplesValidationAsReq, asRes: vo.examplesValidationAsRes}
+	if _, ok := vo.validatedExamples[key]; ok {
+		return nil
+	}
+	if err := validateExample(ctx, ref.Value, schema); err != nil {
+		return err
+	}
+	if vo.validatedExamples != nil {
+		vo.validatedExamples[key] = struct{}{}
+	}
+	return nil
+}
 
-	if vo := getValidationOptions(ctx); vo.examplesValidationAsReq {
+// validateExample validates the value of example against schema. Examples with an
+// externalValue are only validated when external examples validation is enabled,
+// after fetching the external value.
+func validateExample(ctx context.Context, example *Example, schema *Schema) error {
+	if example.ExternalValue == "" {
+		return validateExampleValue(ctx, example.Value, schema)
+	}
+	vo := getValidationOptions(ctx)
+	if vo.externalExamplesLoader == nil {
+		return nil
+	}
+	value, err := readExternalExampleValue(vo.externalExamplesLoader, vo.externalExamplesBase, example.ExternalValue)
+	if err == nil {
+		err = validateExampleValue(ctx, value, schema)
+	}
+	if err != nil {
+		return fmt.Errorf("external value %q: %w", example.ExternalValue, err)
+	}
+	return nil
+}
+
+// readExternalExampleValue reads the payload at externalValue, decoding JSON and YAML
+// payloads and keeping other payloads as strings.
+func readExternalExampleValue(loader *Loader, base *url.URL, externalValue string) (interface{}, error) {
+	location, err := url.Parse(externalValue)
+	if err != nil {
+		return nil, err
+	}
+	if location.Scheme == "" && location.Host == "" && location.Path == "" {
+		return nil, errors.New("no path to read from")
+	}
+	if location, err = resolvePath(base, location); err != nil {
+		return nil, err
+	}
+	data, err := loader.readURL(location)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value, nil
+	}
+	if err := yaml.Unmarshal(data, &value); err == nil {
+		if _, ok := value.(string); !ok {
+			return value, nil
+		}
+	}
+	return string(data), nil
+}
+
+func validateExampleValue(ctx context.Context, input interface{}, schema *Schema) error {
+	vo := getValidationOptions(ctx)
+	opts := make([]SchemaValidationOption, 0, 3)
+	if vo.examplesValidationAsReq {
 		opts = append(opts, VisitAsRequest())
 	} else if vo.examplesValidationAsRes {
 		opts = append(opts, VisitAsResponse())
 	}
-	opts = append(opts, MultiErrors())
+	opts = append(opts, MultiErrors(), WithClock(vo.now))
 
 	return schema.VisitJSON(input, opts...)
 }
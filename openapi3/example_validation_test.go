@@ -2,6 +2,8 @@ package openapi3
 
 import (
 	"bytes"
+	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -524,3 +526,148 @@ components:
 		})
 	}
 }
+
+func TestExternalExamplesValidation(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: External examples, version: 1.0.0}
+paths:
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer}
+          examples:
+            ten: {externalValue: "examples/limit.txt"}
+      responses:
+        '200':
+          description: Users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  required: [id]
+                  properties:
+                    id: {type: integer}
+              examples:
+                users: {externalValue: "http://example.com/users.json"}
+`)
+	payloads := map[string]string{
+		"/specs/examples/limit.txt":     "10",
+		"http://example.com/users.json": `[{"id": 1}, {"id": 2}]`,
+	}
+	var read []string
+	loader := NewLoader()
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		read = append(read, location.String())
+		if payload, ok := payloads[location.String()]; ok {
+			return []byte(payload), nil
+		}
+		return nil, fmt.Errorf("not found: %s", location)
+	}
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	base := &url.URL{Path: "/specs/openapi.yaml"}
+
+	err = doc.Validate(loader.Context)
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"/specs/examples/limit.txt", "http://example.com/users.json"}, read)
+
+	payloads["http://example.com/users.json"] = `[{"id": "one"}]`
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `external value "http://example.com/users.json"`)
+	require.Contains(t, err.Error(), `field must be set to integer or not be present`)
+
+	delete(payloads, "http://example.com/users.json")
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base))
+	require.EqualError(t, err, `invalid paths: invalid path /users: invalid operation GET: `+
+		`example users: external value "http://example.com/users.json": not found: http://example.com/users.json`)
+
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base), DisableExternalExamplesValidation())
+	require.NoError(t, err)
+}
+
+func TestComponentExamplesValidation(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: Component examples, version: 1.0.0}
+paths:
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer}
+          examples:
+            ten: {$ref: '#/components/examples/ten'}
+        - name: offset
+          in: query
+          schema: {type: integer}
+          examples:
+            ten: {$ref: '#/components/examples/ten'}
+      responses:
+        '200':
+          description: Users
+          headers:
+            X-Rate-Limit:
+              schema: {type: integer}
+              example: 100
+          content:
+            application/json:
+              schema: {type: array, items: {}}
+components:
+  examples:
+    ten: {externalValue: "examples/ten.txt"}
+`)
+	var read []string
+	loader := NewLoader()
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		read = append(read, location.String())
+		return []byte("10"), nil
+	}
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	base := &url.URL{Path: "/specs/openapi.yaml"}
+
+	// Validated once against the schemas of both parameters, which are not the same
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/specs/examples/ten.txt", "/specs/examples/ten.txt"}, read)
+
+	read = nil
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base), DisableComponentExamplesValidation())
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	doc.Paths["/users"].Get.Parameters[1].Value.Schema = doc.Paths["/users"].Get.Parameters[0].Value.Schema
+	err = doc.Validate(loader.Context, EnableExternalExamplesValidation(loader, base))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/specs/examples/ten.txt"}, read)
+
+	header := doc.Paths["/users"].Get.Responses.Get(200).Value.Headers["X-Rate-Limit"].Value
+	header.Example = "many"
+	err = doc.Validate(loader.Context)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `invalid operation GET: invalid example: field must be set to integer`)
+
+	header.Examples = Examples{"many": {Value: &Example{Value: "many"}}}
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `invalid paths: invalid path /users: invalid operation GET: `+
+		`header example and examples are mutually exclusive`)
+
+	header.Example = nil
+	err = doc.Validate(loader.Context)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `invalid operation GET: example many: field must be set to integer`)
+
+	err = doc.Validate(loader.Context, DisableExamplesValidation())
+	require.NoError(t, err)
+}
@@ -1,6 +1,11 @@
 package openapi3
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
 
@@ -8,6 +13,10 @@ import (
 // It reads/writes all properties that begin with "x-".
 type ExtensionProps struct {
 	Extensions map[string]interface{} `json:"-" yaml:"-"`
+
+	// keyOrder is the order of keys reproduced when marshaling,
+	// as read by a Loader with PreserveKeyOrder
+	keyOrder *jsoninfo.KeyOrder
 }
 
 // Assert that the type implements the interface
@@ -15,6 +24,11 @@ var _ jsoninfo.StrictStruct = &ExtensionProps{}
 
 // EncodeWith will be invoked by package "jsoninfo"
 func (props *ExtensionProps) EncodeWith(encoder *jsoninfo.ObjectEncoder, value interface{}) error {
+	if props.keyOrder != nil {
+		if err := encoder.EncodeKeyOrder(props.keyOrder); err != nil {
+			return err
+		}
+	}
 	for k, v := range props.Extensions {
 		if err := encoder.EncodeExtension(k, v); err != nil {
 			return err
@@ -31,8 +45,78 @@ func (props *ExtensionProps) DecodeWith(decoder *jsoninfo.ObjectDecoder, value i
 	source := decoder.DecodeExtensionMap()
 	result := make(map[string]interface{}, len(source))
 	for k, v := range source {
-		result[k] = v
+		newValue, ok := extensionTypes[k]
+		if !ok {
+			result[k] = v
+			continue
+		}
+		typed := newValue()
+		if err := json.Unmarshal(v, typed); err != nil {
+			return fmt.Errorf("failed to unmarshal extension %q (%T): %w", k, typed, err)
+		}
+		result[k] = typed
 	}
 	props.Extensions = result
 	return nil
 }
+
+// extensionTypes are the functions returning the values extensions are unmarshaled into
+var extensionTypes = make(map[string]func() interface{})
+
+// ExtensionValidator is implemented by the values of registered extensions
+// that T.Validate validates. See RegisterExtension.
+type ExtensionValidator interface {
+	Validate(ctx context.Context) error
+}
+
+// RegisterExtension makes extension name, which must start with "x-", unmarshal into
+// the pointer newValue returns instead of a json.RawMessage, in the objects unmarshaled
+// afterwards, e.g.
+//
+//	openapi3.RegisterExtension("x-rate-limit", func() interface{} { return &RateLimit{} })
+//
+// The value is marshaled as is, and validated by T.Validate if it is an ExtensionValidator.
+//
+// If the extension is already registered, the function replaces its type.
+// This call is not thread-safe: extensions should not be registered by multiple goroutines.
+func RegisterExtension(name string, newValue func() interface{}) {
+	if len(name) < 3 || name[:2] != "x-" {
+		panic(fmt.Sprintf("extension %q does not start with x-", name))
+	}
+	if newValue == nil {
+		panic("newValue is not defined")
+	}
+	extensionTypes[name] = newValue
+}
+
+// UnregisterExtension makes extension name unmarshal into a json.RawMessage again.
+// This call is not thread-safe: extensions should not be registered by multiple goroutines.
+func UnregisterExtension(name string) {
+	delete(extensionTypes, name)
+}
+
+// validateExtensions validates the values of the extensions of doc that are ExtensionValidators.
+func validateExtensions(ctx context.Context, doc *T) error {
+	errs := newValidationErrors(ctx)
+	err := Walk(doc, func(pointer string, value, parent interface{}) error {
+		extensions := extensionsOf(value)
+		names := make([]string, 0, len(extensions))
+		for name, v := range extensions {
+			if _, ok := v.(ExtensionValidator); ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			wrap := func(e error) error { return fmt.Errorf("invalid extension %s: %w", childPointer(pointer, name), e) }
+			if err := errs.add(extensions[name].(ExtensionValidator).Validate(ctx), wrap); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errs.err()
+}
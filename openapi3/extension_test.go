@@ -1,8 +1,11 @@
 package openapi3
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -123,3 +126,67 @@ func TestExtensionProps_DecodeWith(t *testing.T) {
 		require.Empty(t, value.Field4)
 	})
 }
+
+type rateLimit struct {
+	Requests int    `json:"requests"`
+	Per      string `json:"per"`
+}
+
+func (limit *rateLimit) Validate(ctx context.Context) error {
+	if limit.Requests <= 0 {
+		return errors.New("requests must be positive")
+	}
+	return nil
+}
+
+func TestRegisterExtension(t *testing.T) {
+	RegisterExtension("x-rate-limit", func() interface{} { return &rateLimit{} })
+	defer UnregisterExtension("x-rate-limit")
+
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      x-rate-limit:
+        requests: 10
+        per: second
+      x-other: 1
+      responses:
+        '200':
+          description: The pets
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	operation := doc.Paths["/pets"].Get
+	require.Equal(t, &rateLimit{Requests: 10, Per: "second"}, operation.Extensions["x-rate-limit"])
+	require.Equal(t, json.RawMessage("1"), operation.Extensions["x-other"])
+	require.NoError(t, doc.Validate(loader.Context))
+
+	data, err := json.Marshal(operation)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"x-rate-limit": {"requests": 10, "per": "second"},
+		"x-other": 1,
+		"responses": {"200": {"description": "The pets"}}
+	}`, string(data))
+
+	operation.Extensions["x-rate-limit"].(*rateLimit).Requests = 0
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, "invalid extension /paths/~1pets/get/x-rate-limit: requests must be positive")
+
+	_, err = loader.LoadFromData([]byte(strings.Replace(spec, "requests: 10", "requests: ten", 1)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `failed to unmarshal extension "x-rate-limit" (*openapi3.rateLimit)`)
+
+	UnregisterExtension("x-rate-limit")
+	doc, err = loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.IsType(t, json.RawMessage{}, doc.Paths["/pets"].Get.Extensions["x-rate-limit"])
+
+	require.Panics(t, func() { RegisterExtension("rate-limit", func() interface{} { return &rateLimit{} }) })
+}
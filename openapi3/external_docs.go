@@ -30,7 +30,7 @@ func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
 
 // Validate returns an error if ExternalDocs does not comply with the OpenAPI spec.
 func (e *ExternalDocs) Validate(ctx context.Context, opts ...ValidationOption) error {
-	// ctx = WithValidationOptions(ctx, opts...)
+	ctx = WithValidationOptions(ctx, opts...)
 
 	if e.URL == "" {
 		return errors.New("url is required")
@@ -38,5 +38,10 @@ func (e *ExternalDocs) Validate(ctx context.Context, opts ...ValidationOption) e
 	if _, err := url.Parse(e.URL); err != nil {
 		return fmt.Errorf("url is incorrect: %w", err)
 	}
+	if getValidationOptions(ctx).ExternalDocsURLValidationEnabled {
+		if err := validateAbsoluteURL(e.URL); err != nil {
+			return fmt.Errorf("url is incorrect: %w", err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,203 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// EnumExtractionOptions configure ExtractEnums.
+type EnumExtractionOptions struct {
+	// MinOccurrences is the number of inline schemas an enum must be found in
+	// to be extracted, 2 by default.
+	MinOccurrences int
+
+	// NameExtension is an extension, such as "x-enum-name", of the inline schemas
+	// whose value names the component of their enum. It is not copied to the component.
+	// Enums without one are named after the property or parameter they are the schema of.
+	NameExtension string
+}
+
+// ExtractEnums finds the inline schemas of doc that only define an enum, along with
+// its type, format and annotations, and replaces those defining the same enum,
+// whatever the order of its values, with a reference to a schema added to its components.
+// An enum already defined in the components of doc is referred to instead.
+// The titles and descriptions of the inline schemas are not kept.
+// It returns the references to the schemas added.
+func ExtractEnums(doc *T, options EnumExtractionOptions) ([]string, error) {
+	if doc.frozen {
+		return nil, ErrFrozen
+	}
+	minOccurrences := options.MinOccurrences
+	if minOccurrences <= 0 {
+		minOccurrences = 2
+	}
+
+	type enumGroup struct {
+		refs []*SchemaRef
+		// name is that of the first inline schema, hint the first name extension
+		name, hint string
+		// ref is the reference to the component already defining the enum
+		ref       string
+		component *Schema
+	}
+	var keys []string
+	groups := make(map[string]*enumGroup)
+	err := Walk(doc, func(pointer string, value, parent interface{}) error {
+		ref, ok := value.(*SchemaRef)
+		if !ok || ref.Value == nil {
+			return nil
+		}
+		key, ok := enumKey(ref.Value, options.NameExtension)
+		if !ok {
+			return nil
+		}
+		group := groups[key]
+		if group == nil {
+			group = &enumGroup{}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		if ref.Ref == "" && strings.HasPrefix(pointer, "/components/schemas/") && strings.Count(pointer, "/") == 3 {
+			// Walk visits the components first
+			if group.ref == "" {
+				group.ref = "#" + pointer
+				group.component = ref.Value
+			}
+			return nil
+		}
+		if ref.Ref != "" {
+			return nil
+		}
+		if group.hint == "" {
+			group.hint = enumNameHint(ref.Value, options.NameExtension)
+		}
+		if group.name == "" {
+			group.name = enumName(ref, parent)
+		}
+		group.refs = append(group.refs, ref)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var added []string
+	for _, key := range keys {
+		group := groups[key]
+		if len(group.refs) < minOccurrences && group.ref == "" || len(group.refs) == 0 {
+			continue
+		}
+		if group.ref == "" {
+			first := group.refs[0].Value
+			component := &Schema{
+				ExtensionProps: ExtensionProps{Extensions: enumExtensions(first, options.NameExtension)},
+				Type:           first.Type,
+				Format:         first.Format,
+				Enum:           first.Enum,
+			}
+			name := group.hint
+			if name == "" {
+				name = group.name
+			}
+			ref, err := doc.Components.AddSchema(name, component, CollisionRename)
+			if err != nil {
+				return added, err
+			}
+			group.ref, group.component = ref, component
+			added = append(added, ref)
+		}
+		for _, ref := range group.refs {
+			*ref = SchemaRef{Ref: group.ref, Value: group.component}
+		}
+	}
+	return added, nil
+}
+
+// enumKey returns the key grouping the schemas of the same enum, and whether schema
+// only defines an enum.
+func enumKey(schema *Schema, nameExtension string) (string, bool) {
+	if len(schema.Enum) == 0 {
+		return "", false
+	}
+	stripped := *schema
+	stripped.Type, stripped.Format, stripped.Enum = "", "", nil
+	if !onlyAnnotations(&stripped) {
+		return "", false
+	}
+	values := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", false
+		}
+		values = append(values, string(data))
+	}
+	sort.Strings(values)
+	extensions, err := json.Marshal(enumExtensions(schema, nameExtension))
+	if err != nil {
+		return "", false
+	}
+	return strings.Join([]string{schema.Type, schema.Format, strings.Join(values, ","), string(extensions)}, "\n"), true
+}
+
+// enumExtensions returns the extensions of schema but its name hint.
+func enumExtensions(schema *Schema, nameExtension string) map[string]interface{} {
+	extensions := make(map[string]interface{}, len(schema.Extensions))
+	for k, v := range schema.Extensions {
+		if k != nameExtension {
+			extensions[k] = v
+		}
+	}
+	return extensions
+}
+
+// enumNameHint returns the value of the name extension of schema, if a string.
+func enumNameHint(schema *Schema, nameExtension string) string {
+	if nameExtension == "" {
+		return ""
+	}
+	var name string
+	switch v := schema.Extensions[nameExtension].(type) {
+	case string:
+		name = v
+	case json.RawMessage:
+		if err := json.Unmarshal(v, &name); err != nil {
+			return ""
+		}
+	}
+	return name
+}
+
+// enumName returns the name of the property or parameter ref is the schema of,
+// in upper camel case, or "Enum".
+func enumName(ref *SchemaRef, parent interface{}) string {
+	var name string
+	switch parent := parent.(type) {
+	case *SchemaRef:
+		if parent.Value != nil {
+			for k, v := range parent.Value.Properties {
+				if v == ref {
+					name = k
+				}
+			}
+		}
+	case *ParameterRef:
+		if parent.Value != nil {
+			name = parent.Value.Name
+		}
+	}
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	if sb.Len() == 0 {
+		return "Enum"
+	}
+	return sb.String()
+}
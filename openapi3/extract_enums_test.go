@@ -0,0 +1,94 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEnums(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+      - name: order_status
+        in: query
+        schema:
+          type: string
+          enum: [placed, delivered]
+      - name: color
+        in: query
+        schema:
+          type: string
+          enum: [red, green]
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        status:
+          type: string
+          description: The status of the order of the pet
+          enum: [delivered, placed]
+        size:
+          type: string
+          enum: [small, large]
+          x-enum-name: Size
+        color:
+          $ref: '#/components/schemas/Color'
+        previousStatus:
+          type: string
+          enum: [placed, delivered]
+          maxLength: 9
+    Color:
+      type: string
+      enum: [green, red]
+    Owner:
+      type: object
+      properties:
+        petSize:
+          type: string
+          enum: [large, small]
+`
+	doc, err := NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	added, err := ExtractEnums(doc, EnumExtractionOptions{NameExtension: "x-enum-name"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"#/components/schemas/Size", "#/components/schemas/Status"}, added)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	schemas := doc.Components.Schemas
+	status := schemas["Status"].Value
+	require.Equal(t, &Schema{
+		ExtensionProps: ExtensionProps{Extensions: map[string]interface{}{}},
+		Type:           "string",
+		Enum:           []interface{}{"delivered", "placed"},
+	}, status)
+	require.Equal(t, "#/components/schemas/Status", schemas["Pet"].Value.Properties["status"].Ref)
+	require.Same(t, status, schemas["Pet"].Value.Properties["status"].Value)
+	require.Equal(t, "#/components/schemas/Status", doc.Paths["/pets"].Get.Parameters[0].Value.Schema.Ref)
+
+	require.Equal(t, "#/components/schemas/Size", schemas["Pet"].Value.Properties["size"].Ref)
+	require.Equal(t, "#/components/schemas/Size", schemas["Owner"].Value.Properties["petSize"].Ref)
+	require.NotContains(t, schemas["Size"].Value.Extensions, "x-enum-name")
+
+	// An enum defined in the components is referred to
+	require.Equal(t, "#/components/schemas/Color", doc.Paths["/pets"].Get.Parameters[1].Value.Schema.Ref)
+
+	// Schemas with other keywords are left as they are
+	require.Empty(t, schemas["Pet"].Value.Properties["previousStatus"].Ref)
+}
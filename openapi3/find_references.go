@@ -0,0 +1,57 @@
+package openapi3
+
+import (
+	"sort"
+	"strings"
+)
+
+// FindReferences returns the JSON pointers of the locations of doc that reference
+// the component ref (e.g. "#/components/schemas/Pet"), sorted.
+//
+// Locations include $ref found in paths, components, links and callbacks,
+// security requirements naming a security scheme and discriminator mappings
+// naming a schema.
+func (doc *T) FindReferences(ref string) []string {
+	target := componentRefTarget(doc, nil)
+
+	// Name a discriminator mapping or a security requirement may use instead of ref
+	var schemaName, securitySchemeName string
+	if name := strings.TrimPrefix(ref, "#/components/schemas/"); name != ref && !strings.Contains(name, "/") {
+		schemaName = unescapeRefString(name)
+	}
+	if name := strings.TrimPrefix(ref, "#/components/securitySchemes/"); name != ref && !strings.Contains(name, "/") {
+		securitySchemeName = unescapeRefString(name)
+	}
+
+	var pointers []string
+	// The walk function never fails
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		switch value := value.(type) {
+		case SecurityRequirement:
+			if _, ok := value[securitySchemeName]; ok && securitySchemeName != "" {
+				pointers = append(pointers, childPointer(pointer, securitySchemeName))
+			}
+			return nil
+		case *Discriminator:
+			if schemaName == "" {
+				return nil
+			}
+			for _, key := range sortedMapKeys(value.Mapping) {
+				if mapped := value.Mapping[key]; mapped == ref || mapped == schemaName {
+					pointers = append(pointers, childPointer(pointer, "mapping", key))
+				}
+			}
+			return nil
+		}
+		if to, ok := target(value); ok {
+			if to == ref {
+				pointers = append(pointers, pointer)
+			}
+			// The referenced component is walked at its definition
+			return ErrSkipChildren
+		}
+		return nil
+	})
+	sort.Strings(pointers)
+	return pointers
+}
@@ -0,0 +1,83 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReferences(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: References
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      security:
+        - apiKey: []
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Pet'
+              responses:
+                '200': {description: ok}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-Key}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: Dog
+          pet: '#/components/schemas/Pet'
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"/components/schemas/Animal/discriminator/mapping/pet",
+		"/components/schemas/Dog/allOf/0",
+		"/paths/~1pets/get/callbacks/onEvent/{$request.body#~1url}/post/requestBody/content/application~1json/schema",
+		"/paths/~1pets/get/responses/200/content/application~1json/schema/items",
+	}, doc.FindReferences("#/components/schemas/Pet"))
+
+	require.Equal(t, []string{
+		"/components/schemas/Animal/discriminator/mapping/dog",
+		"/components/schemas/Animal/oneOf/0",
+	}, doc.FindReferences("#/components/schemas/Dog"))
+
+	require.Equal(t, []string{
+		"/paths/~1pets/get/security/0/apiKey",
+		"/security/0/apiKey",
+	}, doc.FindReferences("#/components/securitySchemes/apiKey"))
+
+	require.Empty(t, doc.FindReferences("#/components/schemas/Animal"))
+}
@@ -0,0 +1,55 @@
+package openapi3
+
+import (
+	"errors"
+)
+
+// ErrFrozen is returned by the functions that would modify a document frozen with T.Freeze.
+var ErrFrozen = errors.New("document is frozen")
+
+// Freeze prepares doc for concurrent reads and marks it as immutable.
+//
+// The state otherwise computed lazily when validating values, such as
+// compiled patterns, is computed once, so that goroutines sharing doc
+// only read it when validating documents, requests or responses.
+// The functions of this package modifying a document then panic or return
+// ErrFrozen for doc. Fields cannot be protected from direct assignment,
+// which callers must refrain from.
+//
+// Freeze must be called before doc is shared and after it is loaded and validated.
+func (doc *T) Freeze() {
+	if doc.frozen {
+		return
+	}
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, ok := value.(*SchemaRef); ok && ref.Value != nil {
+			ref.Value.freeze()
+		}
+		return nil
+	})
+	doc.schemaLocations = newSchemaLocations(doc)
+	doc.frozen = true
+}
+
+// IsFrozen reports whether Freeze was called on doc.
+func (doc *T) IsFrozen() bool {
+	return doc.frozen
+}
+
+// mustNotBeFrozen panics if doc is frozen.
+func (doc *T) mustNotBeFrozen() {
+	if doc.frozen {
+		panic(ErrFrozen)
+	}
+}
+
+func (schema *Schema) freeze() {
+	if schema.Pattern != "" && schema.compiledPattern == nil {
+		// Invalid patterns are reported on validation
+		_ = schema.compilePattern()
+	}
+	for pattern := range schema.PatternProperties {
+		_, _ = compilePatternProperty(pattern)
+	}
+	schema.frozen = true
+}
@@ -0,0 +1,79 @@
+package openapi3
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Frozen'
+  version: 0.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: The pets.
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: string, pattern: '^[a-z]+$'}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context, DisableSchemaPatternValidation()))
+	require.False(t, doc.IsFrozen())
+
+	doc.Freeze()
+	require.True(t, doc.IsFrozen())
+	id := doc.Components.Schemas["Pet"].Value.Properties["id"].Value
+	require.NotNil(t, id.compiledPattern)
+
+	schema := doc.Paths["/pets"].Get.Responses.Get(200).Value.Content.Get("application/json").Schema.Value
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, schema.VisitJSON([]interface{}{map[string]interface{}{"id": "rex"}}))
+			require.Error(t, schema.VisitJSON([]interface{}{map[string]interface{}{"id": "Rex"}}))
+			require.NoError(t, doc.Validate(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	require.PanicsWithValue(t, ErrFrozen, func() { doc.AddServer(&Server{URL: "/"}) })
+	require.PanicsWithValue(t, ErrFrozen, func() { doc.AddOperation("/cats", "GET", NewOperation()) })
+	require.PanicsWithValue(t, ErrFrozen, func() { doc.DeclareUsedTags() })
+	require.PanicsWithValue(t, ErrFrozen, func() { doc.InternalizeRefs(context.Background(), nil) })
+	require.Equal(t, ErrFrozen, TransformSchemas(doc, func(pointer string, ref *SchemaRef) (*SchemaRef, error) { return ref, nil }))
+	require.Equal(t, ErrFrozen, loader.ApplyMergePatch(doc, []byte(`{}`), nil))
+}
+
+func TestFreezeInvalidPattern(t *testing.T) {
+	doc := &T{
+		OpenAPI: "3.0.0",
+		Components: Components{
+			Schemas: Schemas{"Bad": NewSchemaRef("", &Schema{Type: "string", Pattern: "["})},
+		},
+	}
+	doc.Freeze()
+	schema := doc.Components.Schemas["Bad"].Value
+	require.Nil(t, schema.compiledPattern)
+	require.Error(t, schema.VisitJSON("a"))
+	require.Nil(t, schema.compiledPattern)
+}
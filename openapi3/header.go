@@ -46,7 +46,7 @@ func (header *Header) SerializationMethod() (*SerializationMethod, error) {
 	if style == "" {
 		style = SerializationSimple
 	}
-	explode := false
+	explode := defaultExplode(style)
 	if header.Explode != nil {
 		explode = *header.Explode
 	}
@@ -84,9 +84,20 @@ func (header *Header) Validate(ctx context.Context, opts ...ValidationOption) er
 		if err := schema.Validate(ctx); err != nil {
 			return fmt.Errorf("header schema is invalid: %w", err)
 		}
+		if header.Example != nil && header.Examples != nil {
+			return errors.New("header example and examples are mutually exclusive")
+		}
+		if vo := getValidationOptions(ctx); !vo.ExamplesValidationDisabled {
+			if err := validateExamples(ctx, header.Example, header.Examples, schema.Value, "example "); err != nil {
+				return err
+			}
+		}
 	}
 
 	if content := header.Content; content != nil {
+		if len(content) != 1 {
+			return errors.New("header content must contain exactly one media type")
+		}
 		if err := content.Validate(ctx); err != nil {
 			return fmt.Errorf("header content is invalid: %w", err)
 		}
@@ -0,0 +1,48 @@
+package openapi3
+
+import "net/http"
+
+// Idempotency tells whether the requests of an operation can be retried,
+// e.g. by clients after a network failure, see Operation.Idempotency.
+type Idempotency struct {
+	// Safe tells whether the requests are read-only, as those of GET, HEAD, OPTIONS and TRACE.
+	// Safe requests are idempotent.
+	Safe bool
+	// Idempotent tells whether sending the requests many times has the same effect as once.
+	Idempotent bool
+	// KeyHeader is the header making the requests idempotent, if any: the requests
+	// with a same value of it are handled once, e.g. "Idempotency-Key".
+	KeyHeader string
+}
+
+// Retryable tells whether a request with header can be sent again: when it is idempotent,
+// or carries the idempotency key header.
+func (idempotency Idempotency) Retryable(header http.Header) bool {
+	return idempotency.Idempotent || (idempotency.KeyHeader != "" && header.Get(idempotency.KeyHeader) != "")
+}
+
+// Idempotency returns the idempotency of operation, for method.
+//
+// Operations are idempotent by the semantics of their method (RFC 7231 section 4.2):
+// GET, HEAD, OPTIONS and TRACE ones are safe, PUT and DELETE ones are idempotent.
+// The x-idempotent extension overrides it, marking an operation idempotent, such as a POST
+// search, or not, making it unsafe as well. The x-idempotency-key-header extension names
+// the header of the key making the requests of an operation idempotent.
+func (operation *Operation) Idempotency(method string) Idempotency {
+	var idempotency Idempotency
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		idempotency.Safe, idempotency.Idempotent = true, true
+	case http.MethodPut, http.MethodDelete:
+		idempotency.Idempotent = true
+	}
+	if operation == nil {
+		return idempotency
+	}
+	if idempotent := operation.Contract.Idempotent; idempotent != nil {
+		idempotency.Idempotent = *idempotent
+		idempotency.Safe = idempotency.Safe && *idempotent
+	}
+	idempotency.KeyHeader = operation.Contract.IdempotencyKeyHeader
+	return idempotency
+}
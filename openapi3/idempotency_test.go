@@ -0,0 +1,57 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationIdempotency(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info: {title: T, version: "1"}
+paths:
+  /orders:
+    get:
+      x-idempotent: false
+      responses:
+        '200': {description: OK}
+    post:
+      x-idempotency-key-header: Idempotency-Key
+      responses:
+        '201': {description: Created}
+    put:
+      responses:
+        '200': {description: OK}
+  /orders/search:
+    post:
+      x-idempotent: true
+      responses:
+        '200': {description: OK}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	orders := doc.Paths["/orders"]
+	require.Equal(t, Idempotency{}, orders.Get.Idempotency(http.MethodGet))
+	require.Equal(t, Idempotency{Idempotent: true}, orders.Put.Idempotency(http.MethodPut))
+	require.Equal(t, Idempotency{Safe: true, Idempotent: true}, (*Operation)(nil).Idempotency(http.MethodHead))
+	require.Equal(t, Idempotency{Idempotent: true}, doc.Paths["/orders/search"].Post.Idempotency(http.MethodPost))
+
+	post := orders.Post.Idempotency(http.MethodPost)
+	require.Equal(t, Idempotency{KeyHeader: "Idempotency-Key"}, post)
+	require.False(t, post.Retryable(http.Header{}))
+	require.True(t, post.Retryable(http.Header{"Idempotency-Key": {"8e03978e"}}))
+
+	for value, expected := range map[string]string{
+		`{"x-idempotent":"yes","responses":{}}`:               "invalid x-idempotent: must be a boolean",
+		`{"x-idempotency-key-header":"","responses":{}}`:      "invalid x-idempotency-key-header: must be a header name",
+		`{"x-idempotency-key-header":"a key","responses":{}}`: "invalid x-idempotency-key-header: must be a header name",
+	} {
+		require.EqualError(t, json.Unmarshal([]byte(value), &Operation{}), expected)
+	}
+}
@@ -3,6 +3,9 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -12,7 +15,8 @@ import (
 type Info struct {
 	ExtensionProps `json:"-" yaml:"-"`
 
-	Title          string   `json:"title" yaml:"title"` // Required
+	Title          string   `json:"title" yaml:"title"`                         // Required
+	Summary        string   `json:"summary,omitempty" yaml:"summary,omitempty"` // OpenAPI 3.1
 	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
 	TermsOfService string   `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
 	Contact        *Contact `json:"contact,omitempty" yaml:"contact,omitempty"`
@@ -46,6 +50,12 @@ func (info *Info) Validate(ctx context.Context, opts ...ValidationOption) error
 		}
 	}
 
+	if v := info.TermsOfService; v != "" && getValidationOptions(ctx).TermsOfServiceValidationEnabled {
+		if err := validateAbsoluteURL(v); err != nil {
+			return fmt.Errorf("invalid termsOfService: %w", err)
+		}
+	}
+
 	if info.Version == "" {
 		return errors.New("value of version must be a non-empty string")
 	}
@@ -79,8 +89,19 @@ func (contact *Contact) UnmarshalJSON(data []byte) error {
 
 // Validate returns an error if Contact does not comply with the OpenAPI spec.
 func (contact *Contact) Validate(ctx context.Context, opts ...ValidationOption) error {
-	// ctx = WithValidationOptions(ctx, opts...)
+	ctx = WithValidationOptions(ctx, opts...)
+	vo := getValidationOptions(ctx)
 
+	if v := contact.URL; v != "" && vo.ContactURLValidationEnabled {
+		if err := validateAbsoluteURL(v); err != nil {
+			return fmt.Errorf("invalid contact url: %w", err)
+		}
+	}
+	if v := contact.Email; v != "" && vo.ContactEmailValidationEnabled {
+		if address, err := mail.ParseAddress(v); err != nil || address.Address != v {
+			return fmt.Errorf("invalid contact email: %q is not an email address", v)
+		}
+	}
 	return nil
 }
 
@@ -89,8 +110,9 @@ func (contact *Contact) Validate(ctx context.Context, opts ...ValidationOption)
 type License struct {
 	ExtensionProps `json:"-" yaml:"-"`
 
-	Name string `json:"name" yaml:"name"` // Required
-	URL  string `json:"url,omitempty" yaml:"url,omitempty"`
+	Name       string `json:"name" yaml:"name"`                                 // Required
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"` // OpenAPI 3.1
+	URL        string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // MarshalJSON returns the JSON encoding of License.
@@ -105,10 +127,30 @@ func (license *License) UnmarshalJSON(data []byte) error {
 
 // Validate returns an error if License does not comply with the OpenAPI spec.
 func (license *License) Validate(ctx context.Context, opts ...ValidationOption) error {
-	// ctx = WithValidationOptions(ctx, opts...)
+	ctx = WithValidationOptions(ctx, opts...)
 
 	if license.Name == "" {
 		return errors.New("value of license name must be a non-empty string")
 	}
+	if license.Identifier != "" && license.URL != "" {
+		return errors.New("license identifier and url are mutually exclusive")
+	}
+	if v := license.URL; v != "" && getValidationOptions(ctx).LicenseURLValidationEnabled {
+		if err := validateAbsoluteURL(v); err != nil {
+			return fmt.Errorf("invalid license url: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateAbsoluteURL returns an error if value is not an absolute URL with a host.
+func validateAbsoluteURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", value)
+	}
 	return nil
 }
@@ -0,0 +1,88 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoStrictValidation(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Third party
+  version: 1.0.0
+  termsOfService: see website
+  contact:
+    name: Support
+    url: www.example.com/support
+    email: Support <support@example.com>
+  license:
+    name: MIT
+    url: /LICENSE
+externalDocs:
+  url: docs/index.html
+paths: {}
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.NoError(t, err, "lenient by default")
+
+	tests := []struct {
+		name string
+		opt  ValidationOption
+		err  string
+	}{
+		{
+			name: "contact url",
+			opt:  EnableContactURLValidation(),
+			err:  `invalid info: invalid contact url: "www.example.com/support" is not an absolute URL`,
+		},
+		{
+			name: "contact email",
+			opt:  EnableContactEmailValidation(),
+			err:  `invalid info: invalid contact email: "Support <support@example.com>" is not an email address`,
+		},
+		{
+			name: "license url",
+			opt:  EnableLicenseURLValidation(),
+			err:  `invalid info: invalid license url: "/LICENSE" is not an absolute URL`,
+		},
+		{
+			name: "terms of service",
+			opt:  EnableTermsOfServiceValidation(),
+			err:  `invalid info: invalid termsOfService: "see website" is not an absolute URL`,
+		},
+		{
+			name: "external docs url",
+			opt:  EnableExternalDocsURLValidation(),
+			err:  `invalid external docs: url is incorrect: "docs/index.html" is not an absolute URL`,
+		},
+		{
+			name: "strict",
+			opt:  EnableStrictMetadataValidation(),
+			err:  `invalid info: invalid contact url: "www.example.com/support" is not an absolute URL`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := doc.Validate(context.Background(), test.opt)
+			require.EqualError(t, err, test.err)
+		})
+	}
+
+	doc.Info.TermsOfService = "https://example.com/terms"
+	doc.Info.Contact.URL = "https://example.com/support"
+	doc.Info.Contact.Email = "support@example.com"
+	doc.Info.License.URL = "https://opensource.org/licenses/MIT"
+	doc.ExternalDocs.URL = "https://example.com/docs"
+	err = doc.Validate(context.Background(), EnableStrictMetadataValidation())
+	require.NoError(t, err)
+
+	doc.Info.Contact.URL = "www.example.com/support"
+	err = doc.Validate(context.Background(), EnableStrictMetadataValidation(), DisableContactURLValidation())
+	require.NoError(t, err)
+}
@@ -223,7 +223,13 @@ func (doc *T) derefSchema(s *Schema, refNameResolver RefNameResolver, parentIsEx
 			doc.derefSchema(s2.Value, refNameResolver, isExternal || parentIsExternal)
 		}
 	}
-	for _, ref := range []*SchemaRef{s.Not, s.AdditionalProperties, s.Items} {
+	for _, s2 := range s.PatternProperties {
+		isExternal := doc.addSchemaToSpec(s2, refNameResolver, parentIsExternal)
+		if s2 != nil {
+			doc.derefSchema(s2.Value, refNameResolver, isExternal || parentIsExternal)
+		}
+	}
+	for _, ref := range []*SchemaRef{s.Not, s.AdditionalProperties.Schema, s.Items, s.Contains, s.PropertyNames, s.If, s.Then, s.Else} {
 		isExternal := doc.addSchemaToSpec(ref, refNameResolver, parentIsExternal)
 		if ref != nil {
 			doc.derefSchema(ref.Value, refNameResolver, isExternal || parentIsExternal)
@@ -332,6 +338,7 @@ func (doc *T) derefPaths(paths map[string]*PathItem, refNameResolver RefNameReso
 //
 //	doc.InternalizeRefs(context.Background(), nil)
 func (doc *T) InternalizeRefs(ctx context.Context, refNameResolver func(ref string) string) {
+	doc.mustNotBeFrozen()
 	doc.resetVisited()
 
 	if refNameResolver == nil {
@@ -380,4 +387,5 @@ func (doc *T) InternalizeRefs(ctx context.Context, refNameResolver func(ref stri
 	}
 
 	doc.derefPaths(doc.Paths, refNameResolver, false)
+	doc.derefPaths(doc.Webhooks, refNameResolver, false)
 }
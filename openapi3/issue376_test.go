@@ -49,8 +49,8 @@ components:
   schemas:
     unset:
       type: number
-    #empty-object:
-    # TODO additionalProperties: {}
+    empty-object:
+      additionalProperties: {}
     object:
       additionalProperties: {type: string}
     boolean:
@@ -70,16 +70,16 @@ info:
 	require.NoError(t, err)
 
 	for propName, propSchema := range doc.Components.Schemas {
-		ap := propSchema.Value.AdditionalProperties
-		apa := propSchema.Value.AdditionalPropertiesAllowed
+		ap := propSchema.Value.AdditionalProperties.Schema
+		apa := propSchema.Value.AdditionalProperties.Has
 
 		encoded, err := propSchema.MarshalJSON()
 		require.NoError(t, err)
 		require.Equal(t, string(encoded), map[string]string{
-			"unset": `{"type":"number"}`,
-			// TODO: "empty-object":`{"additionalProperties":{}}`,
-			"object":  `{"additionalProperties":{"type":"string"}}`,
-			"boolean": `{"additionalProperties":false}`,
+			"unset":        `{"type":"number"}`,
+			"empty-object": `{"additionalProperties":{}}`,
+			"object":       `{"additionalProperties":{"type":"string"}}`,
+			"boolean":      `{"additionalProperties":false}`,
 		}[propName])
 
 		if propName == "unset" {
@@ -89,7 +89,7 @@ info:
 
 		apStr := ""
 		if ap != nil {
-			apStr = fmt.Sprintf("{Ref:%s Value.Type:%v}", (*ap).Ref, (*ap).Value.Type)
+			apStr = fmt.Sprintf("{Ref:%s Value.Type:%v}", ap.Ref, ap.Value.Type)
 		}
 		apaStr := ""
 		if apa != nil {
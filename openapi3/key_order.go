@@ -0,0 +1,93 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/jsoninfo"
+)
+
+// recordKeyOrder sets the key order of the objects of v to that of the JSON data
+// v was decoded from, when loader.PreserveKeyOrder is set.
+func (loader *Loader) recordKeyOrder(data []byte, v interface{}) {
+	if !loader.PreserveKeyOrder {
+		return
+	}
+	setKeyOrder(data, reflect.ValueOf(v))
+}
+
+// setKeyOrder walks data along with the value it was decoded into.
+// Values of references are skipped: their order is recorded where they are defined.
+func setKeyOrder(data []byte, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			setKeyOrder(data, v.Elem())
+		}
+
+	case reflect.Struct:
+		if ref, value := v.FieldByName("Ref"), v.FieldByName("Value"); ref.Kind() == reflect.String && value.Kind() == reflect.Ptr {
+			if ref.String() == "" {
+				setKeyOrder(data, value)
+			}
+			return
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return
+		}
+		if props := v.FieldByName("ExtensionProps"); props.IsValid() && v.CanAddr() {
+			if order, err := jsoninfo.KeyOrderOf(data, v.Addr().Interface()); err == nil {
+				props.Addr().Interface().(*ExtensionProps).keyOrder = order
+			}
+		}
+		for _, field := range jsoninfo.GetTypeInfo(v.Type()).Fields {
+			fieldData, ok := fields[field.JSONName]
+			if !ok || !field.HasJSONTag {
+				continue
+			}
+			if fieldValue, ok := fieldByIndex(v, field.Index); ok {
+				setKeyOrder(fieldData, fieldValue)
+			}
+		}
+
+	case reflect.Map:
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return
+		}
+		keyType := v.Type().Key()
+		if keyType.Kind() != reflect.String || v.Type().Elem().Kind() != reflect.Ptr {
+			// Map values are not addressable
+			return
+		}
+		for key, entryData := range entries {
+			if entry := v.MapIndex(reflect.ValueOf(key).Convert(keyType)); entry.IsValid() {
+				setKeyOrder(entryData, entry)
+			}
+		}
+
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil || len(items) != v.Len() {
+			return
+		}
+		for i, itemData := range items {
+			setKeyOrder(itemData, v.Index(i))
+		}
+	}
+}
+
+// fieldByIndex returns the field of v at index, unless it is in a nil embedded struct.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,95 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyOrderIsPreservedFromJSON(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.0","x-zz":{"b":1,"a":2},"info":{"version":"1","title":"t"},"paths":{"/z":{"get":{"responses":{"404":{"description":"nf"},"200":{"description":"ok"}}}},"/a":{}},"components":{"schemas":{"Zebra":{"type":"object","properties":{"stripes":{"type":"integer"},"name":{"type":"string"}}},"Ant":{"type":"string"}}},"x-aa":true}`)
+
+	doc, err := orderedLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, string(spec), string(data))
+}
+
+func TestKeyOrderIsPreservedFromYAML(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: t
+  version: 1.0.0
+paths:
+  /zoo:
+    post:
+      responses:
+        "201":
+          description: created
+        default:
+          description: error
+  /animals: {}
+components:
+  schemas:
+    Base: &base
+      type: object
+      x-origin: base
+    Zebra:
+      <<: *base
+      properties:
+        stripes:
+          type: integer
+        name:
+          type: string
+x-last: 1
+`[1:])
+
+	doc, err := orderedLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	require.Equal(t, "object", doc.Components.Schemas["Zebra"].Value.Type)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, `{"openapi":"3.0.0","info":{"title":"t","version":"1.0.0"},"paths":{"/zoo":{"post":{"responses":{"201":{"description":"created"},"default":{"description":"error"}}}},"/animals":{}},"components":{"schemas":{"Base":{"type":"object","x-origin":"base"},"Zebra":{"type":"object","x-origin":"base","properties":{"stripes":{"type":"integer"},"name":{"type":"string"}}}}},"x-last":1}`, string(data))
+}
+
+func TestKeyOrderWithNewKeys(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{"/z":{},"/a":{}}}`)
+
+	doc, err := orderedLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	doc.Paths["/m"] = &PathItem{}
+	doc.Paths["/b"] = &PathItem{}
+	doc.Servers = Servers{{URL: "/"}}
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, `{"openapi":"3.0.0","info":{"title":"t","version":"1"},"paths":{"/z":{},"/a":{},"/b":{},"/m":{}},"components":{},"servers":[{"url":"/"}]}`, string(data))
+}
+
+func TestKeyOrderIsNotPreservedByDefault(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.0","info":{"version":"1","title":"t"},"paths":{"/z":{},"/a":{}}}`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	// Documents differing only by their key order are equal
+	other, err := NewLoader().LoadFromData([]byte(`{"paths":{"/a":{},"/z":{}},"info":{"title":"t","version":"1"},"openapi":"3.0.0"}`))
+	require.NoError(t, err)
+	require.Equal(t, other.ExtensionProps, doc.ExtensionProps)
+	require.Equal(t, other.Info, doc.Info)
+	require.Equal(t, other.Paths, doc.Paths)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, `{"components":{},"info":{"title":"t","version":"1"},"openapi":"3.0.0","paths":{"/a":{},"/z":{}}}`, string(data))
+}
+
+func orderedLoader() *Loader {
+	loader := NewLoader()
+	loader.PreserveKeyOrder = true
+	return loader
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 
@@ -60,5 +63,69 @@ func (link *Link) Validate(ctx context.Context, opts ...ValidationOption) error
 	if link.OperationID != "" && link.OperationRef != "" {
 		return fmt.Errorf("operationId %q and operationRef %q are mutually exclusive", link.OperationID, link.OperationRef)
 	}
+
+	names := make([]string, 0, len(link.Parameters))
+	for name := range link.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := validateRuntimeExpressions(link.Parameters[name]); err != nil {
+			return fmt.Errorf("invalid parameter %q: %w", name, err)
+		}
+	}
+	if err := validateRuntimeExpressions(link.RequestBody); err != nil {
+		return fmt.Errorf("invalid requestBody: %w", err)
+	}
 	return nil
 }
+
+// validateLinks returns an error if a link of doc, in its components or a response,
+// has an operationId no operation of doc has, or a local operationRef that does not point to
+// one of its operations. Other operationRefs, to other documents, are not checked.
+func (doc *T) validateLinks(ctx context.Context) error {
+	errs := newValidationErrors(ctx)
+	noWrap := func(e error) error { return e }
+
+	operationIDs := make(map[string]struct{})
+	operationPointers := make(map[string]struct{})
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if operation, ok := value.(*Operation); ok {
+			operationPointers[pointer] = struct{}{}
+			if operation.OperationID != "" {
+				operationIDs[operation.OperationID] = struct{}{}
+			}
+		}
+		return nil
+	})
+
+	var err error
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		ref, ok := value.(*LinkRef)
+		if !ok || ref.Ref != "" || ref.Value == nil {
+			return nil
+		}
+		link := ref.Value
+		var e error
+		if id := link.OperationID; id != "" {
+			if _, ok := operationIDs[id]; !ok {
+				e = fmt.Errorf("link at %s has operationId %q of no operation", pointer, id)
+			}
+		} else if operationRef := link.OperationRef; strings.HasPrefix(operationRef, "#") {
+			target, unescapeErr := url.PathUnescape(operationRef[1:])
+			if _, ok := operationPointers[target]; !ok || unescapeErr != nil {
+				e = fmt.Errorf("link at %s has operationRef %q to no operation", pointer, operationRef)
+			}
+		}
+		if e != nil {
+			if err = errs.add(e, noWrap); err != nil {
+				return ErrStopWalk
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errs.err()
+}
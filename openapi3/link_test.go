@@ -0,0 +1,93 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRuntimeExpression(t *testing.T) {
+	for _, expression := range []string{
+		"$url",
+		"$method",
+		"$statusCode",
+		"$request.header.X-Request-ID",
+		"$request.query.q",
+		"$request.path.id",
+		"$request.body",
+		"$request.body#/user/uuid",
+		"$response.body#/items/0/a~1b",
+		"$response.header.Location",
+	} {
+		require.NoError(t, ValidateRuntimeExpression(expression), expression)
+	}
+
+	for expression, msg := range map[string]string{
+		"$request":                    `invalid runtime expression "$request": must be $url, $method, $statusCode, or start with $request. or $response.`,
+		"$response.status":            `invalid runtime expression "$response.status": source must be header.<token>, query.<name>, path.<name>, body or body#<json-pointer>`,
+		"$request.header.":            `invalid runtime expression "$request.header.": header name is empty`,
+		"$request.header.X Id":        `invalid runtime expression "$request.header.X Id": header name "X Id" contains ' '`,
+		"$response.body#uuid":         `invalid runtime expression "$response.body#uuid": "uuid" is not a JSON pointer`,
+		"$response.body#/user/~2uuid": `invalid runtime expression "$response.body#/user/~2uuid": JSON pointer "/user/~2uuid" has an invalid escape`,
+	} {
+		require.EqualError(t, ValidateRuntimeExpression(expression), msg, expression)
+	}
+}
+
+func TestLinksValidation(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+components:
+  links:
+    User:
+      operationId: getUser
+      parameters:
+        id: $response.body#/id
+    Unknown:
+      operationId: getUsers
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: a user
+          links:
+            self:
+              $ref: "#/components/links/User"
+            manager:
+              operationRef: "#/paths/~1users~1%7Bid%7D/get"
+              parameters:
+                id: "{$response.body#/managerId}"
+            reports:
+              operationRef: "#/paths/~1users~1{id}~1reports/get"
+            team:
+              operationRef: https://example.com/teams.yaml#/paths/~1teams/get
+`[1:]
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background(), EnableMultiErrorValidation())
+	require.EqualError(t, err, `link at /components/links/Unknown has operationId "getUsers" of no operation`+
+		` | link at /paths/~1users~1{id}/get/responses/200/links/reports has operationRef "#/paths/~1users~1{id}~1reports/get" to no operation`)
+
+	doc.Components.Links["User"].Value.Parameters["id"] = "$response.id"
+	err = doc.Components.Links["User"].Validate(context.Background())
+	require.EqualError(t, err, `invalid parameter "id": invalid runtime expression "$response.id": source must be header.<token>, query.<name>, path.<name>, body or body#<json-pointer>`)
+
+	doc.Components.Links["User"].Value.Parameters["id"] = "$response.body#/id"
+	doc.Components.Links["User"].Value.RequestBody = "{$request.body#/name"
+	err = doc.Components.Links["User"].Validate(context.Background())
+	require.EqualError(t, err, `invalid requestBody: runtime expression "$request.body#/name" is not closed by }`)
+}
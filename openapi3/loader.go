@@ -35,15 +35,49 @@ type Loader struct {
 	// ReadFromURIFunc allows overriding the any file/URL reading func
 	ReadFromURIFunc ReadFromURIFunc
 
+	// DisallowYAMLAnchors makes loading YAML documents that use anchors, aliases
+	// or merge keys fail with ErrYAMLAnchorsDisallowed instead of expanding them
+	DisallowYAMLAnchors bool
+
+	// PreserveKeyOrder makes loaded documents remember the order of their keys, including
+	// those of paths, properties, responses and extensions, and marshal them in that order.
+	// Keys added afterwards are marshaled last, sorted.
+	// Documents loaded with it are not reflect.DeepEqual to documents built in code.
+	PreserveKeyOrder bool
+
+	// UnknownFields sets what loading does with the fields of documents that are
+	// neither defined by the specification nor extensions. They are preserved by default.
+	UnknownFields UnknownFieldsPolicy
+
+	// Limits bounds the resources loading documents uses, e.g. documents supplied by untrusted parties.
+	// Loads exceeding them fail with a *ResourceLimitError.
+	Limits LoaderLimits
+
+	// HTTPCache, if set, caches the documents read from HTTP and HTTPS URLs, e.g. across the loads
+	// of documents referring to the same remote documents. Without a ReadFromURIFunc they are
+	// read with ReadFromHTTP(http.DefaultClient) rather than with the cache of DefaultReadFromURI.
+	HTTPCache *HTTPCache
+
+	// UnknownFieldsFound lists the JSON pointers of the unknown fields of the document
+	// last loaded with WarnUnknownFields.
+	UnknownFieldsFound []string
+
 	Context context.Context
 
 	rootDir      string
 	rootLocation string
 
+	// length of the chain of references being followed
+	refChain int
+
 	visitedPathItemRefs map[string]struct{}
 
 	visitedDocuments map[string]*T
 
+	// sources read by the current load
+	provenance *Provenance
+
+	visitedCallback       map[*Callback]struct{}
 	visitedExample        map[*Example]struct{}
 	visitedHeader         map[*Header]struct{}
 	visitedLink           map[*Link]struct{}
@@ -68,7 +102,19 @@ func (loader *Loader) resetVisitedPathItemRefs() {
 // LoadFromURI loads a spec from a remote URL
 func (loader *Loader) LoadFromURI(location *url.URL) (*T, error) {
 	loader.resetVisitedPathItemRefs()
-	return loader.loadFromURIInternal(location)
+	loader.startProvenance()
+	doc, err := loader.loadFromURIInternal(location)
+	if err != nil {
+		return nil, err
+	}
+	if err := loader.checkSchemaCount(doc); err != nil {
+		return nil, err
+	}
+	if err := loader.checkUnknownFields(doc); err != nil {
+		return nil, err
+	}
+	loader.attachProvenance(doc)
+	return doc, nil
 }
 
 // LoadFromFile loads a spec from a local file path
@@ -115,7 +161,7 @@ func (loader *Loader) loadSingleElementFromURI(ref string, rootPath *url.URL, el
 	if err != nil {
 		return nil, err
 	}
-	if err := unmarshal(data, element); err != nil {
+	if err := loader.unmarshal(data, element); err != nil {
 		return nil, err
 	}
 
@@ -123,22 +169,45 @@ func (loader *Loader) loadSingleElementFromURI(ref string, rootPath *url.URL, el
 }
 
 func (loader *Loader) readURL(location *url.URL) ([]byte, error) {
+	read := DefaultReadFromURI
 	if f := loader.ReadFromURIFunc; f != nil {
-		return f(loader, location)
+		read = f
+	} else if loader.HTTPCache != nil {
+		read = readFromURIUncached
+	}
+	var data []byte
+	var err error
+	if cache := loader.HTTPCache; cache != nil {
+		data, err = cache.read(loader, location, read)
+	} else {
+		data, err = read(loader, location)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return DefaultReadFromURI(loader, location)
+	loader.recordSource(location, data)
+	return data, nil
 }
 
 // LoadFromData loads a spec from a byte array
 func (loader *Loader) LoadFromData(data []byte) (*T, error) {
 	loader.resetVisitedPathItemRefs()
+	loader.startProvenance()
+	loader.recordSource(nil, data)
 	doc := &T{}
-	if err := unmarshal(data, doc); err != nil {
+	if err := loader.unmarshal(data, doc); err != nil {
 		return nil, err
 	}
 	if err := loader.ResolveRefsIn(doc, nil); err != nil {
 		return nil, err
 	}
+	if err := loader.checkSchemaCount(doc); err != nil {
+		return nil, err
+	}
+	if err := loader.checkUnknownFields(doc); err != nil {
+		return nil, err
+	}
+	loader.attachProvenance(doc)
 	return doc, nil
 }
 
@@ -146,7 +215,20 @@ func (loader *Loader) LoadFromData(data []byte) (*T, error) {
 // elements and returns a *T with all resolved data or an error if unable to load data or resolve refs.
 func (loader *Loader) LoadFromDataWithPath(data []byte, location *url.URL) (*T, error) {
 	loader.resetVisitedPathItemRefs()
-	return loader.loadFromDataWithPathInternal(data, location)
+	loader.startProvenance()
+	loader.recordSource(location, data)
+	doc, err := loader.loadFromDataWithPathInternal(data, location)
+	if err != nil {
+		return nil, err
+	}
+	if err := loader.checkSchemaCount(doc); err != nil {
+		return nil, err
+	}
+	if err := loader.checkUnknownFields(doc); err != nil {
+		return nil, err
+	}
+	loader.attachProvenance(doc)
+	return doc, nil
 }
 
 func (loader *Loader) loadFromDataWithPathInternal(data []byte, location *url.URL) (*T, error) {
@@ -162,7 +244,7 @@ func (loader *Loader) loadFromDataWithPathInternal(data []byte, location *url.UR
 	doc := &T{}
 	loader.visitedDocuments[uri] = doc
 
-	if err := unmarshal(data, doc); err != nil {
+	if err := loader.unmarshal(data, doc); err != nil {
 		return nil, err
 	}
 	if err := loader.ResolveRefsIn(doc, location); err != nil {
@@ -172,11 +254,34 @@ func (loader *Loader) loadFromDataWithPathInternal(data []byte, location *url.UR
 	return doc, nil
 }
 
-func unmarshal(data []byte, v interface{}) error {
+func (loader *Loader) unmarshal(data []byte, v interface{}) error {
+	if err := loader.Limits.checkData(data, false); err != nil {
+		return err
+	}
 	// See https://github.com/getkin/kin-openapi/issues/680
 	if err := json.Unmarshal(data, v); err != nil {
+		// Convert YAML ourselves to keep the order of keys and expand anchors,
+		// falling back to yaml.Unmarshal for documents that do not convert as is.
+		jsonData, err := yamlToJSON(data, loader.DisallowYAMLAnchors)
+		if err == nil {
+			if err = loader.Limits.checkData(jsonData, true); err != nil {
+				return err
+			}
+			if err = json.Unmarshal(jsonData, v); err == nil {
+				loader.recordKeyOrder(jsonData, v)
+				return nil
+			}
+		}
+		if errors.Is(err, ErrYAMLAnchorsDisallowed) {
+			return err
+		}
 		return yaml.Unmarshal(data, v)
 	}
+	// encoding/json bounds the depth it decodes itself
+	if err := loader.Limits.checkData(data, true); err != nil {
+		return err
+	}
+	loader.recordKeyOrder(data, v)
 	return nil
 }
 
@@ -190,6 +295,10 @@ func (loader *Loader) ResolveRefsIn(doc *T, location *url.URL) (err error) {
 		loader.resetVisitedPathItemRefs()
 	}
 
+	if err = checkLocalRefKinds(doc); err != nil {
+		return
+	}
+
 	// Visit all components
 	components := doc.Components
 	for _, component := range components.Headers {
@@ -212,8 +321,9 @@ func (loader *Loader) ResolveRefsIn(doc *T, location *url.URL) (err error) {
 			return
 		}
 	}
-	for _, component := range components.Schemas {
-		if err = loader.resolveSchemaRef(doc, component, location, []string{}); err != nil {
+	// In the order of their names, for the chains of references followed to be the same on each load
+	for _, name := range sortedMapKeys(components.Schemas) {
+		if err = loader.resolveSchemaRef(doc, components.Schemas[name], location, []string{}); err != nil {
 			return
 		}
 	}
@@ -241,6 +351,36 @@ func (loader *Loader) ResolveRefsIn(doc *T, location *url.URL) (err error) {
 		}
 	}
 
+	pathItems := make([]string, 0, len(components.PathItems))
+	for name := range components.PathItems {
+		pathItems = append(pathItems, name)
+	}
+	sort.Strings(pathItems)
+	for _, name := range pathItems {
+		pathItem := components.PathItems[name]
+		if pathItem == nil {
+			continue
+		}
+		if err = loader.resolvePathItemRef(doc, componentPathItemsPrefix+name, pathItem, location); err != nil {
+			return
+		}
+	}
+
+	webhooks := make([]string, 0, len(doc.Webhooks))
+	for name := range doc.Webhooks {
+		webhooks = append(webhooks, name)
+	}
+	sort.Strings(webhooks)
+	for _, name := range webhooks {
+		pathItem := doc.Webhooks[name]
+		if pathItem == nil {
+			continue
+		}
+		if err = loader.resolvePathItemRef(doc, webhooksPrefix+name, pathItem, location); err != nil {
+			return
+		}
+	}
+
 	// Visit all operations
 	for entrypoint, pathItem := range doc.Paths {
 		if pathItem == nil {
@@ -291,6 +431,7 @@ func (loader *Loader) resolveComponent(
 	componentPath *url.URL,
 	err error,
 ) {
+	target := ref
 	if componentDoc, ref, componentPath, err = loader.resolveRef(doc, ref, path); err != nil {
 		return nil, nil, err
 	}
@@ -328,7 +469,7 @@ func (loader *Loader) resolveComponent(
 		if err2 != nil {
 			return nil, nil, err
 		}
-		if err2 = unmarshal(data, &cursor); err2 != nil {
+		if err2 = loader.unmarshal(data, &cursor); err2 != nil {
 			return nil, nil, err
 		}
 		if cursor, err2 = drill(cursor); err2 != nil || cursor == nil {
@@ -359,17 +500,87 @@ func (loader *Loader) resolveComponent(
 		return componentDoc, componentPath, nil
 
 	default:
+		if kind, expected := refKind(cursor), refKind(resolved); kind != "" && expected != "" {
+			return nil, nil, fmt.Errorf("%q targets a %s, not a %s", target, kind, expected)
+		}
 		return nil, nil, fmt.Errorf("bad data in %q", ref)
 	}
 }
 
+// refKind returns the name of the kind of object value, an XxxRef, is a reference to,
+// or an empty string when value is not one.
+func refKind(value interface{}) string {
+	switch value.(type) {
+	case *SchemaRef:
+		return "schema"
+	case *ParameterRef:
+		return "parameter"
+	case *HeaderRef:
+		return "header"
+	case *RequestBodyRef:
+		return "request body"
+	case *ResponseRef:
+		return "response"
+	case *SecuritySchemeRef:
+		return "security scheme"
+	case *ExampleRef:
+		return "example"
+	case *LinkRef:
+		return "link"
+	case *CallbackRef:
+		return "callback"
+	default:
+		return ""
+	}
+}
+
+// checkLocalRefKinds returns an error if a reference of doc to a location within doc
+// targets an object of another kind than the one expected where it is found,
+// such as a parameter reference to a schema, mentioning both locations.
+// References to locations that do not exist are left to the resolution to report.
+func checkLocalRefKinds(doc *T) error {
+	return Walk(doc, func(pointer string, value, parent interface{}) error {
+		expected := refKind(value)
+		if expected == "" {
+			return nil
+		}
+		ref := reflect.ValueOf(value).Elem().FieldByName("Ref").String()
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+		var cursor interface{} = doc
+		for _, pathPart := range strings.Split(ref[2:], "/") {
+			if schema, ok := cursor.(*SchemaRef); ok && schema.Value == nil {
+				// Not resolved yet
+				return nil
+			}
+			var err error
+			if cursor, err = drillIntoField(cursor, unescapeRefString(pathPart)); err != nil || cursor == nil {
+				return nil
+			}
+		}
+		if _, ok := cursor.(map[string]interface{}); ok {
+			// An extension, decoded as the reference site expects
+			return nil
+		}
+		if reflect.TypeOf(cursor) == reflect.TypeOf(value) {
+			return nil
+		}
+		kind := refKind(cursor)
+		if kind == "" {
+			kind = "value that is not a " + expected
+		}
+		return fmt.Errorf("invalid %s reference at %s: %q targets a %s", expected, pointer, ref, kind)
+	})
+}
+
 func drillIntoField(cursor interface{}, fieldName string) (interface{}, error) {
 	// Special case due to multijson
 	if s, ok := cursor.(*SchemaRef); ok && fieldName == "additionalProperties" {
-		if ap := s.Value.AdditionalPropertiesAllowed; ap != nil {
+		if ap := s.Value.AdditionalProperties.Has; ap != nil {
 			return *ap, nil
 		}
-		return s.Value.AdditionalProperties, nil
+		return s.Value.AdditionalProperties.Schema, nil
 	}
 
 	switch val := reflect.Indirect(reflect.ValueOf(cursor)); val.Kind() {
@@ -415,8 +626,16 @@ func drillIntoField(cursor interface{}, fieldName string) (interface{}, error) {
 			if ff := val.Type().Field(0); ff.PkgPath == "" && ff.Name == "ExtensionProps" {
 				extensions := val.Field(0).Interface().(ExtensionProps).Extensions
 				if enc, ok := extensions[fieldName]; ok {
+					raw, ok := enc.(json.RawMessage)
+					if !ok {
+						// A registered extension
+						var err error
+						if raw, err = json.Marshal(enc); err != nil {
+							return nil, err
+						}
+					}
 					var dec interface{}
-					if err := json.Unmarshal(enc.(json.RawMessage), &dec); err != nil {
+					if err := json.Unmarshal(raw, &dec); err != nil {
 						return nil, err
 					}
 					return dec, nil
@@ -485,6 +704,9 @@ func (loader *Loader) resolveHeaderRef(doc *T, component *HeaderRef, documentPat
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveHeaderRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -497,6 +719,19 @@ func (loader *Loader) resolveHeaderRef(doc *T, component *HeaderRef, documentPat
 		return nil
 	}
 
+	if err := loader.resolveExamples(doc, value.Examples, documentPath); err != nil {
+		return err
+	}
+	for _, contentType := range value.Content {
+		if err := loader.resolveExamples(doc, contentType.Examples, documentPath); err != nil {
+			return err
+		}
+		if schema := contentType.Schema; schema != nil {
+			if err := loader.resolveSchemaRef(doc, schema, documentPath, []string{}); err != nil {
+				return err
+			}
+		}
+	}
 	if schema := value.Schema; schema != nil {
 		if err := loader.resolveSchemaRef(doc, schema, documentPath, []string{}); err != nil {
 			return err
@@ -533,6 +768,9 @@ func (loader *Loader) resolveParameterRef(doc *T, component *ParameterRef, docum
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveParameterRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -548,7 +786,13 @@ func (loader *Loader) resolveParameterRef(doc *T, component *ParameterRef, docum
 	if value.Content != nil && value.Schema != nil {
 		return errors.New("cannot contain both schema and content in a parameter")
 	}
+	if err := loader.resolveExamples(doc, value.Examples, documentPath); err != nil {
+		return err
+	}
 	for _, contentType := range value.Content {
+		if err := loader.resolveExamples(doc, contentType.Examples, documentPath); err != nil {
+			return err
+		}
 		if schema := contentType.Schema; schema != nil {
 			if err := loader.resolveSchemaRef(doc, schema, documentPath, []string{}); err != nil {
 				return err
@@ -590,6 +834,9 @@ func (loader *Loader) resolveRequestBodyRef(doc *T, component *RequestBodyRef, d
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err = loader.resolveRequestBodyRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -652,6 +899,9 @@ func (loader *Loader) resolveResponseRef(doc *T, component *ResponseRef, documen
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveResponseRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -735,6 +985,9 @@ func (loader *Loader) resolveSchemaRef(doc *T, component *SchemaRef, documentPat
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveSchemaRef(doc, &resolved, componentPath, visited); err != nil {
 				return err
 			}
@@ -757,12 +1010,35 @@ func (loader *Loader) resolveSchemaRef(doc *T, component *SchemaRef, documentPat
 			return err
 		}
 	}
+	if v := value.Contains; v != nil {
+		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
+			return err
+		}
+	}
+	for _, v := range []*SchemaRef{value.If, value.Then, value.Else} {
+		if v == nil {
+			continue
+		}
+		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
+			return err
+		}
+	}
 	for _, v := range value.Properties {
 		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
 			return err
 		}
 	}
-	if v := value.AdditionalProperties; v != nil {
+	for _, v := range value.PatternProperties {
+		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
+			return err
+		}
+	}
+	if v := value.PropertyNames; v != nil {
+		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
+			return err
+		}
+	}
+	if v := value.AdditionalProperties.Schema; v != nil {
 		if err := loader.resolveSchemaRef(doc, v, documentPath, visited); err != nil {
 			return err
 		}
@@ -817,6 +1093,9 @@ func (loader *Loader) resolveSecuritySchemeRef(doc *T, component *SecurityScheme
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveSecuritySchemeRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -827,6 +1106,21 @@ func (loader *Loader) resolveSecuritySchemeRef(doc *T, component *SecurityScheme
 	return nil
 }
 
+// resolveExamples resolves the references of examples, in the order of their names.
+func (loader *Loader) resolveExamples(doc *T, examples Examples, documentPath *url.URL) error {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := loader.resolveExampleRef(doc, examples[name], documentPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (loader *Loader) resolveExampleRef(doc *T, component *ExampleRef, documentPath *url.URL) (err error) {
 	if component != nil && component.Value != nil {
 		if loader.visitedExample == nil {
@@ -854,6 +1148,9 @@ func (loader *Loader) resolveExampleRef(doc *T, component *ExampleRef, documentP
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveExampleRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -865,6 +1162,16 @@ func (loader *Loader) resolveExampleRef(doc *T, component *ExampleRef, documentP
 }
 
 func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documentPath *url.URL) (err error) {
+	if component != nil && component.Value != nil {
+		if loader.visitedCallback == nil {
+			loader.visitedCallback = make(map[*Callback]struct{})
+		}
+		if _, ok := loader.visitedCallback[component.Value]; ok {
+			return nil
+		}
+		loader.visitedCallback[component.Value] = struct{}{}
+	}
+
 	if component == nil {
 		return errors.New("invalid callback: value MUST be an object")
 	}
@@ -881,6 +1188,9 @@ func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documen
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveCallbackRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -893,19 +1203,9 @@ func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documen
 		return nil
 	}
 
-	for entrypoint, pathItem := range *value {
-		entrypoint, pathItem := entrypoint, pathItem
+	for _, pathItem := range *value {
+		pathItem := pathItem
 		err = func() (err error) {
-			key := "-"
-			if documentPath != nil {
-				key = documentPath.EscapedPath()
-			}
-			key += entrypoint
-			if _, ok := loader.visitedPathItemRefs[key]; ok {
-				return nil
-			}
-			loader.visitedPathItemRefs[key] = struct{}{}
-
 			if pathItem == nil {
 				return errors.New("invalid path item: value MUST be an object")
 			}
@@ -979,6 +1279,9 @@ func (loader *Loader) resolveLinkRef(doc *T, component *LinkRef, documentPath *u
 			if err != nil {
 				return err
 			}
+			if err := loader.followRef(&resolved); err != nil {
+				return err
+			}
 			if err := loader.resolveLinkRef(doc, &resolved, componentPath); err != nil {
 				return err
 			}
@@ -989,6 +1292,14 @@ func (loader *Loader) resolveLinkRef(doc *T, component *LinkRef, documentPath *u
 	return nil
 }
 
+// componentPathItemsPrefix is the prefix of references to the path items
+// of the components of OpenAPI 3.1 documents.
+const componentPathItemsPrefix = "#/components/pathItems/"
+
+// webhooksPrefix distinguishes the webhooks of OpenAPI 3.1 documents
+// from their paths among the resolved path items.
+const webhooksPrefix = "#/webhooks/"
+
 func (loader *Loader) resolvePathItemRef(doc *T, entrypoint string, pathItem *PathItem, documentPath *url.URL) (err error) {
 	key := "_"
 	if documentPath != nil {
@@ -1004,34 +1315,44 @@ func (loader *Loader) resolvePathItemRef(doc *T, entrypoint string, pathItem *Pa
 		return errors.New("invalid path item: value MUST be an object")
 	}
 	ref := pathItem.Ref
-	if ref != "" {
+	if ref != "" && !pathItem.refResolved {
 		if isSingleRefElement(ref) {
 			var p PathItem
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &p); err != nil {
 				return err
 			}
-			*pathItem = p
+			pathItem.resolveRefWith(p)
 		} else {
 			if doc, ref, documentPath, err = loader.resolveRef(doc, ref, documentPath); err != nil {
 				return
 			}
 
-			rest := strings.TrimPrefix(ref, "#/paths/")
-			if rest == ref {
-				return fmt.Errorf(`expected prefix "#/paths/" in URI %q`, ref)
-			}
-			id := unescapeRefString(rest)
+			var resolved *PathItem
+			if rest := strings.TrimPrefix(ref, componentPathItemsPrefix); rest != ref {
+				id := unescapeRefString(rest)
+				if resolved = doc.Components.PathItems[id]; resolved == nil {
+					return failedToResolveRefFragmentPart(ref, id)
+				}
+				if err = loader.resolvePathItemRef(doc, ref, resolved, documentPath); err != nil {
+					return
+				}
+			} else {
+				rest := strings.TrimPrefix(ref, "#/paths/")
+				if rest == ref {
+					return fmt.Errorf(`expected prefix "#/paths/" or %q in URI %q`, componentPathItemsPrefix, ref)
+				}
+				id := unescapeRefString(rest)
 
-			definitions := doc.Paths
-			if definitions == nil {
-				return failedToResolveRefFragmentPart(ref, "paths")
-			}
-			resolved := definitions[id]
-			if resolved == nil {
-				return failedToResolveRefFragmentPart(ref, id)
+				definitions := doc.Paths
+				if definitions == nil {
+					return failedToResolveRefFragmentPart(ref, "paths")
+				}
+				if resolved = definitions[id]; resolved == nil {
+					return failedToResolveRefFragmentPart(ref, id)
+				}
 			}
 
-			*pathItem = *resolved
+			pathItem.resolveRefWith(*resolved)
 		}
 	}
 	return loader.resolvePathItemRefContinued(doc, pathItem, documentPath)
@@ -0,0 +1,186 @@
+package openapi3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// HTTPCache caches the documents loaders read from HTTP and HTTPS URLs, see Loader.HTTPCache,
+// so that loading documents referring to the same remote documents does not fetch them again.
+// Documents are cached in memory, and on disk as well when Dir is set.
+//
+// The zero value is a cache keeping documents in memory forever.
+// An HTTPCache may be shared by loaders used concurrently.
+type HTTPCache struct {
+	// TTL is how long documents are reused once fetched, forever if not positive.
+	TTL time.Duration
+
+	// Dir is the directory documents are also stored in, if set, so that they are reused
+	// by other caches, e.g. of later runs of a program. It is created if needed.
+	// Failures to read or write it are ignored: documents are fetched instead.
+	Dir string
+
+	mu      sync.Mutex
+	entries map[string]httpCacheEntry
+	stats   HTTPCacheStats
+	// now returns the current time, time.Now if nil
+	now func() time.Time
+}
+
+// HTTPCacheStats counts the reads of the documents of an HTTPCache.
+type HTTPCacheStats struct {
+	// Hits is the number of documents read from the cache, in memory or on disk.
+	Hits int
+	// DiskHits is the number of the hits read from disk.
+	DiskHits int
+	// Misses is the number of documents fetched.
+	Misses int
+}
+
+type httpCacheEntry struct {
+	data    []byte
+	fetched time.Time
+}
+
+// Stats returns the counts of the reads of the documents of cache so far.
+func (cache *HTTPCache) Stats() HTTPCacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.stats
+}
+
+// Clear removes the documents of cache, from memory and from Dir if set, and resets its stats.
+func (cache *HTTPCache) Clear() error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries = nil
+	cache.stats = HTTPCacheStats{}
+	if cache.Dir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(cache.Dir, "*"+httpCacheFileExt))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// httpCacheFileExt is the extension of the files of the documents stored in HTTPCache.Dir.
+const httpCacheFileExt = ".openapi-cache"
+
+// read returns the document at location, from cache if it is fresh there, or read with read.
+// Only HTTP and HTTPS URLs are cached.
+func (cache *HTTPCache) read(loader *Loader, location *url.URL, read ReadFromURIFunc) ([]byte, error) {
+	if location.Scheme != "http" && location.Scheme != "https" {
+		return read(loader, location)
+	}
+	uri := location.String()
+	now := time.Now
+	if cache.now != nil {
+		now = cache.now
+	}
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[uri]
+	if ok && cache.fresh(entry, now()) {
+		cache.stats.Hits++
+		cache.mu.Unlock()
+		return entry.data, nil
+	}
+	cache.mu.Unlock()
+
+	if entry, ok := cache.readFile(uri); ok && cache.fresh(entry, now()) {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		cache.store(uri, entry)
+		cache.stats.Hits++
+		cache.stats.DiskHits++
+		return entry.data, nil
+	}
+
+	data, err := read(loader, location)
+	cache.mu.Lock()
+	cache.stats.Misses++
+	cache.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	entry = httpCacheEntry{data: data, fetched: now()}
+	cache.writeFile(uri, entry)
+	cache.mu.Lock()
+	cache.store(uri, entry)
+	cache.mu.Unlock()
+	return data, nil
+}
+
+func (cache *HTTPCache) fresh(entry httpCacheEntry, now time.Time) bool {
+	return cache.TTL <= 0 || now.Sub(entry.fetched) < cache.TTL
+}
+
+func (cache *HTTPCache) store(uri string, entry httpCacheEntry) {
+	if cache.entries == nil {
+		cache.entries = make(map[string]httpCacheEntry)
+	}
+	cache.entries[uri] = entry
+}
+
+// file returns the path of the file of the document at uri in Dir.
+func (cache *HTTPCache) file(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(cache.Dir, hex.EncodeToString(sum[:])+httpCacheFileExt)
+}
+
+// readFile returns the document at uri stored in Dir, fetched when the file was last modified.
+func (cache *HTTPCache) readFile(uri string) (httpCacheEntry, bool) {
+	if cache.Dir == "" {
+		return httpCacheEntry{}, false
+	}
+	file := cache.file(uri)
+	info, err := os.Stat(file)
+	if err != nil {
+		return httpCacheEntry{}, false
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return httpCacheEntry{}, false
+	}
+	return httpCacheEntry{data: data, fetched: info.ModTime()}, true
+}
+
+// writeFile stores the document at uri in Dir, replacing the file of the document atomically.
+func (cache *HTTPCache) writeFile(uri string, entry httpCacheEntry) {
+	if cache.Dir == "" {
+		return
+	}
+	if err := os.MkdirAll(cache.Dir, 0o755); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(cache.Dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(entry.data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), entry.fetched, entry.fetched)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), cache.file(uri))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
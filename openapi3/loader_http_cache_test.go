@@ -0,0 +1,74 @@
+package openapi3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderHTTPCache(t *testing.T) {
+	var fetches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write([]byte(`
+Pet:
+  type: object
+`))
+	}))
+	defer ts.Close()
+
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "` + ts.URL + `/schemas.yaml#/Pet"
+`)
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	newCache := func() *HTTPCache {
+		cache := &HTTPCache{TTL: time.Hour, Dir: t.TempDir() + "/cache"}
+		cache.now = func() time.Time { return now }
+		return cache
+	}
+	load := func(cache *HTTPCache) {
+		loader := NewLoader()
+		loader.IsExternalRefsAllowed = true
+		loader.HTTPCache = cache
+		doc, err := loader.LoadFromData(spec)
+		require.NoError(t, err)
+		require.Equal(t, "object", doc.Components.Schemas["Pet"].Value.Type)
+	}
+
+	cache := newCache()
+	load(cache)
+	load(cache)
+	require.Equal(t, HTTPCacheStats{Hits: 1, Misses: 1}, cache.Stats())
+	require.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+
+	// Documents on disk are reused by other caches
+	other := &HTTPCache{TTL: time.Hour, Dir: cache.Dir, now: cache.now}
+	load(other)
+	require.Equal(t, HTTPCacheStats{Hits: 1, DiskHits: 1}, other.Stats())
+	require.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+
+	// Stale documents are fetched again
+	now = now.Add(time.Hour)
+	load(cache)
+	require.Equal(t, HTTPCacheStats{Hits: 1, Misses: 2}, cache.Stats())
+	require.EqualValues(t, 2, atomic.LoadInt32(&fetches))
+
+	// The stale document in memory is not refreshed from the cleared directory
+	require.NoError(t, cache.Clear())
+	require.Equal(t, HTTPCacheStats{}, cache.Stats())
+	load(other)
+	require.Equal(t, HTTPCacheStats{Hits: 1, DiskHits: 1, Misses: 1}, other.Stats())
+	require.EqualValues(t, 3, atomic.LoadInt32(&fetches))
+}
@@ -0,0 +1,122 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LoaderLimits bounds the resources a Loader uses to load documents,
+// e.g. documents supplied by untrusted parties. Zero values mean no limit.
+type LoaderLimits struct {
+	// MaxDocumentSize is the maximum size in bytes of each document read, the one loaded
+	// and those it refers to. YAML documents count with the size of their JSON conversion too,
+	// which expands their aliases.
+	MaxDocumentSize int
+
+	// MaxDepth is the maximum nesting depth of the objects and arrays of each document read,
+	// the root object being at depth 1.
+	MaxDepth int
+
+	// MaxRefChain is the maximum number of references to references followed in a row,
+	// e.g. 2 for a schema referring to a schema referring to a schema referring to a schema.
+	MaxRefChain int
+
+	// MaxSchemas is the maximum number of schemas of a loaded document, each counted once
+	// however many times it is referred to.
+	MaxSchemas int
+}
+
+// ErrResourceLimitExceeded matches the errors of loads exceeding a limit of Loader.Limits,
+// see errors.Is.
+var ErrResourceLimitExceeded = errors.New("resource limit exceeded")
+
+// ResourceLimitError is returned by the loads exceeding a limit of Loader.Limits.
+type ResourceLimitError struct {
+	// Limit is the name of the field of LoaderLimits exceeded, e.g. "MaxDepth".
+	Limit string
+	Max   int
+}
+
+func (e *ResourceLimitError) Error() string {
+	return fmt.Sprintf("%v: %s of %d", ErrResourceLimitExceeded, e.Limit, e.Max)
+}
+
+// Is tells whether target is ErrResourceLimitExceeded.
+func (e *ResourceLimitError) Is(target error) bool {
+	return target == ErrResourceLimitExceeded
+}
+
+// checkData returns an error if the JSON or YAML data of a document exceeds the limits.
+// The nesting depth of JSON data is only checked with json set.
+func (limits LoaderLimits) checkData(data []byte, json bool) error {
+	if max := limits.MaxDocumentSize; max > 0 && len(data) > max {
+		return &ResourceLimitError{Limit: "MaxDocumentSize", Max: max}
+	}
+	if max := limits.MaxDepth; max > 0 && json && jsonDepth(data) > max {
+		return &ResourceLimitError{Limit: "MaxDepth", Max: max}
+	}
+	return nil
+}
+
+// jsonDepth returns the nesting depth of the objects and arrays of the JSON data.
+func jsonDepth(data []byte) int {
+	depth, max := 0, 0
+	inString, escaped := false, false
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			if depth++; depth > max {
+				max = depth
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return max
+}
+
+// followRef records that resolved, the value a reference points to, is followed.
+// It returns an error if resolved is itself a reference, not resolved yet, making
+// the chain of references being followed longer than the limits.
+func (loader *Loader) followRef(resolved interface{}) error {
+	if ref, value, ok := refAndValue(resolved); !ok || ref == "" || value != nil {
+		loader.refChain = 0
+		return nil
+	}
+	loader.refChain++
+	if max := loader.Limits.MaxRefChain; max > 0 && loader.refChain > max {
+		loader.refChain = 0
+		return &ResourceLimitError{Limit: "MaxRefChain", Max: max}
+	}
+	return nil
+}
+
+// checkSchemaCount returns an error if doc has more schemas than the limits.
+func (loader *Loader) checkSchemaCount(doc *T) error {
+	max := loader.Limits.MaxSchemas
+	if max <= 0 {
+		return nil
+	}
+	schemas := make(map[*Schema]struct{})
+	return Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, ok := value.(*SchemaRef); ok && ref.Value != nil {
+			if schemas[ref.Value] = struct{}{}; len(schemas) > max {
+				return &ResourceLimitError{Limit: "MaxSchemas", Max: max}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package openapi3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderLimits(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "#/components/schemas/Animal"
+    Animal:
+      $ref: "#/components/schemas/Thing"
+    Thing:
+      type: object
+      properties:
+        tags:
+          type: array
+          items:
+            type: string
+`[1:]
+	jsonSpec := `{"openapi":"3.0.0","info":{"title":"Pets","version":"1.0.0"},"paths":{},` +
+		`"components":{"schemas":{"Thing":{"type":"object","properties":{"tags":{"type":"array","items":{"type":"string"}}}}}}}`
+
+	load := func(limits LoaderLimits, spec string) error {
+		loader := NewLoader()
+		loader.Limits = limits
+		_, err := loader.LoadFromData([]byte(spec))
+		return err
+	}
+	require.NoError(t, load(LoaderLimits{MaxDocumentSize: len(spec), MaxDepth: 7, MaxRefChain: 1, MaxSchemas: 3}, spec))
+	require.NoError(t, load(LoaderLimits{MaxDepth: 7}, jsonSpec))
+
+	for _, test := range []struct {
+		limits LoaderLimits
+		spec   string
+		err    string
+	}{
+		{LoaderLimits{MaxDocumentSize: len(spec) - 1}, spec, "resource limit exceeded: MaxDocumentSize of 314"},
+		{LoaderLimits{MaxDepth: 6}, spec, "resource limit exceeded: MaxDepth of 6"},
+		{LoaderLimits{MaxDepth: 6}, jsonSpec, "resource limit exceeded: MaxDepth of 6"},
+		{LoaderLimits{MaxRefChain: 0}, spec, ""},
+		{LoaderLimits{MaxSchemas: 2}, spec, "resource limit exceeded: MaxSchemas of 2"},
+	} {
+		err := load(test.limits, test.spec)
+		if test.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.EqualError(t, err, test.err)
+		require.True(t, errors.Is(err, ErrResourceLimitExceeded))
+		var limitErr *ResourceLimitError
+		require.True(t, errors.As(err, &limitErr))
+	}
+
+	chain := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    S0:
+      $ref: "#/components/schemas/S1"
+    S1:
+      $ref: "#/components/schemas/S2"
+    S2:
+      $ref: "#/components/schemas/S3"
+    S3:
+      $ref: "#/components/schemas/S4"
+    S4:
+      $ref: "#/components/schemas/S5"
+    S5:
+      type: string
+`[1:]
+	require.NoError(t, load(LoaderLimits{MaxRefChain: 4}, chain))
+	require.EqualError(t, load(LoaderLimits{MaxRefChain: 3}, chain), "resource limit exceeded: MaxRefChain of 3")
+
+	deep := `{"openapi":"3.0.0","info":{"title":"Pets","version":"1.0.0"},"paths":{},"x-deep":` +
+		strings.Repeat("[", 1000) + strings.Repeat("]", 1000) + `}`
+	require.EqualError(t, load(LoaderLimits{MaxDepth: 100}, deep), "resource limit exceeded: MaxDepth of 100")
+}
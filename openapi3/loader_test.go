@@ -441,9 +441,9 @@ components:
 paths:
   /users/{id}:
     get:
-      operationId: getUserById,
+      operationId: getUserById
       parameters:
-        - name: id,
+        - name: id
           in: path
           required: true
           schema:
@@ -559,14 +559,16 @@ servers:
 `
 	for value, expected := range map[string]string{
 		`{url: /}`:                            "",
-		`{url: "http://{x}.{y}.example.com"}`: "invalid servers: server has undeclared variables",
+		`{url: "http://{x}.{y}.example.com"}`: `invalid servers: server URL "http://{x}.{y}.example.com" references undeclared variable "x"`,
 		`{url: "http://{x}.y}.example.com"}`:  "invalid servers: server URL has mismatched { and }",
 		`{url: "http://{x.example.com"}`:      "invalid servers: server URL has mismatched { and }",
 		`{url: "http://{x}.example.com", variables: {x: {default: "www"}}}`:                "",
 		`{url: "http://{x}.example.com", variables: {x: {default: "www", enum: ["www"]}}}`: "",
 		`{url: "http://{x}.example.com", variables: {x: {enum: ["www"]}}}`:                 `invalid servers: field default is required in {"enum":["www"]}`,
-		`{url: "http://www.example.com", variables: {x: {enum: ["www"]}}}`:                 "invalid servers: server has undeclared variables",
-		`{url: "http://{y}.example.com", variables: {x: {enum: ["www"]}}}`:                 "invalid servers: server has undeclared variables",
+		`{url: "http://www.example.com", variables: {x: {enum: ["www"]}}}`:                 `invalid servers: server variable "x" is not referenced by URL "http://www.example.com"`,
+		`{url: "http://{y}.example.com", variables: {x: {enum: ["www"]}}}`:                 `invalid servers: server URL "http://{y}.example.com" references undeclared variable "y"`,
+		`{url: "http://{x}.example.com", variables: {x: {default: "api", enum: ["www"]}}}`: `invalid servers: field default "api" is not one of enum ["www"]`,
+		`{url: "http://{x}.{x}.example.com", variables: {x: {default: "www"}}}`:            "",
 	} {
 		t.Run(value, func(t *testing.T) {
 			loader := NewLoader()
@@ -581,3 +583,83 @@ servers:
 		})
 	}
 }
+
+func TestResolveRefOfWrongKind(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: An API
+  version: v1
+components:
+  schemas:
+    Pet:
+      type: object
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema: {type: integer}
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - $ref: '#/components/schemas/Pet'
+      responses:
+        default:
+          description: Pets.
+`)
+	_, err := NewLoader().LoadFromData(spec)
+	require.EqualError(t, err, `invalid parameter reference at /paths/~1pets/get/parameters/1: "#/components/schemas/Pet" targets a schema`)
+
+	spec = []byte(`
+openapi: 3.0.0
+info:
+  title: An API
+  version: v1
+paths:
+  /pets:
+    get:
+      responses:
+        default:
+          description: Pets.
+          content:
+            application/json:
+              schema:
+                $ref: '#/paths/~1pets/get/responses/default/content'
+`)
+	_, err = NewLoader().LoadFromData(spec)
+	require.EqualError(t, err, `invalid schema reference at /paths/~1pets/get/responses/default/content/application~1json/schema: "#/paths/~1pets/get/responses/default/content" targets a value that is not a schema`)
+
+	spec = []byte(`
+openapi: 3.0.0
+info:
+  title: An API
+  version: v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'common.yaml#/components/schemas/Pet'
+      responses:
+        default:
+          description: Pets.
+`)
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		return []byte(`
+openapi: 3.0.0
+info:
+  title: Common
+  version: v1
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+`), nil
+	}
+	_, err = loader.LoadFromData(spec)
+	require.EqualError(t, err, `"common.yaml#/components/schemas/Pet" targets a schema, not a parameter`)
+}
@@ -38,7 +38,10 @@ func ReadFromURIs(readers ...ReadFromURIFunc) ReadFromURIFunc {
 
 // DefaultReadFromURI returns a caching ReadFromURIFunc which can read remote
 // HTTP URIs and local file URIs.
-var DefaultReadFromURI = URIMapCache(ReadFromURIs(ReadFromHTTP(http.DefaultClient), ReadFromFile))
+var DefaultReadFromURI = URIMapCache(readFromURIUncached)
+
+// readFromURIUncached reads remote HTTP URIs and local file URIs as DefaultReadFromURI does, without caching.
+var readFromURIUncached = ReadFromURIs(ReadFromHTTP(http.DefaultClient), ReadFromFile)
 
 // ReadFromHTTP returns a ReadFromURIFunc which uses the given http.Client to
 // read the contents from a remote HTTP URI. This client may be customized to
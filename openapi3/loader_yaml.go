@@ -0,0 +1,269 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrYAMLAnchorsDisallowed is returned when loading a YAML document that uses
+// anchors, aliases or merge keys while Loader.DisallowYAMLAnchors is set.
+var ErrYAMLAnchorsDisallowed = errors.New("YAML anchors, aliases and merge keys are disallowed")
+
+// maxYAMLAliasExpansion bounds the number of nodes written out through aliases,
+// protecting against documents that expand exponentially ("billion laughs").
+const maxYAMLAliasExpansion = 1 << 20
+
+// yamlToJSON converts a YAML document to JSON, keeping mapping keys in the
+// order they appear so that the key order of the document survives loading.
+// Aliases and merge keys are expanded, unless disallowAnchors is set.
+func yamlToJSON(data []byte, disallowAnchors bool) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	c := yamlConverter{
+		disallowAnchors: disallowAnchors,
+		onStack:         make(map[*yaml.Node]struct{}),
+	}
+	if err := c.write(&node); err != nil {
+		return nil, err
+	}
+	return c.buf.Bytes(), nil
+}
+
+type yamlConverter struct {
+	buf             bytes.Buffer
+	disallowAnchors bool
+
+	// aliased nodes being written, to detect anchors containing themselves
+	onStack map[*yaml.Node]struct{}
+	// depth of aliases being written and number of nodes they produced
+	aliasDepth, aliasExpanded int
+	// depth of merge keys being expanded
+	mergeDepth int
+}
+
+func (c *yamlConverter) write(node *yaml.Node) error {
+	if c.disallowAnchors && node.Anchor != "" {
+		return fmt.Errorf("%w: anchor %q at line %d", ErrYAMLAnchorsDisallowed, node.Anchor, node.Line)
+	}
+	if c.aliasDepth > 0 {
+		if c.aliasExpanded++; c.aliasExpanded > maxYAMLAliasExpansion {
+			return errors.New("YAML document expands to too many nodes through aliases")
+		}
+	}
+
+	buf := &c.buf
+	switch node.Kind {
+	case 0:
+		// Empty document
+		buf.WriteString("null")
+		return nil
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return c.write(node.Content[0])
+	case yaml.AliasNode:
+		if c.disallowAnchors {
+			return fmt.Errorf("%w: alias %q at line %d", ErrYAMLAnchorsDisallowed, node.Value, node.Line)
+		}
+		return c.writeAlias(node)
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := c.write(item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		pairs, err := c.mappingPairs(node)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		for i, pair := range pairs {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(pair.key)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := c.write(pair.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.ScalarNode:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		return nil
+	default:
+		return fmt.Errorf("unsupported YAML node kind %d at line %d", node.Kind, node.Line)
+	}
+}
+
+func (c *yamlConverter) writeAlias(node *yaml.Node) error {
+	target := node.Alias
+	if _, ok := c.onStack[target]; ok {
+		return fmt.Errorf("YAML anchor %q value contains itself (line %d)", node.Value, node.Line)
+	}
+	c.onStack[target] = struct{}{}
+	c.aliasDepth++
+	err := c.write(target)
+	c.aliasDepth--
+	delete(c.onStack, target)
+	return err
+}
+
+type yamlPair struct {
+	key   string
+	value *yaml.Node
+}
+
+// mappingPairs lists the entries of a mapping in document order,
+// expanding merge keys ("<<") without overriding explicitly set keys.
+func (c *yamlConverter) mappingPairs(node *yaml.Node) ([]yamlPair, error) {
+	explicit := make(map[string]struct{}, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; !isYAMLMergeKey(key) {
+			name, err := c.keyString(key)
+			if err != nil {
+				return nil, err
+			}
+			explicit[name] = struct{}{}
+		}
+	}
+
+	var pairs []yamlPair
+	seen := make(map[string]int, len(node.Content)/2)
+	add := func(name string, value *yaml.Node, override bool) {
+		if i, ok := seen[name]; ok {
+			if override {
+				pairs[i].value = value
+			}
+			return
+		}
+		seen[name] = len(pairs)
+		pairs = append(pairs, yamlPair{key: name, value: value})
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isYAMLMergeKey(key) {
+			name, err := c.keyString(key)
+			if err != nil {
+				return nil, err
+			}
+			add(name, value, true)
+			continue
+		}
+		if c.disallowAnchors {
+			return nil, fmt.Errorf("%w: merge key at line %d", ErrYAMLAnchorsDisallowed, key.Line)
+		}
+		merged, err := c.mergedPairs(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range merged {
+			if _, ok := explicit[pair.key]; !ok {
+				add(pair.key, pair.value, false)
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// mergedPairs lists the entries a merge key brings in.
+func (c *yamlConverter) mergedPairs(value *yaml.Node) ([]yamlPair, error) {
+	if c.mergeDepth++; c.mergeDepth > 100 {
+		return nil, fmt.Errorf("YAML merge keys nested too deeply at line %d", value.Line)
+	}
+	defer func() { c.mergeDepth-- }()
+	for value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	switch value.Kind {
+	case yaml.MappingNode:
+		return c.mappingPairs(value)
+	case yaml.SequenceNode:
+		// Earlier mappings in the sequence take precedence
+		var pairs []yamlPair
+		seen := make(map[string]struct{})
+		for _, item := range value.Content {
+			itemPairs, err := c.mergedPairs(item)
+			if err != nil {
+				return nil, err
+			}
+			for _, pair := range itemPairs {
+				if _, ok := seen[pair.key]; !ok {
+					seen[pair.key] = struct{}{}
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+		return pairs, nil
+	default:
+		return nil, fmt.Errorf("cannot merge YAML node of kind %d at line %d", value.Kind, value.Line)
+	}
+}
+
+func isYAMLMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge"
+}
+
+func (c *yamlConverter) keyString(key *yaml.Node) (string, error) {
+	if c.disallowAnchors && key.Anchor != "" {
+		return "", fmt.Errorf("%w: anchor %q at line %d", ErrYAMLAnchorsDisallowed, key.Anchor, key.Line)
+	}
+	if key.Kind == yaml.AliasNode {
+		if c.disallowAnchors {
+			return "", fmt.Errorf("%w: alias %q at line %d", ErrYAMLAnchorsDisallowed, key.Value, key.Line)
+		}
+		key = key.Alias
+	}
+	if key.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("unsupported YAML map key at line %d", key.Line)
+	}
+	var value interface{}
+	if err := key.Decode(&value); err != nil {
+		return "", err
+	}
+	switch k := value.(type) {
+	case string:
+		return k, nil
+	case int:
+		return strconv.Itoa(k), nil
+	case int64:
+		return strconv.FormatInt(k, 10), nil
+	case uint64:
+		return strconv.FormatUint(k, 10), nil
+	case float64:
+		return strconv.FormatFloat(k, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(k), nil
+	default:
+		return "", fmt.Errorf("unsupported YAML map key %q at line %d", key.Value, key.Line)
+	}
+}
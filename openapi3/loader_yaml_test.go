@@ -0,0 +1,116 @@
+package openapi3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var specWithYAMLAnchors = []byte(`
+openapi: 3.0.0
+info:
+  title: &title Anchored
+  version: 1.0.0
+  description: *title
+paths:
+  /pets:
+    get:
+      responses: &responses
+        "200":
+          description: ok
+        default: &error
+          description: error
+    post:
+      responses:
+        <<: *responses
+        "201":
+          description: created
+        default:
+          <<: *error
+          headers:
+            X-Request-Id:
+              schema: {type: string}
+components:
+  schemas:
+    Named: &named
+      type: object
+      properties:
+        name: {type: string}
+    Dated: &dated
+      type: object
+      properties:
+        date: {type: string}
+    Pet:
+      <<: [*named, *dated]
+      required: [name]
+`[1:])
+
+func TestLoadYAMLAnchors(t *testing.T) {
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(specWithYAMLAnchors)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	require.Equal(t, "Anchored", doc.Info.Description)
+
+	get := doc.Paths["/pets"].Get
+	require.Equal(t, "ok", *get.Responses["200"].Value.Description)
+	require.Equal(t, "error", *get.Responses.Default().Value.Description)
+
+	post := doc.Paths["/pets"].Post
+	require.Len(t, post.Responses, 3)
+	require.Equal(t, "created", *post.Responses["201"].Value.Description)
+	require.Equal(t, "error", *post.Responses.Default().Value.Description)
+	require.Contains(t, post.Responses.Default().Value.Headers, "X-Request-Id")
+	require.NotContains(t, get.Responses.Default().Value.Headers, "X-Request-Id")
+
+	// Earlier merged mappings take precedence
+	pet := doc.Components.Schemas["Pet"].Value
+	require.Equal(t, "object", pet.Type)
+	require.Contains(t, pet.Properties, "name")
+	require.NotContains(t, pet.Properties, "date")
+	require.Equal(t, []string{"name"}, pet.Required)
+}
+
+func TestLoadYAMLAnchorsDisallowed(t *testing.T) {
+	loader := NewLoader()
+	loader.DisallowYAMLAnchors = true
+	_, err := loader.LoadFromData(specWithYAMLAnchors)
+	require.True(t, errors.Is(err, ErrYAMLAnchorsDisallowed))
+	require.EqualError(t, err, `YAML anchors, aliases and merge keys are disallowed: anchor "title" at line 3`)
+
+	_, err = loader.LoadFromData([]byte(`
+openapi: 3.0.0
+info: {title: t, version: 1.0.0}
+paths: {}
+`[1:]))
+	require.NoError(t, err)
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	data, err := yamlToJSON([]byte(`
+b: &b
+  z: 1
+  a: [x, *b]
+`[1:]), false)
+	require.Nil(t, data)
+	require.EqualError(t, err, `YAML anchor "b" value contains itself (line 3)`)
+
+	data, err = yamlToJSON([]byte(`
+a: &a
+  <<: *a
+`[1:]), false)
+	require.Nil(t, data)
+	require.Error(t, err)
+
+	data, err = yamlToJSON([]byte(`
+z: 1
+a:
+  200: two hundred
+  "1.50": quoted
+  true: yes
+`[1:]), false)
+	require.NoError(t, err)
+	require.Equal(t, `{"z":1,"a":{"200":"two hundred","1.50":"quoted","true":"yes"}}`, string(data))
+}
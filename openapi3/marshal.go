@@ -0,0 +1,227 @@
+package openapi3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// RefMarshalMode tells how references are written when marshaling a document.
+type RefMarshalMode int
+
+const (
+	// RefsAsWritten writes each $ref as it appeared in the loaded document.
+	RefsAsWritten RefMarshalMode = iota
+
+	// RefsInternalized writes references to other documents as references
+	// to the components section, where the referenced values are added.
+	// See (*T).InternalizeRefs.
+	RefsInternalized
+
+	// RefsInlined writes the referenced values in place of every $ref.
+	// Recursive references cannot be inlined and make marshaling fail.
+	RefsInlined
+)
+
+// MarshalOption allows the modification of how a document is marshaled.
+type MarshalOption func(options *marshalOptions)
+
+type marshalOptions struct {
+	refMode         RefMarshalMode
+	refNameResolver RefNameResolver
+	sortedKeys      bool
+}
+
+// WithRefMarshalMode sets how references of all kinds (schemas, parameters, responses, ...) are written.
+// By default, references are written as they appear in the document.
+func WithRefMarshalMode(mode RefMarshalMode) MarshalOption {
+	return func(options *marshalOptions) {
+		options.refMode = mode
+	}
+}
+
+// WithRefNameResolver sets how components added by RefsInternalized are named.
+// By default, DefaultRefNameResolver is used.
+func WithRefNameResolver(refNameResolver RefNameResolver) MarshalOption {
+	return func(options *marshalOptions) {
+		options.refNameResolver = refNameResolver
+	}
+}
+
+// WithSortedKeys writes the keys of all objects in lexical order.
+// By default, keys are written in the order they were read, new keys last.
+func WithSortedKeys() MarshalOption {
+	return func(options *marshalOptions) {
+		options.sortedKeys = true
+	}
+}
+
+// MarshalJSONWith returns the JSON encoding of T, configured by opts.
+// The document itself is left unchanged.
+func (doc *T) MarshalJSONWith(opts ...MarshalOption) ([]byte, error) {
+	return doc.marshalJSON("", "", opts...)
+}
+
+// MarshalJSONIndent is like MarshalJSONWith but applies Indent to format the output.
+// Each JSON element in the output will begin on a new line beginning with prefix
+// followed by one or more copies of indent according to the indentation nesting.
+// Numbers are written exactly as they were read.
+func (doc *T) MarshalJSONIndent(prefix, indent string, opts ...MarshalOption) ([]byte, error) {
+	return doc.marshalJSON(prefix, indent, opts...)
+}
+
+func (doc *T) marshalJSON(prefix, indent string, opts ...MarshalOption) ([]byte, error) {
+	options := &marshalOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	doc, err := doc.withMarshalOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.sortedKeys {
+		// Objects decoded as maps are marshaled with sorted keys,
+		// json.Number keeps numbers as they were written.
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var contents interface{}
+		if err := dec.Decode(&contents); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(contents); err != nil {
+			return nil, err
+		}
+	}
+
+	if prefix == "" && indent == "" {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, prefix, indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// withMarshalOptions returns the document to marshal given options:
+// either doc itself or a rewritten copy of it.
+func (doc *T) withMarshalOptions(options *marshalOptions) (*T, error) {
+	switch options.refMode {
+	case RefsAsWritten:
+		return doc, nil
+	case RefsInternalized:
+		doc = deepCopy(doc).(*T)
+		doc.InternalizeRefs(context.Background(), options.refNameResolver)
+		return doc, nil
+	case RefsInlined:
+		doc = deepCopy(doc).(*T)
+		inliner := refInliner{
+			done:    make(map[copiedKey]struct{}),
+			onStack: make(map[copiedKey]struct{}),
+		}
+		if err := inliner.inline(reflect.ValueOf(doc), ""); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unsupported reference marshal mode %d", options.refMode)
+	}
+}
+
+// isRefType reports whether t is one of the XxxRef types: a struct made of
+// a Ref string and a Value pointer.
+func isRefType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return false
+	}
+	ref, value := t.Field(0), t.Field(1)
+	return ref.Name == "Ref" && ref.Type.Kind() == reflect.String &&
+		value.Name == "Value" && value.Type.Kind() == reflect.Ptr
+}
+
+// refInliner empties the Ref of every XxxRef it finds so that values are marshaled instead.
+type refInliner struct {
+	done    map[copiedKey]struct{}
+	onStack map[copiedKey]struct{}
+}
+
+func (inliner *refInliner) inline(v reflect.Value, ref string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		ptr := copiedKey{typ: v.Type(), ptr: v.Pointer()}
+		if _, ok := inliner.onStack[ptr]; ok {
+			return fmt.Errorf("cannot inline recursive reference %q", ref)
+		}
+		if _, ok := inliner.done[ptr]; ok {
+			return nil
+		}
+		inliner.onStack[ptr] = struct{}{}
+		if err := inliner.inline(v.Elem(), ref); err != nil {
+			return err
+		}
+		delete(inliner.onStack, ptr)
+		inliner.done[ptr] = struct{}{}
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			return inliner.inline(v.Elem(), ref)
+		}
+
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			if err := inliner.inline(iter.Value(), ref); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Raw JSON or bytes
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := inliner.inline(v.Index(i), ref); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		if isRefType(v.Type()) {
+			refField, valueField := v.Field(0), v.Field(1)
+			if r := refField.String(); r != "" {
+				if valueField.IsNil() {
+					return foundUnresolvedRef(r)
+				}
+				ref = r
+			}
+			if err := inliner.inline(valueField, ref); err != nil {
+				return err
+			}
+			refField.SetString("")
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				if err := inliner.inline(field, ref); err != nil {
+					return err
+				}
+			}
+		}
+		if v.CanAddr() {
+			if pathItem, ok := v.Addr().Interface().(*PathItem); ok {
+				pathItem.Ref = ""
+			}
+		}
+	}
+	return nil
+}
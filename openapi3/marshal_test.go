@@ -0,0 +1,149 @@
+package openapi3
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSONWithRefMarshalMode(t *testing.T) {
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile("testdata/spec.yaml")
+	require.NoError(t, err)
+
+	asIs, err := doc.MarshalJSON()
+	require.NoError(t, err)
+
+	regexpRef := regexp.MustCompile(`"\$ref":`)
+	regexpExternalRef := regexp.MustCompile(`"\$ref":"[^#]`)
+
+	t.Run("as written", func(t *testing.T) {
+		data, err := doc.MarshalJSONWith(WithRefMarshalMode(RefsAsWritten))
+		require.NoError(t, err)
+		require.Equal(t, string(asIs), string(data))
+		require.NotEmpty(t, regexpExternalRef.FindAll(data, -1))
+	})
+
+	t.Run("internalized", func(t *testing.T) {
+		data, err := doc.MarshalJSONWith(WithRefMarshalMode(RefsInternalized))
+		require.NoError(t, err)
+		require.NotEmpty(t, regexpRef.FindAll(data, -1))
+		require.Empty(t, regexpExternalRef.FindAll(data, -1))
+
+		internalized, err := NewLoader().LoadFromData(data)
+		require.NoError(t, err)
+		require.NoError(t, internalized.Validate(loader.Context))
+	})
+
+	t.Run("inlined", func(t *testing.T) {
+		data, err := doc.MarshalJSONWith(WithRefMarshalMode(RefsInlined))
+		require.NoError(t, err)
+		require.Empty(t, regexpRef.FindAll(data, -1))
+
+		inlined, err := NewLoader().LoadFromData(data)
+		require.NoError(t, err)
+		require.NoError(t, inlined.Validate(loader.Context))
+	})
+
+	// The document is left untouched
+	data, err := doc.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, string(asIs), string(data))
+}
+
+func TestMarshalJSONWithRefsInlinedRecursive(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: t, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        next:
+          $ref: '#/components/schemas/Node'
+`[1:])
+
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	_, err = doc.MarshalJSONWith(WithRefMarshalMode(RefsInlined))
+	require.EqualError(t, err, `cannot inline recursive reference "#/components/schemas/Node"`)
+
+	_, err = doc.MarshalJSONWith(WithRefMarshalMode(RefsInternalized))
+	require.NoError(t, err)
+}
+
+func TestMarshalJSONWithRefsInlinedUnresolved(t *testing.T) {
+	doc := &T{
+		OpenAPI: "3.0.0",
+		Info:    &Info{Title: "t", Version: "1"},
+		Paths:   Paths{},
+		Components: Components{
+			Schemas: Schemas{"Pet": {Ref: "#/components/schemas/Missing"}},
+		},
+	}
+	_, err := doc.MarshalJSONWith(WithRefMarshalMode(RefsInlined))
+	require.EqualError(t, err, `found unresolved ref: "#/components/schemas/Missing"`)
+}
+
+func TestDeepCopy(t *testing.T) {
+	node := &Schema{Type: "object", Properties: Schemas{}}
+	node.Properties["next"] = &SchemaRef{Ref: "#/components/schemas/Node", Value: node}
+	doc := &T{Components: Components{Schemas: Schemas{"Node": {Value: node}}}}
+
+	copied := deepCopy(doc).(*T)
+	copiedNode := copied.Components.Schemas["Node"].Value
+	require.NotSame(t, node, copiedNode)
+	require.Same(t, copiedNode, copiedNode.Properties["next"].Value)
+
+	copiedNode.Type = "string"
+	require.Equal(t, "object", node.Type)
+}
+
+func TestMarshalJSONIndent(t *testing.T) {
+	spec := []byte(`{"openapi":"3.0.0","info":{"version":"1","title":"t"},"paths":{},"x-z":{"b":1.50,"a":1e3}}`)
+	loader := NewLoader()
+	loader.PreserveKeyOrder = true
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+
+	data, err := doc.MarshalJSONIndent("", "  ")
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "openapi": "3.0.0",
+  "info": {
+    "version": "1",
+    "title": "t"
+  },
+  "paths": {},
+  "x-z": {
+    "b": 1.50,
+    "a": 1e3
+  },
+  "components": {}
+}`, string(data))
+
+	data, err = doc.MarshalJSONIndent(">", "\t", WithSortedKeys())
+	require.NoError(t, err)
+	require.Equal(t, `{
+>	"components": {},
+>	"info": {
+>		"title": "t",
+>		"version": "1"
+>	},
+>	"openapi": "3.0.0",
+>	"paths": {},
+>	"x-z": {
+>		"a": 1e3,
+>		"b": 1.50
+>	}
+>}`, string(data))
+
+	again, err := doc.MarshalJSONIndent(">", "\t", WithSortedKeys())
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
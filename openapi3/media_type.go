@@ -3,8 +3,6 @@ package openapi3
 import (
 	"context"
 	"errors"
-	"fmt"
-	"sort"
 
 	"github.com/go-openapi/jsonpointer"
 
@@ -94,27 +92,8 @@ func (mediaType *MediaType) Validate(ctx context.Context, opts ...ValidationOpti
 			return nil
 		}
 
-		if example := mediaType.Example; example != nil {
-			if err := validateExampleValue(ctx, example, schema.Value); err != nil {
-				return fmt.Errorf("invalid example: %w", err)
-			}
-		}
-
-		if examples := mediaType.Examples; examples != nil {
-			names := make([]string, 0, len(examples))
-			for name := range examples {
-				names = append(names, name)
-			}
-			sort.Strings(names)
-			for _, k := range names {
-				v := examples[k]
-				if err := v.Validate(ctx); err != nil {
-					return fmt.Errorf("example %s: %w", k, err)
-				}
-				if err := validateExampleValue(ctx, v.Value.Value, schema.Value); err != nil {
-					return fmt.Errorf("example %s: %w", k, err)
-				}
-			}
+		if err := validateExamples(ctx, mediaType.Example, mediaType.Examples, schema.Value, "example "); err != nil {
+			return err
 		}
 	}
 
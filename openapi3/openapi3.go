@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -15,14 +16,19 @@ type T struct {
 
 	OpenAPI      string               `json:"openapi" yaml:"openapi"` // Required
 	Components   Components           `json:"components,omitempty" yaml:"components,omitempty"`
-	Info         *Info                `json:"info" yaml:"info"`   // Required
-	Paths        Paths                `json:"paths" yaml:"paths"` // Required
+	Info         *Info                `json:"info" yaml:"info"`                             // Required
+	Paths        Paths                `json:"paths" yaml:"paths"`                           // Required
+	Webhooks     PathItems            `json:"webhooks,omitempty" yaml:"webhooks,omitempty"` // OpenAPI 3.1
 	Security     SecurityRequirements `json:"security,omitempty" yaml:"security,omitempty"`
 	Servers      Servers              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Tags         Tags                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 
-	visited visitedComponent
+	visited    visitedComponent
+	frozen     bool
+	provenance *Provenance
+	// computed once frozen, see SchemaError.SchemaPointer
+	schemaLocations map[*Schema]string
 }
 
 // MarshalJSON returns the JSON encoding of T.
@@ -36,6 +42,7 @@ func (doc *T) UnmarshalJSON(data []byte) error {
 }
 
 func (doc *T) AddOperation(path string, method string, operation *Operation) {
+	doc.mustNotBeFrozen()
 	paths := doc.Paths
 	if paths == nil {
 		paths = make(Paths)
@@ -43,13 +50,53 @@ func (doc *T) AddOperation(path string, method string, operation *Operation) {
 	}
 	pathItem := paths[path]
 	if pathItem == nil {
+		order := doc.PathsInOrder()
 		pathItem = &PathItem{}
 		paths[path] = pathItem
+		doc.setPathsOrder(append(order, path))
 	}
 	pathItem.SetOperation(method, operation)
 }
 
+// PathsInOrder returns the paths of doc in the order they were read by a Loader
+// with PreserveKeyOrder or added with AddOperation, which is the order they are marshaled in.
+// Paths is a map, which does not keep an order itself: paths set directly in doc.Paths,
+// like all the paths of documents loaded without PreserveKeyOrder, come last, sorted.
+func (doc *T) PathsInOrder() []string {
+	var order []string
+	if doc.keyOrder != nil {
+		order = doc.keyOrder.Fields["paths"]
+	}
+	paths := make([]string, 0, len(doc.Paths))
+	listed := make(map[string]struct{}, len(order))
+	for _, path := range order {
+		if _, ok := doc.Paths[path]; ok {
+			listed[path] = struct{}{}
+			paths = append(paths, path)
+		}
+	}
+	rest := make([]string, 0, len(doc.Paths)-len(paths))
+	for path := range doc.Paths {
+		if _, ok := listed[path]; !ok {
+			rest = append(rest, path)
+		}
+	}
+	sort.Strings(rest)
+	return append(paths, rest...)
+}
+
+func (doc *T) setPathsOrder(paths []string) {
+	if doc.keyOrder == nil {
+		doc.keyOrder = &jsoninfo.KeyOrder{}
+	}
+	if doc.keyOrder.Fields == nil {
+		doc.keyOrder.Fields = make(map[string][]string)
+	}
+	doc.keyOrder.Fields["paths"] = paths
+}
+
 func (doc *T) AddServer(server *Server) {
+	doc.mustNotBeFrozen()
 	doc.Servers = append(doc.Servers, server)
 }
 
@@ -61,60 +108,134 @@ func (doc *T) Validate(ctx context.Context, opts ...ValidationOption) error {
 	if doc.OpenAPI == "" {
 		return errors.New("value of openapi must be a non-empty string")
 	}
+	vo := getValidationOptions(ctx)
+	version, ok := vo.versionProfile()
+	if profile := vo.VersionProfile; profile != "" {
+		if err := doc.validateVersionProfile(profile); err != nil {
+			return err
+		}
+		if err := doc.validateVersionFields(version); err != nil {
+			return err
+		}
+	}
+	if !ok {
+		// Without a profile, follow the version of the document
+		version, _ = parseOpenAPIVersion(doc.OpenAPI)
+	}
+	if !vo.ExamplesValidationDisabled && vo.validatedExamples == nil {
+		// Validate the examples of components once against each schema
+		copied := *vo
+		copied.validatedExamples = make(map[validatedExample]struct{})
+		ctx = context.WithValue(ctx, validationOptionsKey{}, &copied)
+	}
 
+	errs := newValidationErrors(ctx)
 	var wrap func(error) error
 	// NOTE: only mention info/components/paths/... key in this func's errors.
 
 	wrap = func(e error) error { return fmt.Errorf("invalid components: %w", e) }
-	if err := doc.Components.Validate(ctx); err != nil {
-		return wrap(err)
+	if err := errs.add(doc.Components.Validate(ctx), wrap); err != nil {
+		return err
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid info: %w", e) }
 	if v := doc.Info; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
 		}
-	} else {
-		return wrap(errors.New("must be an object"))
+	} else if err := errs.add(errors.New("must be an object"), wrap); err != nil {
+		return err
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid paths: %w", e) }
 	if v := doc.Paths; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
+		}
+	} else if version.minor < 1 {
+		// Paths are optional as of OpenAPI 3.1
+		if err := errs.add(errors.New("must be an object"), wrap); err != nil {
+			return err
 		}
-	} else {
-		return wrap(errors.New("must be an object"))
+	}
+
+	webhooks := make([]string, 0, len(doc.Webhooks))
+	for name := range doc.Webhooks {
+		webhooks = append(webhooks, name)
+	}
+	sort.Strings(webhooks)
+	for _, name := range webhooks {
+		name := name
+		wrap = func(e error) error { return fmt.Errorf("invalid webhooks: webhook %q: %w", name, e) }
+		var err error
+		if v := doc.Webhooks[name]; v == nil {
+			err = errors.New("value MUST be an object")
+		} else {
+			err = v.Validate(ctx)
+		}
+		if err = errs.add(err, wrap); err != nil {
+			return err
+		}
+	}
+
+	// Operations are found in paths, webhooks and callbacks
+	if err := errs.add(doc.validateOperationIDs(ctx), func(e error) error { return e }); err != nil {
+		return err
+	}
+
+	// Security requirements are found in paths, webhooks and callbacks
+	if err := errs.add(doc.validateSecurityRequirements(ctx), func(e error) error { return e }); err != nil {
+		return err
+	}
+
+	// Links are found in components and in responses
+	if err := errs.add(doc.validateLinks(ctx), func(e error) error { return e }); err != nil {
+		return err
+	}
+
+	// Discriminators are found in both components and paths
+	if err := errs.add(doc.validateDiscriminatorMappings(), func(e error) error { return e }); err != nil {
+		return err
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid security: %w", e) }
 	if v := doc.Security; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
 		}
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid servers: %w", e) }
 	if v := doc.Servers; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
 		}
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid tags: %w", e) }
 	if v := doc.Tags; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
+		}
+	}
+	if vo.DeclaredTagsValidationEnabled {
+		if err := errs.add(doc.CheckTagsDeclared(), wrap); err != nil {
+			return err
 		}
 	}
 
 	wrap = func(e error) error { return fmt.Errorf("invalid external docs: %w", e) }
 	if v := doc.ExternalDocs; v != nil {
-		if err := v.Validate(ctx); err != nil {
-			return wrap(err)
+		if err := errs.add(v.Validate(ctx), wrap); err != nil {
+			return err
+		}
+	}
+
+	if len(extensionTypes) != 0 {
+		if err := errs.add(validateExtensions(ctx, doc), func(e error) error { return e }); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return errs.err()
 }
@@ -0,0 +1,641 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI31Document(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+info:
+  title: Pets
+  summary: A pet store.
+  version: 1.0.0
+  license:
+    name: Apache 2.0
+    identifier: Apache-2.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Pets'
+components:
+  pathItems:
+    Pets:
+      get:
+        responses:
+          '200':
+            description: The pets.
+            content:
+              application/json:
+                schema:
+                  type: array
+                  items: {$ref: '#/components/schemas/Pet'}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: [string, 'null']
+          maxLength: 10
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	require.NoError(t, doc.Validate(loader.Context, EnableVersionProfileValidation("3.1.0")))
+
+	require.Equal(t, "A pet store.", doc.Info.Summary)
+	require.Equal(t, "Apache-2.0", doc.Info.License.Identifier)
+	require.NotNil(t, doc.Paths["/pets"].Get)
+	require.Same(t, doc.Components.PathItems["Pets"].Get, doc.Paths["/pets"].Get)
+
+	name := doc.Components.Schemas["Pet"].Value.Properties["name"].Value
+	require.Equal(t, TypeString, name.Type)
+	require.True(t, name.Nullable)
+	require.NoError(t, name.VisitJSON(nil))
+	require.NoError(t, name.VisitJSON("Rex"))
+	require.Error(t, name.VisitJSON(42))
+
+	data, err := json.Marshal(name)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":["string","null"],"maxLength":10}`, string(data))
+
+	data, err = json.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"summary":"A pet store."`)
+	require.Contains(t, string(data), `"identifier":"Apache-2.0"`)
+	require.Contains(t, string(data), `"pathItems":{"Pets":`)
+	require.Contains(t, string(data), `"/pets":{"$ref":"#/components/pathItems/Pets"}`)
+
+	var walked []string
+	err = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if _, ok := value.(*PathItem); ok {
+			walked = append(walked, pointer)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/components/pathItems/Pets", "/paths/~1pets"}, walked)
+}
+
+func TestOpenAPI31Webhooks(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+info: {title: Pets, version: 1.0.0}
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        '200':
+          description: The webhook was received.
+  petGone:
+    $ref: '#/components/pathItems/PetGone'
+components:
+  pathItems:
+    PetGone:
+      delete:
+        parameters:
+          - $ref: '#/components/parameters/PetID'
+        responses:
+          '204':
+            description: The webhook was received.
+  parameters:
+    PetID:
+      name: id
+      in: query
+      schema: {type: integer}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	newPet := doc.Webhooks["newPet"]
+	require.NotNil(t, newPet.Post)
+	require.NotNil(t, newPet.Post.RequestBody.Value.Content["application/json"].Schema.Value)
+	petGone := doc.Webhooks["petGone"]
+	require.Equal(t, "#/components/pathItems/PetGone", petGone.Ref)
+	require.NotNil(t, petGone.Delete)
+	require.Equal(t, "id", petGone.Delete.Parameters[0].Value.Name)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"webhooks":{"newPet":{"post":`)
+	require.Contains(t, string(data), `"petGone":{"$ref":"#/components/pathItems/PetGone"}`)
+
+	doc2, err := NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	require.Len(t, doc2.Webhooks, 2)
+	require.NotNil(t, doc2.Webhooks["petGone"].Delete)
+
+	var walked []string
+	err = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if _, ok := value.(*PathItem); ok {
+			walked = append(walked, pointer)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/components/pathItems/PetGone", "/webhooks/newPet", "/webhooks/petGone"}, walked)
+
+	doc.Webhooks["broken"] = nil
+	require.EqualError(t, doc.Validate(loader.Context), `invalid webhooks: webhook "broken": value MUST be an object`)
+}
+
+func TestOpenAPI31WithoutPaths(t *testing.T) {
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(`{"openapi":"3.1.0","info":{"title":"Empty","version":"1.0.0"}}`))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	doc.OpenAPI = "3.0.3"
+	require.EqualError(t, doc.Validate(loader.Context), "invalid paths: must be an object")
+}
+
+func TestOpenAPI31FieldsRejectedByOpenAPI30Profile(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec string
+		err  string
+	}{
+		"info summary": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","summary":"S","version":"1"},"paths":{}}`,
+			err:  "invalid info: summary is not supported by OpenAPI 3.0.3",
+		},
+		"license identifier": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1","license":{"name":"MIT","identifier":"MIT"}},"paths":{}}`,
+			err:  "invalid info: license identifier is not supported by OpenAPI 3.0.3",
+		},
+		"path items": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"pathItems":{"P":{}}}}`,
+			err:  "invalid components: pathItems are not supported by OpenAPI 3.0.3",
+		},
+		"webhooks": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"webhooks":{"w":{}}}`,
+			err:  "invalid webhooks: webhooks are not supported by OpenAPI 3.0.3",
+		},
+		"null type": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"type":["null","integer"]}}}}`,
+			err:  `invalid components: schema "S": a "null" type is not supported by OpenAPI 3.0.3, use nullable instead`,
+		},
+		"type array": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"type":["string","integer"]}}}}`,
+			err:  `invalid components: schema "S": a type array of several types is not supported by OpenAPI 3.0.3, use anyOf instead`,
+		},
+		"null type only": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"type":["null"]}}}}`,
+			err:  `invalid components: schema "S": a "null" type is not supported by OpenAPI 3.0.3, use nullable instead`,
+		},
+		"contains": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"type":"array","items":{},"contains":{}}}}}`,
+			err:  `invalid components: schema "S": contains, minContains and maxContains are not supported by OpenAPI 3.0.3`,
+		},
+		"if": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"if":{},"then":{}}}}}`,
+			err:  `invalid components: schema "S": if, then and else are not supported by OpenAPI 3.0.3`,
+		},
+		"patternProperties": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"patternProperties":{"^x-":{}}}}}}`,
+			err:  `invalid components: schema "S": patternProperties are not supported by OpenAPI 3.0.3`,
+		},
+		"contentMediaType": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"type":"string","contentMediaType":"application/json"}}}}`,
+			err:  `invalid components: schema "S": contentEncoding and contentMediaType are not supported by OpenAPI 3.0.3`,
+		},
+		"propertyNames": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"propertyNames":{"format":"uuid"}}}}}`,
+			err:  `invalid components: schema "S": propertyNames is not supported by OpenAPI 3.0.3`,
+		},
+		"const": {
+			spec: `{"openapi":"3.0.3","info":{"title":"T","version":"1"},"paths":{},"components":{"schemas":{"S":{"const":"s"}}}}`,
+			err:  `invalid components: schema "S": const is not supported by OpenAPI 3.0.3, use an enum of one value instead`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			loader := NewLoader()
+			doc, err := loader.LoadFromData([]byte(tc.spec))
+			require.NoError(t, err)
+			require.NoError(t, doc.Validate(loader.Context))
+			require.EqualError(t, doc.Validate(loader.Context, EnableVersionProfileValidation("3.0.3")), tc.err)
+		})
+	}
+}
+
+func TestLicenseIdentifierAndURL(t *testing.T) {
+	license := &License{Name: "MIT", Identifier: "MIT", URL: "https://opensource.org/licenses/MIT"}
+	require.EqualError(t, license.Validate(context.Background()), "license identifier and url are mutually exclusive")
+}
+
+func TestSchemaTypeArrays(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{"type":["null","integer"],"minimum":1}`), &schema))
+	require.Equal(t, TypeInteger, schema.Type)
+	require.True(t, schema.Nullable)
+
+	data, err := json.Marshal(&schema)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":["integer","null"],"minimum":1}`, string(data))
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"type":[]}`), &Schema{}), "type: a type array must not be empty")
+	require.EqualError(t, json.Unmarshal([]byte(`{"type":["string","string"]}`), &Schema{}), `type: "string" is repeated in the type array`)
+}
+
+func TestSchemaTypeArrayAlongWithAnyOf(t *testing.T) {
+	for _, spec := range []string{
+		`{"type":["string","integer"],"anyOf":[{"enum":["ab",1]},{"enum":["cd",2]}]}`,
+		`{"type":["string","integer","null"],"anyOf":[{"enum":["ab",1]},{"enum":["cd",2]}],"allOf":[{"not":{"const":"cd"}}]}`,
+	} {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(spec), &schema))
+		require.NoError(t, schema.Validate(context.Background()))
+
+		require.NoError(t, schema.VisitJSON("ab"))
+		require.NoError(t, schema.VisitJSON(float64(1)))
+		require.False(t, schema.IsMatching("a"))
+		require.False(t, schema.IsMatching(float64(0)))
+		require.False(t, schema.IsMatching(true))
+
+		data, err := json.Marshal(&schema)
+		require.NoError(t, err)
+		require.JSONEq(t, spec, string(data))
+	}
+}
+
+func TestSchemaTypeArraysOfSeveralTypes(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+paths: {}
+components:
+  schemas:
+    Id:
+      type: [string, integer]
+      minLength: 3
+      minimum: 1
+    OptionalId:
+      type: [string, integer, "null"]
+    Nothing:
+      type: ["null"]
+    Pet:
+      type: object
+      properties:
+        id: {$ref: '#/components/schemas/Id'}
+        owner: {type: [string, "null"]}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	require.NoError(t, doc.Validate(loader.Context, EnableVersionProfileValidation("3.1.0")))
+
+	id := doc.Components.Schemas["Id"].Value
+	require.NoError(t, id.VisitJSON("abc"))
+	require.NoError(t, id.VisitJSON(float64(12)))
+	require.False(t, id.IsMatching("ab"))
+	require.False(t, id.IsMatching(float64(0)))
+	require.False(t, id.IsMatching(1.5))
+	require.False(t, id.IsMatching(true))
+	require.False(t, id.IsMatching(nil))
+
+	optionalID := doc.Components.Schemas["OptionalId"].Value
+	require.NoError(t, optionalID.VisitJSON(nil))
+	require.NoError(t, optionalID.VisitJSON("abc"))
+	require.False(t, optionalID.IsMatching(true))
+
+	nothing := doc.Components.Schemas["Nothing"].Value
+	require.Equal(t, TypeNull, nothing.Type)
+	require.NoError(t, nothing.VisitJSON(nil))
+	require.False(t, nothing.IsMatching("abc"))
+	require.False(t, nothing.IsMatching(map[string]interface{}{}))
+
+	// Type arrays are marshaled as they were read
+	data, err := json.Marshal(doc.Components.Schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Id": {"type": ["string", "integer"], "minLength": 3, "minimum": 1},
+		"OptionalId": {"type": ["string", "integer", "null"]},
+		"Nothing": {"type": ["null"]},
+		"Pet": {"type": "object", "properties": {
+			"id": {"$ref": "#/components/schemas/Id"},
+			"owner": {"type": ["string", "null"]}
+		}}
+	}`, string(data))
+}
+
+func TestSchemaConst(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Pet:
+      oneOf:
+      - type: object
+        required: [kind]
+        properties:
+          kind: {const: cat}
+          lives: {type: integer}
+      - type: object
+        required: [kind]
+        properties:
+          kind: {const: dog}
+          good: {type: boolean}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	pet := doc.Components.Schemas["Pet"].Value
+	require.Equal(t, "cat", pet.OneOf[0].Value.Properties["kind"].Value.Const)
+
+	require.NoError(t, pet.VisitJSON(map[string]interface{}{"kind": "cat", "lives": 9.0}))
+	require.NoError(t, pet.VisitJSON(map[string]interface{}{"kind": "dog"}))
+	require.Error(t, pet.VisitJSON(map[string]interface{}{"kind": "bird"}))
+
+	kind := pet.OneOf[1].Value.Properties["kind"].Value
+	err = kind.VisitJSON("cat")
+	require.Error(t, err)
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "const", schemaErr.SchemaField)
+	require.Equal(t, `value must be "dog"`, schemaErr.Reason)
+	require.False(t, kind.IsMatching("cat"))
+	require.True(t, kind.IsMatching("dog"))
+
+	data, err := json.Marshal(kind)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"const":"dog"}`, string(data))
+}
+
+func TestSchemaConstNull(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Nothing:
+      const: null
+    NoName:
+      type: string
+      const: null
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	nothing := doc.Components.Schemas["Nothing"].Value
+	require.True(t, nothing.HasConst())
+	require.Nil(t, nothing.Const)
+	require.NoError(t, nothing.VisitJSON(nil))
+	err = nothing.VisitJSON("cat")
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "const", schemaErr.SchemaField)
+	require.Equal(t, "value must be null", schemaErr.Reason)
+	require.False(t, nothing.IsMatching(map[string]interface{}{}))
+
+	// The type still applies
+	require.False(t, doc.Components.Schemas["NoName"].Value.IsMatching(nil))
+
+	data, err := json.Marshal(nothing)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"const":null}`, string(data))
+
+	// A const other than null rejects null
+	require.False(t, (&Schema{Const: "dog", Nullable: true}).IsMatching(nil))
+	require.True(t, (&Schema{Const: "dog"}).HasConst())
+	require.False(t, (&Schema{}).HasConst())
+}
+
+func TestSchemaContains(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Admin:
+      type: object
+      required: [role]
+      properties:
+        role: {const: admin}
+    Users:
+      type: array
+      items: {type: object}
+      contains: {$ref: '#/components/schemas/Admin'}
+    FewAdmins:
+      type: array
+      items: {}
+      contains: {$ref: '#/components/schemas/Admin'}
+      minContains: 2
+      maxContains: 3
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	users := doc.Components.Schemas["Users"].Value
+	require.Equal(t, doc.Components.Schemas["Admin"].Value, users.Contains.Value)
+
+	admin := map[string]interface{}{"role": "admin"}
+	guest := map[string]interface{}{"role": "guest"}
+	require.NoError(t, users.VisitJSON([]interface{}{guest, admin}))
+	require.False(t, users.IsMatching([]interface{}{}))
+
+	err = users.VisitJSON([]interface{}{guest})
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "contains", schemaErr.SchemaField)
+	require.Equal(t, "no item matches the contains schema", schemaErr.Reason)
+
+	// The items not matching are reported with their index
+	err = users.VisitJSON([]interface{}{guest, map[string]interface{}{}}, MultiErrors())
+	var me MultiError
+	require.ErrorAs(t, err, &me)
+	require.Len(t, me, 3)
+	pointers := make([][]string, 0, len(me))
+	for _, err := range me {
+		require.ErrorAs(t, err, &schemaErr)
+		pointers = append(pointers, schemaErr.JSONPointer())
+	}
+	require.Equal(t, [][]string{nil, {"0", "role"}, {"1", "role"}}, pointers)
+
+	fewAdmins := doc.Components.Schemas["FewAdmins"].Value
+	require.NoError(t, fewAdmins.VisitJSON([]interface{}{admin, guest, admin}))
+	err = fewAdmins.VisitJSON([]interface{}{admin, guest})
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "minContains", schemaErr.SchemaField)
+	require.Equal(t, "at least 2 items must match the contains schema", schemaErr.Reason)
+	err = fewAdmins.VisitJSON([]interface{}{admin, admin, admin, admin})
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "maxContains", schemaErr.SchemaField)
+
+	noAdmins := &Schema{Contains: users.Contains, MinContains: new(uint64)}
+	require.NoError(t, noAdmins.VisitJSON([]interface{}{guest}))
+}
+
+func TestSchemaConditionals(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        country: {type: string}
+        postalCode: {type: string}
+      if:
+        properties:
+          country: {const: US}
+      then:
+        properties:
+          postalCode: {pattern: '^[0-9]{5}$'}
+      else:
+        properties:
+          postalCode: {pattern: '^[A-Z0-9 ]+$'}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	address := doc.Components.Schemas["Address"].Value
+
+	require.NoError(t, address.VisitJSON(map[string]interface{}{"country": "US", "postalCode": "12345"}))
+	require.NoError(t, address.VisitJSON(map[string]interface{}{"country": "CA", "postalCode": "K1A 0B1"}))
+	require.False(t, address.IsMatching(map[string]interface{}{"country": "US", "postalCode": "K1A 0B1"}))
+
+	err = address.VisitJSON(map[string]interface{}{"country": "US", "postalCode": "K1A 0B1"})
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "then", schemaErr.SchemaField)
+	require.ErrorAs(t, schemaErr.Origin, &schemaErr)
+	require.Equal(t, "pattern", schemaErr.SchemaField)
+	require.Equal(t, []string{"postalCode"}, schemaErr.JSONPointer())
+
+	err = address.VisitJSON(map[string]interface{}{"country": "CA", "postalCode": "k1a"})
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "else", schemaErr.SchemaField)
+
+	// Without then nor else, if has no effect
+	require.NoError(t, (&Schema{If: address.If}).VisitJSON(map[string]interface{}{"country": 1}))
+}
+
+func TestSchemaConditionalLeavesValueUnchanged(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: Schemas{
+			"kind": NewStringSchema().NewRef(),
+		},
+		If: (&Schema{
+			Properties: Schemas{
+				"flag": (&Schema{Type: TypeBoolean, Default: true}).NewRef(),
+				"kind": (&Schema{Type: TypeString, ReadOnly: true}).NewRef(),
+			},
+		}).NewRef(),
+		Then: (&Schema{Required: []string{"kind"}}).NewRef(),
+	}
+
+	for name, opts := range map[string][]SchemaValidationOption{
+		"request":  {VisitAsRequest(), StripReadOnlyProperties(nil)},
+		"response": {VisitAsResponse()},
+		"defaults": {ApplyDefaults()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			value := map[string]interface{}{"kind": "x"}
+			require.NoError(t, schema.VisitJSON(value, opts...))
+			require.Equal(t, map[string]interface{}{"kind": "x"}, value)
+		})
+	}
+}
+
+func TestSchemaPatternProperties(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Labels:
+      type: object
+      properties:
+        name: {type: string}
+      patternProperties:
+        '^x-': {type: string}
+        '^x-count': {type: integer}
+        '^n[0-9]+$': {type: number}
+      additionalProperties: false
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	labels := doc.Components.Schemas["Labels"].Value
+
+	require.NoError(t, labels.VisitJSON(map[string]interface{}{"name": "a", "x-team": "b", "n1": 1.5}))
+
+	// Only the keys matching neither a property nor a pattern are additional properties
+	err = labels.VisitJSON(map[string]interface{}{"name": "a", "other": "b"})
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "properties", schemaErr.SchemaField)
+	require.Equal(t, `property "other" is unsupported`, schemaErr.Reason)
+
+	err = labels.VisitJSON(map[string]interface{}{"n1": "one"})
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "type", schemaErr.SchemaField)
+	require.Equal(t, []string{"n1"}, schemaErr.JSONPointer())
+
+	// A key is validated against every pattern it matches
+	require.False(t, labels.IsMatching(map[string]interface{}{"x-count": 1.0}))
+	require.False(t, labels.IsMatching(map[string]interface{}{"x-count": "1"}))
+
+	err = (&Schema{PatternProperties: Schemas{"(": labels.Properties["name"]}}).Validate(loader.Context)
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "patternProperties", schemaErr.SchemaField)
+	require.Equal(t, `cannot compile pattern "(": error parsing regexp: missing closing ): `+"`(`", schemaErr.Reason)
+}
+
+func TestSchemaPropertyNames(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Owners:
+      type: object
+      propertyNames: {type: string, pattern: '^[0-9a-f]{8}$', maxLength: 8}
+      additionalProperties: {type: string}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	owners := doc.Components.Schemas["Owners"].Value
+
+	require.NoError(t, owners.VisitJSON(map[string]interface{}{"0123abcd": "a", "89abcdef": "b"}))
+	require.NoError(t, owners.VisitJSON(map[string]interface{}{}))
+
+	err = owners.VisitJSON(map[string]interface{}{"0123abcd": "a", "owner": "b"})
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "propertyNames", schemaErr.SchemaField)
+	require.Equal(t, "owner", schemaErr.Value)
+	require.Equal(t, []string{"owner"}, schemaErr.JSONPointer())
+	require.Contains(t, err.Error(), `string "owner" doesn't match the regular expression "^[0-9a-f]{8}$"`)
+
+	err = owners.VisitJSON(map[string]interface{}{"a": "a", "b": "b"}, MultiErrors())
+	var me MultiError
+	require.ErrorAs(t, err, &me)
+	require.Len(t, me, 2)
+}
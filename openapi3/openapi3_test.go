@@ -468,3 +468,55 @@ components:
 		})
 	}
 }
+
+func TestValidationMultiError(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: ''
+paths:
+  /pets:
+    get:
+      responses: {}
+  /pets/{id}:
+    get:
+      responses:
+        '200':
+          description: A pet
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: nonsense
+  requestBodies:
+    Owner:
+      description: An owner
+servers:
+- url: ''
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `invalid components: schema "Pet": unsupported 'type' value "nonsense"`)
+
+	err = doc.Validate(loader.Context, EnableMultiErrorValidation())
+	require.IsType(t, MultiError{}, err)
+	var messages []string
+	for _, e := range err.(MultiError) {
+		messages = append(messages, e.Error())
+	}
+	require.Equal(t, []string{
+		`invalid components: schema "Pet": unsupported 'type' value "nonsense"`,
+		`invalid components: request body "Owner": content of the request body is required`,
+		`invalid info: value of version must be a non-empty string`,
+		`invalid paths: invalid path /pets: invalid operation GET: the responses object MUST contain at least one response code`,
+		`invalid paths: operation GET /pets/{id} must define exactly all path parameters (missing: [id])`,
+		`invalid servers: value of url must be a non-empty string`,
+	}, messages)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
+	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 
@@ -49,6 +51,10 @@ type Operation struct {
 	Servers *Servers `json:"servers,omitempty" yaml:"servers,omitempty"`
 
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	// Contract holds the limits declared by the contract extensions of the operation.
+	// It is set when unmarshaling, from the extensions which are kept as they are.
+	Contract OperationContract `json:"-" yaml:"-"`
 }
 
 var _ jsonpointer.JSONPointable = (*Operation)(nil)
@@ -64,7 +70,10 @@ func (operation *Operation) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON sets Operation to a copy of data.
 func (operation *Operation) UnmarshalJSON(data []byte) error {
-	return jsoninfo.UnmarshalStrictStruct(data, operation)
+	if err := jsoninfo.UnmarshalStrictStruct(data, operation); err != nil {
+		return err
+	}
+	return operation.decodeContract()
 }
 
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
@@ -117,13 +126,56 @@ func (operation *Operation) AddResponse(status int, response *Response) {
 		responses = NewResponses()
 		operation.Responses = responses
 	}
-	code := "default"
-	if status != 0 {
-		code = strconv.FormatInt(int64(status), 10)
+	if status == 0 {
+		responses.SetDefault(&ResponseRef{Value: response})
+		return
 	}
-	responses[code] = &ResponseRef{
-		Value: response,
+	responses.SetStatus(status, &ResponseRef{Value: response})
+}
+
+// AddResponseWithJSONSchema adds a response for status (0 for "default") described by
+// the status text and holding JSON content of the given schema, and returns the operation.
+func (operation *Operation) AddResponseWithJSONSchema(status int, schema *Schema) *Operation {
+	return operation.AddResponseWithJSONSchemaRef(status, NewSchemaRef("", schema))
+}
+
+// AddResponseWithJSONSchemaRef is AddResponseWithJSONSchema for a schema reference,
+// e.g. NewSchemaRef("#/components/schemas/Pet", nil).
+func (operation *Operation) AddResponseWithJSONSchemaRef(status int, schema *SchemaRef) *Operation {
+	operation.AddResponse(status, NewResponse().WithDescription(http.StatusText(status)).WithJSONSchemaRef(schema))
+	return operation
+}
+
+// AddJSONRequestBody sets the request body to JSON content of the given schema,
+// and returns the operation.
+func (operation *Operation) AddJSONRequestBody(schema *Schema, required bool) *Operation {
+	return operation.AddJSONRequestBodyRef(NewSchemaRef("", schema), required)
+}
+
+// AddJSONRequestBodyRef is AddJSONRequestBody for a schema reference.
+func (operation *Operation) AddJSONRequestBodyRef(schema *SchemaRef, required bool) *Operation {
+	operation.RequestBody = &RequestBodyRef{
+		Value: NewRequestBody().WithJSONSchemaRef(schema).WithRequired(required),
 	}
+	return operation
+}
+
+// AddQueryParam adds a query parameter of the given schema, and returns the operation.
+func (operation *Operation) AddQueryParam(name string, schema *Schema, required bool) *Operation {
+	operation.AddParameter(NewQueryParameter(name).WithSchema(schema).WithRequired(required))
+	return operation
+}
+
+// AddPathParam adds a path parameter of the given schema, and returns the operation.
+func (operation *Operation) AddPathParam(name string, schema *Schema) *Operation {
+	operation.AddParameter(NewPathParameter(name).WithSchema(schema))
+	return operation
+}
+
+// AddHeaderParam adds a header parameter of the given schema, and returns the operation.
+func (operation *Operation) AddHeaderParam(name string, schema *Schema, required bool) *Operation {
+	operation.AddParameter(NewHeaderParameter(name).WithSchema(schema).WithRequired(required))
+	return operation
 }
 
 // Validate returns an error if Operation does not comply with the OpenAPI spec.
@@ -150,6 +202,12 @@ func (operation *Operation) Validate(ctx context.Context, opts ...ValidationOpti
 		return errors.New("value of responses must be an object")
 	}
 
+	if v := operation.Callbacks; v != nil {
+		if err := v.Validate(ctx); err != nil {
+			return err
+		}
+	}
+
 	if v := operation.ExternalDocs; v != nil {
 		if err := v.Validate(ctx); err != nil {
 			return fmt.Errorf("invalid external docs: %w", err)
@@ -158,3 +216,61 @@ func (operation *Operation) Validate(ctx context.Context, opts ...ValidationOpti
 
 	return nil
 }
+
+// validateOperationIDs returns an error if operationIds of doc, in its paths, webhooks
+// and callbacks, are used by several operations or do not match the OperationIDPattern.
+func (doc *T) validateOperationIDs(ctx context.Context) error {
+	errs := newValidationErrors(ctx)
+	noWrap := func(e error) error { return e }
+
+	var pattern *regexp.Regexp
+	if p := getValidationOptions(ctx).OperationIDPattern; p != "" {
+		var err error
+		if pattern, err = regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid operation id pattern %q: %w", p, err)
+		}
+	}
+
+	var operationIDs []string
+	pointers := make(map[string][]string)
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		operation, ok := value.(*Operation)
+		if !ok || operation.OperationID == "" {
+			return nil
+		}
+		id := operation.OperationID
+		if _, ok := pointers[id]; !ok {
+			operationIDs = append(operationIDs, id)
+		}
+		pointers[id] = append(pointers[id], pointer)
+		return nil
+	})
+
+	for _, id := range operationIDs {
+		if pattern != nil && !pattern.MatchString(id) {
+			err := fmt.Errorf("operation id %q at %s does not match pattern %q", id, pointers[id][0], pattern)
+			if err = errs.add(err, noWrap); err != nil {
+				return err
+			}
+		}
+		locations := pointers[id]
+		if len(locations) < 2 {
+			continue
+		}
+		inPaths := 0
+		for _, pointer := range locations {
+			if strings.HasPrefix(pointer, "/paths/") {
+				inPaths++
+			}
+		}
+		if inPaths == len(locations) {
+			// Reported by Paths.Validate
+			continue
+		}
+		err := fmt.Errorf("operations at %s have the same operation id %q", strings.Join(locations, ", "), id)
+		if err = errs.add(err, noWrap); err != nil {
+			return err
+		}
+	}
+	return errs.err()
+}
@@ -0,0 +1,131 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OperationContract holds the limits and metadata an operation declares with extensions
+// registered by RegisterContractExtension, such as its maximum response size.
+// It is decoded from the extensions of the operation when it is unmarshaled.
+// The zero value of a limit means the operation does not declare it.
+type OperationContract struct {
+	// MaxRequestBytes is the maximum size of request bodies, from x-max-request-bytes.
+	MaxRequestBytes int64
+	// MaxResponseBytes is the maximum size of response bodies, from x-max-response-bytes.
+	MaxResponseBytes int64
+	// Timeout is the time allowed to handle a request, from x-timeout-ms.
+	Timeout time.Duration
+
+	// Idempotent overrides whether the operation is idempotent, which otherwise
+	// depends on its method, from x-idempotent. See Operation.Idempotency.
+	Idempotent *bool
+	// IdempotencyKeyHeader is the request header making requests idempotent,
+	// from x-idempotency-key-header, e.g. "Idempotency-Key".
+	IdempotencyKeyHeader string
+
+	// Values holds the values decoded by the contract extensions
+	// registered without a field of their own, by extension name.
+	Values map[string]interface{}
+}
+
+// ContractExtensionDecoder decodes the JSON value of a contract extension into contract.
+type ContractExtensionDecoder func(contract *OperationContract, value json.RawMessage) error
+
+var contractExtensions = map[string]ContractExtensionDecoder{
+	"x-max-request-bytes": func(contract *OperationContract, value json.RawMessage) (err error) {
+		contract.MaxRequestBytes, err = decodeContractSize(value)
+		return
+	},
+	"x-max-response-bytes": func(contract *OperationContract, value json.RawMessage) (err error) {
+		contract.MaxResponseBytes, err = decodeContractSize(value)
+		return
+	},
+	"x-timeout-ms": func(contract *OperationContract, value json.RawMessage) error {
+		ms, err := decodeContractSize(value)
+		if err != nil {
+			return err
+		}
+		if ms == 0 {
+			return errors.New("must be a positive integer")
+		}
+		contract.Timeout = time.Duration(ms) * time.Millisecond
+		return nil
+	},
+	"x-idempotent": func(contract *OperationContract, value json.RawMessage) error {
+		var idempotent bool
+		if err := json.Unmarshal(value, &idempotent); err != nil {
+			return errors.New("must be a boolean")
+		}
+		contract.Idempotent = &idempotent
+		return nil
+	},
+	"x-idempotency-key-header": func(contract *OperationContract, value json.RawMessage) error {
+		var header string
+		if err := json.Unmarshal(value, &header); err != nil || header == "" || strings.IndexFunc(header, func(c rune) bool { return !isTokenChar(c) }) >= 0 {
+			return errors.New("must be a header name")
+		}
+		contract.IdempotencyKeyHeader = header
+		return nil
+	},
+}
+
+func decodeContractSize(value json.RawMessage) (int64, error) {
+	var n int64
+	if err := json.Unmarshal(value, &n); err != nil || n < 0 {
+		return 0, errors.New("must be a non-negative integer")
+	}
+	return n, nil
+}
+
+// RegisterContractExtension registers the decoder of an operation extension,
+// which must start with "x-", making it part of the OperationContract
+// of the operations unmarshaled afterwards.
+//
+// If a decoder for the extension already exists, the function replaces it.
+// This call is not thread-safe: contract extensions should not be registered by multiple goroutines.
+func RegisterContractExtension(name string, decoder ContractExtensionDecoder) {
+	if len(name) < 3 || name[:2] != "x-" {
+		panic(fmt.Sprintf("extension %q does not start with x-", name))
+	}
+	if decoder == nil {
+		panic("decoder is not defined")
+	}
+	contractExtensions[name] = decoder
+}
+
+// UnregisterContractExtension removes the decoder of an operation extension,
+// which is then left in the extensions of the operations unmarshaled afterwards.
+// This call is not thread-safe: contract extensions should not be registered by multiple goroutines.
+func UnregisterContractExtension(name string) {
+	delete(contractExtensions, name)
+}
+
+// decodeContract sets the contract of the operation from its extensions.
+func (operation *Operation) decodeContract() error {
+	operation.Contract = OperationContract{}
+	names := make([]string, 0, len(operation.Extensions))
+	for name := range operation.Extensions {
+		if _, ok := contractExtensions[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := operation.Extensions[name].(json.RawMessage)
+		if !ok {
+			var err error
+			if value, err = json.Marshal(operation.Extensions[name]); err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+		}
+		if err := contractExtensions[name](&operation.Contract, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
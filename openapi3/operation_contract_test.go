@@ -0,0 +1,69 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationContract(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info: {title: T, version: "1"}
+paths:
+  /reports:
+    get:
+      x-max-request-bytes: 0
+      x-max-response-bytes: 1048576
+      x-timeout-ms: 250
+      x-team: reports
+      responses:
+        '200': {description: OK}
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	operation := doc.Paths["/reports"].Get
+	require.Equal(t, OperationContract{MaxResponseBytes: 1 << 20, Timeout: 250 * time.Millisecond}, operation.Contract)
+
+	// Extensions are kept as they are
+	data, err := json.Marshal(operation)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"x-max-request-bytes":0,"x-max-response-bytes":1048576,"x-timeout-ms":250,"x-team":"reports","responses":{"200":{"description":"OK"}}}`, string(data))
+
+	for value, expected := range map[string]string{
+		`{"x-max-response-bytes":-1,"responses":{}}`:   "invalid x-max-response-bytes: must be a non-negative integer",
+		`{"x-max-request-bytes":"1kB","responses":{}}`: "invalid x-max-request-bytes: must be a non-negative integer",
+		`{"x-timeout-ms":0,"responses":{}}`:            "invalid x-timeout-ms: must be a positive integer",
+	} {
+		require.EqualError(t, json.Unmarshal([]byte(value), &Operation{}), expected)
+	}
+}
+
+func TestRegisterContractExtension(t *testing.T) {
+	RegisterContractExtension("x-team", func(contract *OperationContract, value json.RawMessage) error {
+		var team string
+		if err := json.Unmarshal(value, &team); err != nil || team == "" {
+			return errors.New("must be a team name")
+		}
+		if contract.Values == nil {
+			contract.Values = make(map[string]interface{})
+		}
+		contract.Values["x-team"] = team
+		return nil
+	})
+	defer UnregisterContractExtension("x-team")
+
+	var operation Operation
+	require.NoError(t, json.Unmarshal([]byte(`{"x-team":"reports","x-timeout-ms":10,"responses":{}}`), &operation))
+	require.Equal(t, OperationContract{Timeout: 10 * time.Millisecond, Values: map[string]interface{}{"x-team": "reports"}}, operation.Contract)
+	require.EqualError(t, json.Unmarshal([]byte(`{"x-team":"","responses":{}}`), &operation), "invalid x-team: must be a team name")
+
+	require.Panics(t, func() {
+		RegisterContractExtension("team", func(*OperationContract, json.RawMessage) error { return nil })
+	})
+}
@@ -33,6 +33,27 @@ func TestAddResponse(t *testing.T) {
 	require.NotNil(t, "status 400", operation.Responses.Get(400).Value)
 }
 
+func TestOperationBuilders(t *testing.T) {
+	op := NewOperation().
+		AddPathParam("id", NewStringSchema()).
+		AddQueryParam("limit", NewIntegerSchema(), false).
+		AddHeaderParam("X-Request-Id", NewStringSchema(), true).
+		AddJSONRequestBody(NewObjectSchema(), true).
+		AddResponseWithJSONSchemaRef(200, NewSchemaRef("#/components/schemas/Pet", NewObjectSchema())).
+		AddResponseWithJSONSchema(0, NewObjectSchema())
+
+	require.True(t, op.Parameters.GetByInAndName("path", "id").Required)
+	require.False(t, op.Parameters.GetByInAndName("query", "limit").Required)
+	require.Equal(t, "integer", op.Parameters.GetByInAndName("query", "limit").Schema.Value.Type)
+	require.True(t, op.Parameters.GetByInAndName("header", "X-Request-Id").Required)
+	require.True(t, op.RequestBody.Value.Required)
+	require.Equal(t, "object", op.RequestBody.Value.Content.Get("application/json").Schema.Value.Type)
+	require.Equal(t, "OK", *op.Responses.Get(200).Value.Description)
+	require.Equal(t, "#/components/schemas/Pet", op.Responses.Get(200).Value.Content.Get("application/json").Schema.Ref)
+	require.Equal(t, "object", op.Responses.Default().Value.Content.Get("application/json").Schema.Value.Type)
+	require.NoError(t, op.Validate(context.Background()))
+}
+
 func operationWithoutResponses() *Operation {
 	initOperation()
 	return operation
@@ -71,3 +92,55 @@ func TestOperationValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOperationIDs(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+info:
+  title: An API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      responses:
+        '201':
+          description: Added
+      callbacks:
+        petAdded:
+          '{$request.body#/callbackUrl}':
+            post:
+              operationId: notifyPet
+              responses:
+                '200':
+                  description: Notified
+webhooks:
+  newPet:
+    post:
+      operationId: notifyPet
+      responses:
+        '200':
+          description: Notified
+  pet-removed:
+    post:
+      operationId: remove_pet
+      responses:
+        '200':
+          description: Notified
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	err = doc.Validate(loader.Context)
+	require.EqualError(t, err, `operations at /paths/~1pets/post/callbacks/petAdded/{$request.body#~1callbackUrl}/post, `+
+		`/webhooks/newPet/post have the same operation id "notifyPet"`)
+
+	err = doc.Validate(loader.Context, EnableOperationIDPatternValidation("^[a-z][a-zA-Z0-9]*$"), EnableMultiErrorValidation())
+	require.EqualError(t, err, `operations at /paths/~1pets/post/callbacks/petAdded/{$request.body#~1callbackUrl}/post, `+
+		`/webhooks/newPet/post have the same operation id "notifyPet" | `+
+		`operation id "remove_pet" at /webhooks/pet-removed/post does not match pattern "^[a-z][a-zA-Z0-9]*$"`)
+
+	err = doc.Validate(loader.Context, EnableOperationIDPatternValidation("["))
+	require.EqualError(t, err, "invalid operation id pattern \"[\": error parsing regexp: missing closing ]: `[`")
+}
@@ -0,0 +1,92 @@
+package openapi3
+
+import (
+	"sort"
+)
+
+// OperationInfo describes an operation of a document along with the settings
+// it inherits from its path item and from the document.
+type OperationInfo struct {
+	Path      string
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+
+	// Parameters lists the path item parameters not overridden by the operation
+	// (same name and location) followed by the operation's own parameters.
+	Parameters Parameters
+
+	// Servers are the operation's servers if any, otherwise those of the path item,
+	// otherwise those of the document.
+	Servers Servers
+
+	// Security is the operation's security requirements if set, otherwise those of the document.
+	Security SecurityRequirements
+}
+
+// Operations lists the operations of the document sorted by path then method,
+// with their effective parameters, servers and security requirements.
+func (doc *T) Operations() []*OperationInfo {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var infos []*OperationInfo
+	for _, path := range paths {
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := operations[method]
+			info := &OperationInfo{
+				Path:       path,
+				Method:     method,
+				PathItem:   pathItem,
+				Operation:  operation,
+				Parameters: effectiveParameters(pathItem.Parameters, operation.Parameters),
+				Servers:    doc.Servers,
+				Security:   doc.Security,
+			}
+			if operation.Servers != nil && len(*operation.Servers) != 0 {
+				info.Servers = *operation.Servers
+			} else if len(pathItem.Servers) != 0 {
+				info.Servers = pathItem.Servers
+			}
+			if operation.Security != nil {
+				info.Security = *operation.Security
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+func effectiveParameters(pathItemParameters, operationParameters Parameters) Parameters {
+	parameters := make(Parameters, 0, len(pathItemParameters)+len(operationParameters))
+	for _, parameterRef := range pathItemParameters {
+		if parameterRef == nil {
+			continue
+		}
+		if parameter := parameterRef.Value; parameter != nil {
+			if operationParameters.GetByInAndName(parameter.In, parameter.Name) != nil {
+				continue
+			}
+		}
+		parameters = append(parameters, parameterRef)
+	}
+	for _, parameterRef := range operationParameters {
+		if parameterRef != nil {
+			parameters = append(parameters, parameterRef)
+		}
+	}
+	return parameters
+}
@@ -0,0 +1,77 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperations(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Operations
+  version: 1.0.0
+servers:
+  - url: https://doc.example.com
+security:
+  - apiKey: []
+paths:
+  /pets/{id}:
+    servers:
+      - url: https://path.example.com
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+      - {name: verbose, in: query, schema: {type: boolean}}
+    get:
+      parameters:
+        - {name: verbose, in: query, schema: {type: string}}
+        - {name: verbose, in: header, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+    delete:
+      servers:
+        - url: https://op.example.com
+      security: []
+      responses:
+        '204': {description: deleted}
+  /a:
+    post:
+      responses:
+        '200': {description: ok}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-Key}
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(NewLoader().Context))
+
+	ops := doc.Operations()
+	require.Len(t, ops, 3)
+
+	require.Equal(t, "/a", ops[0].Path)
+	require.Equal(t, "POST", ops[0].Method)
+	require.Empty(t, ops[0].Parameters)
+	require.Equal(t, "https://doc.example.com", ops[0].Servers[0].URL)
+	require.Equal(t, doc.Security, ops[0].Security)
+
+	del := ops[1]
+	require.Equal(t, "/pets/{id}", del.Path)
+	require.Equal(t, "DELETE", del.Method)
+	require.Same(t, doc.Paths["/pets/{id}"], del.PathItem)
+	require.Len(t, del.Parameters, 2)
+	require.Equal(t, "https://op.example.com", del.Servers[0].URL)
+	require.NotNil(t, del.Security)
+	require.Empty(t, del.Security)
+
+	get := ops[2]
+	require.Equal(t, "GET", get.Method)
+	require.Equal(t, "https://path.example.com", get.Servers[0].URL)
+	var names []string
+	for _, p := range get.Parameters {
+		names = append(names, p.Value.In+":"+p.Value.Name)
+	}
+	require.Equal(t, []string{"path:id", "query:verbose", "header:verbose"}, names)
+	require.Equal(t, "string", get.Parameters[1].Value.Schema.Value.Type)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
 	"strconv"
 
 	"github.com/go-openapi/jsonpointer"
@@ -59,6 +58,9 @@ func NewParameters() Parameters {
 
 func (parameters Parameters) GetByInAndName(in string, name string) *Parameter {
 	for _, item := range parameters {
+		if item == nil {
+			continue
+		}
 		if v := item.Value; v != nil {
 			if v.Name == name && v.In == in {
 				return v
@@ -220,7 +222,8 @@ func (parameter Parameter) JSONLookup(token string) (interface{}, error) {
 
 // SerializationMethod returns a parameter's serialization method.
 // When a parameter's serialization method is not defined the method returns
-// the default serialization method corresponding to a parameter's location.
+// the default serialization method corresponding to a parameter's location,
+// exploding values by default for the form and deepObject styles only.
 func (parameter *Parameter) SerializationMethod() (*SerializationMethod, error) {
 	switch parameter.In {
 	case ParameterInPath, ParameterInHeader:
@@ -228,7 +231,7 @@ func (parameter *Parameter) SerializationMethod() (*SerializationMethod, error)
 		if style == "" {
 			style = SerializationSimple
 		}
-		explode := false
+		explode := defaultExplode(style)
 		if parameter.Explode != nil {
 			explode = *parameter.Explode
 		}
@@ -238,7 +241,7 @@ func (parameter *Parameter) SerializationMethod() (*SerializationMethod, error)
 		if style == "" {
 			style = SerializationForm
 		}
-		explode := true
+		explode := defaultExplode(style)
 		if parameter.Explode != nil {
 			explode = *parameter.Explode
 		}
@@ -310,6 +313,9 @@ func (parameter *Parameter) Validate(ctx context.Context, opts ...ValidationOpti
 	}
 
 	if content := parameter.Content; content != nil {
+		if len(content) != 1 {
+			return fmt.Errorf("parameter %q content must contain exactly one media type", parameter.Name)
+		}
 		if err := content.Validate(ctx); err != nil {
 			return fmt.Errorf("parameter %q content is invalid: %w", parameter.Name, err)
 		}
@@ -326,25 +332,8 @@ func (parameter *Parameter) Validate(ctx context.Context, opts ...ValidationOpti
 		if vo := getValidationOptions(ctx); vo.ExamplesValidationDisabled {
 			return nil
 		}
-		if example := parameter.Example; example != nil {
-			if err := validateExampleValue(ctx, example, schema.Value); err != nil {
-				return fmt.Errorf("invalid example: %w", err)
-			}
-		} else if examples := parameter.Examples; examples != nil {
-			names := make([]string, 0, len(examples))
-			for name := range examples {
-				names = append(names, name)
-			}
-			sort.Strings(names)
-			for _, k := range names {
-				v := examples[k]
-				if err := v.Validate(ctx); err != nil {
-					return fmt.Errorf("%s: %w", k, err)
-				}
-				if err := validateExampleValue(ctx, v.Value.Value, schema.Value); err != nil {
-					return fmt.Errorf("%s: %w", k, err)
-				}
-			}
+		if err := validateExamples(ctx, parameter.Example, parameter.Examples, schema.Value, ""); err != nil {
+			return err
 		}
 	}
 
@@ -0,0 +1,74 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterSerializationMethod(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	for _, test := range []struct {
+		parameter *Parameter
+		want      SerializationMethod
+	}{
+		{&Parameter{In: ParameterInPath}, SerializationMethod{Style: SerializationSimple}},
+		{&Parameter{In: ParameterInPath, Style: SerializationMatrix}, SerializationMethod{Style: SerializationMatrix}},
+		{&Parameter{In: ParameterInHeader, Explode: boolPtr(true)}, SerializationMethod{Style: SerializationSimple, Explode: true}},
+		{&Parameter{In: ParameterInQuery}, SerializationMethod{Style: SerializationForm, Explode: true}},
+		{&Parameter{In: ParameterInQuery, Style: SerializationPipeDelimited}, SerializationMethod{Style: SerializationPipeDelimited}},
+		{&Parameter{In: ParameterInQuery, Style: SerializationDeepObject}, SerializationMethod{Style: SerializationDeepObject, Explode: true}},
+		{&Parameter{In: ParameterInCookie, Explode: boolPtr(false)}, SerializationMethod{Style: SerializationForm}},
+	} {
+		sm, err := test.parameter.SerializationMethod()
+		require.NoError(t, err)
+		require.Equal(t, test.want, *sm, "%+v", test.parameter)
+	}
+}
+
+func TestParameterValidation(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	schema := NewStringSchema().NewRef()
+	content := NewContentWithJSONSchema(NewObjectSchema())
+	for _, test := range []struct {
+		parameter *Parameter
+		err       string
+	}{
+		{&Parameter{Name: "id", In: ParameterInPath, Required: true, Style: SerializationLabel, Schema: schema}, ""},
+		{&Parameter{Name: "ids", In: ParameterInQuery, Style: SerializationSpaceDelimited, Schema: schema}, ""},
+		{&Parameter{Name: "filter", In: ParameterInQuery, Style: SerializationDeepObject, Schema: schema}, ""},
+		{&Parameter{Name: "filter", In: ParameterInQuery, Content: content}, ""},
+		{
+			&Parameter{Name: "X-Filter", In: ParameterInHeader, Style: SerializationDeepObject, Schema: schema},
+			`parameter "X-Filter" schema is invalid: serialization method with style="deepObject" and explode=true is not supported by a header parameter`,
+		},
+		{
+			&Parameter{Name: "filter", In: ParameterInQuery, Style: SerializationDeepObject, Explode: boolPtr(false), Schema: schema},
+			`parameter "filter" schema is invalid: serialization method with style="deepObject" and explode=false is not supported by a query parameter`,
+		},
+		{
+			&Parameter{Name: "session", In: ParameterInCookie, Style: SerializationSimple, Schema: schema},
+			`parameter "session" schema is invalid: serialization method with style="simple" and explode=false is not supported by a cookie parameter`,
+		},
+		{
+			&Parameter{Name: "filter", In: ParameterInQuery, Schema: schema, Content: content},
+			`parameter "filter" schema is invalid: parameter must contain exactly one of content and schema`,
+		},
+		{
+			&Parameter{Name: "filter", In: ParameterInQuery},
+			`parameter "filter" schema is invalid: parameter must contain exactly one of content and schema`,
+		},
+		{
+			&Parameter{Name: "filter", In: ParameterInQuery, Content: Content{"application/json": content["application/json"], "text/plain": NewMediaType()}},
+			`parameter "filter" content must contain exactly one media type`,
+		},
+	} {
+		err := test.parameter.Validate(context.Background())
+		if test.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, test.err)
+		}
+	}
+}
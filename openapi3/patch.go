@@ -0,0 +1,466 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch document to doc.
+//
+// The patch operates on the document as it is marshaled, with references as written.
+// The references of the patched document are then resolved again, relative to
+// location (nil for documents loaded with LoadFromData), and the document is validated.
+// doc is modified only once all of these succeed.
+func (loader *Loader) ApplyJSONPatch(doc *T, patch []byte, location *url.URL, opts ...ValidationOption) error {
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return fmt.Errorf("invalid JSON patch: %w", err)
+	}
+	return loader.patch(doc, location, opts, func(document interface{}) (interface{}, error) {
+		for i, operation := range operations {
+			var err error
+			if document, err = operation.apply(document); err != nil {
+				return nil, fmt.Errorf("JSON patch operation %d (%s): %w", i, operation.Op, err)
+			}
+		}
+		return document, nil
+	})
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch document to doc.
+// References, validation and failures are handled as by ApplyJSONPatch.
+func (loader *Loader) ApplyMergePatch(doc *T, patch []byte, location *url.URL, opts ...ValidationOption) error {
+	mergePatch, err := decodeOrderedJSON(patch)
+	if err != nil {
+		return fmt.Errorf("invalid JSON merge patch: %w", err)
+	}
+	return loader.patch(doc, location, opts, func(document interface{}) (interface{}, error) {
+		return applyMergePatch(document, mergePatch), nil
+	})
+}
+
+func (loader *Loader) patch(doc *T, location *url.URL, opts []ValidationOption, apply func(interface{}) (interface{}, error)) error {
+	if doc.frozen {
+		return ErrFrozen
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	document, err := decodeOrderedJSON(data)
+	if err != nil {
+		return err
+	}
+	if document, err = apply(document); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(document); err != nil {
+		return err
+	}
+
+	patched := &T{}
+	if err := json.Unmarshal(data, patched); err != nil {
+		return err
+	}
+	loader.recordKeyOrder(data, patched)
+	loader.resetVisitedPathItemRefs()
+	if err := loader.ResolveRefsIn(patched, location); err != nil {
+		return err
+	}
+	if err := patched.Validate(loader.Context, opts...); err != nil {
+		return err
+	}
+	*doc = *patched
+	return nil
+}
+
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (operation jsonPatchOperation) apply(document interface{}) (_ interface{}, err error) {
+	if operation.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parseJSONPointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("%q: %w", *operation.Path, err)
+		}
+	}()
+	var from []string
+	switch operation.Op {
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, errors.New("missing from")
+		}
+		if from, err = parseJSONPointer(*operation.From); err != nil {
+			return nil, err
+		}
+	}
+	var value interface{}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		if value, err = decodeOrderedJSON(operation.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch operation.Op {
+	case "add":
+		return jsonPointerAdd(document, path, value)
+	case "remove":
+		document, _, err = jsonPointerRemove(document, path)
+		return document, err
+	case "replace":
+		if document, _, err = jsonPointerRemove(document, path); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(document, path, value)
+	case "move":
+		if len(from) < len(path) && strings.HasPrefix(*operation.Path, *operation.From+"/") {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		if document, value, err = jsonPointerRemove(document, from); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(document, path, value)
+	case "copy":
+		if value, err = jsonPointerGet(document, from); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(document, path, cloneOrderedJSON(value))
+	case "test":
+		actual, err := jsonPointerGet(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalOrderedJSON(actual, value) {
+			return nil, errors.New("test failed")
+		}
+		return document, nil
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", operation.Op)
+	}
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("JSON pointer %q does not start with a slash", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = unescapeRefString(token)
+	}
+	return tokens, nil
+}
+
+func jsonArrayIndex(token string, length int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index >= length {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return index, nil
+}
+
+func jsonPointerGet(document interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := document.(type) {
+		case *orderedJSONObject:
+			value, ok := node.values[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			document = value
+		case []interface{}:
+			index, err := jsonArrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			document = node[index]
+		default:
+			return nil, fmt.Errorf("cannot find %q in a scalar value", token)
+		}
+	}
+	return document, nil
+}
+
+// jsonPointerUpdate replaces the container at path[:len(path)-1] with the result of update.
+func jsonPointerUpdate(document interface{}, path []string, update func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(document, path[0])
+	}
+	child, err := jsonPointerGet(document, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = jsonPointerUpdate(child, path[1:], update); err != nil {
+		return nil, err
+	}
+	switch node := document.(type) {
+	case *orderedJSONObject:
+		node.set(path[0], child)
+	case []interface{}:
+		index, _ := jsonArrayIndex(path[0], len(node))
+		node[index] = child
+	}
+	return document, nil
+}
+
+func jsonPointerAdd(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return jsonPointerUpdate(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch node := container.(type) {
+		case *orderedJSONObject:
+			node.set(token, value)
+			return node, nil
+		case []interface{}:
+			index := len(node)
+			if token != "-" {
+				var err error
+				if index, err = jsonArrayIndex(token, len(node)+1); err != nil {
+					return nil, err
+				}
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar value", token)
+		}
+	})
+}
+
+func jsonPointerRemove(document interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, document, nil
+	}
+	var removed interface{}
+	document, err := jsonPointerUpdate(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch node := container.(type) {
+		case *orderedJSONObject:
+			value, ok := node.values[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			removed = value
+			node.delete(token)
+			return node, nil
+		case []interface{}:
+			index, err := jsonArrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			removed = node[index]
+			return append(node[:index:index], node[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar value", token)
+		}
+	})
+	return document, removed, err
+}
+
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(*orderedJSONObject)
+	if !ok {
+		return cloneOrderedJSON(patch)
+	}
+	targetObject, ok := target.(*orderedJSONObject)
+	if !ok {
+		targetObject = &orderedJSONObject{values: make(map[string]interface{})}
+	}
+	for _, key := range patchObject.keys {
+		if value := patchObject.values[key]; value == nil {
+			targetObject.delete(key)
+		} else {
+			targetObject.set(key, applyMergePatch(targetObject.values[key], value))
+		}
+	}
+	return targetObject
+}
+
+// orderedJSONObject is a JSON object that keeps the order of its keys.
+type orderedJSONObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (object *orderedJSONObject) set(key string, value interface{}) {
+	if _, ok := object.values[key]; !ok {
+		object.keys = append(object.keys, key)
+	}
+	object.values[key] = value
+}
+
+func (object *orderedJSONObject) delete(key string) {
+	if _, ok := object.values[key]; !ok {
+		return
+	}
+	delete(object.values, key)
+	for i, k := range object.keys {
+		if k == key {
+			object.keys = append(object.keys[:i:i], object.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// MarshalJSON returns the JSON encoding of object, with keys in order.
+func (object *orderedJSONObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range object.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte(':')
+		if data, err = json.Marshal(object.values[key]); err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrderedJSON decodes data into *orderedJSONObject, []interface{}, json.Number,
+// string, bool or nil values.
+func decodeOrderedJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeOrderedJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func decodeOrderedJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		object := &orderedJSONObject{values: make(map[string]interface{})}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := tok.(string)
+			value, err := decodeOrderedJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			object.set(key, value)
+		}
+		_, err := dec.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrderedJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := dec.Token()
+		return array, err
+	default:
+		return tok, nil
+	}
+}
+
+func cloneOrderedJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *orderedJSONObject:
+		object := &orderedJSONObject{
+			keys:   append([]string(nil), value.keys...),
+			values: make(map[string]interface{}, len(value.values)),
+		}
+		for key, v := range value.values {
+			object.values[key] = cloneOrderedJSON(v)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(value))
+		for i, v := range value {
+			array[i] = cloneOrderedJSON(v)
+		}
+		return array
+	default:
+		return value
+	}
+}
+
+func equalOrderedJSON(a, b interface{}) bool {
+	switch a := a.(type) {
+	case *orderedJSONObject:
+		b, ok := b.(*orderedJSONObject)
+		if !ok || len(a.values) != len(b.values) {
+			return false
+		}
+		for key, value := range a.values {
+			other, ok := b.values[key]
+			if !ok || !equalOrderedJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalOrderedJSON(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okA := new(big.Float).SetString(string(a))
+		y, okB := new(big.Float).SetString(string(b))
+		return okA && okB && x.Cmp(y) == 0
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,122 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const patchSpec = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://dev.example.com
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+    Owner:
+      type: object
+`
+
+func TestApplyJSONPatch(t *testing.T) {
+	loader := NewLoader()
+	loader.PreserveKeyOrder = true
+	doc, err := loader.LoadFromData([]byte(patchSpec))
+	require.NoError(t, err)
+
+	err = loader.ApplyJSONPatch(doc, []byte(`[
+		{"op": "test", "path": "/servers/0/url", "value": "https://dev.example.com"},
+		{"op": "replace", "path": "/servers/0/url", "value": "https://prod.example.com"},
+		{"op": "add", "path": "/components/schemas/Pet/properties/owner", "value": {"$ref": "#/components/schemas/Owner"}},
+		{"op": "copy", "from": "/paths/~1pets", "path": "/paths/~1animals"},
+		{"op": "move", "from": "/info/title", "path": "/info/description"},
+		{"op": "add", "path": "/info/title", "value": "Animals"},
+		{"op": "add", "path": "/tags", "value": [{"name": "b"}]},
+		{"op": "add", "path": "/tags/0", "value": {"name": "a"}},
+		{"op": "add", "path": "/tags/-", "value": {"name": "c"}},
+		{"op": "remove", "path": "/tags/1"}
+	]`), nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "https://prod.example.com", doc.Servers[0].URL)
+	require.Equal(t, "Animals", doc.Info.Title)
+	require.Equal(t, "Pets", doc.Info.Description)
+	require.Equal(t, []string{"a", "c"}, []string{doc.Tags[0].Name, doc.Tags[1].Name})
+
+	// References of the patched document are resolved
+	owner := doc.Components.Schemas["Pet"].Value.Properties["owner"]
+	require.Equal(t, "#/components/schemas/Owner", owner.Ref)
+	require.Same(t, doc.Components.Schemas["Owner"].Value, owner.Value)
+	schema := doc.Paths["/animals"].Get.Responses["200"].Value.Content["application/json"].Schema
+	require.Same(t, doc.Components.Schemas["Pet"].Value, schema.Value)
+
+	// Key order is kept
+	data, err := json.Marshal(doc.Info)
+	require.NoError(t, err)
+	require.Equal(t, `{"version":"1.0.0","description":"Pets","title":"Animals"}`, string(data))
+}
+
+func TestApplyJSONPatchFailures(t *testing.T) {
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(patchSpec))
+	require.NoError(t, err)
+
+	for name, patch := range map[string]string{
+		"failed test":       `[{"op": "test", "path": "/info/title", "value": "Animals"}]`,
+		"missing member":    `[{"op": "remove", "path": "/info/summary"}]`,
+		"bad index":         `[{"op": "add", "path": "/servers/01", "value": {"url": "/"}}]`,
+		"move into child":   `[{"op": "move", "from": "/info", "path": "/info/x"}]`,
+		"unknown operation": `[{"op": "frobnicate", "path": "/info"}]`,
+		"dangling ref":      `[{"op": "remove", "path": "/components/schemas/Pet"}]`,
+		"invalid document":  `[{"op": "remove", "path": "/info/version"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := loader.ApplyJSONPatch(doc, []byte(patch), nil)
+			require.Error(t, err)
+			require.Equal(t, "Pets", doc.Info.Title)
+			require.Equal(t, "1.0.0", doc.Info.Version)
+			require.Contains(t, doc.Components.Schemas, "Pet")
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(patchSpec))
+	require.NoError(t, err)
+
+	err = loader.ApplyMergePatch(doc, []byte(`{
+		"info": {"title": "Prod pets", "x-env": "prod"},
+		"servers": [{"url": "https://prod.example.com"}],
+		"components": {"schemas": {"Owner": null, "Pet": {"required": ["name"]}}}
+	}`), nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "Prod pets", doc.Info.Title)
+	require.Equal(t, "1.0.0", doc.Info.Version)
+	require.Contains(t, doc.Info.Extensions, "x-env")
+	require.Len(t, doc.Servers, 1)
+	require.Equal(t, "https://prod.example.com", doc.Servers[0].URL)
+	require.NotContains(t, doc.Components.Schemas, "Owner")
+	pet := doc.Components.Schemas["Pet"].Value
+	require.Equal(t, []string{"name"}, pet.Required)
+	require.Contains(t, pet.Properties, "name")
+	schema := doc.Paths["/pets"].Get.Responses["200"].Value.Content["application/json"].Schema
+	require.Same(t, pet, schema.Value)
+}
@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
@@ -28,13 +29,51 @@ type PathItem struct {
 	Trace       *Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
 	Servers     Servers    `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Parameters  Parameters `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// set once Ref is resolved, with the summary and description of the referenced path item
+	refResolved                bool
+	refSummary, refDescription string
 }
 
 // MarshalJSON returns the JSON encoding of PathItem.
+// When Ref is set, only Ref and the summary and description overriding
+// those of the referenced path item are written.
 func (pathItem *PathItem) MarshalJSON() ([]byte, error) {
+	if ref := pathItem.Ref; ref != "" {
+		value := pathItemRef{Ref: ref}
+		if s := pathItem.Summary; s != pathItem.refSummary {
+			value.Summary = s
+		}
+		if s := pathItem.Description; s != pathItem.refDescription {
+			value.Description = s
+		}
+		return json.Marshal(value)
+	}
 	return jsoninfo.MarshalStrictStruct(pathItem)
 }
 
+type pathItemRef struct {
+	Ref         string `json:"$ref"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// resolveRefWith sets the contents of pathItem to those of the referenced path item,
+// keeping Ref. Per OpenAPI 3.1, a summary or description set next to Ref overrides the referenced one.
+func (pathItem *PathItem) resolveRefWith(resolved PathItem) {
+	ref, summary, description := pathItem.Ref, pathItem.Summary, pathItem.Description
+	*pathItem = resolved
+	pathItem.Ref = ref
+	pathItem.refResolved = true
+	pathItem.refSummary, pathItem.refDescription = resolved.Summary, resolved.Description
+	if summary != "" {
+		pathItem.Summary = summary
+	}
+	if description != "" {
+		pathItem.Description = description
+	}
+}
+
 // UnmarshalJSON sets PathItem to a copy of data.
 func (pathItem *PathItem) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, pathItem)
@@ -42,6 +81,9 @@ func (pathItem *PathItem) UnmarshalJSON(data []byte) error {
 
 func (pathItem *PathItem) Operations() map[string]*Operation {
 	operations := make(map[string]*Operation, 4)
+	if pathItem == nil {
+		return operations
+	}
 	if v := pathItem.Connect; v != nil {
 		operations[http.MethodConnect] = v
 	}
@@ -73,6 +115,9 @@ func (pathItem *PathItem) Operations() map[string]*Operation {
 }
 
 func (pathItem *PathItem) GetOperation(method string) *Operation {
+	if pathItem == nil {
+		return nil
+	}
 	switch method {
 	case http.MethodConnect:
 		return pathItem.Connect
@@ -141,3 +186,6 @@ func (pathItem *PathItem) Validate(ctx context.Context, opts ...ValidationOption
 	}
 	return nil
 }
+
+// PathItems is the map of path items of the components and of the webhooks of OpenAPI 3.1 documents.
+type PathItems map[string]*PathItem
@@ -0,0 +1,89 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathItemRefRoundTrip(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: t, version: 1.0.0}
+paths:
+  /pets:
+    summary: All pets
+    description: Lists pets
+    get:
+      responses:
+        "200":
+          description: ok
+  /animals:
+    $ref: '#/paths/~1pets'
+  /beasts:
+    $ref: '#/paths/~1pets'
+    summary: All beasts
+`[1:])
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	// Resolved for validation and routing
+	animals := doc.Paths["/animals"]
+	require.Equal(t, "#/paths/~1pets", animals.Ref)
+	require.NotNil(t, animals.Get)
+	require.Equal(t, "All pets", animals.Summary)
+	require.Equal(t, "Lists pets", animals.Description)
+
+	// Siblings of $ref override the referenced values
+	beasts := doc.Paths["/beasts"]
+	require.NotNil(t, beasts.Get)
+	require.Equal(t, "All beasts", beasts.Summary)
+	require.Equal(t, "Lists pets", beasts.Description)
+
+	// Resolving again is a no-op
+	err = loader.ResolveRefsIn(doc, nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc.Paths)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "/pets": {"summary": "All pets", "description": "Lists pets", "get": {"responses": {"200": {"description": "ok"}}}},
+  "/animals": {"$ref": "#/paths/~1pets"},
+  "/beasts": {"$ref": "#/paths/~1pets", "summary": "All beasts"}
+}`, string(data))
+
+	data, err = doc.MarshalJSONWith(WithRefMarshalMode(RefsInlined))
+	require.NoError(t, err)
+	inlined, err := NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	require.Empty(t, inlined.Paths["/beasts"].Ref)
+	require.NotNil(t, inlined.Paths["/beasts"].Get)
+	require.Equal(t, "All beasts", inlined.Paths["/beasts"].Summary)
+}
+
+func TestPathItemRefExternal(t *testing.T) {
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile("testdata/pathref.openapi.yml")
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+
+	pathItem := doc.Paths["/test"]
+	require.Equal(t, "circularref.openapi.yml#/paths/~1test", pathItem.Ref)
+	require.NotNil(t, pathItem.Get)
+
+	data, err := json.Marshal(pathItem)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"$ref": "circularref.openapi.yml#/paths/~1test"}`, string(data))
+}
+
+func TestPathItemRefNotLoaded(t *testing.T) {
+	pathItem := &PathItem{Ref: "other.yaml#/paths/~1x", Summary: "X"}
+	data, err := json.Marshal(pathItem)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"$ref": "other.yaml#/paths/~1x", "summary": "X"}`, string(data))
+}
@@ -3,17 +3,29 @@ package openapi3
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 )
 
 // Paths is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#paths-object
+//
+// Paths does not keep the order of its paths: the order in which they were read
+// by a Loader with PreserveKeyOrder or added with T.AddOperation is kept by T,
+// see T.PathsInOrder.
+// Paths differing only by the names of their template variables, e.g.
+// "/users/{id}" and "/users/{userId}", conflict and fail validation.
+// So do paths matching the same requests when neither is more specific than the other,
+// e.g. "/users/{id}/posts" and "/{kind}/me/posts", which both match "/users/me/posts",
+// whereas "/users/me" is more specific than "/users/{id}".
 type Paths map[string]*PathItem
 
 // Validate returns an error if Paths does not comply with the OpenAPI spec.
 func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
+	errs := newValidationErrors(ctx)
+	noWrap := func(e error) error { return e }
 
 	normalizedPaths := make(map[string]string, len(paths))
 
@@ -25,7 +37,10 @@ func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error
 	for _, path := range keys {
 		pathItem := paths[path]
 		if path == "" || path[0] != '/' {
-			return fmt.Errorf("path %q does not start with a forward slash (/)", path)
+			if err := errs.add(fmt.Errorf("path %q does not start with a forward slash (/)", path), noWrap); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if pathItem == nil {
@@ -35,18 +50,13 @@ func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error
 
 		normalizedPath, _, varsInPath := normalizeTemplatedPath(path)
 		if oldPath, ok := normalizedPaths[normalizedPath]; ok {
-			return fmt.Errorf("conflicting paths %q and %q", path, oldPath)
-		}
-		normalizedPaths[path] = path
-
-		var commonParams []string
-		for _, parameterRef := range pathItem.Parameters {
-			if parameterRef != nil {
-				if parameter := parameterRef.Value; parameter != nil && parameter.In == ParameterInPath {
-					commonParams = append(commonParams, parameter.Name)
-				}
+			if err := errs.add(fmt.Errorf("conflicting paths %q and %q", path, oldPath), noWrap); err != nil {
+				return err
 			}
+			continue
 		}
+		normalizedPaths[normalizedPath] = path
+
 		operations := pathItem.Operations()
 		methods := make([]string, 0, len(operations))
 		for method := range operations {
@@ -54,61 +64,163 @@ func (paths Paths) Validate(ctx context.Context, opts ...ValidationOption) error
 		}
 		sort.Strings(methods)
 		for _, method := range methods {
-			operation := operations[method]
-			var setParams []string
-			for _, parameterRef := range operation.Parameters {
-				if parameterRef != nil {
-					if parameter := parameterRef.Value; parameter != nil && parameter.In == ParameterInPath {
-						setParams = append(setParams, parameter.Name)
-					}
-				}
-			}
-			if expected := len(setParams) + len(commonParams); expected != len(varsInPath) {
-				expected -= len(varsInPath)
-				if expected < 0 {
-					expected *= -1
-				}
-				missing := make(map[string]struct{}, expected)
-				definedParams := append(setParams, commonParams...)
-				for _, name := range definedParams {
-					if _, ok := varsInPath[name]; !ok {
-						missing[name] = struct{}{}
-					}
-				}
-				for name := range varsInPath {
-					got := false
-					for _, othername := range definedParams {
-						if othername == name {
-							got = true
-							break
-						}
-					}
-					if !got {
-						missing[name] = struct{}{}
-					}
-				}
-				if len(missing) != 0 {
-					missings := make([]string, 0, len(missing))
-					for name := range missing {
-						missings = append(missings, name)
-					}
-					return fmt.Errorf("operation %s %s must define exactly all path parameters (missing: %v)", method, path, missings)
-				}
+			if err := errs.add(validatePathParameters(method, path, varsInPath, pathItem, operations[method]), noWrap); err != nil {
+				return err
 			}
 		}
 
-		if err := pathItem.Validate(ctx); err != nil {
-			return fmt.Errorf("invalid path %s: %v", path, err)
+		wrap := func(e error) error { return fmt.Errorf("invalid path %s: %v", path, e) }
+		if err := errs.add(pathItem.Validate(ctx), wrap); err != nil {
+			return err
 		}
 	}
 
-	if err := paths.validateUniqueOperationIDs(); err != nil {
+	validPaths := make([]string, 0, len(normalizedPaths))
+	for _, path := range normalizedPaths {
+		validPaths = append(validPaths, path)
+	}
+	if err := errs.add(validateAmbiguousPaths(validPaths), noWrap); err != nil {
 		return err
 	}
 
+	if err := errs.add(paths.validateUniqueOperationIDs(), noWrap); err != nil {
+		return err
+	}
+
+	return errs.err()
+}
+
+// validatePathParameters returns an error if the path parameters of the operation
+// of method, including those of its path item, are not exactly the variables of the template
+// of path, vars, reporting the variables without a parameter and the parameters not in path.
+// Variables are named without the syntax routers may support, such as a regular expression
+// after ':' or '|', or a '*' or '.*' wildcard suffix.
+func validatePathParameters(method, path string, vars map[string]struct{}, pathItem *PathItem, operation *Operation) error {
+	names := make(map[string]struct{}, len(vars))
+	for name := range vars {
+		if i := strings.IndexAny(name, ":|"); i >= 0 {
+			name = name[:i]
+		}
+		if name = strings.TrimSuffix(name, ".*"); strings.HasSuffix(name, "*") {
+			name = name[:len(name)-1]
+		}
+		names[strings.TrimSpace(name)] = struct{}{}
+	}
+	defined := make(map[string]struct{})
+	var notInPath []string
+	for _, parameters := range []Parameters{pathItem.Parameters, operation.Parameters} {
+		for _, parameterRef := range parameters {
+			if parameterRef == nil || parameterRef.Value == nil || parameterRef.Value.In != ParameterInPath {
+				continue
+			}
+			name := parameterRef.Value.Name
+			if _, ok := defined[name]; ok {
+				// Overridden by the operation
+				continue
+			}
+			defined[name] = struct{}{}
+			if _, ok := names[name]; !ok {
+				notInPath = append(notInPath, name)
+			}
+		}
+	}
+	var missing []string
+	for name := range names {
+		if _, ok := defined[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 && len(notInPath) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	sort.Strings(notInPath)
+	var details []string
+	if len(missing) != 0 {
+		details = append(details, fmt.Sprintf("missing: %v", missing))
+	}
+	if len(notInPath) != 0 {
+		details = append(details, fmt.Sprintf("not in path: %v", notInPath))
+	}
+	return fmt.Errorf("operation %s %s must define exactly all path parameters (%s)", method, path, strings.Join(details, ", "))
+}
+
+// validateAmbiguousPaths returns an error for the first pair of paths matching the same requests
+// without one being more specific than the other, that is having a segment of fixed text or
+// text around variables where the other has a variable in each segment where they differ.
+// Paths with a wildcard variable, which may match several segments, are not checked.
+func validateAmbiguousPaths(paths []string) error {
+	sort.Strings(paths)
+	segments := make([][]string, len(paths))
+	for i, path := range paths {
+		normalizedPath, _, _ := normalizeTemplatedPath(path)
+		if !strings.Contains(normalizedPath, "*}") {
+			segments[i] = strings.Split(normalizedPath, "/")
+		}
+	}
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			if pathsAmbiguous(segments[i], segments[j]) {
+				return fmt.Errorf("ambiguous paths %q and %q: they match the same requests and neither is more specific", paths[i], paths[j])
+			}
+		}
+	}
 	return nil
 }
 
+// pathsAmbiguous tells whether the paths with the normalized segments a and b match the same requests
+// with neither being more specific than the other.
+func pathsAmbiguous(a, b []string) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	aMoreSpecific, bMoreSpecific := false, false
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		ra, rb := pathSegmentSpecificity(a[i]), pathSegmentSpecificity(b[i])
+		switch {
+		case ra == rb:
+			// Different texts, or texts around variables that may overlap but are not compared
+			return false
+		case ra > rb:
+			if rb != 0 && !pathSegmentMatches(b[i], a[i]) {
+				return false
+			}
+			aMoreSpecific = true
+		default:
+			if ra != 0 && !pathSegmentMatches(a[i], b[i]) {
+				return false
+			}
+			bMoreSpecific = true
+		}
+	}
+	return aMoreSpecific && bMoreSpecific
+}
+
+// pathSegmentSpecificity is 2 for a normalized segment of fixed text, 1 for one with text around
+// variables, and 0 for a single variable.
+func pathSegmentSpecificity(segment string) int {
+	switch {
+	case segment == "{}":
+		return 0
+	case strings.Contains(segment, "{}"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// pathSegmentMatches tells whether the normalized segment with variables template matches the text of segment.
+func pathSegmentMatches(template, segment string) bool {
+	parts := strings.Split(template, "{}")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".+") + "$").MatchString(segment)
+}
+
 // Find returns a path that matches the key.
 //
 // The method ignores differences in template variable names (except possible "*" suffix).
@@ -129,10 +241,15 @@ func (paths Paths) Find(key string) *PathItem {
 	}
 
 	normalizedPath, expected, _ := normalizeTemplatedPath(key)
-	for path, pathItem := range paths {
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	for _, path := range keys {
 		pathNormalized, got, _ := normalizeTemplatedPath(path)
 		if got == expected && pathNormalized == normalizedPath {
-			return pathItem
+			return paths[path]
 		}
 	}
 	return nil
@@ -75,6 +75,132 @@ paths:
 `,
 			wantErr: `operations "POST /pets" and "POST /users" have the same operation id "createPet"`,
 		},
+		{
+			name: "paths differing only by variable names",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/{id}:
+  /pets/{petId}:
+`,
+			wantErr: `conflicting paths "/pets/{petId}" and "/pets/{id}"`,
+		},
+		{
+			name: "paths more specific than others",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/mine:
+  /pets/{id}:
+  /pets/{id}.json:
+  /pets/{id}/toys/{toyId}:
+  /pets/mine/toys/{toyId}:
+`,
+		},
+		{
+			name: "paths matching the same requests",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/{id}/toys:
+  /{kind}/mine/toys:
+  /{kind}/mine/food:
+`,
+			wantErr: `ambiguous paths "/pets/{id}/toys" and "/{kind}/mine/toys": they match the same requests and neither is more specific`,
+		},
+		{
+			name: "paths with text around variables matching the same requests",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/{id}.json:
+  /{kind}/mine.json:
+`,
+			wantErr: `ambiguous paths "/pets/{id}.json" and "/{kind}/mine.json": they match the same requests and neither is more specific`,
+		},
+		{
+			name: "path parameter not in path",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        200:
+          description: "a pet"
+`,
+			wantErr: `operation GET /pets/{id} must define exactly all path parameters (missing: [id], not in path: [petId])`,
+		},
+		{
+			name: "path parameters of the path item",
+			spec: `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+  license:
+    name: MIT
+paths:
+  /pets/{id}/{rest:.*}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: rest
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        200:
+          description: "a pet"
+    delete:
+      responses:
+        204:
+          description: "pet deleted"
+`,
+			wantErr: `operation DELETE /pets/{id}/{rest:.*} must define exactly all path parameters (missing: [rest])`,
+		},
 	}
 
 	for i := range tests {
@@ -92,3 +218,49 @@ paths:
 		})
 	}
 }
+
+func TestPathsFind(t *testing.T) {
+	paths := Paths{
+		"/pets":               &PathItem{Summary: "pets"},
+		"/pets/{petId}":       &PathItem{Summary: "pet"},
+		"/pets/{petId}/toys":  &PathItem{Summary: "toys"},
+		"/files/{filepath*}":  &PathItem{Summary: "files"},
+		"/users/{id}/friends": &PathItem{Summary: "friends"},
+	}
+	require.Equal(t, "pets", paths.Find("/pets").Summary)
+	require.Equal(t, "pet", paths.Find("/pets/{id}").Summary)
+	require.Equal(t, "toys", paths.Find("/pets/{name}/toys").Summary)
+	require.Equal(t, "files", paths.Find("/files/{path*}").Summary)
+	require.Nil(t, paths.Find("/pets/{petId}/friends"))
+}
+
+func TestPathsInOrder(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /zoos: {}
+  /pets: {}
+`
+	loader := NewLoader()
+	loader.PreserveKeyOrder = true
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/zoos", "/pets"}, doc.PathsInOrder())
+
+	doc.AddOperation("/users", "GET", NewOperation().AddResponseWithJSONSchema(200, NewObjectSchema()))
+	doc.AddOperation("/animals", "GET", NewOperation().AddResponseWithJSONSchema(200, NewObjectSchema()))
+	doc.Paths["/direct"] = &PathItem{}
+	require.Equal(t, []string{"/zoos", "/pets", "/users", "/animals", "/direct"}, doc.PathsInOrder())
+
+	data, err := doc.MarshalJSON()
+	require.NoError(t, err)
+	require.Regexp(t, `"/zoos".*"/pets".*"/users".*"/animals".*"/direct"`, string(data))
+
+	doc = &T{}
+	doc.AddOperation("/b", "GET", NewOperation())
+	doc.AddOperation("/a", "GET", NewOperation())
+	require.Equal(t, []string{"/b", "/a"}, doc.PathsInOrder())
+}
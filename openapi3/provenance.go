@@ -0,0 +1,88 @@
+package openapi3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"time"
+)
+
+// Provenance describes what a document was loaded from.
+type Provenance struct {
+	// LoadedAt is the time the Loader started loading the document.
+	LoadedAt time.Time
+
+	// Sources are the documents read to load the document, itself first and then
+	// the documents its references were resolved in, in the order they were read.
+	Sources []Source
+}
+
+// Source is a document read by a Loader.
+type Source struct {
+	// Location is the URI the document was read from,
+	// empty for the data of Loader.LoadFromData.
+	Location string
+
+	// Hash is the SHA-256 of the bytes read, as "sha256:" followed by its hexadecimal encoding.
+	Hash string
+
+	Size int
+}
+
+// Provenance returns what doc was loaded from, nil if it was not loaded by a Loader
+// nor given a provenance with SetProvenance.
+func (doc *T) Provenance() *Provenance {
+	return doc.provenance
+}
+
+// SetProvenance sets what doc was loaded from, e.g. for a document built or
+// modified after it was loaded.
+func (doc *T) SetProvenance(provenance *Provenance) {
+	doc.mustNotBeFrozen()
+	doc.provenance = provenance
+}
+
+// ContentHash returns the SHA-256 of the normalized JSON encoding of doc, as "sha256:"
+// followed by its hexadecimal encoding. Documents with the same contents have the same
+// hash whatever their format, the order of their keys and how they are indented.
+// The hash changes with the contents of doc, unlike the hashes of its Provenance.
+func (doc *T) ContentHash() (string, error) {
+	data, err := doc.MarshalJSONWith(WithSortedKeys())
+	if err != nil {
+		return "", err
+	}
+	return hashData(data), nil
+}
+
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// startProvenance starts recording the sources read to load a document.
+func (loader *Loader) startProvenance() {
+	loader.provenance = &Provenance{LoadedAt: time.Now()}
+}
+
+// recordSource records data, read from location, among the sources of the document being loaded.
+func (loader *Loader) recordSource(location *url.URL, data []byte) {
+	provenance := loader.provenance
+	if provenance == nil {
+		return
+	}
+	source := Source{Hash: hashData(data), Size: len(data)}
+	if location != nil {
+		source.Location = location.String()
+	}
+	for _, s := range provenance.Sources {
+		if s == source {
+			return
+		}
+	}
+	provenance.Sources = append(provenance.Sources, source)
+}
+
+// attachProvenance sets the sources recorded since startProvenance as the provenance of doc.
+func (loader *Loader) attachProvenance(doc *T) {
+	doc.provenance, loader.provenance = loader.provenance, nil
+}
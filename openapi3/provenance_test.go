@@ -0,0 +1,99 @@
+package openapi3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	root := []byte(`
+openapi: 3.0.0
+info: {title: Pets, version: v1}
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: Pets.
+          content:
+            application/json:
+              schema: {$ref: 'pet.yaml'}
+`)
+	pet := []byte(`{"type": "object", "properties": {"name": {"type": "string"}}}`)
+	hash := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		switch location.Path {
+		case "/specs/openapi.yaml":
+			return root, nil
+		case "/specs/pet.yaml":
+			return pet, nil
+		}
+		return nil, fmt.Errorf("not found: %s", location)
+	}
+	before := time.Now()
+	doc, err := loader.LoadFromURI(&url.URL{Path: "/specs/openapi.yaml"})
+	require.NoError(t, err)
+	provenance := doc.Provenance()
+	require.NotNil(t, provenance)
+	require.False(t, provenance.LoadedAt.Before(before))
+	require.Equal(t, []Source{
+		{Location: "/specs/openapi.yaml", Hash: hash(root), Size: len(root)},
+		{Location: "/specs/pet.yaml", Hash: hash(pet), Size: len(pet)},
+	}, provenance.Sources)
+
+	minimal := []byte(`{"openapi": "3.0.0", "info": {"title": "Pets", "version": "v1"}, "paths": {}}`)
+	doc, err = NewLoader().LoadFromData(minimal)
+	require.NoError(t, err)
+	require.Equal(t, []Source{{Hash: hash(minimal), Size: len(minimal)}}, doc.Provenance().Sources)
+
+	require.Nil(t, (&T{}).Provenance())
+	doc.SetProvenance(nil)
+	require.Nil(t, doc.Provenance())
+}
+
+func TestContentHash(t *testing.T) {
+	yamlDoc, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: v1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: Pets.
+`))
+	require.NoError(t, err)
+	jsonDoc, err := NewLoader().LoadFromData([]byte(`{
+  "paths": {"/pets": {"get": {"responses": {"200": {"description": "Pets."}}}}},
+  "info": {"version": "v1", "title": "Pets"},
+  "openapi": "3.0.0"
+}`))
+	require.NoError(t, err)
+	require.NotEqual(t, yamlDoc.Provenance().Sources, jsonDoc.Provenance().Sources)
+
+	hash, err := yamlDoc.ContentHash()
+	require.NoError(t, err)
+	require.Regexp(t, `^sha256:[0-9a-f]{64}$`, hash)
+	jsonHash, err := jsonDoc.ContentHash()
+	require.NoError(t, err)
+	require.Equal(t, hash, jsonHash)
+
+	jsonDoc.Info.Version = "v2"
+	jsonHash, err = jsonDoc.ContentHash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, jsonHash)
+}
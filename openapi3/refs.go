@@ -47,6 +47,27 @@ func (value *CallbackRef) Validate(ctx context.Context, opts ...ValidationOption
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Callback of CallbackRef, or an *UnresolvedRefError if it is not set,
+// including when CallbackRef is nil.
+func (value *CallbackRef) Resolve() (*Callback, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Callback returns the Callback of CallbackRef, or an empty Callback if it is not set,
+// including when CallbackRef is nil. The empty Callback is not set as its value.
+func (value *CallbackRef) Callback() *Callback {
+	if value == nil || value.Value == nil {
+		return &Callback{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value CallbackRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -90,6 +111,27 @@ func (value *ExampleRef) Validate(ctx context.Context, opts ...ValidationOption)
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Example of ExampleRef, or an *UnresolvedRefError if it is not set,
+// including when ExampleRef is nil.
+func (value *ExampleRef) Resolve() (*Example, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Example returns the Example of ExampleRef, or an empty Example if it is not set,
+// including when ExampleRef is nil. The empty Example is not set as its value.
+func (value *ExampleRef) Example() *Example {
+	if value == nil || value.Value == nil {
+		return &Example{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value ExampleRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -133,6 +175,27 @@ func (value *HeaderRef) Validate(ctx context.Context, opts ...ValidationOption)
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Header of HeaderRef, or an *UnresolvedRefError if it is not set,
+// including when HeaderRef is nil.
+func (value *HeaderRef) Resolve() (*Header, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Header returns the Header of HeaderRef, or an empty Header if it is not set,
+// including when HeaderRef is nil. The empty Header is not set as its value.
+func (value *HeaderRef) Header() *Header {
+	if value == nil || value.Value == nil {
+		return &Header{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value HeaderRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -174,6 +237,27 @@ func (value *LinkRef) Validate(ctx context.Context, opts ...ValidationOption) er
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Link of LinkRef, or an *UnresolvedRefError if it is not set,
+// including when LinkRef is nil.
+func (value *LinkRef) Resolve() (*Link, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Link returns the Link of LinkRef, or an empty Link if it is not set,
+// including when LinkRef is nil. The empty Link is not set as its value.
+func (value *LinkRef) Link() *Link {
+	if value == nil || value.Value == nil {
+		return &Link{}
+	}
+	return value.Value
+}
+
 // ParameterRef represents either a Parameter or a $ref to a Parameter.
 // When serializing and both fields are set, Ref is preferred over Value.
 type ParameterRef struct {
@@ -207,6 +291,27 @@ func (value *ParameterRef) Validate(ctx context.Context, opts ...ValidationOptio
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Parameter of ParameterRef, or an *UnresolvedRefError if it is not set,
+// including when ParameterRef is nil.
+func (value *ParameterRef) Resolve() (*Parameter, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Parameter returns the Parameter of ParameterRef, or an empty Parameter if it is not set,
+// including when ParameterRef is nil. The empty Parameter is not set as its value.
+func (value *ParameterRef) Parameter() *Parameter {
+	if value == nil || value.Value == nil {
+		return &Parameter{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value ParameterRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -250,6 +355,27 @@ func (value *ResponseRef) Validate(ctx context.Context, opts ...ValidationOption
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Response of ResponseRef, or an *UnresolvedRefError if it is not set,
+// including when ResponseRef is nil.
+func (value *ResponseRef) Resolve() (*Response, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Response returns the Response of ResponseRef, or an empty Response if it is not set,
+// including when ResponseRef is nil. The empty Response is not set as its value.
+func (value *ResponseRef) Response() *Response {
+	if value == nil || value.Value == nil {
+		return &Response{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value ResponseRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -293,6 +419,27 @@ func (value *RequestBodyRef) Validate(ctx context.Context, opts ...ValidationOpt
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the RequestBody of RequestBodyRef, or an *UnresolvedRefError if it is not set,
+// including when RequestBodyRef is nil.
+func (value *RequestBodyRef) Resolve() (*RequestBody, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// RequestBody returns the RequestBody of RequestBodyRef, or an empty RequestBody if it is not set,
+// including when RequestBodyRef is nil. The empty RequestBody is not set as its value.
+func (value *RequestBodyRef) RequestBody() *RequestBody {
+	if value == nil || value.Value == nil {
+		return &RequestBody{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value RequestBodyRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -343,6 +490,27 @@ func (value *SchemaRef) Validate(ctx context.Context, opts ...ValidationOption)
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the Schema of SchemaRef, or an *UnresolvedRefError if it is not set,
+// including when SchemaRef is nil.
+func (value *SchemaRef) Resolve() (*Schema, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// Schema returns the Schema of SchemaRef, or an empty Schema if it is not set,
+// including when SchemaRef is nil. The empty Schema is not set as its value.
+func (value *SchemaRef) Schema() *Schema {
+	if value == nil || value.Value == nil {
+		return &Schema{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value SchemaRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
@@ -386,6 +554,27 @@ func (value *SecuritySchemeRef) Validate(ctx context.Context, opts ...Validation
 	return foundUnresolvedRef(value.Ref)
 }
 
+// Resolve returns the SecurityScheme of SecuritySchemeRef, or an *UnresolvedRefError if it is not set,
+// including when SecuritySchemeRef is nil.
+func (value *SecuritySchemeRef) Resolve() (*SecurityScheme, error) {
+	if value == nil {
+		return nil, &UnresolvedRefError{}
+	}
+	if value.Value == nil {
+		return nil, &UnresolvedRefError{Ref: value.Ref}
+	}
+	return value.Value, nil
+}
+
+// SecurityScheme returns the SecurityScheme of SecuritySchemeRef, or an empty SecurityScheme if it is not set,
+// including when SecuritySchemeRef is nil. The empty SecurityScheme is not set as its value.
+func (value *SecuritySchemeRef) SecurityScheme() *SecurityScheme {
+	if value == nil || value.Value == nil {
+		return &SecurityScheme{}
+	}
+	return value.Value
+}
+
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (value SecuritySchemeRef) JSONLookup(token string) (interface{}, error) {
 	if token == "$ref" {
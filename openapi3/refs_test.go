@@ -273,3 +273,36 @@ components:
 	_, _, err = ptr.Get(root)
 	require.Error(t, err)
 }
+
+func TestRefAccessors(t *testing.T) {
+	var nilRef *SchemaRef
+	_, err := nilRef.Resolve()
+	var unresolved *UnresolvedRefError
+	require.ErrorAs(t, err, &unresolved)
+	require.Equal(t, &Schema{}, nilRef.Schema())
+
+	unresolvedRef := &SchemaRef{Ref: "#/components/schemas/Pet"}
+	_, err = unresolvedRef.Resolve()
+	require.EqualError(t, err, `found unresolved ref: "#/components/schemas/Pet"`)
+	require.ErrorAs(t, err, &unresolved)
+	require.Equal(t, "#/components/schemas/Pet", unresolved.Ref)
+	require.Equal(t, "", unresolvedRef.Schema().Type)
+	require.Nil(t, unresolvedRef.Value)
+
+	resolvedRef := &SchemaRef{Ref: "#/components/schemas/Pet", Value: NewObjectSchema()}
+	schema, err := resolvedRef.Resolve()
+	require.NoError(t, err)
+	require.Same(t, resolvedRef.Value, schema)
+	require.Same(t, resolvedRef.Value, resolvedRef.Schema())
+
+	var responses Responses
+	require.Nil(t, responses.Get(200).Response().Description)
+	require.Nil(t, responses.Get(200).Response().Content.Get("application/json"))
+
+	var pathItem *PathItem
+	require.Nil(t, pathItem.GetOperation("GET"))
+	require.Empty(t, pathItem.Operations())
+	require.Nil(t, Parameters{nil}.GetByInAndName(ParameterInPath, "id"))
+	require.Nil(t, Tags{nil}.Get("pets"))
+	require.Nil(t, (&RequestBodyRef{}).RequestBody().GetMediaType("application/json"))
+}
@@ -87,11 +87,10 @@ func (requestBody *RequestBody) WithFormDataSchema(value *Schema) *RequestBody {
 }
 
 func (requestBody *RequestBody) GetMediaType(mediaType string) *MediaType {
-	m := requestBody.Content
-	if m == nil {
+	if requestBody == nil {
 		return nil
 	}
-	return m[mediaType]
+	return requestBody.Content[mediaType]
 }
 
 // MarshalJSON returns the JSON encoding of RequestBody.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 
@@ -24,14 +25,95 @@ func NewResponses() Responses {
 	return r
 }
 
+// ResponseRange is a range of status codes keying Responses, such as 4XX.
+type ResponseRange int
+
+const (
+	ResponseRange1XX ResponseRange = iota + 1
+	ResponseRange2XX
+	ResponseRange3XX
+	ResponseRange4XX
+	ResponseRange5XX
+)
+
+// String returns the key of the range in Responses, e.g. "4XX".
+func (r ResponseRange) String() string {
+	return strconv.Itoa(int(r)) + "XX"
+}
+
 func (responses Responses) Default() *ResponseRef {
 	return responses["default"]
 }
 
+// SetDefault sets the response for the status codes not otherwise documented.
+func (responses Responses) SetDefault(response *ResponseRef) {
+	responses["default"] = response
+}
+
+// Get returns the response for the exact status code status, see FindFor to fall back
+// on its range and the default response.
 func (responses Responses) Get(status int) *ResponseRef {
 	return responses[strconv.FormatInt(int64(status), 10)]
 }
 
+// SetStatus sets the response for a status code.
+func (responses Responses) SetStatus(status int, response *ResponseRef) {
+	responses[strconv.FormatInt(int64(status), 10)] = response
+}
+
+// GetRange returns the response for a range of status codes, keyed as in "4XX" or "4xx".
+func (responses Responses) GetRange(r ResponseRange) *ResponseRef {
+	key := r.String()
+	if response := responses[key]; response != nil {
+		return response
+	}
+	return responses[strings.ToLower(key)]
+}
+
+// SetRange sets the response for a range of status codes.
+func (responses Responses) SetRange(r ResponseRange, response *ResponseRef) {
+	delete(responses, strings.ToLower(r.String()))
+	responses[r.String()] = response
+}
+
+// validateResponsesKey returns an error if key is not a status code,
+// a range of status codes or "default".
+func validateResponsesKey(key string) error {
+	if key == "default" {
+		return nil
+	}
+	if len(key) == 3 && key[0] >= '1' && key[0] <= '5' {
+		if rest := key[1:]; rest == "XX" || rest == "xx" {
+			return nil
+		}
+		if key[1] >= '0' && key[1] <= '9' && key[2] >= '0' && key[2] <= '9' {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid response key %q: must be a status code, a range of status codes such as 2XX, or default", key)
+}
+
+// FindFor returns the response documented for status along with its key,
+// looking for the exact status code, then its range (e.g. "5XX") then "default".
+// It returns nil and "" if there is none.
+func (responses Responses) FindFor(status int) (*ResponseRef, string) {
+	code := strconv.FormatInt(int64(status), 10)
+	if response := responses[code]; response != nil {
+		return response, code
+	}
+	if len(code) == 3 {
+		for _, key := range []string{code[:1] + "XX", code[:1] + "xx"} {
+			if response := responses[key]; response != nil {
+				return response, key
+			}
+		}
+	}
+	if response := responses.Default(); response != nil {
+		return response, "default"
+	}
+	return nil, ""
+}
+
 // Validate returns an error if Responses does not comply with the OpenAPI spec.
 func (responses Responses) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
@@ -46,6 +128,14 @@ func (responses Responses) Validate(ctx context.Context, opts ...ValidationOptio
 	}
 	sort.Strings(keys)
 	for _, key := range keys {
+		if err := validateResponsesKey(key); err != nil {
+			return err
+		}
+		if upper := strings.ToUpper(key); upper != key && strings.HasSuffix(upper, "XX") {
+			if _, ok := responses[upper]; ok {
+				return fmt.Errorf("responses %q and %q are the same range of status codes", upper, key)
+			}
+		}
 		v := responses[key]
 		if err := v.Validate(ctx); err != nil {
 			return err
@@ -0,0 +1,66 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponsesFindFor(t *testing.T) {
+	responses := Responses{
+		"200":     &ResponseRef{Value: NewResponse().WithDescription("ok")},
+		"4XX":     &ResponseRef{Value: NewResponse().WithDescription("client error")},
+		"5xx":     &ResponseRef{Value: NewResponse().WithDescription("server error")},
+		"404":     &ResponseRef{Value: NewResponse().WithDescription("not found")},
+		"default": &ResponseRef{Value: NewResponse().WithDescription("other")},
+	}
+	for status, expected := range map[int]string{
+		200: "200",
+		201: "default",
+		404: "404",
+		400: "4XX",
+		503: "5xx",
+		302: "default",
+	} {
+		response, key := responses.FindFor(status)
+		require.Equal(t, expected, key, status)
+		require.Same(t, responses[expected], response)
+	}
+
+	delete(responses, "default")
+	response, key := responses.FindFor(302)
+	require.Nil(t, response)
+	require.Empty(t, key)
+}
+
+func TestResponsesSetters(t *testing.T) {
+	created := &ResponseRef{Value: NewResponse().WithDescription("created")}
+	clientError := &ResponseRef{Value: NewResponse().WithDescription("client error")}
+	other := &ResponseRef{Value: NewResponse().WithDescription("other")}
+
+	responses := Responses{"4xx": &ResponseRef{Value: NewResponse().WithDescription("old")}}
+	responses.SetStatus(201, created)
+	responses.SetRange(ResponseRange4XX, clientError)
+	responses.SetDefault(other)
+	require.Equal(t, Responses{"201": created, "4XX": clientError, "default": other}, responses)
+	require.Same(t, created, responses.Get(201))
+	require.Same(t, clientError, responses.GetRange(ResponseRange4XX))
+	require.Nil(t, responses.GetRange(ResponseRange5XX))
+	require.Same(t, other, responses.Default())
+	require.Equal(t, "5XX", ResponseRange5XX.String())
+	require.NoError(t, responses.Validate(context.Background()))
+}
+
+func TestResponsesKeysValidation(t *testing.T) {
+	response := &ResponseRef{Value: NewResponse().WithDescription("ok")}
+	for _, key := range []string{"200", "599", "1XX", "5xx", "default"} {
+		require.NoError(t, Responses{key: response}.Validate(context.Background()), key)
+	}
+	for _, key := range []string{"2000", "20x", "20", "6XX", "099", "Default", ""} {
+		err := Responses{key: response}.Validate(context.Background())
+		require.EqualError(t, err, `invalid response key "`+key+`": must be a status code, a range of status codes such as 2XX, or default`)
+	}
+	err := Responses{"200": response, "2XX": response, "2xx": response}.Validate(context.Background())
+	require.EqualError(t, err, `responses "2XX" and "2xx" are the same range of status codes`)
+}
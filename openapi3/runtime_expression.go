@@ -0,0 +1,99 @@
+package openapi3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateRuntimeExpression returns an error if expression is not a runtime expression,
+// such as $request.path.id or $response.body#/items/0/id, as used by links and callbacks.
+// See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#runtimeExpression
+func ValidateRuntimeExpression(expression string) error {
+	switch expression {
+	case "$url", "$method", "$statusCode":
+		return nil
+	}
+	var source string
+	switch {
+	case strings.HasPrefix(expression, "$request."):
+		source = expression[len("$request."):]
+	case strings.HasPrefix(expression, "$response."):
+		source = expression[len("$response."):]
+	default:
+		return fmt.Errorf("invalid runtime expression %q: must be $url, $method, $statusCode, or start with $request. or $response.", expression)
+	}
+	if err := validateRuntimeExpressionSource(source); err != nil {
+		return fmt.Errorf("invalid runtime expression %q: %v", expression, err)
+	}
+	return nil
+}
+
+func validateRuntimeExpressionSource(source string) error {
+	switch {
+	case strings.HasPrefix(source, "header."):
+		token := source[len("header."):]
+		if token == "" {
+			return fmt.Errorf("header name is empty")
+		}
+		for _, c := range token {
+			if !isTokenChar(c) {
+				return fmt.Errorf("header name %q contains %q", token, c)
+			}
+		}
+		return nil
+	case strings.HasPrefix(source, "query."), strings.HasPrefix(source, "path."):
+		return nil
+	case source == "body":
+		return nil
+	case strings.HasPrefix(source, "body#"):
+		pointer := source[len("body#"):]
+		if pointer != "" && pointer[0] != '/' {
+			return fmt.Errorf("%q is not a JSON pointer", pointer)
+		}
+		for i := 0; i < len(pointer); i++ {
+			if pointer[i] == '~' && (i+1 == len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1')) {
+				return fmt.Errorf("JSON pointer %q has an invalid escape", pointer)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("source must be header.<token>, query.<name>, path.<name>, body or body#<json-pointer>")
+	}
+}
+
+// isTokenChar tells whether c can be part of an RFC 7230 token, such as a header name.
+func isTokenChar(c rune) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", c)
+}
+
+// validateRuntimeExpressions returns an error if value is a string starting with $ that is not
+// a runtime expression, or embeds runtime expressions between braces that are not valid,
+// as in "id={$request.path.id}".
+func validateRuntimeExpressions(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	if strings.HasPrefix(s, "$") {
+		return ValidateRuntimeExpression(s)
+	}
+	for {
+		i := strings.Index(s, "{$")
+		if i < 0 {
+			return nil
+		}
+		s = s[i+1:]
+		j := strings.IndexByte(s, '}')
+		if j < 0 {
+			return fmt.Errorf("runtime expression %q is not closed by }", s)
+		}
+		if err := ValidateRuntimeExpression(s[:j]); err != nil {
+			return err
+		}
+		s = s[j+1:]
+	}
+}
@@ -12,10 +12,12 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"sync"
 	"unicode/utf16"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/mohae/deepcopy"
+	"gopkg.in/yaml.v3"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -48,6 +50,10 @@ var (
 	ErrSchemaInputNaN = errors.New("floating point NaN is not allowed")
 	// ErrSchemaInputInf may be returned when validating a number
 	ErrSchemaInputInf = errors.New("floating point Inf is not allowed")
+
+	// ErrSchemaValidationTimeout is returned when validation takes longer than allowed
+	// by WithTimeout, or than the deadline of the context set by WithContext
+	ErrSchemaValidationTimeout = errors.New("schema validation timed out")
 )
 
 // Float64Ptr is a helper for defining OpenAPI schemas.
@@ -119,11 +125,15 @@ type Schema struct {
 	AnyOf        SchemaRefs    `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 	AllOf        SchemaRefs    `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 	Not          *SchemaRef    `json:"not,omitempty" yaml:"not,omitempty"`
+	If           *SchemaRef    `json:"if,omitempty" yaml:"if,omitempty"`     // OpenAPI 3.1
+	Then         *SchemaRef    `json:"then,omitempty" yaml:"then,omitempty"` // OpenAPI 3.1
+	Else         *SchemaRef    `json:"else,omitempty" yaml:"else,omitempty"` // OpenAPI 3.1
 	Type         string        `json:"type,omitempty" yaml:"type,omitempty"`
 	Title        string        `json:"title,omitempty" yaml:"title,omitempty"`
 	Format       string        `json:"format,omitempty" yaml:"format,omitempty"`
 	Description  string        `json:"description,omitempty" yaml:"description,omitempty"`
 	Enum         []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Const        interface{}   `json:"const,omitempty" yaml:"const,omitempty"` // OpenAPI 3.1
 	Default      interface{}   `json:"default,omitempty" yaml:"default,omitempty"`
 	Example      interface{}   `json:"example,omitempty" yaml:"example,omitempty"`
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
@@ -134,7 +144,14 @@ type Schema struct {
 	ExclusiveMin bool `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 	ExclusiveMax bool `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 	// Properties
-	Nullable        bool `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Nullable bool `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	// nullType is set when Nullable was read from a "null" type, as of OpenAPI 3.1
+	nullType bool
+	// nullConst is set when a null Const was read, as of OpenAPI 3.1
+	nullConst bool
+	// typeArray is how the schemas of each of the types of the type array
+	// it was read from are held, as of OpenAPI 3.1
+	typeArray       typeArrayForm
 	ReadOnly        bool `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	WriteOnly       bool `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
 	AllowEmptyValue bool `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
@@ -150,21 +167,103 @@ type Schema struct {
 	MinLength       uint64  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	MaxLength       *uint64 `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 	Pattern         string  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-	compiledPattern *regexp.Regexp
+	compiledPattern Regexp
+	// OpenAPI 3.1
+	ContentEncoding  string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	// frozen is set by T.Freeze for the schema not to be modified when validating
+	frozen bool
 
 	// Array
 	MinItems uint64     `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 	MaxItems *uint64    `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
 	Items    *SchemaRef `json:"items,omitempty" yaml:"items,omitempty"`
+	// OpenAPI 3.1
+	Contains    *SchemaRef `json:"contains,omitempty" yaml:"contains,omitempty"`
+	MinContains *uint64    `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	MaxContains *uint64    `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
 
 	// Object
-	Required                    []string       `json:"required,omitempty" yaml:"required,omitempty"`
-	Properties                  Schemas        `json:"properties,omitempty" yaml:"properties,omitempty"`
-	MinProps                    uint64         `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
-	MaxProps                    *uint64        `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
-	AdditionalPropertiesAllowed *bool          `multijson:"additionalProperties,omitempty" json:"-" yaml:"-"` // In this order...
-	AdditionalProperties        *SchemaRef     `multijson:"additionalProperties,omitempty" json:"-" yaml:"-"` // ...for multijson
-	Discriminator               *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	Required             []string             `json:"required,omitempty" yaml:"required,omitempty"`
+	Properties           Schemas              `json:"properties,omitempty" yaml:"properties,omitempty"`
+	PatternProperties    Schemas              `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"` // OpenAPI 3.1
+	PropertyNames        *SchemaRef           `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`         // OpenAPI 3.1
+	MinProps             uint64               `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+	MaxProps             *uint64              `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	AdditionalProperties AdditionalProperties `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Discriminator        *Discriminator       `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+}
+
+// AdditionalProperties is the additionalProperties keyword of a Schema, which is
+// absent (the zero value), a boolean set in Has or a schema set in Schema.
+type AdditionalProperties struct {
+	Has    *bool
+	Schema *SchemaRef
+}
+
+// MarshalJSON returns the JSON encoding of AdditionalProperties.
+func (addProps AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if addProps.Schema != nil {
+		return json.Marshal(addProps.Schema)
+	}
+	if addProps.Has != nil {
+		return json.Marshal(*addProps.Has)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON sets AdditionalProperties to a copy of data.
+func (addProps *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	*addProps = AdditionalProperties{}
+	switch trimmed := bytes.TrimSpace(data); string(trimmed) {
+	case "null":
+		return nil
+	case "true", "false":
+		has := string(trimmed) == "true"
+		addProps.Has = &has
+		return nil
+	}
+	ref := &SchemaRef{}
+	if err := json.Unmarshal(data, ref); err != nil {
+		return err
+	}
+	addProps.Schema = ref
+	return nil
+}
+
+// MarshalYAML returns the YAML encoding of AdditionalProperties.
+func (addProps AdditionalProperties) MarshalYAML() (interface{}, error) {
+	if addProps.Schema != nil {
+		return addProps.Schema, nil
+	}
+	if addProps.Has != nil {
+		return *addProps.Has, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML sets AdditionalProperties to a copy of the YAML node.
+func (addProps *AdditionalProperties) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return addProps.UnmarshalJSON(data)
+}
+
+// IsZero tells whether additionalProperties is absent.
+func (addProps AdditionalProperties) IsZero() bool {
+	return addProps.Has == nil && addProps.Schema == nil
+}
+
+// Allowed tells whether properties not listed in properties are allowed,
+// that is unless additionalProperties is false.
+func (addProps AdditionalProperties) Allowed() bool {
+	return addProps.Schema != nil || addProps.Has == nil || *addProps.Has
 }
 
 var _ jsonpointer.JSONPointable = (*Schema)(nil)
@@ -173,25 +272,184 @@ func NewSchema() *Schema {
 	return &Schema{}
 }
 
+// typeArrayForm is how a Schema holds a type array of several types.
+type typeArrayForm int
+
+const (
+	noTypeArray typeArrayForm = iota
+	// typeArrayAnyOf is an AnyOf of a schema of each type.
+	typeArrayAnyOf
+	// typeArrayAllOf ends AllOf with a schema with an anyOf of a schema of each type
+	// and a schema with the anyOf of the schema, which it had besides the type array.
+	typeArrayAllOf
+)
+
 // MarshalJSON returns the JSON encoding of Schema.
 func (schema *Schema) MarshalJSON() ([]byte, error) {
 	return jsoninfo.MarshalStrictStruct(schema)
 }
 
+// EncodeWith will be invoked by package "jsoninfo"
+func (schema *Schema) EncodeWith(encoder *jsoninfo.ObjectEncoder, value interface{}) error {
+	if schema.nullConst && schema.Const == nil {
+		// omitempty drops a null Const
+		if err := encoder.EncodeExtension("const", nil); err != nil {
+			return err
+		}
+	}
+	nullType := schema.nullType && schema.Nullable
+	if !nullType && schema.typeArray == noTypeArray {
+		return schema.ExtensionProps.EncodeWith(encoder, value)
+	}
+	// Write the type array it was read from instead of nullable and anyOf
+	copied := *schema
+	var types []string
+	typeSchemas := schema.AnyOf
+	switch n := len(schema.AllOf); {
+	case schema.typeArray == typeArrayAnyOf:
+		copied.AnyOf = nil
+	case schema.typeArray == typeArrayAllOf && n >= 2 && schema.AllOf[n-2].Value != nil && schema.AllOf[n-1].Value != nil:
+		typeSchemas = schema.AllOf[n-2].Value.AnyOf
+		copied.AnyOf = schema.AllOf[n-1].Value.AnyOf
+		if copied.AllOf = schema.AllOf[:n-2]; len(copied.AllOf) == 0 {
+			copied.AllOf = nil
+		}
+	default:
+		typeSchemas = nil
+	}
+	if schema.typeArray != noTypeArray {
+		for _, ref := range typeSchemas {
+			if ref != nil && ref.Value != nil {
+				types = append(types, ref.Value.Type)
+			}
+		}
+	} else if schema.Type != "" && schema.Type != TypeNull {
+		types = append(types, schema.Type)
+	}
+	if nullType {
+		copied.Nullable = false
+		types = append(types, TypeNull)
+	}
+	copied.Type = ""
+	if err := copied.ExtensionProps.EncodeWith(encoder, &copied); err != nil {
+		return err
+	}
+	return encoder.EncodeExtension("type", types)
+}
+
 // UnmarshalJSON sets Schema to a copy of data.
+// As of OpenAPI 3.1, type can be an array of types: "null" sets Nullable,
+// and several other types make an anyOf of a schema of each type,
+// combined in allOf with the anyOf of the schema if it has one.
+// The null type only makes a schema of TypeNull.
 func (schema *Schema) UnmarshalJSON(data []byte) error {
-	return jsoninfo.UnmarshalStrictStruct(data, schema)
+	data, types, err := extractTypeArray(data)
+	if err != nil {
+		return err
+	}
+	if err := jsoninfo.UnmarshalStrictStruct(data, schema); err != nil {
+		return err
+	}
+	if schema.Const == nil && bytes.Contains(data, []byte(`"const"`)) {
+		keys, _ := jsoninfo.ObjectKeys(data)
+		for _, key := range keys {
+			if key == "const" {
+				schema.nullConst = true
+			}
+		}
+	}
+	if types == nil {
+		return nil
+	}
+	if len(types) == 0 {
+		return errors.New("type: a type array must not be empty")
+	}
+	var others []string
+	seen := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		if _, ok := seen[t]; ok {
+			return fmt.Errorf("type: %q is repeated in the type array", t)
+		}
+		seen[t] = struct{}{}
+		if t == TypeNull {
+			schema.Nullable, schema.nullType = true, true
+		} else {
+			others = append(others, t)
+		}
+	}
+	switch len(others) {
+	case 0:
+		schema.Type = TypeNull
+	case 1:
+		schema.Type = others[0]
+	default:
+		typeSchemas := make(SchemaRefs, 0, len(others))
+		for _, t := range others {
+			typeSchemas = append(typeSchemas, NewSchemaRef("", &Schema{Type: t}))
+		}
+		if len(schema.AnyOf) == 0 {
+			schema.AnyOf, schema.typeArray = typeSchemas, typeArrayAnyOf
+			break
+		}
+		// Both must match
+		schema.AllOf = append(schema.AllOf,
+			NewSchemaRef("", &Schema{AnyOf: typeSchemas}),
+			NewSchemaRef("", &Schema{AnyOf: schema.AnyOf}))
+		schema.AnyOf, schema.typeArray = nil, typeArrayAllOf
+	}
+	return nil
+}
+
+// extractTypeArray returns data without the type of the JSON object it holds
+// when that type is an array, and the types of that array.
+func extractTypeArray(data []byte) ([]byte, []string, error) {
+	if !bytes.Contains(data, []byte(`"type"`)) {
+		return data, nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		// Unmarshaling reports the error
+		return data, nil, nil
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return data, nil, nil
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return data, nil, nil
+		}
+		if tok != "type" || len(value) == 0 || value[0] != '[' {
+			continue
+		}
+		var types []string
+		if err := json.Unmarshal(value, &types); err != nil {
+			return nil, nil, fmt.Errorf("type: %w", err)
+		}
+		end := int(dec.InputOffset())
+		start := end - len(value)
+		stripped := make([]byte, 0, len(data)-len(value)+2)
+		stripped = append(stripped, data[:start]...)
+		stripped = append(stripped, `""`...)
+		stripped = append(stripped, data[end:]...)
+		return stripped, types, nil
+	}
+	return data, nil, nil
 }
 
 // JSONLookup implements github.com/go-openapi/jsonpointer#JSONPointable
 func (schema Schema) JSONLookup(token string) (interface{}, error) {
 	switch token {
 	case "additionalProperties":
-		if schema.AdditionalProperties != nil {
-			if schema.AdditionalProperties.Ref != "" {
-				return &Ref{Ref: schema.AdditionalProperties.Ref}, nil
+		if addProps := schema.AdditionalProperties.Schema; addProps != nil {
+			if addProps.Ref != "" {
+				return &Ref{Ref: addProps.Ref}, nil
 			}
-			return schema.AdditionalProperties.Value, nil
+			return addProps.Value, nil
+		}
+		if has := schema.AdditionalProperties.Has; has != nil {
+			return *has, nil
 		}
 	case "not":
 		if schema.Not != nil {
@@ -207,6 +465,41 @@ func (schema Schema) JSONLookup(token string) (interface{}, error) {
 			}
 			return schema.Items.Value, nil
 		}
+	case "if":
+		if schema.If != nil {
+			if schema.If.Ref != "" {
+				return &Ref{Ref: schema.If.Ref}, nil
+			}
+			return schema.If.Value, nil
+		}
+	case "then":
+		if schema.Then != nil {
+			if schema.Then.Ref != "" {
+				return &Ref{Ref: schema.Then.Ref}, nil
+			}
+			return schema.Then.Value, nil
+		}
+	case "else":
+		if schema.Else != nil {
+			if schema.Else.Ref != "" {
+				return &Ref{Ref: schema.Else.Ref}, nil
+			}
+			return schema.Else.Value, nil
+		}
+	case "propertyNames":
+		if schema.PropertyNames != nil {
+			if schema.PropertyNames.Ref != "" {
+				return &Ref{Ref: schema.PropertyNames.Ref}, nil
+			}
+			return schema.PropertyNames.Value, nil
+		}
+	case "contains":
+		if schema.Contains != nil {
+			if schema.Contains.Ref != "" {
+				return &Ref{Ref: schema.Contains.Ref}, nil
+			}
+			return schema.Contains.Value, nil
+		}
 	case "oneOf":
 		return schema.OneOf, nil
 	case "anyOf":
@@ -223,6 +516,8 @@ func (schema Schema) JSONLookup(token string) (interface{}, error) {
 		return schema.Description, nil
 	case "enum":
 		return schema.Enum, nil
+	case "const":
+		return schema.Const, nil
 	case "default":
 		return schema.Default, nil
 	case "example":
@@ -230,7 +525,7 @@ func (schema Schema) JSONLookup(token string) (interface{}, error) {
 	case "externalDocs":
 		return schema.ExternalDocs, nil
 	case "additionalPropertiesAllowed":
-		return schema.AdditionalPropertiesAllowed, nil
+		return schema.AdditionalProperties.Has, nil
 	case "uniqueItems":
 		return schema.UniqueItems, nil
 	case "exclusiveMin":
@@ -261,14 +556,24 @@ func (schema Schema) JSONLookup(token string) (interface{}, error) {
 		return schema.MaxLength, nil
 	case "pattern":
 		return schema.Pattern, nil
+	case "contentEncoding":
+		return schema.ContentEncoding, nil
+	case "contentMediaType":
+		return schema.ContentMediaType, nil
 	case "minItems":
 		return schema.MinItems, nil
 	case "maxItems":
 		return schema.MaxItems, nil
+	case "minContains":
+		return schema.MinContains, nil
+	case "maxContains":
+		return schema.MaxContains, nil
 	case "required":
 		return schema.Required, nil
 	case "properties":
 		return schema.Properties, nil
+	case "patternProperties":
+		return schema.PatternProperties, nil
 	case "minProps":
 		return schema.MinProps, nil
 	case "maxProps":
@@ -538,30 +843,35 @@ func (schema *Schema) WithMaxProperties(i int64) *Schema {
 }
 
 func (schema *Schema) WithAnyAdditionalProperties() *Schema {
-	schema.AdditionalProperties = nil
 	t := true
-	schema.AdditionalPropertiesAllowed = &t
+	schema.AdditionalProperties = AdditionalProperties{Has: &t}
+	return schema
+}
+
+// WithoutAdditionalProperties sets additionalProperties to false.
+func (schema *Schema) WithoutAdditionalProperties() *Schema {
+	f := false
+	schema.AdditionalProperties = AdditionalProperties{Has: &f}
 	return schema
 }
 
 func (schema *Schema) WithAdditionalProperties(v *Schema) *Schema {
 	if v == nil {
-		schema.AdditionalProperties = nil
+		schema.AdditionalProperties = AdditionalProperties{}
 	} else {
-		schema.AdditionalProperties = &SchemaRef{
-			Value: v,
-		}
+		schema.AdditionalProperties = AdditionalProperties{Schema: &SchemaRef{Value: v}}
 	}
 	return schema
 }
 
 func (schema *Schema) IsEmpty() bool {
-	if schema.Type != "" || schema.Format != "" || len(schema.Enum) != 0 ||
+	if schema.Type != "" || schema.Format != "" || len(schema.Enum) != 0 || schema.HasConst() ||
 		schema.UniqueItems || schema.ExclusiveMin || schema.ExclusiveMax ||
 		schema.Nullable || schema.ReadOnly || schema.WriteOnly || schema.AllowEmptyValue ||
 		schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil ||
 		schema.MinLength != 0 || schema.MaxLength != nil || schema.Pattern != "" ||
-		schema.MinItems != 0 || schema.MaxItems != nil ||
+		schema.ContentEncoding != "" || schema.ContentMediaType != "" ||
+		schema.MinItems != 0 || schema.MaxItems != nil || schema.Contains != nil ||
 		len(schema.Required) != 0 ||
 		schema.MinProps != 0 || schema.MaxProps != nil {
 		return false
@@ -569,20 +879,28 @@ func (schema *Schema) IsEmpty() bool {
 	if n := schema.Not; n != nil && !n.Value.IsEmpty() {
 		return false
 	}
-	if ap := schema.AdditionalProperties; ap != nil && !ap.Value.IsEmpty() {
+	if ap := schema.AdditionalProperties.Schema; ap != nil && !ap.Value.IsEmpty() {
 		return false
 	}
-	if apa := schema.AdditionalPropertiesAllowed; apa != nil && !*apa {
+	if !schema.AdditionalProperties.Allowed() {
 		return false
 	}
 	if items := schema.Items; items != nil && !items.Value.IsEmpty() {
 		return false
 	}
+	if pn := schema.PropertyNames; pn != nil && !pn.Value.IsEmpty() {
+		return false
+	}
 	for _, s := range schema.Properties {
 		if !s.Value.IsEmpty() {
 			return false
 		}
 	}
+	for _, s := range schema.PatternProperties {
+		if !s.Value.IsEmpty() {
+			return false
+		}
+	}
 	for _, s := range schema.OneOf {
 		if !s.Value.IsEmpty() {
 			return false
@@ -598,6 +916,13 @@ func (schema *Schema) IsEmpty() bool {
 			return false
 		}
 	}
+	if schema.If != nil {
+		for _, s := range []*SchemaRef{schema.Then, schema.Else} {
+			if s != nil && !s.Value.IsEmpty() {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -621,6 +946,18 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 		return errors.New("a property MUST NOT be marked as both readOnly and writeOnly being true")
 	}
 
+	if version, ok := validationOpts.versionProfile(); ok {
+		if err = validateSchemaVersionProfile(schema, version); err != nil {
+			return
+		}
+	}
+
+	if schema.Discriminator != nil {
+		if err = schema.validateDiscriminator(ctx); err != nil {
+			return
+		}
+	}
+
 	for _, item := range schema.OneOf {
 		v := item.Value
 		if v == nil {
@@ -664,13 +1001,14 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 	schemaType := schema.Type
 	switch schemaType {
 	case "":
+	case TypeNull:
 	case TypeBoolean:
 	case TypeNumber:
 		if format := schema.Format; len(format) > 0 {
 			switch format {
 			case "float", "double":
 			default:
-				if validationOpts.SchemaFormatValidationEnabled {
+				if _, ok := formatValidators[format]; !ok && validationOpts.SchemaFormatValidationEnabled {
 					return unsupportedFormat(format)
 				}
 			}
@@ -680,7 +1018,7 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 			switch format {
 			case "int32", "int64":
 			default:
-				if validationOpts.SchemaFormatValidationEnabled {
+				if _, ok := formatValidators[format]; !ok && validationOpts.SchemaFormatValidationEnabled {
 					return unsupportedFormat(format)
 				}
 			}
@@ -702,7 +1040,8 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 			case "email", "hostname", "ipv4", "ipv6", "uri", "uri-reference":
 			default:
 				// Try to check for custom defined formats
-				if _, ok := SchemaStringFormats[format]; !ok && validationOpts.SchemaFormatValidationEnabled {
+				_, isString := SchemaStringFormats[format]
+				if _, ok := formatValidators[format]; !ok && !isString && validationOpts.SchemaFormatValidationEnabled {
 					return unsupportedFormat(format)
 				}
 			}
@@ -731,6 +1070,29 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 		}
 	}
 
+	if ref := schema.Contains; ref != nil {
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(ctx, stack); err != nil {
+			return
+		}
+	}
+
+	for _, ref := range []*SchemaRef{schema.If, schema.Then, schema.Else} {
+		if ref == nil {
+			continue
+		}
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(ctx, stack); err != nil {
+			return
+		}
+	}
+
 	properties := make([]string, 0, len(schema.Properties))
 	for name := range schema.Properties {
 		properties = append(properties, name)
@@ -747,7 +1109,40 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 		}
 	}
 
-	if ref := schema.AdditionalProperties; ref != nil {
+	patterns := make([]string, 0, len(schema.PatternProperties))
+	for pattern := range schema.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if _, err = compilePatternProperty(pattern); err != nil {
+			return &SchemaError{
+				Schema:      schema,
+				SchemaField: "patternProperties",
+				Reason:      fmt.Sprintf("cannot compile pattern %q: %v", pattern, err),
+			}
+		}
+		ref := schema.PatternProperties[pattern]
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(ctx, stack); err != nil {
+			return
+		}
+	}
+
+	if ref := schema.PropertyNames; ref != nil {
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(ctx, stack); err != nil {
+			return
+		}
+	}
+
+	if ref := schema.AdditionalProperties.Schema; ref != nil {
 		v := ref.Value
 		if v == nil {
 			return foundUnresolvedRef(ref.Ref)
@@ -763,8 +1158,12 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 		}
 	}
 
+	if err = schema.validateEnum(validationOpts); err != nil {
+		return
+	}
+
 	if v := schema.Default; v != nil {
-		if err := schema.VisitJSON(v); err != nil {
+		if err := schema.VisitJSON(v, WithClock(validationOpts.now)); err != nil {
 			return fmt.Errorf("invalid default: %w", err)
 		}
 	}
@@ -778,6 +1177,31 @@ func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error)
 	return
 }
 
+// validateEnum returns an error if a value of the enum of schema does not match
+// its type and format, or is null while schema is not nullable.
+// The other keywords of schema, e.g. minLength, are not checked.
+func (schema *Schema) validateEnum(validationOpts *ValidationOptions) error {
+	typed := &Schema{Type: schema.Type, Format: schema.Format, Nullable: schema.Nullable}
+	for _, value := range schema.Enum {
+		err := typed.VisitJSON(value, WithClock(validationOpts.now))
+		if err == nil {
+			continue
+		}
+		var schemaErr *SchemaError
+		if errors.As(err, &schemaErr) {
+			switch {
+			case schemaErr.Origin != nil:
+				err = schemaErr.Origin
+			case schemaErr.Reason != "":
+				err = errors.New(schemaErr.Reason)
+			}
+		}
+		data, _ := json.Marshal(value)
+		return fmt.Errorf("invalid enum value %s: %w", data, err)
+	}
+	return nil
+}
+
 func (schema *Schema) IsMatching(value interface{}) bool {
 	settings := newSchemaValidationSettings(FailFast())
 	return schema.visitJSON(settings, value) == nil
@@ -808,14 +1232,44 @@ func (schema *Schema) IsMatchingJSONObject(value map[string]interface{}) bool {
 	return schema.visitJSON(settings, value) == nil
 }
 
+// VisitJSONWithDefaults validates value like VisitJSON with ApplyDefaults, and returns
+// value with the defaults of the schemas of the properties it is missing set,
+// or the default of schema if value is nil.
+func (schema *Schema) VisitJSONWithDefaults(value interface{}, opts ...SchemaValidationOption) (interface{}, error) {
+	if value == nil && schema.Default != nil {
+		value = deepcopy.Copy(schema.Default)
+	}
+	if err := schema.VisitJSON(value, append(opts, ApplyDefaults())...); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 func (schema *Schema) VisitJSON(value interface{}, opts ...SchemaValidationOption) error {
 	settings := newSchemaValidationSettings(opts...)
-	return schema.visitJSON(settings, value)
+	err := schema.visitJSON(settings, value)
+	if settings.interruption != nil {
+		// Values not visited may have been reported as not matching alternatives
+		return settings.interruption
+	}
+	return err
 }
 
 func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interface{}) (err error) {
+	if err = settings.interrupted(); err != nil {
+		return
+	}
 	switch value := value.(type) {
 	case nil:
+		if schema.HasConst() {
+			if err = schema.visitConst(settings, value); err != nil {
+				return
+			}
+			if schema.Type == "" {
+				// const: null allows null values without nullable
+				return
+			}
+		}
 		return schema.visitJSONNull(settings)
 	case float64:
 		if math.IsNaN(value) {
@@ -832,7 +1286,14 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 	if err = schema.visitSetOperations(settings, value); err != nil {
 		return
 	}
+	if err = schema.visitJSONValue(settings, value); err != nil {
+		return
+	}
+	return schema.visitFormatValidator(settings, value)
+}
 
+// visitJSONValue validates value against the keywords of its type.
+func (schema *Schema) visitJSONValue(settings *schemaValidationSettings, value interface{}) error {
 	switch value := value.(type) {
 	case bool:
 		return schema.visitJSONBoolean(settings, value)
@@ -870,6 +1331,28 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 	}
 }
 
+// visitFormatValidator checks value with the validator registered for the format of schema.
+func (schema *Schema) visitFormatValidator(settings *schemaValidationSettings, value interface{}) error {
+	v, ok := formatValidators[schema.Format]
+	if !ok {
+		return nil
+	}
+	if err := v.validate(value, settings.clock); err != nil {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           "format",
+			Reason:                fmt.Sprintf("value doesn't match the format %q", schema.Format),
+			Origin:                err,
+			customizeMessageError: settings.customizeMessageError,
+		}
+	}
+	return nil
+}
+
 func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, value interface{}) (err error) {
 	if enum := schema.Enum; len(enum) != 0 {
 		for _, v := range enum {
@@ -889,12 +1372,16 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 		}
 	}
 
+	if err := schema.visitConst(settings, value); err != nil {
+		return err
+	}
+
 	if ref := schema.Not; ref != nil {
 		v := ref.Value
 		if v == nil {
 			return foundUnresolvedRef(ref.Ref)
 		}
-		if err := v.visitJSON(settings, value); err == nil {
+		if err := settings.try(v, value); err == nil {
 			if settings.failfast {
 				return errSchema
 			}
@@ -908,29 +1395,15 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 	}
 
 	if v := schema.OneOf; len(v) > 0 {
-		var discriminatorRef string
-		if schema.Discriminator != nil {
-			pn := schema.Discriminator.PropertyName
-			if valuemap, okcheck := value.(map[string]interface{}); okcheck {
-				discriminatorVal, okcheck := valuemap[pn]
-				if !okcheck {
-					return errors.New("input does not contain the discriminator property")
-				}
-
-				discriminatorValString, okcheck := discriminatorVal.(string)
-				if !okcheck {
-					return errors.New("descriminator value is not a string")
-				}
-
-				if discriminatorRef, okcheck = schema.Discriminator.Mapping[discriminatorValString]; len(schema.Discriminator.Mapping) > 0 && !okcheck {
-					return errors.New("input does not contain a valid discriminator value")
-				}
-			}
+		selected, err := schema.discriminatedBranch(settings, v, value)
+		if err != nil {
+			return err
 		}
 
 		var (
 			ok               = 0
 			validationErrors = multiErrorForOneOf{}
+			failedBranches   SchemaRefs
 			matchedOneOfIdx  = 0
 			tempValue        = value
 		)
@@ -940,17 +1413,18 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 				return foundUnresolvedRef(item.Ref)
 			}
 
-			if discriminatorRef != "" && discriminatorRef != item.Ref {
+			if selected >= 0 && idx != selected {
 				continue
 			}
 
 			// make a deep copy to protect origin value from being injected default value that defined in mismatched oneOf schema
-			if settings.asreq || settings.asrep {
+			if settings.setsDefaults() {
 				tempValue = deepcopy.Copy(value)
 			}
 
-			if err := v.visitJSON(settings, tempValue); err != nil {
+			if err := settings.try(v, tempValue); err != nil {
 				validationErrors = append(validationErrors, err)
+				failedBranches = append(failedBranches, item)
 				continue
 			}
 
@@ -959,6 +1433,11 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 		}
 
 		if ok != 1 {
+			if ok == 0 && len(validationErrors) > 1 && !settings.failfast {
+				if i := schema.bestBranch(failedBranches, validationErrors, value); i >= 0 {
+					validationErrors = multiErrorForOneOf{validationErrors[i]}
+				}
+			}
 			if len(validationErrors) > 1 {
 				return fmt.Errorf("doesn't match schema due to: %w", validationErrors)
 			}
@@ -980,45 +1459,64 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 			return e
 		}
 
-		if settings.asreq || settings.asrep {
+		if settings.setsDefaults() || settings.branchMatched != nil || settings.itemsTruncated != nil {
 			_ = v[matchedOneOfIdx].Value.visitJSON(settings, value)
 		}
+		settings.matched(schema, "oneOf", matchedOneOfIdx)
 	}
 
 	if v := schema.AnyOf; len(v) > 0 {
+		selected, err := schema.discriminatedBranch(settings, v, value)
+		if err != nil {
+			return err
+		}
+
 		var (
-			ok              = false
-			matchedAnyOfIdx = 0
-			tempValue       = value
+			ok               = false
+			validationErrors []error
+			failedBranches   SchemaRefs
+			matchedAnyOfIdx  = 0
+			tempValue        = value
 		)
 		for idx, item := range v {
 			v := item.Value
 			if v == nil {
 				return foundUnresolvedRef(item.Ref)
 			}
+			if selected >= 0 && idx != selected {
+				continue
+			}
 			// make a deep copy to protect origin value from being injected default value that defined in mismatched anyOf schema
-			if settings.asreq || settings.asrep {
+			if settings.setsDefaults() {
 				tempValue = deepcopy.Copy(value)
 			}
-			if err := v.visitJSON(settings, tempValue); err == nil {
+			err := settings.try(v, tempValue)
+			if err == nil {
 				ok = true
 				matchedAnyOfIdx = idx
 				break
 			}
+			validationErrors = append(validationErrors, err)
+			failedBranches = append(failedBranches, item)
 		}
 		if !ok {
 			if settings.failfast {
 				return errSchema
 			}
-			return &SchemaError{
+			e := &SchemaError{
 				Value:                 value,
 				Schema:                schema,
 				SchemaField:           "anyOf",
 				customizeMessageError: settings.customizeMessageError,
 			}
+			if i := schema.bestBranch(failedBranches, validationErrors, value); i >= 0 {
+				e.Origin = validationErrors[i]
+			}
+			return e
 		}
 
 		_ = v[matchedAnyOfIdx].Value.visitJSON(settings, value)
+		settings.matched(schema, "anyOf", matchedAnyOfIdx)
 	}
 
 	for _, item := range schema.AllOf {
@@ -1039,7 +1537,73 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 			}
 		}
 	}
-	return
+
+	return schema.visitConditional(settings, value)
+}
+
+// HasConst tells whether schema has a const, which is null if Const is nil
+// and the schema was read with const: null.
+func (schema *Schema) HasConst() bool {
+	return schema.Const != nil || schema.nullConst
+}
+
+// visitConst validates value against the const of schema, if any.
+func (schema *Schema) visitConst(settings *schemaValidationSettings, value interface{}) error {
+	if !schema.HasConst() || reflect.DeepEqual(schema.Const, value) {
+		return nil
+	}
+	if settings.failfast {
+		return errSchema
+	}
+	data, _ := json.Marshal(schema.Const)
+	return &SchemaError{
+		Value:                 value,
+		Schema:                schema,
+		SchemaField:           "const",
+		Reason:                fmt.Sprintf("value must be %s", data),
+		customizeMessageError: settings.customizeMessageError,
+	}
+}
+
+// visitConditional validates value against the then schema if it matches
+// the if schema, and against the else schema otherwise.
+func (schema *Schema) visitConditional(settings *schemaValidationSettings, value interface{}) error {
+	ref := schema.If
+	if ref == nil || (schema.Then == nil && schema.Else == nil) {
+		return nil
+	}
+	v := ref.Value
+	if v == nil {
+		return foundUnresolvedRef(ref.Ref)
+	}
+	// if only asserts a condition: protect value from its defaults and readOnly stripping
+	condValue := value
+	if settings.setsDefaults() || settings.stripReadOnly {
+		condValue = deepcopy.Copy(value)
+	}
+	field, branch := "then", schema.Then
+	if err := settings.try(v, condValue); err != nil {
+		field, branch = "else", schema.Else
+	}
+	if branch == nil {
+		return nil
+	}
+	if branch.Value == nil {
+		return foundUnresolvedRef(branch.Ref)
+	}
+	if err := branch.Value.visitJSON(settings, value); err != nil {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           field,
+			Origin:                err,
+			customizeMessageError: settings.customizeMessageError,
+		}
+	}
+	return nil
 }
 
 func (schema *Schema) visitJSONNull(settings *schemaValidationSettings) (err error) {
@@ -1111,7 +1675,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 			formatMin = formatMinInt64
 			formatMax = formatMaxInt64
 		default:
-			if settings.formatValidationEnabled {
+			if _, ok := formatValidators[schema.Format]; !ok && settings.formatValidationEnabled {
 				return unsupportedFormat(schema.Format)
 			}
 		}
@@ -1319,8 +1883,12 @@ func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value
 	// "format"
 	var formatStrErr string
 	var formatErr error
-	if format := schema.Format; format != "" {
-		if f, ok := SchemaStringFormats[format]; ok {
+	if format := schema.Format; format != "" && formatValidators[format] == nil {
+		if validate, ok := builtinFormatValidators[format]; ok && settings.formatValidationEnabled {
+			if err := validate(value); err != nil {
+				formatStrErr = fmt.Sprintf("string doesn't match the format %q: %v", format, err)
+			}
+		} else if f, ok := SchemaStringFormats[format]; ok {
 			switch {
 			case f.regexp != nil && f.callback == nil:
 				if cp := f.regexp; !cp.MatchString(value) {
@@ -1351,6 +1919,14 @@ func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value
 
 	}
 
+	// "contentEncoding" and "contentMediaType"
+	if err := schema.visitJSONStringContent(settings, value); err != nil {
+		if !settings.multiError {
+			return err
+		}
+		me = append(me, err)
+	}
+
 	if len(me) > 0 {
 		return me
 	}
@@ -1435,7 +2011,7 @@ func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value [
 		if itemSchema == nil {
 			return foundUnresolvedRef(itemSchemaRef.Ref)
 		}
-		for i, item := range value {
+		for i, item := range value[:settings.truncated(schema, value, len(value))] {
 			if err := itemSchema.visitJSON(settings, item); err != nil {
 				err = markSchemaErrorIndex(err, i)
 				if !settings.multiError {
@@ -1450,6 +2026,18 @@ func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value [
 		}
 	}
 
+	// "contains", "minContains" and "maxContains"
+	if err := schema.visitJSONArrayContains(settings, value); err != nil {
+		if !settings.multiError {
+			return err
+		}
+		if containsMe, ok := err.(MultiError); ok {
+			me = append(me, containsMe...)
+		} else {
+			me = append(me, err)
+		}
+	}
+
 	if len(me) > 0 {
 		return me
 	}
@@ -1457,6 +2045,77 @@ func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value [
 	return nil
 }
 
+// visitJSONArrayContains checks the number of items of value matching the contains schema.
+// With multiple errors, the errors of the items not matching are reported
+// along with a too small number of matching items.
+func (schema *Schema) visitJSONArrayContains(settings *schemaValidationSettings, value []interface{}) error {
+	ref := schema.Contains
+	if ref == nil {
+		return nil
+	}
+	containsSchema := ref.Value
+	if containsSchema == nil {
+		return foundUnresolvedRef(ref.Ref)
+	}
+	minContains, maxContains := uint64(1), schema.MaxContains
+	if v := schema.MinContains; v != nil {
+		minContains = *v
+	}
+
+	var matches uint64
+	var failures MultiError
+	items := value[:settings.truncated(schema, value, len(value))]
+	for i, item := range items {
+		if err := settings.try(containsSchema, item); err != nil {
+			if settings.multiError {
+				err = markSchemaErrorIndex(err, i)
+				if itemMe, ok := err.(MultiError); ok {
+					failures = append(failures, itemMe...)
+				} else {
+					failures = append(failures, err)
+				}
+			}
+			continue
+		}
+		matches++
+	}
+
+	// Items left unvalidated may match
+	if matches < minContains && len(items) == len(value) {
+		if settings.failfast {
+			return errSchema
+		}
+		field, reason := "contains", "no item matches the contains schema"
+		if schema.MinContains != nil {
+			field, reason = "minContains", fmt.Sprintf("at least %d items must match the contains schema", minContains)
+		}
+		err := &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           field,
+			Reason:                reason,
+			customizeMessageError: settings.customizeMessageError,
+		}
+		if !settings.multiError {
+			return err
+		}
+		return append(MultiError{err}, failures...)
+	}
+	if maxContains != nil && matches > *maxContains {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:                 value,
+			Schema:                schema,
+			SchemaField:           "maxContains",
+			Reason:                fmt.Sprintf("at most %d items may match the contains schema", *maxContains),
+			customizeMessageError: settings.customizeMessageError,
+		}
+	}
+	return nil
+}
+
 func (schema *Schema) VisitJSONObject(value map[string]interface{}) error {
 	settings := newSchemaValidationSettings()
 	return schema.visitJSONObject(settings, value)
@@ -1469,7 +2128,7 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 
 	var me MultiError
 
-	if settings.asreq || settings.asrep {
+	if settings.setsDefaults() {
 		properties := make([]string, 0, len(schema.Properties))
 		for propName := range schema.Properties {
 			properties = append(properties, propName)
@@ -1482,20 +2141,41 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 
 			if value[propName] == nil {
 				if dlft := propSchema.Value.Default; dlft != nil && !reqRO && !repWO {
-					value[propName] = dlft
+					value[propName] = deepcopy.Copy(dlft)
 					if f := settings.defaultsSet; f != nil {
 						settings.onceSettingDefaults.Do(f)
 					}
 				}
 			}
 
-			if value[propName] != nil {
-				if reqRO {
-					me = append(me, fmt.Errorf("readOnly property %q in request", propName))
-				} else if repWO {
-					me = append(me, fmt.Errorf("writeOnly property %q in response", propName))
+			if value[propName] == nil || !(reqRO || repWO) {
+				continue
+			}
+			if reqRO && settings.stripReadOnly {
+				delete(value, propName)
+				if f := settings.readOnlyStripped; f != nil {
+					f()
 				}
+				continue
 			}
+			if settings.failfast {
+				return errSchema
+			}
+			field, reason := "readOnly", fmt.Sprintf("readOnly property %q in request", propName)
+			if repWO {
+				field, reason = "writeOnly", fmt.Sprintf("writeOnly property %q in response", propName)
+			}
+			err := &SchemaError{
+				Value:                 value,
+				Schema:                schema,
+				SchemaField:           field,
+				Reason:                reason,
+				customizeMessageError: settings.customizeMessageError,
+			}
+			if !settings.multiError {
+				return err
+			}
+			me = append(me, err)
 		}
 	}
 
@@ -1541,7 +2221,7 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 
 	// "additionalProperties"
 	var additionalProperties *Schema
-	if ref := schema.AdditionalProperties; ref != nil {
+	if ref := schema.AdditionalProperties.Schema; ref != nil {
 		additionalProperties = ref.Value
 	}
 	keys := make([]string, 0, len(value))
@@ -1549,34 +2229,93 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
+	if properties != nil || len(schema.PatternProperties) != 0 || schema.PropertyNames != nil || additionalProperties != nil || !schema.AdditionalProperties.Allowed() {
+		keys = keys[:settings.truncated(schema, value, len(keys))]
+	}
+
+	// "propertyNames"
+	if ref := schema.PropertyNames; ref != nil {
+		propertyNames := ref.Value
+		if propertyNames == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		for _, k := range keys {
+			if err := propertyNames.visitJSON(settings, k); err != nil {
+				if settings.failfast {
+					return errSchema
+				}
+				err = markSchemaErrorKey(&SchemaError{
+					Value:                 k,
+					Schema:                schema,
+					SchemaField:           "propertyNames",
+					Origin:                err,
+					customizeMessageError: settings.customizeMessageError,
+				}, k)
+				if !settings.multiError {
+					return err
+				}
+				me = append(me, err)
+			}
+		}
+	}
+
+	// "patternProperties"
+	patterns := make([]string, 0, len(schema.PatternProperties))
+	for pattern := range schema.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	patternRegexps := make([]Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compilePatternProperty(pattern)
+		if err != nil {
+			return &SchemaError{
+				Value:                 value,
+				Schema:                schema,
+				SchemaField:           "patternProperties",
+				Reason:                fmt.Sprintf("cannot compile pattern %q: %v", pattern, err),
+				customizeMessageError: settings.customizeMessageError,
+			}
+		}
+		patternRegexps = append(patternRegexps, re)
+	}
+
 	for _, k := range keys {
 		v := value[k]
-		if properties != nil {
-			propertyRef := properties[k]
-			if propertyRef != nil {
-				p := propertyRef.Value
-				if p == nil {
-					return foundUnresolvedRef(propertyRef.Ref)
+		// Keys matching a property or a pattern are not additional properties
+		var propertySchemas SchemaRefs
+		if propertyRef := properties[k]; propertyRef != nil {
+			propertySchemas = append(propertySchemas, propertyRef)
+		}
+		for i, re := range patternRegexps {
+			if re.MatchString(k) {
+				propertySchemas = append(propertySchemas, schema.PatternProperties[patterns[i]])
+			}
+		}
+		for _, propertyRef := range propertySchemas {
+			p := propertyRef.Value
+			if p == nil {
+				return foundUnresolvedRef(propertyRef.Ref)
+			}
+			if err := p.visitJSON(settings, v); err != nil {
+				if settings.failfast {
+					return errSchema
 				}
-				if err := p.visitJSON(settings, v); err != nil {
-					if settings.failfast {
-						return errSchema
-					}
-					err = markSchemaErrorKey(err, k)
-					if !settings.multiError {
-						return err
-					}
-					if v, ok := err.(MultiError); ok {
-						me = append(me, v...)
-						continue
-					}
-					me = append(me, err)
+				err = markSchemaErrorKey(err, k)
+				if !settings.multiError {
+					return err
 				}
-				continue
+				if v, ok := err.(MultiError); ok {
+					me = append(me, v...)
+					continue
+				}
+				me = append(me, err)
 			}
 		}
-		allowed := schema.AdditionalPropertiesAllowed
-		if additionalProperties != nil || allowed == nil || *allowed {
+		if len(propertySchemas) != 0 {
+			continue
+		}
+		if additionalProperties != nil || schema.AdditionalProperties.Allowed() {
 			if additionalProperties != nil {
 				if err := additionalProperties.visitJSON(settings, v); err != nil {
 					if settings.failfast {
@@ -1657,8 +2396,64 @@ func (schema *Schema) expectedType(settings *schemaValidationSettings, typ strin
 	}
 }
 
-func (schema *Schema) compilePattern() (err error) {
-	if schema.compiledPattern, err = regexp.Compile(schema.Pattern); err != nil {
+// Regexp is a compiled regular expression of pattern or patternProperties.
+type Regexp interface {
+	MatchString(s string) bool
+}
+
+// RegexpCompiler compiles the regular expressions of pattern and patternProperties.
+type RegexpCompiler func(expr string) (Regexp, error)
+
+var regexpCompiler RegexpCompiler = compileRE2
+
+func compileRE2(expr string) (Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+// SetRegexpCompiler sets the compiler of the regular expressions of pattern and
+// patternProperties, e.g. to use an ECMA-262 compatible engine supporting
+// the look-arounds and backreferences that the RE2 syntax of package regexp lacks.
+// A nil compiler restores the default, regexp.Compile.
+//
+// Patterns already compiled for schemas are not compiled again: set the compiler
+// before loading and validating documents.
+// This call is not thread-safe: the compiler should not be set by multiple goroutines.
+func SetRegexpCompiler(compiler RegexpCompiler) {
+	if compiler == nil {
+		compiler = compileRE2
+	}
+	regexpCompiler = compiler
+	patternPropertiesRegexps.Range(func(pattern, _ interface{}) bool {
+		patternPropertiesRegexps.Delete(pattern)
+		return true
+	})
+}
+
+// patternPropertiesRegexps caches the regular expressions of patternProperties by pattern.
+var patternPropertiesRegexps sync.Map
+
+func compilePatternProperty(pattern string) (Regexp, error) {
+	if re, ok := patternPropertiesRegexps.Load(pattern); ok {
+		return re.(Regexp), nil
+	}
+	re, err := regexpCompiler(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternPropertiesRegexps.Store(pattern, re)
+	return re, nil
+}
+
+func (schema *Schema) compilePattern() error {
+	compiled, err := regexpCompiler(schema.Pattern)
+	if !schema.frozen {
+		schema.compiledPattern = compiled
+	}
+	if err != nil {
 		return &SchemaError{
 			Schema:      schema,
 			SchemaField: "pattern",
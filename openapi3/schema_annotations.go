@@ -0,0 +1,93 @@
+package openapi3
+
+// AnnotationPolicy is how PropagateAnnotations handles an annotation of schemas,
+// such as their title or description, across allOf compositions.
+type AnnotationPolicy int
+
+const (
+	// KeepAnnotation leaves the annotation of schemas as it is.
+	KeepAnnotation AnnotationPolicy = iota
+	// InheritAnnotation sets the annotation of a schema that has none to that of
+	// the first of its allOf branches to have one, be it a reference or not.
+	// Branches inherit theirs first, so that the annotation of a schema composed
+	// from another composed schema is found.
+	InheritAnnotation
+	// StripAnnotation removes the annotation of the allOf branches that are not
+	// references, which mostly repeat or describe a part of the composition.
+	// The schemas referenced by branches are shared and left as they are.
+	StripAnnotation
+	// HoistAnnotation moves the annotation of allOf branches to the composition:
+	// it is inherited as with InheritAnnotation, then stripped as with StripAnnotation.
+	HoistAnnotation
+)
+
+// AnnotationOptions are the policies of PropagateAnnotations for each annotation.
+type AnnotationOptions struct {
+	Title       AnnotationPolicy
+	Description AnnotationPolicy
+}
+
+// PropagateAnnotations applies options to the titles and descriptions of the schemas
+// of doc, including those nested in other schemas, so that tools flattening allOf
+// compositions into a single schema keep the human-readable context of their parts.
+func PropagateAnnotations(doc *T, options AnnotationOptions) error {
+	if doc.frozen {
+		return ErrFrozen
+	}
+	p := &annotationPropagator{
+		options: options,
+		done:    make(map[*Schema]bool),
+	}
+	return Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, ok := value.(*SchemaRef); ok && ref.Value != nil {
+			p.propagate(ref.Value)
+		}
+		return nil
+	})
+}
+
+type annotationPropagator struct {
+	options AnnotationOptions
+	// schemas being (false) or already (true) propagated to
+	done map[*Schema]bool
+}
+
+func (p *annotationPropagator) propagate(schema *Schema) {
+	if _, ok := p.done[schema]; ok {
+		return
+	}
+	p.done[schema] = false
+	defer func() { p.done[schema] = true }()
+
+	for _, branch := range schema.AllOf {
+		if branch != nil && branch.Value != nil {
+			p.propagate(branch.Value)
+		}
+	}
+	propagateAnnotation(schema, p.options.Title, func(s *Schema) *string { return &s.Title })
+	propagateAnnotation(schema, p.options.Description, func(s *Schema) *string { return &s.Description })
+}
+
+// propagateAnnotation applies policy to the annotation that field returns the address of.
+func propagateAnnotation(schema *Schema, policy AnnotationPolicy, field func(*Schema) *string) {
+	if policy == InheritAnnotation || policy == HoistAnnotation {
+		if annotation := field(schema); *annotation == "" {
+			for _, branch := range schema.AllOf {
+				if branch == nil || branch.Value == nil {
+					continue
+				}
+				if v := *field(branch.Value); v != "" {
+					*annotation = v
+					break
+				}
+			}
+		}
+	}
+	if policy == StripAnnotation || policy == HoistAnnotation {
+		for _, branch := range schema.AllOf {
+			if branch != nil && branch.Ref == "" && branch.Value != nil {
+				*field(branch.Value) = ""
+			}
+		}
+	}
+}
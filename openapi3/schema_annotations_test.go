@@ -0,0 +1,108 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagateAnnotations(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      title: Pet
+      description: An animal living with people.
+      type: object
+      properties:
+        name:
+          type: string
+    Dog:
+      allOf:
+      - $ref: '#/components/schemas/Pet'
+      - description: The properties of dogs.
+        type: object
+        properties:
+          breed:
+            type: string
+    Puppy:
+      allOf:
+      - $ref: '#/components/schemas/Dog'
+      - title: Young dog
+        type: object
+        properties:
+          weeks:
+            type: integer
+`
+	load := func(t *testing.T) *T {
+		doc, err := NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		return doc
+	}
+	annotations := func(doc *T, name string) (string, string) {
+		schema := doc.Components.Schemas[name].Value
+		return schema.Title, schema.Description
+	}
+	branch := func(doc *T, name string) *Schema {
+		return doc.Components.Schemas[name].Value.AllOf[1].Value
+	}
+
+	t.Run("keep", func(t *testing.T) {
+		doc := load(t)
+		err := PropagateAnnotations(doc, AnnotationOptions{})
+		require.NoError(t, err)
+		title, description := annotations(doc, "Puppy")
+		require.Empty(t, title)
+		require.Empty(t, description)
+	})
+
+	t.Run("inherit", func(t *testing.T) {
+		doc := load(t)
+		err := PropagateAnnotations(doc, AnnotationOptions{Title: InheritAnnotation, Description: InheritAnnotation})
+		require.NoError(t, err)
+		title, description := annotations(doc, "Dog")
+		require.Equal(t, "Pet", title)
+		require.Equal(t, "An animal living with people.", description)
+		title, description = annotations(doc, "Puppy")
+		require.Equal(t, "Pet", title)
+		require.Equal(t, "An animal living with people.", description)
+		require.Equal(t, "The properties of dogs.", branch(doc, "Dog").Description)
+	})
+
+	t.Run("strip", func(t *testing.T) {
+		doc := load(t)
+		err := PropagateAnnotations(doc, AnnotationOptions{Title: StripAnnotation, Description: StripAnnotation})
+		require.NoError(t, err)
+		require.Empty(t, branch(doc, "Dog").Description)
+		require.Empty(t, branch(doc, "Puppy").Title)
+		title, description := annotations(doc, "Pet")
+		require.Equal(t, "Pet", title)
+		require.Equal(t, "An animal living with people.", description)
+	})
+
+	t.Run("hoist", func(t *testing.T) {
+		doc := load(t)
+		// The inline branch first
+		allOf := doc.Components.Schemas["Puppy"].Value.AllOf
+		allOf[0], allOf[1] = allOf[1], allOf[0]
+		err := PropagateAnnotations(doc, AnnotationOptions{Title: HoistAnnotation, Description: KeepAnnotation})
+		require.NoError(t, err)
+		title, description := annotations(doc, "Puppy")
+		require.Equal(t, "Young dog", title)
+		require.Empty(t, description)
+		require.Empty(t, allOf[0].Value.Title)
+		require.Equal(t, "Pet", allOf[1].Value.Title)
+	})
+
+	t.Run("frozen", func(t *testing.T) {
+		doc := load(t)
+		doc.Freeze()
+		err := PropagateAnnotations(doc, AnnotationOptions{Title: InheritAnnotation})
+		require.Equal(t, ErrFrozen, err)
+	})
+}
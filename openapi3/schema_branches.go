@@ -0,0 +1,94 @@
+package openapi3
+
+import "errors"
+
+// bestBranch returns the index in branches, the oneOf or anyOf branches value failed,
+// of the branch whose error in errs best explains the failure: the branch the
+// discriminator of schema selects or else, among the branches whose type fits value,
+// the one with the fewest errors and then the most properties of value declared.
+// It returns -1 when no branch stands out.
+func (schema *Schema) bestBranch(branches SchemaRefs, errs []error, value interface{}) int {
+	if discriminator := schema.Discriminator; discriminator != nil {
+		if object, ok := value.(map[string]interface{}); ok {
+			if name, ok := object[discriminator.PropertyName].(string); ok {
+				for i, branch := range branches {
+					if discriminator.selects(branch.Ref, name) {
+						return i
+					}
+				}
+			}
+		}
+	}
+
+	type score struct {
+		typeFits   bool
+		errors     int
+		properties int
+	}
+	better := func(a, b score) bool {
+		if a.typeFits != b.typeFits {
+			return a.typeFits
+		}
+		if a.errors != b.errors {
+			return a.errors < b.errors
+		}
+		return a.properties > b.properties
+	}
+
+	best, unique := -1, false
+	var bestScore score
+	for i, branch := range branches {
+		s := score{
+			typeFits: !isRootTypeError(errs[i]),
+			errors:   countErrors(errs[i]),
+		}
+		if object, ok := value.(map[string]interface{}); ok && branch.Value != nil {
+			for name := range object {
+				if declared, _ := declaresProperty(branch.Value, name, make(map[*Schema]struct{})); declared {
+					s.properties++
+				}
+			}
+		}
+		switch {
+		case best < 0 || better(s, bestScore):
+			best, bestScore, unique = i, s, true
+		case !better(bestScore, s):
+			unique = false
+		}
+	}
+	if !unique {
+		return -1
+	}
+	return best
+}
+
+// isRootTypeError tells whether err reports that the value itself, rather than
+// something within it, is not of the type of the schema.
+func isRootTypeError(err error) bool {
+	var me MultiError
+	if errors.As(err, &me) {
+		for _, err := range me {
+			if isRootTypeError(err) {
+				return true
+			}
+		}
+		return false
+	}
+	if e, ok := err.(*SchemaError); ok {
+		return e.SchemaField == "type" && len(e.reversePath) == 0
+	}
+	return false
+}
+
+// countErrors returns the number of errors err is made of.
+func countErrors(err error) int {
+	var me MultiError
+	if !errors.As(err, &me) {
+		return 1
+	}
+	n := 0
+	for _, err := range me {
+		n += countErrors(err)
+	}
+	return n
+}
@@ -0,0 +1,97 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaBestBranchErrors(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: Branches, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Cat:
+      type: object
+      required: [name, scratches]
+      properties:
+        name: {type: string}
+        scratches: {type: boolean}
+    Dog:
+      type: object
+      required: [name, barks]
+      properties:
+        name: {type: string}
+        barks: {type: boolean}
+        age: {type: integer}
+    OneOfPets:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+    AnyOfPets:
+      anyOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+    DiscriminatedPets:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+      discriminator:
+        propertyName: name
+    NameOrPet:
+      oneOf:
+        - type: string
+          minLength: 5
+        - $ref: "#/components/schemas/Dog"
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	schema := func(name string) *Schema { return doc.Components.Schemas[name].Value }
+
+	dogish := map[string]interface{}{"name": "Rex", "barks": "loudly"}
+	for _, name := range []string{"OneOfPets", "AnyOfPets"} {
+		t.Run(name, func(t *testing.T) {
+			err := schema(name).VisitJSON(dogish)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), `Error at "/barks": field must be set to boolean`)
+			require.NotContains(t, err.Error(), "scratches")
+		})
+	}
+
+	t.Run("fewest errors", func(t *testing.T) {
+		err := schema("OneOfPets").VisitJSON(map[string]interface{}{
+			"name": "Rex", "barks": "loudly", "age": "three",
+		}, MultiErrors())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `property "scratches" is missing`)
+		require.NotContains(t, err.Error(), "field must be set")
+	})
+
+	t.Run("type fits", func(t *testing.T) {
+		err := schema("NameOrPet").VisitJSON("Rex")
+		require.EqualError(t, err, "minimum string length is 5\nSchema:\n  {\n    \"minLength\": 5,\n    \"type\": \"string\"\n  }\n\nValue:\n  \"Rex\"\n")
+	})
+
+	t.Run("discriminator", func(t *testing.T) {
+		err := schema("DiscriminatedPets").VisitJSON(map[string]interface{}{"name": "Cat", "barks": "no"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `property "scratches" is missing`)
+		require.NotContains(t, err.Error(), "field must be set")
+	})
+
+	t.Run("no branch stands out", func(t *testing.T) {
+		err := schema("OneOfPets").VisitJSON(map[string]interface{}{"name": "Rex"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "doesn't match schema due to: ")
+		require.Contains(t, err.Error(), `property "scratches" is missing`)
+		require.Contains(t, err.Error(), `property "barks" is missing`)
+
+		err = schema("AnyOfPets").VisitJSON(map[string]interface{}{"name": "Rex"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `Doesn't match schema "anyOf"`)
+	})
+}
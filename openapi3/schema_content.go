@@ -0,0 +1,83 @@
+package openapi3
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/quotedprintable"
+	"strings"
+)
+
+// contentDecoders decode strings by contentEncoding, as of OpenAPI 3.1.
+// Strings of other encodings are not decoded.
+var contentDecoders = map[string]func(value string) ([]byte, error){
+	"7bit":   func(value string) ([]byte, error) { return []byte(value), nil },
+	"8bit":   func(value string) ([]byte, error) { return []byte(value), nil },
+	"binary": func(value string) ([]byte, error) { return []byte(value), nil },
+	"quoted-printable": func(value string) ([]byte, error) {
+		return ioutil.ReadAll(quotedprintable.NewReader(strings.NewReader(value)))
+	},
+	"base16":    hex.DecodeString,
+	"base32":    base32.StdEncoding.DecodeString,
+	"base64":    base64.StdEncoding.DecodeString,
+	"base64url": base64.URLEncoding.DecodeString,
+}
+
+// visitJSONStringContent checks that value can be decoded according to
+// the contentEncoding of schema and, for JSON media types, that the decoded
+// content parses according to its contentMediaType.
+func (schema *Schema) visitJSONStringContent(settings *schemaValidationSettings, value string) error {
+	if !settings.contentValidationEnabled || (schema.ContentEncoding == "" && schema.ContentMediaType == "") {
+		return nil
+	}
+
+	content := []byte(value)
+	if encoding := schema.ContentEncoding; encoding != "" {
+		decode, ok := contentDecoders[strings.ToLower(encoding)]
+		if !ok {
+			return nil
+		}
+		var err error
+		if content, err = decode(value); err != nil {
+			if settings.failfast {
+				return errSchema
+			}
+			return &SchemaError{
+				Value:                 value,
+				Schema:                schema,
+				SchemaField:           "contentEncoding",
+				Reason:                fmt.Sprintf("string is not valid %s: %v", encoding, err),
+				customizeMessageError: settings.customizeMessageError,
+			}
+		}
+	}
+
+	if mediaType := schema.ContentMediaType; mediaType != "" && isJSONMediaType(mediaType) {
+		var v interface{}
+		if err := json.Unmarshal(content, &v); err != nil {
+			if settings.failfast {
+				return errSchema
+			}
+			return &SchemaError{
+				Value:                 value,
+				Schema:                schema,
+				SchemaField:           "contentMediaType",
+				Reason:                fmt.Sprintf("content is not valid %s: %v", mediaType, err),
+				customizeMessageError: settings.customizeMessageError,
+			}
+		}
+	}
+	return nil
+}
+
+func isJSONMediaType(mediaType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
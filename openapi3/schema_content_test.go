@@ -0,0 +1,64 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaContent(t *testing.T) {
+	const spec = `
+openapi: 3.1.0
+info: {title: T, version: "1"}
+components:
+  schemas:
+    Attachment:
+      type: string
+      contentEncoding: base64
+    Payload:
+      type: string
+      contentEncoding: base64
+      contentMediaType: application/json
+    Document:
+      type: string
+      contentMediaType: application/vnd.api+json; charset=utf-8
+    Unknown:
+      type: string
+      contentEncoding: rot13
+      contentMediaType: text/plain
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	schema := func(name string) *Schema { return doc.Components.Schemas[name].Value }
+
+	// Without the option, the keywords are annotations
+	require.NoError(t, schema("Attachment").VisitJSON("not base64!"))
+
+	opt := EnableContentValidation()
+	require.NoError(t, schema("Attachment").VisitJSON("aGVsbG8=", opt))
+	err = schema("Attachment").VisitJSON("not base64!", opt)
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "contentEncoding", schemaErr.SchemaField)
+	require.Equal(t, "string is not valid base64: illegal base64 data at input byte 3", schemaErr.Reason)
+
+	// {"a":1}
+	require.NoError(t, schema("Payload").VisitJSON("eyJhIjoxfQ==", opt))
+	// {"a":1
+	err = schema("Payload").VisitJSON("eyJhIjox", opt)
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "contentMediaType", schemaErr.SchemaField)
+	require.Equal(t, "content is not valid application/json: unexpected end of JSON input", schemaErr.Reason)
+
+	require.NoError(t, schema("Document").VisitJSON(`{"data":[]}`, opt))
+	require.Error(t, schema("Document").VisitJSON(`{"data":`, opt))
+
+	// Other encodings and media types are not checked
+	require.NoError(t, schema("Unknown").VisitJSON("anything", opt))
+
+	err = schema("Payload").VisitJSON("!!!", opt, MultiErrors())
+	require.IsType(t, MultiError{}, err)
+	require.Len(t, err.(MultiError), 1)
+}
@@ -1,10 +1,15 @@
 package openapi3
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
+	"net/mail"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 )
 
 const (
@@ -39,6 +44,88 @@ func DefineStringFormatCallback(name string, callback FormatCallback) {
 	SchemaStringFormats[name] = Format{callback: callback}
 }
 
+// FormatValidator checks that value, of any JSON type, matches a format.
+type FormatValidator func(value interface{}) error
+
+// TimeFormatValidator checks that value, of any JSON type, matches a format that depends
+// on the current time now, such as that of dates that are not in the future.
+type TimeFormatValidator func(value interface{}, now time.Time) error
+
+// formatValidator is a FormatValidator or a TimeFormatValidator.
+type formatValidator struct {
+	fn     FormatValidator
+	timeFn TimeFormatValidator
+}
+
+func (v *formatValidator) validate(value interface{}, now func() time.Time) error {
+	if v.timeFn != nil {
+		return v.timeFn(value, now())
+	}
+	return v.fn(value)
+}
+
+var formatValidators = make(map[string]*formatValidator)
+
+// RegisterFormatValidator registers fn as the validator of the values of schemas
+// of format name, whatever their type. It takes precedence over the string formats
+// defined by DefineStringFormat and DefineStringFormatCallback, and name is then
+// a supported format when validating documents with EnableSchemaFormatValidation.
+//
+// If a validator for the format already exists, the function replaces it.
+// This call is not thread-safe: format validators should not be registered by multiple goroutines.
+func RegisterFormatValidator(name string, fn func(value interface{}) error) {
+	if name == "" {
+		panic("format is not defined")
+	}
+	if fn == nil {
+		panic("validator is not defined")
+	}
+	formatValidators[name] = &formatValidator{fn: fn}
+}
+
+// RegisterTimeFormatValidator registers fn as the validator of the values of schemas
+// of format name like RegisterFormatValidator, calling it with the time of the clock
+// of validation, time.Now unless another one is set with WithClock or WithValidationClock.
+//
+// If a validator for the format already exists, the function replaces it.
+// This call is not thread-safe: format validators should not be registered by multiple goroutines.
+func RegisterTimeFormatValidator(name string, fn func(value interface{}, now time.Time) error) {
+	if name == "" {
+		panic("format is not defined")
+	}
+	if fn == nil {
+		panic("validator is not defined")
+	}
+	formatValidators[name] = &formatValidator{timeFn: fn}
+}
+
+// UnregisterFormatValidator removes the validator registered for format name.
+// This call is not thread-safe: format validators should not be registered by multiple goroutines.
+func UnregisterFormatValidator(name string) {
+	delete(formatValidators, name)
+}
+
+// IsMatchingStringFormat reports whether value matches the string format registered
+// under name, as schemas with that format check. Any value matches unregistered formats.
+// Validators registered with RegisterTimeFormatValidator are called with the current time.
+func IsMatchingStringFormat(name, value string) bool {
+	if v, ok := formatValidators[name]; ok {
+		return v.validate(value, time.Now) == nil
+	}
+	f, ok := SchemaStringFormats[name]
+	if !ok {
+		return true
+	}
+	switch {
+	case f.regexp != nil && f.callback == nil:
+		return f.regexp.MatchString(value)
+	case f.regexp == nil && f.callback != nil:
+		return f.callback(value) == nil
+	default:
+		return false
+	}
+}
+
 func validateIP(ip string) error {
 	parsed := net.ParseIP(ip)
 	if parsed == nil {
@@ -104,3 +191,104 @@ func DefineIPv4Format() {
 func DefineIPv6Format() {
 	DefineStringFormatCallback("ipv6", validateIPv6)
 }
+
+// builtinFormatValidators check the strings of common formats when format validation is enabled.
+var builtinFormatValidators = map[string]FormatCallback{
+	"uuid":          validateUUID,
+	"email":         validateEmail,
+	"hostname":      validateHostname,
+	"ipv4":          validateIPv4Address,
+	"ipv6":          validateIPv6Address,
+	"uri":           validateURI,
+	"uri-reference": validateURIReference,
+	"date":          validateDate,
+	"duration":      validateDuration,
+	"byte":          validateByte,
+}
+
+var (
+	uuidRegexp     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	durationRegexp = regexp.MustCompile(`^P(?:[0-9]+W|(?:[0-9]+Y)?(?:[0-9]+M)?(?:[0-9]+D)?(?:T(?:[0-9]+H)?(?:[0-9]+M)?(?:[0-9]+(?:\.[0-9]+)?S)?)?)$`)
+	hostnameLabel  = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+func validateUUID(value string) error {
+	if !uuidRegexp.MatchString(value) {
+		return errors.New("not a UUID")
+	}
+	return nil
+}
+
+func validateEmail(value string) error {
+	address, err := mail.ParseAddress(value)
+	if err != nil {
+		return err
+	}
+	if address.Address != value {
+		return errors.New("not a bare email address")
+	}
+	return nil
+}
+
+func validateHostname(value string) error {
+	if len(value) > 253 {
+		return errors.New("longer than 253 characters")
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(value, "."), ".") {
+		if !hostnameLabel.MatchString(label) {
+			return fmt.Errorf("invalid label %q", label)
+		}
+	}
+	return nil
+}
+
+func validateIPv4Address(value string) error {
+	if ip := net.ParseIP(value); ip == nil || strings.Contains(value, ":") {
+		return errors.New("not an IPv4 address")
+	}
+	return nil
+}
+
+func validateIPv6Address(value string) error {
+	if ip := net.ParseIP(value); ip == nil || !strings.Contains(value, ":") {
+		return errors.New("not an IPv6 address")
+	}
+	return nil
+}
+
+func validateURI(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return errors.New("not an absolute URI")
+	}
+	return nil
+}
+
+func validateURIReference(value string) error {
+	_, err := url.Parse(value)
+	return err
+}
+
+func validateDate(value string) error {
+	_, err := time.Parse("2006-01-02", value)
+	return err
+}
+
+func validateDuration(value string) error {
+	if value == "P" || strings.HasSuffix(value, "T") || !durationRegexp.MatchString(value) {
+		return errors.New("not an ISO 8601 duration")
+	}
+	return nil
+}
+
+func validateByte(value string) error {
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		if _, errURL := base64.URLEncoding.DecodeString(value); errURL != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,3 +106,136 @@ components:
 	delete(SchemaStringFormats, "ipv4")
 	SchemaErrorDetailsDisabled = false
 }
+
+func TestIsMatchingStringFormat(t *testing.T) {
+	DefineStringFormat("test-digits", `^[0-9]+$`)
+	DefineStringFormatCallback("test-even", func(value string) error {
+		if len(value)%2 != 0 {
+			return errors.New("odd length")
+		}
+		return nil
+	})
+	defer delete(SchemaStringFormats, "test-digits")
+	defer delete(SchemaStringFormats, "test-even")
+
+	require.True(t, IsMatchingStringFormat("test-digits", "123"))
+	require.False(t, IsMatchingStringFormat("test-digits", "12a"))
+	require.True(t, IsMatchingStringFormat("test-even", "ab"))
+	require.False(t, IsMatchingStringFormat("test-even", "abc"))
+	require.True(t, IsMatchingStringFormat("test-unknown", "anything"))
+}
+
+func TestRegisterFormatValidator(t *testing.T) {
+	errNotEven := errors.New("not even")
+	RegisterFormatValidator("even", func(value interface{}) error {
+		switch v := value.(type) {
+		case float64:
+			if int64(v)%2 == 0 {
+				return nil
+			}
+		case string:
+			if len(v)%2 == 0 {
+				return nil
+			}
+		}
+		return errNotEven
+	})
+	defer UnregisterFormatValidator("even")
+	DefineStringFormat("even", `^$`)
+	defer delete(SchemaStringFormats, "even")
+
+	integer := NewIntegerSchema().WithFormat("even")
+	require.NoError(t, integer.Validate(context.Background(), EnableSchemaFormatValidation()))
+	require.NoError(t, integer.VisitJSON(float64(2), EnableFormatValidation()))
+	err := integer.VisitJSON(float64(3))
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "format", schemaErr.SchemaField)
+	require.Equal(t, `value doesn't match the format "even"`, schemaErr.Reason)
+	require.ErrorIs(t, err, errNotEven)
+
+	// Type errors are reported first
+	err = integer.VisitJSON("ab")
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "type", schemaErr.SchemaField)
+
+	// The validator takes precedence over string formats
+	str := NewStringSchema().WithFormat("even")
+	require.NoError(t, str.VisitJSON("ab"))
+	require.Error(t, str.VisitJSON("abc"))
+	require.True(t, IsMatchingStringFormat("even", "ab"))
+	require.False(t, IsMatchingStringFormat("even", "a"))
+
+	UnregisterFormatValidator("even")
+	require.NoError(t, integer.VisitJSON(float64(3)))
+	require.Error(t, integer.Validate(context.Background(), EnableSchemaFormatValidation()))
+
+	require.Panics(t, func() { RegisterFormatValidator("", func(interface{}) error { return nil }) })
+	require.Panics(t, func() { RegisterFormatValidator("even", nil) })
+}
+
+func TestRegisterTimeFormatValidator(t *testing.T) {
+	RegisterTimeFormatValidator("past-date-time", func(value interface{}, now time.Time) error {
+		s, _ := value.(string)
+		at, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		if at.After(now) {
+			return errors.New("in the future")
+		}
+		return nil
+	})
+	defer UnregisterFormatValidator("past-date-time")
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	schema := NewStringSchema().WithFormat("past-date-time")
+	require.NoError(t, schema.VisitJSON("2019-12-31T23:59:59Z", WithClock(clock)))
+	err := schema.VisitJSON("2020-01-01T00:00:01Z", WithClock(clock))
+	require.EqualError(t, errors.Unwrap(err), "in the future")
+	require.NoError(t, schema.VisitJSON("2020-01-01T00:00:01Z"))
+
+	schema.Example = "2020-06-01T00:00:00Z"
+	require.NoError(t, schema.Validate(context.Background()))
+	err = schema.Validate(context.Background(), WithValidationClock(clock))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid example")
+
+	require.Panics(t, func() { RegisterTimeFormatValidator("", func(interface{}, time.Time) error { return nil }) })
+	require.Panics(t, func() { RegisterTimeFormatValidator("past-date-time", nil) })
+}
+
+func TestBuiltinFormatValidators(t *testing.T) {
+	for format, values := range map[string]struct{ valid, invalid []string }{
+		"uuid":          {[]string{"123e4567-e89b-12d3-a456-426614174000"}, []string{"123e4567e89b12d3a456426614174000", "not-a-uuid"}},
+		"email":         {[]string{"user@example.com"}, []string{"user", "User <user@example.com>"}},
+		"hostname":      {[]string{"example.com", "localhost", "a-b.example.com."}, []string{"-a.example.com", "a..b", "a_b.com"}},
+		"ipv4":          {[]string{"192.0.2.1"}, []string{"2001:db8::1", "256.0.0.1"}},
+		"ipv6":          {[]string{"2001:db8::1", "::ffff:192.0.2.1"}, []string{"192.0.2.1", "2001:db8:::1"}},
+		"uri":           {[]string{"https://example.com/a?b#c", "urn:isbn:0451450523"}, []string{"/relative", "%zz"}},
+		"uri-reference": {[]string{"/relative", "https://example.com"}, []string{"%zz"}},
+		"date":          {[]string{"2020-02-29"}, []string{"2021-02-29", "2020-1-2", "2020-01-02T03:04:05Z"}},
+		"duration":      {[]string{"P1Y2M3DT4H5M6.5S", "PT1M", "P3W", "P1D"}, []string{"P", "PT", "P1DT", "1D", "P1W2D"}},
+		"byte":          {[]string{"ZXhhbXBsZQ==", "_-8=", ""}, []string{"ZXhhbXBsZQ", "!!"}},
+	} {
+		schema := NewStringSchema().WithFormat(format)
+		for _, value := range values.valid {
+			require.NoError(t, schema.VisitJSON(value, EnableFormatValidation()), "%s %q", format, value)
+		}
+		for _, value := range values.invalid {
+			err := schema.VisitJSON(value, EnableFormatValidation())
+			var schemaErr *SchemaError
+			require.ErrorAs(t, err, &schemaErr, "%s %q", format, value)
+			require.Equal(t, "format", schemaErr.SchemaField)
+			require.Contains(t, schemaErr.Reason, `string doesn't match the format "`+format+`": `)
+		}
+	}
+
+	// The built-in validators are opt-in
+	require.NoError(t, NewStringSchema().WithFormat("uuid").VisitJSON("not-a-uuid"))
+	require.NoError(t, NewStringSchema().WithFormat("date").VisitJSON("2021-02-29"))
+	err := NewStringSchema().WithFormat("duration").VisitJSON("P1H", EnableFormatValidation())
+	require.EqualError(t, err, `string doesn't match the format "duration": not an ISO 8601 duration`+
+		"\nSchema:\n  {\n    \"format\": \"duration\",\n    \"type\": \"string\"\n  }\n\nValue:\n  \"P1H\"\n")
+}
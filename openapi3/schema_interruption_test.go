@@ -0,0 +1,42 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidationInterruption(t *testing.T) {
+	item := &Schema{
+		OneOf: SchemaRefs{
+			NewSchemaRef("", NewStringSchema().WithPattern("^a+$")),
+			NewSchemaRef("", NewStringSchema().WithPattern("^b+$")),
+			NewSchemaRef("", &Schema{Not: NewSchemaRef("", NewStringSchema())}),
+		},
+	}
+	schema := NewArraySchema().WithItems(item)
+	value := make([]interface{}, 0, 1000)
+	for i := 0; i < cap(value); i++ {
+		value = append(value, "aaaa")
+	}
+
+	require.NoError(t, schema.VisitJSON(value))
+	require.NoError(t, schema.VisitJSON(value, WithContext(context.Background()), WithTimeout(time.Minute)))
+
+	err := schema.VisitJSON(value, WithTimeout(time.Nanosecond))
+	require.Equal(t, ErrSchemaValidationTimeout, err)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = schema.VisitJSON(value, WithContext(canceled), MultiErrors())
+	require.Equal(t, context.Canceled, err)
+	// Interruptions are checked periodically, starting with the first value
+	require.Equal(t, context.Canceled, NewStringSchema().VisitJSON("a", WithContext(canceled)))
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	err = schema.VisitJSON(value, WithContext(expired))
+	require.Equal(t, ErrSchemaValidationTimeout, err)
+}
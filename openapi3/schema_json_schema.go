@@ -0,0 +1,228 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// JSONSchemaDraft202012 is the dialect of JSON Schema draft 2020-12.
+	JSONSchemaDraft202012 = "https://json-schema.org/draft/2020-12/schema"
+	// JSONSchemaDraft07 is the dialect of JSON Schema draft-07.
+	JSONSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+)
+
+// ToJSONSchema returns schema as a self-contained JSON Schema document of the given dialect,
+// JSONSchemaDraft202012 (the default when empty) or JSONSchemaDraft07.
+//
+// The schemas schema references are added to $defs (definitions in draft-07), named
+// after the last part of their reference, and references are rewritten to point there.
+// OpenAPI-specific keywords are converted: nullable adds "null" to type, example becomes
+// examples, boolean exclusiveMinimum and exclusiveMaximum become numbers, while
+// discriminator, xml, externalDocs and extensions are removed.
+func (schema *Schema) ToJSONSchema(dialect string) (map[string]interface{}, error) {
+	if dialect == "" {
+		dialect = JSONSchemaDraft202012
+	}
+	defsKeyword := "$defs"
+	switch dialect {
+	case JSONSchemaDraft202012:
+	case JSONSchemaDraft07:
+		defsKeyword = "definitions"
+	default:
+		return nil, fmt.Errorf("unsupported JSON Schema dialect %q", dialect)
+	}
+
+	e := &jsonSchemaExporter{
+		defsPrefix: "#/" + defsKeyword + "/",
+		names:      make(map[string]string),
+		taken:      make(map[string]struct{}),
+		schemas:    make(map[string]*Schema),
+	}
+	e.collect(schema)
+
+	root, err := e.export(schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.refs) != 0 {
+		defs := make(map[string]interface{}, len(e.refs))
+		for _, ref := range e.refs {
+			def, err := e.export(e.schemas[ref])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ref, err)
+			}
+			defs[e.names[ref]] = def
+		}
+		root[defsKeyword] = defs
+	}
+	root["$schema"] = dialect
+	return root, nil
+}
+
+type jsonSchemaExporter struct {
+	defsPrefix string
+	// references in the order they were found
+	refs []string
+	// names of the definitions of references
+	names   map[string]string
+	taken   map[string]struct{}
+	schemas map[string]*Schema
+}
+
+// collect names the references found in schema and the schemas it references.
+func (e *jsonSchemaExporter) collect(schema *Schema) {
+	if schema == nil {
+		return
+	}
+	refs := make(SchemaRefs, 0, len(schema.Properties)+len(schema.PatternProperties)+len(schema.AllOf)+len(schema.OneOf)+len(schema.AnyOf)+8)
+	for _, name := range sortedMapKeys(schema.Properties) {
+		refs = append(refs, schema.Properties[name])
+	}
+	for _, pattern := range sortedMapKeys(schema.PatternProperties) {
+		refs = append(refs, schema.PatternProperties[pattern])
+	}
+	refs = append(refs, schema.Items, schema.Contains, schema.PropertyNames, schema.AdditionalProperties.Schema, schema.Not, schema.If, schema.Then, schema.Else)
+	refs = append(refs, schema.AllOf...)
+	refs = append(refs, schema.OneOf...)
+	refs = append(refs, schema.AnyOf...)
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		if ref.Ref == "" {
+			e.collect(ref.Value)
+			continue
+		}
+		if _, ok := e.names[ref.Ref]; ok || ref.Value == nil {
+			continue
+		}
+		name := unescapeRefString(ref.Ref[strings.LastIndex(ref.Ref, "/")+1:])
+		if name == "" {
+			name = "schema"
+		}
+		for i := 2; ; i++ {
+			if _, ok := e.taken[name]; !ok {
+				break
+			}
+			name = strings.TrimRight(name, "0123456789") + strconv.Itoa(i)
+		}
+		e.taken[name] = struct{}{}
+		e.names[ref.Ref] = name
+		e.schemas[ref.Ref] = ref.Value
+		e.refs = append(e.refs, ref.Ref)
+		e.collect(ref.Value)
+	}
+}
+
+// export returns the JSON Schema of schema.
+func (e *jsonSchemaExporter) export(schema *Schema) (map[string]interface{}, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if err := e.convert(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// convert rewrites m, the JSON of an OpenAPI schema, in place.
+func (e *jsonSchemaExporter) convert(m map[string]interface{}) error {
+	if ref, ok := m["$ref"].(string); ok {
+		name, ok := e.names[ref]
+		if !ok {
+			return foundUnresolvedRef(ref)
+		}
+		m["$ref"] = e.defsPrefix + escapeJSONPointerToken(name)
+		return nil
+	}
+
+	for key := range m {
+		if strings.HasPrefix(key, "x-") {
+			delete(m, key)
+		}
+	}
+	delete(m, "discriminator")
+	delete(m, "xml")
+	delete(m, "externalDocs")
+
+	if nullable, _ := m["nullable"].(bool); nullable {
+		if t, ok := m["type"].(string); ok {
+			m["type"] = []interface{}{t, "null"}
+		}
+		if enum, ok := m["enum"].([]interface{}); ok {
+			m["enum"] = append(enum, nil)
+		}
+	}
+	delete(m, "nullable")
+
+	if example, ok := m["example"]; ok {
+		m["examples"] = []interface{}{example}
+		delete(m, "example")
+	}
+
+	for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		if exclusive, ok := m[bound[0]].(bool); ok {
+			delete(m, bound[0])
+			if value, ok := m[bound[1]]; ok && exclusive {
+				m[bound[0]] = value
+				delete(m, bound[1])
+			}
+		}
+	}
+
+	// Recurse into subschemas
+	if properties, ok := m["properties"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := e.convertSubschema(properties[name]); err != nil {
+				return fmt.Errorf("property %q: %w", name, err)
+			}
+		}
+	}
+	if properties, ok := m["patternProperties"].(map[string]interface{}); ok {
+		patterns := make([]string, 0, len(properties))
+		for pattern := range properties {
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+		for _, pattern := range patterns {
+			if err := e.convertSubschema(properties[pattern]); err != nil {
+				return fmt.Errorf("pattern property %q: %w", pattern, err)
+			}
+		}
+	}
+	for _, keyword := range []string{"items", "contains", "propertyNames", "additionalProperties", "not", "if", "then", "else"} {
+		if err := e.convertSubschema(m[keyword]); err != nil {
+			return fmt.Errorf("%s: %w", keyword, err)
+		}
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		items, _ := m[keyword].([]interface{})
+		for i, item := range items {
+			if err := e.convertSubschema(item); err != nil {
+				return fmt.Errorf("%s[%d]: %w", keyword, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *jsonSchemaExporter) convertSubschema(value interface{}) error {
+	if m, ok := value.(map[string]interface{}); ok {
+		return e.convert(m)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaToJSONSchema(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: test, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      discriminator: {propertyName: kind}
+      x-internal: true
+      properties:
+        name: {type: string, example: Rex, nullable: true}
+        kind: {type: string, enum: [cat, dog], nullable: true}
+        age: {type: integer, minimum: 0, exclusiveMinimum: true, maximum: 30, exclusiveMaximum: false}
+        owner: {$ref: '#/components/schemas/Owner'}
+        tags:
+          type: array
+          items: {$ref: '#/components/schemas/Tag'}
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items: {$ref: '#/components/schemas/Pet'}
+    Tag:
+      type: string
+      xml: {name: tag}
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	exported, err := doc.Components.Schemas["Pet"].Value.ToJSONSchema("")
+	require.NoError(t, err)
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": ["string", "null"], "examples": ["Rex"]},
+    "kind": {"type": ["string", "null"], "enum": ["cat", "dog", null]},
+    "age": {"type": "integer", "exclusiveMinimum": 0, "maximum": 30},
+    "owner": {"$ref": "#/$defs/Owner"},
+    "tags": {"type": "array", "items": {"$ref": "#/$defs/Tag"}}
+  },
+  "$defs": {
+    "Owner": {
+      "type": "object",
+      "properties": {"pets": {"type": "array", "items": {"$ref": "#/$defs/Pet"}}}
+    },
+    "Pet": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": ["string", "null"], "examples": ["Rex"]},
+        "kind": {"type": ["string", "null"], "enum": ["cat", "dog", null]},
+        "age": {"type": "integer", "exclusiveMinimum": 0, "maximum": 30},
+        "owner": {"$ref": "#/$defs/Owner"},
+        "tags": {"type": "array", "items": {"$ref": "#/$defs/Tag"}}
+      }
+    },
+    "Tag": {"type": "string"}
+  }
+}`, string(data))
+
+	exported, err = doc.Components.Schemas["Owner"].Value.ToJSONSchema(JSONSchemaDraft07)
+	require.NoError(t, err)
+	require.Equal(t, JSONSchemaDraft07, exported["$schema"])
+	require.Contains(t, exported, "definitions")
+	require.Equal(t, "#/definitions/Pet", exported["properties"].(map[string]interface{})["pets"].(map[string]interface{})["items"].(map[string]interface{})["$ref"])
+
+	_, err = doc.Components.Schemas["Pet"].Value.ToJSONSchema("https://example.com/dialect")
+	require.EqualError(t, err, `unsupported JSON Schema dialect "https://example.com/dialect"`)
+}
+
+func TestSchemaToJSONSchemaUnresolvedRef(t *testing.T) {
+	schema := NewObjectSchema().WithPropertyRef("foo", &SchemaRef{Ref: "#/components/schemas/Foo"})
+	_, err := schema.ToJSONSchema(JSONSchemaDraft202012)
+	require.EqualError(t, err, `property "foo": found unresolved ref: "#/components/schemas/Foo"`)
+}
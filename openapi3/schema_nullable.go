@@ -0,0 +1,138 @@
+package openapi3
+
+import (
+	"encoding/json"
+)
+
+// TypeNull is the type of the null value as of OpenAPI 3.1,
+// used by the nullable forms of NormalizeNullable.
+const TypeNull = "null"
+
+// NullableForm is a way of allowing null values in a schema, see NormalizeNullable.
+type NullableForm int
+
+const (
+	// NullableKeyword is the nullable keyword of OpenAPI 3.0: nullable: true
+	NullableKeyword NullableForm = iota
+	// NullableTypeArray is a type array with "null" of OpenAPI 3.1: type: [string, "null"]
+	NullableTypeArray
+	// NullableOneOf is a oneOf of a schema and the null type: oneOf: [{type: string}, {type: "null"}]
+	NullableOneOf
+	// NullableAnyOf is an anyOf of a schema and the null type: anyOf: [{type: string}, {type: "null"}]
+	NullableAnyOf
+)
+
+// NormalizeNullable rewrites the nullable schemas of doc, including those nested
+// in other schemas, into form, whichever of the forms of NullableForm they use.
+// A oneOf or anyOf is considered nullable when it has two schemas one of which
+// is of TypeNull and has no other keyword.
+//
+// A nullable reference, which cannot be made nullable itself as it is shared,
+// is wrapped in an allOf with the nullable keyword:
+//
+//	allOf: [$ref: '#/components/schemas/Pet']
+//	nullable: true
+//
+// Schemas without a type, like these, keep the nullable keyword with NullableTypeArray,
+// as a type array with only "null" would only allow null values.
+//
+// Note that the validation of values with this package only honors
+// the NullableKeyword and NullableTypeArray forms.
+func NormalizeNullable(doc *T, form NullableForm) error {
+	return TransformSchemas(doc, func(pointer string, ref *SchemaRef) (*SchemaRef, error) {
+		normalizeNullable(ref.Value, form)
+		return nil, nil
+	})
+}
+
+func normalizeNullable(schema *Schema, form NullableForm) {
+	switch form {
+	case NullableKeyword, NullableTypeArray:
+		if !schema.Nullable {
+			union, branch := nullableUnion(schema)
+			if branch == nil {
+				return
+			}
+			if *union = nil; branch.Ref == "" && onlyAnnotations(schema) {
+				// Merge the schema into its parent, keeping the annotations of the parent
+				merged := *branch.Value
+				if merged.Title == "" {
+					merged.Title = schema.Title
+				}
+				if merged.Description == "" {
+					merged.Description = schema.Description
+				}
+				*schema = merged
+			} else {
+				schema.AllOf = append(schema.AllOf, branch)
+			}
+			schema.Nullable = true
+		}
+		schema.nullType = form == NullableTypeArray && (schema.Type != "" || schema.typeArray != noTypeArray)
+
+	case NullableOneOf, NullableAnyOf:
+		var branch *SchemaRef
+		if schema.Nullable {
+			if stripped := *schema; len(stripped.AllOf) == 1 && stripped.AllOf[0].Ref != "" {
+				// A nullable reference
+				stripped.AllOf, stripped.Nullable, stripped.nullType = nil, false, false
+				if onlyAnnotations(&stripped) {
+					branch = schema.AllOf[0]
+				}
+			}
+			if branch == nil {
+				copied := *schema
+				copied.Nullable, copied.nullType = false, false
+				branch = &SchemaRef{Value: &copied}
+			}
+			*schema = Schema{}
+		} else {
+			union, b := nullableUnion(schema)
+			if b == nil {
+				return
+			}
+			*union, branch = nil, b
+		}
+		union := SchemaRefs{branch, NewSchemaRef("", &Schema{Type: TypeNull})}
+		if form == NullableOneOf {
+			schema.OneOf = union
+		} else {
+			schema.AnyOf = union
+		}
+	}
+}
+
+// nullableUnion returns the oneOf or anyOf of schema that makes it nullable,
+// along with its schema that is not of TypeNull, or nil if it has none.
+func nullableUnion(schema *Schema) (*SchemaRefs, *SchemaRef) {
+	for _, union := range []*SchemaRefs{&schema.OneOf, &schema.AnyOf} {
+		if refs := *union; len(refs) == 2 {
+			if isNullSchema(refs[1]) && !isNullSchema(refs[0]) {
+				return union, refs[0]
+			}
+			if isNullSchema(refs[0]) && !isNullSchema(refs[1]) {
+				return union, refs[1]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isNullSchema tells whether ref is a schema of TypeNull without other keywords.
+func isNullSchema(ref *SchemaRef) bool {
+	if ref == nil || ref.Ref != "" || ref.Value == nil || ref.Value.Type != TypeNull {
+		return false
+	}
+	copied := *ref.Value
+	copied.Type = ""
+	return onlyAnnotations(&copied)
+}
+
+// onlyAnnotations tells whether schema has no keyword besides its title and description.
+func onlyAnnotations(schema *Schema) bool {
+	copied := *schema
+	copied.ExtensionProps = ExtensionProps{}
+	copied.Title, copied.Description = "", ""
+	data, err := json.Marshal(&copied)
+	return err == nil && string(data) == "{}"
+}
@@ -0,0 +1,101 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeNullable(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        keyword:
+          type: string
+          nullable: true
+        typeArray:
+          type: [integer, 'null']
+        oneOf:
+          description: A number or null
+          oneOf:
+          - type: number
+          - type: 'null'
+        anyOf:
+          anyOf:
+          - type: 'null'
+          - $ref: '#/components/schemas/Owner'
+        reference:
+          allOf:
+          - $ref: '#/components/schemas/Owner'
+          nullable: true
+        notNullable:
+          oneOf:
+          - type: string
+          - type: integer
+    Owner:
+      type: object
+`
+	normalized := func(t *testing.T, form NullableForm) map[string]interface{} {
+		doc, err := NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		err = NormalizeNullable(doc, form)
+		require.NoError(t, err)
+		data, err := json.Marshal(doc.Components.Schemas["Pet"].Value.Properties)
+		require.NoError(t, err)
+		var properties map[string]interface{}
+		err = json.Unmarshal(data, &properties)
+		require.NoError(t, err)
+		return properties
+	}
+	owner := map[string]interface{}{"$ref": "#/components/schemas/Owner"}
+	null := map[string]interface{}{"type": "null"}
+	notNullable := map[string]interface{}{"oneOf": []interface{}{
+		map[string]interface{}{"type": "string"},
+		map[string]interface{}{"type": "integer"},
+	}}
+
+	require.Equal(t, map[string]interface{}{
+		"keyword":     map[string]interface{}{"type": "string", "nullable": true},
+		"typeArray":   map[string]interface{}{"type": "integer", "nullable": true},
+		"oneOf":       map[string]interface{}{"type": "number", "nullable": true, "description": "A number or null"},
+		"anyOf":       map[string]interface{}{"allOf": []interface{}{owner}, "nullable": true},
+		"reference":   map[string]interface{}{"allOf": []interface{}{owner}, "nullable": true},
+		"notNullable": notNullable,
+	}, normalized(t, NullableKeyword))
+
+	require.Equal(t, map[string]interface{}{
+		"keyword":     map[string]interface{}{"type": []interface{}{"string", "null"}},
+		"typeArray":   map[string]interface{}{"type": []interface{}{"integer", "null"}},
+		"oneOf":       map[string]interface{}{"type": []interface{}{"number", "null"}, "description": "A number or null"},
+		"anyOf":       map[string]interface{}{"allOf": []interface{}{owner}, "nullable": true},
+		"reference":   map[string]interface{}{"allOf": []interface{}{owner}, "nullable": true},
+		"notNullable": notNullable,
+	}, normalized(t, NullableTypeArray))
+
+	require.Equal(t, map[string]interface{}{
+		"keyword":     map[string]interface{}{"oneOf": []interface{}{map[string]interface{}{"type": "string"}, null}},
+		"typeArray":   map[string]interface{}{"oneOf": []interface{}{map[string]interface{}{"type": "integer"}, null}},
+		"oneOf":       map[string]interface{}{"oneOf": []interface{}{map[string]interface{}{"type": "number"}, null}, "description": "A number or null"},
+		"anyOf":       map[string]interface{}{"oneOf": []interface{}{owner, null}},
+		"reference":   map[string]interface{}{"oneOf": []interface{}{owner, null}},
+		"notNullable": notNullable,
+	}, normalized(t, NullableOneOf))
+
+	require.Equal(t, map[string]interface{}{
+		"keyword":     map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "string"}, null}},
+		"typeArray":   map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "integer"}, null}},
+		"oneOf":       map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "number"}, null}, "description": "A number or null"},
+		"anyOf":       map[string]interface{}{"anyOf": []interface{}{owner, null}},
+		"reference":   map[string]interface{}{"anyOf": []interface{}{owner, null}},
+		"notNullable": notNullable,
+	}, normalized(t, NullableAnyOf))
+}
@@ -182,3 +182,54 @@ components:
 	assert.ErrorAs(t, err, &sErr)
 	assert.Equal(t, []string{"first", "second", "third"}, sErr.JSONPointer())
 }
+
+func TestVisitJSON_Discriminator_SelectsBranch(t *testing.T) {
+	spec := []byte(`
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        kind: {type: string}
+        lives: {type: integer, maximum: 9}
+    Dog:
+      type: object
+      properties:
+        kind: {type: string}
+        barks: {type: boolean}
+    OneOfPet:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+      discriminator:
+        propertyName: kind
+    AnyOfPet:
+      anyOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+      discriminator:
+        propertyName: kind
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	for _, name := range []string{"OneOfPet", "AnyOfPet"} {
+		schema := doc.Components.Schemas[name].Value
+
+		// Without a mapping, the discriminator value is the name of the schema
+		require.NoError(t, schema.VisitJSON(map[string]interface{}{"kind": "Cat", "lives": 9}), name)
+
+		// Only the selected schema reports errors, even if another one matches
+		err = schema.VisitJSON(map[string]interface{}{"kind": "Cat", "lives": 10})
+		require.Error(t, err, name)
+		require.Contains(t, err.Error(), `Error at "/lives": number must be at most 9`, name)
+		err = schema.VisitJSON(map[string]interface{}{"kind": "Dog", "lives": 10})
+		require.NoError(t, err, name)
+
+		err = schema.VisitJSON(map[string]interface{}{"kind": "Snake"})
+		require.EqualError(t, err, "input does not contain a valid discriminator value", name)
+		var schemaErr *SchemaError
+		require.ErrorAs(t, err, &schemaErr, name)
+		require.Equal(t, "discriminator", schemaErr.SchemaField, name)
+	}
+}
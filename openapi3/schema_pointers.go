@@ -0,0 +1,50 @@
+package openapi3
+
+import "strings"
+
+// InstancePointer returns the JSON Pointer of the value that does not match the schema
+// within the value validated, e.g. "/pets/0/age", or an empty string for the value itself.
+func (err *SchemaError) InstancePointer() string {
+	var sb strings.Builder
+	for i := len(err.reversePath) - 1; i >= 0; i-- {
+		sb.WriteByte('/')
+		sb.WriteString(escapeJSONPointerToken(err.reversePath[i]))
+	}
+	return sb.String()
+}
+
+// SchemaPointer returns the JSON Pointer, as a URI fragment, of the keyword of the schema
+// within doc that the value does not match, e.g. "#/components/schemas/Pet/properties/age/maximum".
+// Schemas reached through references are located at their definition.
+// It returns an empty string when the schema is not part of doc.
+func (err *SchemaError) SchemaPointer(doc *T) string {
+	locations := doc.schemaLocations
+	if locations == nil {
+		locations = newSchemaLocations(doc)
+	}
+	location, ok := locations[err.Schema]
+	if !ok {
+		return ""
+	}
+	pointer := "#" + location
+	if err.SchemaField != "" {
+		pointer += "/" + escapeJSONPointerToken(err.SchemaField)
+	}
+	return pointer
+}
+
+// newSchemaLocations returns the JSON Pointers of the schemas of doc,
+// that of their definition for schemas that are referenced.
+func newSchemaLocations(doc *T) map[*Schema]string {
+	locations := make(map[*Schema]string)
+	// The walk function never fails
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, ok := value.(*SchemaRef); ok && ref.Value != nil {
+			if _, ok := locations[ref.Value]; !ok {
+				locations[ref.Value] = pointer
+			}
+		}
+		return nil
+	})
+	return locations
+}
@@ -0,0 +1,67 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaErrorPointers(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: Pets, version: v1}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                pets:
+                  type: array
+                  items: {$ref: '#/components/schemas/Pet'}
+                "owner/name": {type: string}
+      responses:
+        '201': {description: Created.}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        age: {type: integer, maximum: 30}
+`)
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	schema := doc.Paths["/pets"].Post.RequestBody.Value.Content["application/json"].Schema.Value
+
+	schemaErrorOf := func(value interface{}) *SchemaError {
+		err := schema.VisitJSON(value)
+		require.Error(t, err)
+		var schemaErr *SchemaError
+		require.ErrorAs(t, err, &schemaErr)
+		return schemaErr
+	}
+
+	schemaErr := schemaErrorOf(map[string]interface{}{
+		"pets": []interface{}{map[string]interface{}{"age": 3}, map[string]interface{}{"age": 31}},
+	})
+	require.Equal(t, "/pets/1/age", schemaErr.InstancePointer())
+	require.Equal(t, "#/components/schemas/Pet/properties/age/maximum", schemaErr.SchemaPointer(doc))
+
+	schemaErr = schemaErrorOf(map[string]interface{}{"owner/name": 1})
+	require.Equal(t, "/owner~1name", schemaErr.InstancePointer())
+	require.Equal(t, "#/paths/~1pets/post/requestBody/content/application~1json/schema/properties/owner~1name/type", schemaErr.SchemaPointer(doc))
+
+	schemaErr = schemaErrorOf("pets")
+	require.Empty(t, schemaErr.InstancePointer())
+	require.Equal(t, "#/paths/~1pets/post/requestBody/content/application~1json/schema/type", schemaErr.SchemaPointer(doc))
+
+	doc.Freeze()
+	require.Equal(t, "#/paths/~1pets/post/requestBody/content/application~1json/schema/type", schemaErr.SchemaPointer(doc))
+
+	schemaErr = &SchemaError{Schema: NewStringSchema(), SchemaField: "type"}
+	require.Empty(t, schemaErr.SchemaPointer(doc))
+}
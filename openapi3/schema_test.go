@@ -801,11 +801,11 @@ var schemaExamples = []schemaExample{
 	{
 		Schema: &Schema{
 			Type: "object",
-			AdditionalProperties: &SchemaRef{
+			AdditionalProperties: AdditionalProperties{Schema: &SchemaRef{
 				Value: &Schema{
 					Type: "number",
 				},
-			},
+			}},
 		},
 		Serialization: map[string]interface{}{
 			"type": "object",
@@ -828,8 +828,8 @@ var schemaExamples = []schemaExample{
 	},
 	{
 		Schema: &Schema{
-			Type:                        "object",
-			AdditionalPropertiesAllowed: BoolPtr(true),
+			Type:                 "object",
+			AdditionalProperties: AdditionalProperties{Has: BoolPtr(true)},
 		},
 		Serialization: map[string]interface{}{
 			"type":                 "object",
@@ -1354,3 +1354,129 @@ enum:
 	err = schema.VisitJSON(map[string]interface{}{"d": "e"})
 	require.Error(t, err)
 }
+
+func TestSchemaEnumValidation(t *testing.T) {
+	for _, test := range []struct {
+		schema string
+		err    string
+	}{
+		{`{"type": "integer", "enum": [1, 2]}`, ""},
+		{`{"type": "string", "nullable": true, "enum": ["a", null]}`, ""},
+		{`{"type": "string", "format": "date", "enum": ["2020-01-02"]}`, ""},
+		{`{"type": "string", "minLength": 3, "enum": ["a"]}`, ""},
+		{`{"type": "integer", "enum": [1, "a"]}`, `invalid enum value "a": field must be set to integer or not be present`},
+		{`{"type": "integer", "enum": [1.5]}`, `invalid enum value 1.5: value "1.5" must be an integer`},
+		{`{"type": "string", "enum": ["a", null]}`, `invalid enum value null: Value is not nullable`},
+		{`{"type": "array", "items": {"type": "string"}, "enum": [["a"], "b"]}`, `invalid enum value "b": field must be set to array or not be present`},
+		{`{"type": "string", "format": "date", "enum": ["tomorrow"]}`, `invalid enum value "tomorrow": string doesn't match the format "date" (regular expression "^[0-9]{4}-(0[0-9]|10|11|12)-([0-2][0-9]|30|31)$")`},
+	} {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(test.schema), &schema))
+		err := schema.Validate(context.Background())
+		if test.err == "" {
+			require.NoError(t, err, test.schema)
+		} else {
+			require.EqualError(t, err, test.err, test.schema)
+		}
+	}
+}
+
+type matchStringFunc func(s string) bool
+
+func (f matchStringFunc) MatchString(s string) bool { return f(s) }
+
+func TestSetRegexpCompiler(t *testing.T) {
+	const notAdmin = `^(?!admin$)`
+	schema := &Schema{
+		Type: "object",
+		PatternProperties: Schemas{
+			notAdmin: NewStringSchema().WithPattern(notAdmin).NewRef(),
+		},
+	}
+	err := schema.Validate(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `cannot compile pattern "^(?!admin$)"`)
+
+	SetRegexpCompiler(func(expr string) (Regexp, error) {
+		if expr == notAdmin {
+			return matchStringFunc(func(s string) bool { return s != "admin" }), nil
+		}
+		return compileRE2(expr)
+	})
+	defer SetRegexpCompiler(nil)
+
+	schema = &Schema{
+		Type: "object",
+		PatternProperties: Schemas{
+			notAdmin: NewStringSchema().WithPattern(notAdmin).NewRef(),
+		},
+	}
+	require.NoError(t, schema.Validate(context.Background()))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"owner": "alice", "admin": 1}))
+	err = schema.VisitJSON(map[string]interface{}{"owner": "admin"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `string "admin" doesn't match the regular expression "^(?!admin$)"`)
+	err = schema.VisitJSON(map[string]interface{}{"owner": 1})
+	require.Error(t, err)
+}
+
+func TestVisitJSONWithDefaults(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: Schemas{
+			"limit": NewIntegerSchema().WithDefault(float64(10)).NewRef(),
+			"tags":  NewArraySchema().WithItems(NewStringSchema()).WithDefault([]interface{}{"all"}).NewRef(),
+			"sort":  NewStringSchema().NewRef(),
+		},
+		Default: map[string]interface{}{"sort": "name"},
+	}
+
+	value, err := schema.VisitJSONWithDefaults(map[string]interface{}{"limit": float64(5)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"limit": float64(5), "tags": []interface{}{"all"}}, value)
+
+	// Defaults are copied and remain unchanged
+	value.(map[string]interface{})["tags"].([]interface{})[0] = "none"
+	require.Equal(t, []interface{}{"all"}, schema.Properties["tags"].Value.Default)
+
+	value, err = schema.VisitJSONWithDefaults(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"sort": "name", "limit": float64(10), "tags": []interface{}{"all"}}, value)
+	require.Equal(t, map[string]interface{}{"sort": "name"}, schema.Default)
+
+	_, err = schema.VisitJSONWithDefaults(map[string]interface{}{"limit": "5"})
+	require.Error(t, err)
+
+	object := map[string]interface{}{}
+	require.NoError(t, schema.VisitJSON(object))
+	require.Empty(t, object)
+	require.NoError(t, schema.VisitJSON(object, ApplyDefaults()))
+	require.Len(t, object, 2)
+}
+
+func TestAdditionalPropertiesYAML(t *testing.T) {
+	for name, tc := range map[string]struct {
+		addProps AdditionalProperties
+		expected string
+	}{
+		"absent": {AdditionalProperties{}, "type: object\n"},
+		"true":   {AdditionalProperties{Has: BoolPtr(true)}, "type: object\nadditionalProperties: true\n"},
+		"false":  {AdditionalProperties{Has: BoolPtr(false)}, "type: object\nadditionalProperties: false\n"},
+		"empty":  {AdditionalProperties{Schema: NewSchemaRef("", &Schema{})}, "type: object\nadditionalProperties: {}\n"},
+		"schema": {AdditionalProperties{Schema: NewStringSchema().NewRef()}, "type: object\nadditionalProperties:\n    type: string\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := yaml.Marshal(&Schema{Type: TypeObject, AdditionalProperties: tc.addProps})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+
+			var schema Schema
+			require.NoError(t, yaml.Unmarshal(data, &schema))
+			require.Equal(t, tc.addProps.Has, schema.AdditionalProperties.Has)
+			require.Equal(t, tc.addProps.Schema == nil, schema.AdditionalProperties.Schema == nil)
+			data, err = yaml.Marshal(&schema)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package openapi3
 
 import (
+	"context"
 	"sync"
+	"time"
 )
 
 // SchemaValidationOption describes options a user has when validating request / response bodies.
@@ -11,15 +13,40 @@ type schemaValidationSettings struct {
 	failfast                  bool
 	multiError                bool
 	asreq, asrep              bool // exclusive (XOR) fields
+	stripReadOnly             bool
+	readOnlyStripped          func()
 	formatValidationEnabled   bool
 	patternValidationDisabled bool
+	contentValidationEnabled  bool
 
 	onceSettingDefaults sync.Once
+	applyDefaults       bool
 	defaultsSet         func()
 
 	customizeMessageError func(err *SchemaError) string
+
+	branchMatched func(schema *Schema, keyword string, index int)
+	// number of alternatives being tried, whose matches are not reported
+	trials int
+
+	itemsLimit     int
+	itemsTruncated func(schema *Schema, value interface{})
+
+	// now is the clock of the validators of time formats
+	now func() time.Time
+
+	ctx      context.Context
+	timeout  time.Duration
+	deadline time.Time
+	// number of values visited, to check for interruptions periodically
+	visits uint
+	// interruption is the error validation was interrupted with, returned by VisitJSON
+	interruption error
 }
 
+// interruptionCheckInterval is the number of values visited between checks for interruptions.
+const interruptionCheckInterval = 64
+
 // FailFast returns schema validation errors quicker.
 func FailFast() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.failfast = true }
@@ -37,7 +64,17 @@ func VisitAsResponse() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.asreq, s.asrep = false, true }
 }
 
+// StripReadOnlyProperties makes validation with VisitAsRequest remove the readOnly
+// properties of objects instead of failing, calling stripped, if not nil, for each.
+func StripReadOnlyProperties(stripped func()) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.stripReadOnly, s.readOnlyStripped = true, stripped }
+}
+
 // EnableFormatValidation setting makes Validate not return an error when validating documents that mention schema formats that are not defined by the OpenAPIv3 specification.
+//
+// It also makes validation check strings of the formats uuid, email, hostname, ipv4, ipv6,
+// uri, uri-reference, date, duration and byte with built-in validators, which take precedence
+// over those defined with DefineStringFormat or DefineStringFormatCallback.
 func EnableFormatValidation() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.formatValidationEnabled = true }
 }
@@ -47,11 +84,63 @@ func DisablePatternValidation() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.patternValidationDisabled = true }
 }
 
+// EnableContentValidation makes validation decode strings according to the contentEncoding
+// of their schema, and parse the decoded content when their contentMediaType is a JSON media type.
+// Without it, contentEncoding and contentMediaType are only annotations.
+func EnableContentValidation() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.contentValidationEnabled = true }
+}
+
+// ApplyDefaults makes validation set the missing properties of objects to the default
+// of their schema, as it does with VisitAsRequest and VisitAsResponse, modifying the value
+// being validated. See also Schema.VisitJSONWithDefaults and DefaultsSet.
+func ApplyDefaults() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.applyDefaults = true }
+}
+
 // DefaultsSet executes the given callback (once) IFF schema validation set default values.
 func DefaultsSet(f func()) SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.defaultsSet = f }
 }
 
+// BranchMatched calls f when the value being validated, or a value it contains,
+// matches the alternative at index of the oneOf or anyOf (keyword) of schema.
+func BranchMatched(f func(schema *Schema, keyword string, index int)) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.branchMatched = f }
+}
+
+// LimitItemsValidation makes validation check only the first n items of arrays
+// against the items schema, and only the first n properties of objects, in key order,
+// against the properties and additionalProperties of the schema.
+// Other keywords, such as minItems or required, still apply to the whole value.
+// truncated, if not nil, is called with each array or object not fully validated
+// and its schema.
+func LimitItemsValidation(n int, truncated func(schema *Schema, value interface{})) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.itemsLimit, s.itemsTruncated = n, truncated }
+}
+
+// WithContext makes validation stop when ctx is done, with the error of ctx
+// or with ErrSchemaValidationTimeout when its deadline passed.
+func WithContext(ctx context.Context) SchemaValidationOption {
+	return func(s *schemaValidationSettings) {
+		if ctx != nil && ctx.Done() != nil {
+			s.ctx = ctx
+		}
+	}
+}
+
+// WithClock makes validation call the validators registered with RegisterTimeFormatValidator
+// with the time now returns, e.g. a fixed time for tests to be deterministic.
+// A nil now stands for time.Now, the default clock.
+func WithClock(now func() time.Time) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.now = now }
+}
+
+// WithTimeout makes validation stop with ErrSchemaValidationTimeout when it takes longer than d.
+func WithTimeout(d time.Duration) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.timeout = d }
+}
+
 // SetSchemaErrorMessageCustomizer allows to override the schema error message.
 // If the passed function returns an empty string, it returns to the previous Error() implementation.
 func SetSchemaErrorMessageCustomizer(f func(err *SchemaError) string) SchemaValidationOption {
@@ -63,5 +152,68 @@ func newSchemaValidationSettings(opts ...SchemaValidationOption) *schemaValidati
 	for _, opt := range opts {
 		opt(settings)
 	}
+	if settings.timeout > 0 {
+		settings.deadline = time.Now().Add(settings.timeout)
+	}
 	return settings
 }
+
+// interrupted returns the error validation is interrupted with, once its context
+// is done or its deadline passed. These are checked every interruptionCheckInterval values.
+func (settings *schemaValidationSettings) interrupted() error {
+	if settings.interruption != nil || (settings.ctx == nil && settings.deadline.IsZero()) {
+		return settings.interruption
+	}
+	settings.visits++
+	if settings.visits%interruptionCheckInterval != 1 {
+		return nil
+	}
+	if ctx := settings.ctx; ctx != nil {
+		if err := ctx.Err(); err == context.DeadlineExceeded {
+			settings.interruption = ErrSchemaValidationTimeout
+		} else if err != nil {
+			settings.interruption = err
+		}
+	}
+	if settings.interruption == nil && !settings.deadline.IsZero() && time.Now().After(settings.deadline) {
+		settings.interruption = ErrSchemaValidationTimeout
+	}
+	return settings.interruption
+}
+
+// clock returns the current time of the clock of validation.
+func (settings *schemaValidationSettings) clock() time.Time {
+	if settings.now != nil {
+		return settings.now()
+	}
+	return time.Now()
+}
+
+// setsDefaults tells whether validation sets the missing properties of objects to their default.
+func (settings *schemaValidationSettings) setsDefaults() bool {
+	return settings.asreq || settings.asrep || settings.applyDefaults
+}
+
+// try validates value against schema without reporting matched branches.
+func (settings *schemaValidationSettings) try(schema *Schema, value interface{}) error {
+	settings.trials++
+	defer func() { settings.trials-- }()
+	return schema.visitJSON(settings, value)
+}
+
+// truncated limits items, the items or sorted property names of value, to the items limit.
+func (settings *schemaValidationSettings) truncated(schema *Schema, value interface{}, items int) int {
+	if limit := settings.itemsLimit; limit > 0 && items > limit {
+		if settings.itemsTruncated != nil && settings.trials == 0 {
+			settings.itemsTruncated(schema, value)
+		}
+		return limit
+	}
+	return items
+}
+
+func (settings *schemaValidationSettings) matched(schema *Schema, keyword string, index int) {
+	if settings.branchMatched != nil && settings.trials == 0 {
+		settings.branchMatched(schema, keyword, index)
+	}
+}
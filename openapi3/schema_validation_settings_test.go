@@ -34,3 +34,21 @@ components:
 
 	// Output: field "Some field" should be string
 }
+
+func ExampleLimitItemsValidation() {
+	schema := openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema().WithMin(0)).WithMaxItems(5)
+	opt := openapi3.LimitItemsValidation(2, func(schema *openapi3.Schema, value interface{}) {
+		fmt.Printf("validated 2 of %d items\n", len(value.([]interface{})))
+	})
+
+	fmt.Println(schema.VisitJSON([]interface{}{1.0, 2.0, -3.0}, opt) == nil)
+	fmt.Println(schema.VisitJSON([]interface{}{1.0, -2.0, 3.0}, opt) == nil)
+	fmt.Println(schema.VisitJSON([]interface{}{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}, opt) == nil)
+
+	// Output:
+	// validated 2 of 3 items
+	// true
+	// validated 2 of 3 items
+	// false
+	// false
+}
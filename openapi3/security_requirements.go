@@ -2,6 +2,8 @@ package openapi3
 
 import (
 	"context"
+	"fmt"
+	"sort"
 )
 
 type SecurityRequirements []SecurityRequirement
@@ -49,3 +51,59 @@ func (security *SecurityRequirement) Validate(ctx context.Context, opts ...Valid
 
 	return nil
 }
+
+// validateSecurityRequirements returns an error if a security requirement of doc, at the
+// top level or in an operation, names a security scheme its components do not define,
+// or requires OAuth2 scopes that none of the flows of the scheme declares.
+func (doc *T) validateSecurityRequirements(ctx context.Context) error {
+	errs := newValidationErrors(ctx)
+	noWrap := func(e error) error { return e }
+
+	var err error
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		security, ok := value.(SecurityRequirement)
+		if !ok {
+			return nil
+		}
+		names := make([]string, 0, len(security))
+		for name := range security {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ref, ok := doc.Components.SecuritySchemes[name]
+			if !ok {
+				e := fmt.Errorf("security requirement at %s names undefined security scheme %q", pointer, name)
+				if err = errs.add(e, noWrap); err != nil {
+					return ErrStopWalk
+				}
+				continue
+			}
+			if ref == nil || ref.Value == nil || ref.Value.Type != "oauth2" || ref.Value.Flows == nil {
+				continue
+			}
+			flows := ref.Value.Flows
+			for _, scope := range security[name] {
+				declared := false
+				for _, flow := range []*OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+					if flow != nil {
+						if _, ok := flow.Scopes[scope]; ok {
+							declared = true
+						}
+					}
+				}
+				if !declared {
+					e := fmt.Errorf("security requirement at %s requires scope %q undeclared by the flows of security scheme %q", pointer, scope, name)
+					if err = errs.add(e, noWrap); err != nil {
+						return ErrStopWalk
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errs.err()
+}
@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -62,3 +63,59 @@ func TestSecurityRequirementEncoding(t *testing.T) {
 		require.Equal(t, test.json, string(b), "incorrect requirements encoding")
 	}
 }
+
+func TestValidateSecurityRequirements(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Security
+  version: 1.0.0
+security:
+  - apiKey: []
+  - oauth: [read]
+paths:
+  /pets:
+    get:
+      security:
+        - oauth: [read, write, admin]
+        - basic: []
+      responses:
+        '200':
+          description: ok
+    post:
+      security: []
+      responses:
+        '201':
+          description: created
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/authorize
+          scopes:
+            read: Read pets
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            write: Write pets
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `security requirement at /paths/~1pets/get/security/0 requires scope "admin" undeclared by the flows of security scheme "oauth"`)
+
+	err = doc.Validate(context.Background(), EnableMultiErrorValidation())
+	require.EqualError(t, err, `security requirement at /paths/~1pets/get/security/0 requires scope "admin" undeclared by the flows of security scheme "oauth" | `+
+		`security requirement at /paths/~1pets/get/security/1 names undefined security scheme "basic"`)
+
+	doc.Components.SecuritySchemes["basic"] = &SecuritySchemeRef{Value: NewSecurityScheme().WithType("http").WithScheme("basic")}
+	(*doc.Paths["/pets"].Get.Security)[0]["oauth"] = []string{"read", "write"}
+	require.NoError(t, doc.Validate(context.Background()))
+}
@@ -15,3 +15,10 @@ type SerializationMethod struct {
 	Style   string
 	Explode bool
 }
+
+// defaultExplode returns the explode value of the parameters, headers and encodings
+// of style that do not set it: true for the form style and false for others.
+// The deepObject style, only defined for exploded values, is exploded as well.
+func defaultExplode(style string) bool {
+	return style == SerializationForm || style == SerializationDeepObject
+}
@@ -49,6 +49,19 @@ func (servers Servers) MatchURL(parsedURL *url.URL) (*Server, []string, string)
 	return nil, nil, ""
 }
 
+// Match returns the first server whose URL template matches u, along with
+// the values of its variables and the remaining path. See Server.Match.
+func (servers Servers) Match(u *url.URL) (*Server, map[string]string, string) {
+	rawURL := *u
+	rawURL.RawQuery, rawURL.Fragment = "", ""
+	for _, server := range servers {
+		if variables, remaining, ok := server.Match(rawURL.String()); ok {
+			return server, variables, remaining
+		}
+	}
+	return nil, nil, ""
+}
+
 // Server is specified by OpenAPI/Swagger standard version 3.
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#serverObject
 type Server struct {
@@ -66,12 +79,7 @@ func (server *Server) BasePath() (string, error) {
 		return "/", nil
 	}
 
-	uri := server.URL
-	for name, svar := range server.Variables {
-		uri = strings.ReplaceAll(uri, "{"+name+"}", svar.Default)
-	}
-
-	u, err := url.ParseRequestURI(uri)
+	u, err := server.ResolveURL(nil)
 	if err != nil {
 		return "", err
 	}
@@ -164,6 +172,93 @@ func (server Server) MatchRawURL(input string) ([]string, string, bool) {
 	return params, input, true
 }
 
+// Expand returns the URL of server with its variables replaced by values,
+// or by their default when absent from values.
+// It fails for values of unknown variables and values not in the variable's enum.
+func (server *Server) Expand(values map[string]string) (string, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		variable := server.Variables[name]
+		if variable == nil {
+			return "", fmt.Errorf("server %q has no variable %q", server.URL, name)
+		}
+		if !variable.allows(values[name]) {
+			return "", fmt.Errorf("value %q of server variable %q is not one of %q", values[name], name, variable.Enum)
+		}
+	}
+
+	var sb strings.Builder
+	pattern := server.URL
+	for {
+		i := strings.IndexByte(pattern, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(pattern[i:], '}')
+		if j < 0 {
+			return "", errors.New("server URL has mismatched { and }")
+		}
+		name := strings.TrimSpace(pattern[i+1 : i+j])
+		value, ok := values[name]
+		if !ok {
+			variable := server.Variables[name]
+			if variable == nil {
+				return "", fmt.Errorf("server %q has undeclared variable %q", server.URL, name)
+			}
+			value = variable.Default
+		}
+		sb.WriteString(pattern[:i])
+		sb.WriteString(value)
+		pattern = pattern[i+j+1:]
+	}
+	sb.WriteString(pattern)
+	return sb.String(), nil
+}
+
+// ResolveURL returns the concrete URL of server, its URL template with its variables
+// replaced by vars, or by their default when absent from vars. See Expand.
+// The URL returned is relative for servers with a relative URL: clients resolve it
+// against the URL of the document, routers match the path of requests against it.
+func (server *Server) ResolveURL(vars map[string]string) (*url.URL, error) {
+	expanded, err := server.Expand(vars)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(expanded)
+}
+
+// Match tells whether rawURL matches the URL template of server, returning the
+// values of the variables and the path remaining after the server's URL.
+// Values must be in the variables' enums, if any.
+// Servers with a relative URL match the path of absolute URLs.
+func (server *Server) Match(rawURL string) (map[string]string, string, bool) {
+	if strings.HasPrefix(server.URL, "/") {
+		if u, err := url.Parse(rawURL); err == nil && u.IsAbs() {
+			rawURL = u.EscapedPath()
+		}
+	}
+	values, remaining, ok := server.MatchRawURL(rawURL)
+	if !ok {
+		return nil, "", false
+	}
+	names, err := server.ParameterNames()
+	if err != nil || len(names) != len(values) {
+		return nil, "", false
+	}
+	variables := make(map[string]string, len(names))
+	for i, name := range names {
+		if variable := server.Variables[name]; variable != nil && !variable.allows(values[i]) {
+			return nil, "", false
+		}
+		variables[name] = values[i]
+	}
+	return variables, remaining, true
+}
+
 // Validate returns an error if Server does not comply with the OpenAPI spec.
 func (server *Server) Validate(ctx context.Context, opts ...ValidationOption) (err error) {
 	ctx = WithValidationOptions(ctx, opts...)
@@ -177,8 +272,16 @@ func (server *Server) Validate(ctx context.Context, opts ...ValidationOption) (e
 		return errors.New("server URL has mismatched { and }")
 	}
 
-	if opening != len(server.Variables) {
-		return errors.New("server has undeclared variables")
+	names, err := server.ParameterNames()
+	if err != nil {
+		return err
+	}
+	referenced := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := server.Variables[name]; !ok {
+			return fmt.Errorf("server URL %q references undeclared variable %q", server.URL, name)
+		}
+		referenced[name] = struct{}{}
 	}
 
 	variables := make([]string, 0, len(server.Variables))
@@ -188,8 +291,11 @@ func (server *Server) Validate(ctx context.Context, opts ...ValidationOption) (e
 	sort.Strings(variables)
 	for _, name := range variables {
 		v := server.Variables[name]
-		if !strings.Contains(server.URL, "{"+name+"}") {
-			return errors.New("server has undeclared variables")
+		if _, ok := referenced[name]; !ok {
+			return fmt.Errorf("server variable %q is not referenced by URL %q", name, server.URL)
+		}
+		if v == nil {
+			return fmt.Errorf("server variable %q: value MUST be an object", name)
 		}
 		if err = v.Validate(ctx); err != nil {
 			return
@@ -218,6 +324,19 @@ func (serverVariable *ServerVariable) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, serverVariable)
 }
 
+// allows tells whether value is in the enum of the variable, if any.
+func (serverVariable *ServerVariable) allows(value string) bool {
+	if len(serverVariable.Enum) == 0 {
+		return true
+	}
+	for _, allowed := range serverVariable.Enum {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate returns an error if ServerVariable does not comply with the OpenAPI spec.
 func (serverVariable *ServerVariable) Validate(ctx context.Context, opts ...ValidationOption) error {
 	// ctx = WithValidationOptions(ctx, opts...)
@@ -229,5 +348,8 @@ func (serverVariable *ServerVariable) Validate(ctx context.Context, opts ...Vali
 		}
 		return fmt.Errorf("field default is required in %s", data)
 	}
+	if !serverVariable.allows(serverVariable.Default) {
+		return fmt.Errorf("field default %q is not one of enum %q", serverVariable.Default, serverVariable.Enum)
+	}
 	return nil
 }
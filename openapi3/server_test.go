@@ -3,6 +3,7 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -201,3 +202,63 @@ func TestServersBasePath(t *testing.T) {
 		})
 	}
 }
+
+func TestServerExpandAndMatch(t *testing.T) {
+	server := &Server{
+		URL: "https://{region}.example.com:{port}/{basePath}",
+		Variables: map[string]*ServerVariable{
+			"region":   {Default: "eu", Enum: []string{"eu", "us"}},
+			"port":     {Default: "443"},
+			"basePath": {Default: "v1"},
+		},
+	}
+
+	expanded, err := server.Expand(nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://eu.example.com:443/v1", expanded)
+	expanded, err = server.Expand(map[string]string{"region": "us", "port": "8443"})
+	require.NoError(t, err)
+	require.Equal(t, "https://us.example.com:8443/v1", expanded)
+	_, err = server.Expand(map[string]string{"region": "asia"})
+	require.EqualError(t, err, `value "asia" of server variable "region" is not one of ["eu" "us"]`)
+	_, err = server.Expand(map[string]string{"host": "x"})
+	require.EqualError(t, err, `server "https://{region}.example.com:{port}/{basePath}" has no variable "host"`)
+
+	resolved, err := server.ResolveURL(map[string]string{"region": "us"})
+	require.NoError(t, err)
+	require.Equal(t, "us.example.com:443", resolved.Host)
+	require.Equal(t, "/v1", resolved.Path)
+	_, err = server.ResolveURL(map[string]string{"region": "asia"})
+	require.EqualError(t, err, `value "asia" of server variable "region" is not one of ["eu" "us"]`)
+	resolved, err = (&Server{URL: "/api"}).ResolveURL(nil)
+	require.NoError(t, err)
+	require.False(t, resolved.IsAbs())
+	require.Equal(t, "https://example.com/api", (&url.URL{Scheme: "https", Host: "example.com"}).ResolveReference(resolved).String())
+
+	variables, remaining, ok := server.Match("https://us.example.com:8443/v2/pets")
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"region": "us", "port": "8443", "basePath": "v2"}, variables)
+	require.Equal(t, "/pets", remaining)
+	_, _, ok = server.Match("https://asia.example.com:8443/v2/pets")
+	require.False(t, ok)
+
+	relative := &Server{URL: "/api"}
+	variables, remaining, ok = relative.Match("https://example.com/api/pets")
+	require.True(t, ok)
+	require.Empty(t, variables)
+	require.Equal(t, "/pets", remaining)
+
+	servers := Servers{server, relative}
+	u, err := url.Parse("https://eu.example.com:443/v1/pets?limit=1")
+	require.NoError(t, err)
+	matched, variables, remaining := servers.Match(u)
+	require.Same(t, server, matched)
+	require.Equal(t, "eu", variables["region"])
+	require.Equal(t, "/pets", remaining)
+
+	u, err = url.Parse("https://asia.example.com/api/pets")
+	require.NoError(t, err)
+	matched, _, remaining = servers.Match(u)
+	require.Same(t, relative, matched)
+	require.Equal(t, "/pets", remaining)
+}
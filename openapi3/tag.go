@@ -3,6 +3,7 @@ package openapi3
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 )
@@ -12,13 +13,121 @@ type Tags []*Tag
 
 func (tags Tags) Get(name string) *Tag {
 	for _, tag := range tags {
-		if tag.Name == name {
+		if tag != nil && tag.Name == name {
 			return tag
 		}
 	}
 	return nil
 }
 
+// Reorder returns the tags with the given names first, in that order,
+// followed by the other tags in their current order.
+func (tags Tags) Reorder(names ...string) Tags {
+	reordered := make(Tags, 0, len(tags))
+	placed := make(map[*Tag]struct{}, len(tags))
+	for _, name := range names {
+		if tag := tags.Get(name); tag != nil {
+			if _, ok := placed[tag]; !ok {
+				placed[tag] = struct{}{}
+				reordered = append(reordered, tag)
+			}
+		}
+	}
+	for _, tag := range tags {
+		if _, ok := placed[tag]; !ok {
+			reordered = append(reordered, tag)
+		}
+	}
+	return reordered
+}
+
+// Merge returns the tags merged with others, keeping a single definition per name:
+// definitions of the same name are combined, the first non-empty description and
+// external docs winning, and new names are appended in order.
+// The tags themselves are not modified.
+func (tags Tags) Merge(others ...*Tag) Tags {
+	merged := make(Tags, 0, len(tags)+len(others))
+	for _, tag := range append(append([]*Tag(nil), tags...), others...) {
+		if tag == nil {
+			continue
+		}
+		existing := merged.Get(tag.Name)
+		if existing == nil {
+			copied := *tag
+			if tag.Extensions != nil {
+				copied.Extensions = make(map[string]interface{}, len(tag.Extensions))
+				for k, v := range tag.Extensions {
+					copied.Extensions[k] = v
+				}
+			}
+			merged = append(merged, &copied)
+			continue
+		}
+		if existing.Description == "" {
+			existing.Description = tag.Description
+		}
+		if existing.ExternalDocs == nil {
+			existing.ExternalDocs = tag.ExternalDocs
+		}
+		for k, v := range tag.Extensions {
+			if _, ok := existing.Extensions[k]; !ok {
+				if existing.Extensions == nil {
+					existing.Extensions = make(map[string]interface{})
+				}
+				existing.Extensions[k] = v
+			}
+		}
+	}
+	return merged
+}
+
+// UsedTags returns the tags of the operations of doc, in the order of
+// T.Operations, each once.
+func (doc *T) UsedTags() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, operation := range doc.Operations() {
+		for _, name := range operation.Operation.Tags {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// UndeclaredTags returns the tags used by operations of doc that are not declared in doc.Tags.
+func (doc *T) UndeclaredTags() []string {
+	var names []string
+	for _, name := range doc.UsedTags() {
+		if doc.Tags.Get(name) == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CheckTagsDeclared returns an error listing the tags used by operations of doc
+// that are not declared in doc.Tags, if any.
+func (doc *T) CheckTagsDeclared() error {
+	if names := doc.UndeclaredTags(); len(names) != 0 {
+		return fmt.Errorf("tags used by operations are not declared: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// DeclareUsedTags appends the tags used by operations of doc that are not declared
+// to doc.Tags, and returns their names.
+func (doc *T) DeclareUsedTags() []string {
+	doc.mustNotBeFrozen()
+	names := doc.UndeclaredTags()
+	for _, name := range names {
+		doc.Tags = append(doc.Tags, &Tag{Name: name})
+	}
+	return names
+}
+
 // Validate returns an error if Tags does not comply with the OpenAPI spec.
 func (tags Tags) Validate(ctx context.Context, opts ...ValidationOption) error {
 	ctx = WithValidationOptions(ctx, opts...)
@@ -0,0 +1,63 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagsUtilities(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      tags: [pets, read]
+      responses:
+        '200': {description: ok}
+  /users:
+    post:
+      tags: [users, pets]
+      responses:
+        '201': {description: created}
+`[1:]
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pets", "read", "users"}, doc.UsedTags())
+	require.Equal(t, []string{"read", "users"}, doc.UndeclaredTags())
+	require.NoError(t, doc.Validate(loader.Context))
+	err = doc.Validate(loader.Context, EnableDeclaredTagsValidation())
+	require.EqualError(t, err, "invalid tags: tags used by operations are not declared: read, users")
+
+	require.Equal(t, []string{"read", "users"}, doc.DeclareUsedTags())
+	require.Empty(t, doc.UndeclaredTags())
+	require.NoError(t, doc.Validate(context.Background(), EnableDeclaredTagsValidation()))
+
+	names := func(tags Tags) []string {
+		var names []string
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+	require.Equal(t, []string{"users", "pets", "read"}, names(doc.Tags.Reorder("users", "unknown", "pets")))
+
+	merged := doc.Tags.Merge(
+		&Tag{Name: "pets", Description: "Pets"},
+		&Tag{Name: "read", Description: "Reads"},
+		&Tag{Name: "pets", Description: "Ignored"},
+		&Tag{Name: "admin"},
+	)
+	require.Equal(t, []string{"pets", "read", "users", "admin"}, names(merged))
+	require.Equal(t, "Pets", merged.Get("pets").Description)
+	require.Equal(t, "Reads", merged.Get("read").Description)
+	require.Empty(t, doc.Tags.Get("pets").Description)
+}
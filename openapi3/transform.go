@@ -0,0 +1,73 @@
+package openapi3
+
+// SchemaTransformer is called by TransformSchemas with a schema and its JSON pointer.
+// It may mutate ref.Value in place and return ref, or return another SchemaRef
+// to use instead. Returning nil keeps ref.
+// Like a WalkFunc, it may return ErrSkipChildren or ErrStopWalk.
+type SchemaTransformer func(pointer string, ref *SchemaRef) (*SchemaRef, error)
+
+// TransformSchemas walks doc as Walk does and calls transform once per schema,
+// including those nested in other schemas. The schemas returned by transform
+// are walked in turn.
+//
+// A schema shared through references (e.g. defined under #/components/schemas)
+// is transformed once, at its definition: when transform returns another SchemaRef,
+// its Value replaces the schema for all references to it, which keep their Ref.
+func TransformSchemas(doc *T, transform SchemaTransformer) error {
+	if doc.frozen {
+		return ErrFrozen
+	}
+	// Schemas replaced by transform, with their replacements
+	replaced := make(map[*Schema]*Schema)
+	replacement := func(schema *Schema) *Schema {
+		for i := 0; i < len(replaced); i++ {
+			next, ok := replaced[schema]
+			if !ok {
+				break
+			}
+			schema = next
+		}
+		return schema
+	}
+
+	w := &walker{walked: make(map[interface{}]struct{})}
+	w.walkFunc = func(pointer string, value, parent interface{}) error {
+		ref, ok := value.(*SchemaRef)
+		if !ok || ref.Value == nil {
+			return nil
+		}
+		if schema := replacement(ref.Value); schema != ref.Value {
+			ref.Value = schema
+			return nil
+		}
+		if _, ok := w.walked[ref.Value]; ok {
+			return nil
+		}
+
+		schema := ref.Value
+		newRef, err := transform(pointer, ref)
+		if newRef != nil && newRef != ref {
+			*ref = *newRef
+			if ref.Value != schema && ref.Value != nil {
+				// Keep the walker from walking the replaced schema elsewhere
+				w.walked[schema] = struct{}{}
+				replaced[schema] = ref.Value
+			}
+		}
+		return err
+	}
+	if err := w.walkT(doc); err != nil && err != ErrStopWalk {
+		return err
+	}
+	if len(replaced) == 0 {
+		return nil
+	}
+
+	// References reached before the schema they point to was replaced
+	return Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, ok := value.(*SchemaRef); ok && ref.Value != nil {
+			ref.Value = replacement(ref.Value)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformSchemas(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: t, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        secret:
+          type: string
+          x-internal: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: string
+      nullable: true
+`[1:])
+
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	var pointers []string
+	err = TransformSchemas(doc, func(pointer string, ref *SchemaRef) (*SchemaRef, error) {
+		pointers = append(pointers, pointer)
+		schema := ref.Value
+
+		// Mutate in place: strip internal properties
+		for name, property := range schema.Properties {
+			if _, ok := property.Value.Extensions["x-internal"]; ok {
+				delete(schema.Properties, name)
+			}
+		}
+
+		// Replace: nullable strings become oneOf
+		if schema.Nullable && schema.Type == "string" {
+			return NewOneOfSchema(NewStringSchema(), &Schema{Type: "null"}).NewRef(), nil
+		}
+		return ref, nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"/components/schemas/Owner",
+		"/components/schemas/Owner/oneOf/0",
+		"/components/schemas/Owner/oneOf/1",
+		"/components/schemas/Pet",
+		"/components/schemas/Pet/properties/name",
+		"/paths/~1pets/get/responses/200/content/application~1json/schema",
+	}, pointers)
+
+	pet := doc.Components.Schemas["Pet"].Value
+	require.NotContains(t, pet.Properties, "secret")
+
+	// References follow the replaced schema and keep their $ref
+	owner := pet.Properties["owner"]
+	require.Equal(t, "#/components/schemas/Owner", owner.Ref)
+	require.Same(t, doc.Components.Schemas["Owner"].Value, owner.Value)
+	require.Len(t, owner.Value.OneOf, 2)
+
+	data, err := json.Marshal(doc.Components.Schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "Owner": {"oneOf": [{"type": "string"}, {"type": "null"}]},
+  "Pet": {"type": "object", "properties": {"name": {"type": "string"}, "owner": {"$ref": "#/components/schemas/Owner"}}}
+}`, string(data))
+}
+
+func TestTransformSchemasReferenceBeforeDefinition(t *testing.T) {
+	target := &SchemaRef{Value: NewStringSchema()}
+	doc := &T{
+		OpenAPI: "3.0.0",
+		Info:    &Info{Title: "t", Version: "1"},
+		Paths: Paths{
+			"/a": {Get: &Operation{Responses: Responses{
+				"200": {Value: &Response{Content: NewContentWithJSONSchemaRef(target)}},
+			}}},
+			"/b": {Get: &Operation{Responses: Responses{
+				"200": {Value: &Response{Content: NewContentWithJSONSchemaRef(
+					&SchemaRef{Ref: "#/paths/~1a/get/responses/200/content/application~1json/schema", Value: target.Value},
+				)}},
+			}}},
+		},
+	}
+
+	err := TransformSchemas(doc, func(pointer string, ref *SchemaRef) (*SchemaRef, error) {
+		return NewIntegerSchema().NewRef(), nil
+	})
+	require.NoError(t, err)
+
+	a := doc.Paths["/a"].Get.Responses["200"].Value.Content.Get("application/json").Schema
+	b := doc.Paths["/b"].Get.Responses["200"].Value.Content.Get("application/json").Schema
+	require.Equal(t, "integer", a.Value.Type)
+	require.Same(t, a.Value, b.Value)
+	require.NotEmpty(t, b.Ref)
+}
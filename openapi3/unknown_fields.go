@@ -0,0 +1,92 @@
+package openapi3
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsPolicy is what a Loader does with the unknown fields of documents:
+// the fields neither defined by the OpenAPI specification nor extensions starting with "x-".
+type UnknownFieldsPolicy int
+
+const (
+	// PreserveUnknownFields keeps unknown fields in the Extensions of the objects
+	// they are found in, like extensions.
+	PreserveUnknownFields UnknownFieldsPolicy = iota
+	// WarnUnknownFields preserves unknown fields and lists them in Loader.UnknownFieldsFound.
+	WarnUnknownFields
+	// RejectUnknownFields makes loading documents with unknown fields fail with an UnknownFieldsError.
+	RejectUnknownFields
+)
+
+// UnknownFieldsError is returned by a Loader with RejectUnknownFields
+// for documents with unknown fields.
+type UnknownFieldsError struct {
+	// Pointers are the JSON pointers of the unknown fields.
+	Pointers []string
+}
+
+func (err *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields: %s", strings.Join(err.Pointers, ", "))
+}
+
+// UnknownFields returns the JSON pointers of the unknown fields of doc, the fields
+// neither defined by the OpenAPI specification nor extensions starting with "x-",
+// which are kept in the Extensions of the objects they are found in.
+func UnknownFields(doc *T) []string {
+	var pointers []string
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		extensions := extensionsOf(value)
+		keys := make([]string, 0, len(extensions))
+		for key := range extensions {
+			if !strings.HasPrefix(key, "x-") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			pointers = append(pointers, childPointer(pointer, key))
+		}
+		return nil
+	})
+	return pointers
+}
+
+// extensionsOf returns the Extensions of a value visited by Walk, or of the value
+// of an XxxRef wrapper that is not a reference.
+func extensionsOf(value interface{}) map[string]interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	if f := v.FieldByName("Value"); f.IsValid() && f.Kind() == reflect.Ptr && !f.IsNil() {
+		if ref := v.FieldByName("Ref"); ref.IsValid() && ref.String() != "" {
+			// Visited at its definition
+			return nil
+		}
+		v = f.Elem()
+	}
+	if f := v.FieldByName("ExtensionProps"); f.IsValid() {
+		if props, ok := f.Interface().(ExtensionProps); ok {
+			return props.Extensions
+		}
+	}
+	return nil
+}
+
+// checkUnknownFields applies the UnknownFieldsPolicy of loader to doc.
+func (loader *Loader) checkUnknownFields(doc *T) error {
+	loader.UnknownFieldsFound = nil
+	if loader.UnknownFields == PreserveUnknownFields {
+		return nil
+	}
+	pointers := UnknownFields(doc)
+	if len(pointers) != 0 && loader.UnknownFields == RejectUnknownFields {
+		return &UnknownFieldsError{Pointers: pointers}
+	}
+	loader.UnknownFieldsFound = pointers
+	return nil
+}
@@ -0,0 +1,73 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderUnknownFields(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+  x-audience: public
+  audience: public
+paths:
+  /pets:
+    get:
+      operationID: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+components:
+  schemas:
+    Pets:
+      type: array
+      uniqueitems: true
+      items:
+        type: object
+        properties:
+          name:
+            type: string
+            maxlength: 10
+`)
+	pointers := []string{
+		"/components/schemas/Pets/uniqueitems",
+		"/components/schemas/Pets/items/properties/name/maxlength",
+		"/info/audience",
+		"/paths/~1pets/get/operationID",
+	}
+
+	loader := NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NotNil(t, doc.Info.Extensions["audience"])
+	require.Equal(t, pointers, UnknownFields(doc))
+	require.Empty(t, loader.UnknownFieldsFound)
+
+	loader = NewLoader()
+	loader.UnknownFields = WarnUnknownFields
+	doc, err = loader.LoadFromData(spec)
+	require.NoError(t, err)
+	require.NotNil(t, doc.Info.Extensions["audience"])
+	require.Equal(t, pointers, loader.UnknownFieldsFound)
+
+	loader = NewLoader()
+	loader.UnknownFields = RejectUnknownFields
+	_, err = loader.LoadFromData(spec)
+	require.EqualError(t, err, "unknown fields: /components/schemas/Pets/uniqueitems, "+
+		"/components/schemas/Pets/items/properties/name/maxlength, /info/audience, /paths/~1pets/get/operationID")
+	require.Equal(t, pointers, err.(*UnknownFieldsError).Pointers)
+
+	result := doc.ValidateWithResult(context.Background(), WithCheckSeverity(CheckUnknownField, SeverityError))
+	require.EqualError(t, result.Err(), "/components/schemas/Pets/uniqueitems: unknown field | "+
+		"/components/schemas/Pets/items/properties/name/maxlength: unknown field | "+
+		"/info/audience: unknown field | /paths/~1pets/get/operationID: unknown field")
+}
@@ -0,0 +1,40 @@
+package openapi3
+
+import (
+	"fmt"
+)
+
+// UnresolvedRefError is a $ref of a document whose value is not set.
+// It is also returned by the Resolve methods of the XxxRef types.
+type UnresolvedRefError struct {
+	// Pointer is the JSON pointer to the object with the $ref, empty if not known.
+	Pointer string
+	Ref     string
+}
+
+func (e *UnresolvedRefError) Error() string {
+	if e.Pointer == "" {
+		return foundUnresolvedRef(e.Ref).Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Pointer, foundUnresolvedRef(e.Ref))
+}
+
+// ValidateRefs returns a MultiError of an *UnresolvedRefError for each object of doc
+// with a $ref whose value is not set, such as those added to the document after it was loaded
+// or those of documents unmarshaled without a Loader, or nil if there are none.
+// References are otherwise only found unresolved when validating the objects that have them,
+// or when using them to validate requests and responses.
+func (doc *T) ValidateRefs() error {
+	var me MultiError
+	// The walk function never fails
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if ref, refValue, ok := refAndValue(value); ok && ref != "" && refValue == nil {
+			me = append(me, &UnresolvedRefError{Pointer: pointer, Ref: ref})
+		}
+		return nil
+	})
+	if len(me) == 0 {
+		return nil
+	}
+	return me
+}
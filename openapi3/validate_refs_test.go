@@ -0,0 +1,68 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/invopop/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRefs(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: References
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/id'
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    id:
+      name: id
+      in: path
+      required: true
+      schema: {type: integer}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+`)
+	doc, err := NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.ValidateRefs())
+
+	doc.Paths["/pets/{id}"].Get.Responses.Get(200).Value.Content.Get("application/json").Schema.Value.Items = NewSchemaRef("#/components/schemas/Missing", nil)
+	err = doc.ValidateRefs()
+	require.EqualError(t, err, `/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/items: found unresolved ref: "#/components/schemas/Missing"`)
+
+	var unmarshaled T
+	require.NoError(t, yaml.Unmarshal(spec, &unmarshaled))
+	err = unmarshaled.ValidateRefs()
+	require.Error(t, err)
+	var refs []string
+	for _, e := range err.(MultiError) {
+		unresolved := e.(*UnresolvedRefError)
+		refs = append(refs, unresolved.Pointer+" "+unresolved.Ref)
+	}
+	require.Equal(t, []string{
+		"/components/schemas/Pet/properties/owner #/components/schemas/Owner",
+		"/paths/~1pets~1{id}/parameters/0 #/components/parameters/id",
+		"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/items #/components/schemas/Pet",
+	}, refs)
+}
@@ -1,6 +1,10 @@
 package openapi3
 
-import "context"
+import (
+	"context"
+	"net/url"
+	"time"
+)
 
 // ValidationOption allows the modification of how the OpenAPI document is validated.
 type ValidationOption func(options *ValidationOptions)
@@ -10,7 +14,24 @@ type ValidationOptions struct {
 	SchemaFormatValidationEnabled                    bool
 	SchemaPatternValidationDisabled                  bool
 	ExamplesValidationDisabled                       bool
+	ComponentExamplesValidationDisabled              bool
+	DeclaredTagsValidationEnabled                    bool
+	DiscriminatorPropertyValidationEnabled           bool
+	VersionProfile                                   string
+	ContactURLValidationEnabled                      bool
+	ContactEmailValidationEnabled                    bool
+	LicenseURLValidationEnabled                      bool
+	TermsOfServiceValidationEnabled                  bool
+	ExternalDocsURLValidationEnabled                 bool
+	MultiErrorEnabled                                bool
+	OperationIDPattern                               string
 	examplesValidationAsReq, examplesValidationAsRes bool
+	externalExamplesLoader                           *Loader
+	externalExamplesBase                             *url.URL
+	checkSeverities                                  map[string]Severity
+	validatedExamples                                map[validatedExample]struct{}
+	validatedCallbacks                               map[*Callback]struct{}
+	now                                              func() time.Time
 }
 
 type validationOptionsKey struct{}
@@ -62,6 +83,236 @@ func DisableExamplesValidation() ValidationOption {
 	}
 }
 
+// EnableComponentExamplesValidation does the opposite of DisableComponentExamplesValidation.
+// By default, the examples of components are validated.
+func EnableComponentExamplesValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ComponentExamplesValidationDisabled = false
+	}
+}
+
+// DisableComponentExamplesValidation makes Validate skip the validation of the examples
+// media types, parameters and headers refer to in components.examples, which are otherwise
+// validated against the schema of each of them, only validating inline examples.
+// By default, the examples of components are validated.
+func DisableComponentExamplesValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ComponentExamplesValidationDisabled = true
+	}
+}
+
+// EnableDeclaredTagsValidation makes Validate return an error when operations use tags
+// that are not declared in the top-level tags list.
+// By default, declared tags validation is disabled.
+func EnableDeclaredTagsValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.DeclaredTagsValidationEnabled = true
+	}
+}
+
+// DisableDeclaredTagsValidation does the opposite of EnableDeclaredTagsValidation.
+// By default, declared tags validation is disabled.
+func DisableDeclaredTagsValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.DeclaredTagsValidationEnabled = false
+	}
+}
+
+// EnableDiscriminatorPropertyValidation makes Validate return an error when the schemas
+// a discriminator selects from do not declare and require the discriminator property.
+// By default, discriminator property validation is disabled.
+func EnableDiscriminatorPropertyValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.DiscriminatorPropertyValidationEnabled = true
+	}
+}
+
+// DisableDiscriminatorPropertyValidation does the opposite of EnableDiscriminatorPropertyValidation.
+// By default, discriminator property validation is disabled.
+func DisableDiscriminatorPropertyValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.DiscriminatorPropertyValidationEnabled = false
+	}
+}
+
+// EnableMultiErrorValidation makes Validate return a MultiError of the errors of every
+// component, path, webhook and other top-level field of documents, instead of the first one.
+// The errors are those Validate returns by default, that of the first problem found in each.
+// By default, validation stops at the first error.
+func EnableMultiErrorValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.MultiErrorEnabled = true
+	}
+}
+
+// DisableMultiErrorValidation does the opposite of EnableMultiErrorValidation.
+// By default, validation stops at the first error.
+func DisableMultiErrorValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.MultiErrorEnabled = false
+	}
+}
+
+// EnableVersionProfileValidation makes Validate check documents against the rules of
+// a specific version of the OpenAPI specification, such as "3.0.0", "3.0.3" or "3.1.0":
+// the openapi field must have the same minor version and at most the same patch version,
+// keywords that version does not support are rejected and fields it makes optional
+// (such as paths in 3.1) are not required.
+// By default, fields of any version are accepted and paths are only optional
+// when the openapi field of the document is a 3.1 version.
+func EnableVersionProfileValidation(version string) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.VersionProfile = version
+	}
+}
+
+// DisableVersionProfileValidation does the opposite of EnableVersionProfileValidation.
+func DisableVersionProfileValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.VersionProfile = ""
+	}
+}
+
+// EnableOperationIDPatternValidation makes Validate return an error when an operationId
+// does not match pattern, a regular expression such as "^[a-z][a-zA-Z0-9]*$".
+// By default, operationIds may be any string, unique across the document.
+func EnableOperationIDPatternValidation(pattern string) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.OperationIDPattern = pattern
+	}
+}
+
+// DisableOperationIDPatternValidation does the opposite of EnableOperationIDPatternValidation.
+func DisableOperationIDPatternValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.OperationIDPattern = ""
+	}
+}
+
+// EnableContactURLValidation makes Validate return an error when the url of info.contact
+// is not an absolute URL.
+// By default, contact URL validation is disabled.
+func EnableContactURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ContactURLValidationEnabled = true
+	}
+}
+
+// DisableContactURLValidation does the opposite of EnableContactURLValidation.
+// By default, contact URL validation is disabled.
+func DisableContactURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ContactURLValidationEnabled = false
+	}
+}
+
+// EnableContactEmailValidation makes Validate return an error when the email of info.contact
+// is not an email address.
+// By default, contact email validation is disabled.
+func EnableContactEmailValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ContactEmailValidationEnabled = true
+	}
+}
+
+// DisableContactEmailValidation does the opposite of EnableContactEmailValidation.
+// By default, contact email validation is disabled.
+func DisableContactEmailValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ContactEmailValidationEnabled = false
+	}
+}
+
+// EnableLicenseURLValidation makes Validate return an error when the url of info.license
+// is not an absolute URL.
+// By default, license URL validation is disabled.
+func EnableLicenseURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.LicenseURLValidationEnabled = true
+	}
+}
+
+// DisableLicenseURLValidation does the opposite of EnableLicenseURLValidation.
+// By default, license URL validation is disabled.
+func DisableLicenseURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.LicenseURLValidationEnabled = false
+	}
+}
+
+// EnableTermsOfServiceValidation makes Validate return an error when info.termsOfService
+// is not an absolute URL.
+// By default, terms of service validation is disabled.
+func EnableTermsOfServiceValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.TermsOfServiceValidationEnabled = true
+	}
+}
+
+// DisableTermsOfServiceValidation does the opposite of EnableTermsOfServiceValidation.
+// By default, terms of service validation is disabled.
+func DisableTermsOfServiceValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.TermsOfServiceValidationEnabled = false
+	}
+}
+
+// EnableExternalDocsURLValidation makes Validate return an error when the url of
+// external docs is not an absolute URL, instead of only checking that it parses.
+// By default, external docs URL validation is disabled.
+func EnableExternalDocsURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ExternalDocsURLValidationEnabled = true
+	}
+}
+
+// DisableExternalDocsURLValidation does the opposite of EnableExternalDocsURLValidation.
+// By default, external docs URL validation is disabled.
+func DisableExternalDocsURLValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ExternalDocsURLValidationEnabled = false
+	}
+}
+
+// EnableStrictMetadataValidation enables all of contact URL, contact email, license URL,
+// terms of service and external docs URL validation.
+func EnableStrictMetadataValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.ContactURLValidationEnabled = true
+		options.ContactEmailValidationEnabled = true
+		options.LicenseURLValidationEnabled = true
+		options.TermsOfServiceValidationEnabled = true
+		options.ExternalDocsURLValidationEnabled = true
+	}
+}
+
+// EnableExternalExamplesValidation makes Validate fetch the externalValue of examples
+// with loader's ReadFromURIFunc and validate the payload against the associated schema.
+// Relative externalValue URLs are resolved against base, which may be nil.
+// By default, examples with an externalValue are not validated.
+func EnableExternalExamplesValidation(loader *Loader, base *url.URL) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.externalExamplesLoader, options.externalExamplesBase = loader, base
+	}
+}
+
+// DisableExternalExamplesValidation does the opposite of EnableExternalExamplesValidation.
+// By default, examples with an externalValue are not validated.
+func DisableExternalExamplesValidation() ValidationOption {
+	return func(options *ValidationOptions) {
+		options.externalExamplesLoader, options.externalExamplesBase = nil, nil
+	}
+}
+
+// WithValidationClock makes Validate check defaults and examples against the format validators
+// registered with RegisterTimeFormatValidator at the time now returns.
+// A nil now stands for time.Now, the default clock.
+func WithValidationClock(now func() time.Time) ValidationOption {
+	return func(options *ValidationOptions) {
+		options.now = now
+	}
+}
+
 // WithValidationOptions allows adding validation options to a context object that can be used when validationg any OpenAPI type.
 func WithValidationOptions(ctx context.Context, opts ...ValidationOption) context.Context {
 	if len(opts) == 0 {
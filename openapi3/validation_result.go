@@ -0,0 +1,198 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Severity is how serious a validation issue is, see ValidateWithResult.
+type Severity int
+
+const (
+	// SeverityOff disables a check.
+	SeverityOff Severity = iota
+	// SeverityWarning is that of issues that do not make documents invalid.
+	SeverityWarning
+	// SeverityError is that of issues that make documents invalid.
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityOff:
+		return "off"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(severity))
+}
+
+// The checks of ValidateWithResult, whose severity can be changed with WithCheckSeverity.
+const (
+	// CheckSpec reports the violations of the OpenAPI specification found by Validate.
+	// Its default severity is SeverityError.
+	CheckSpec = "spec"
+	// CheckOperationID reports operations without an operationId.
+	// Its default severity is SeverityWarning.
+	CheckOperationID = "operation-id"
+	// CheckOperationDescription reports operations without a summary nor a description.
+	// Its default severity is SeverityWarning.
+	CheckOperationDescription = "operation-description"
+	// CheckInfoDescription reports documents whose info has no description.
+	// Its default severity is SeverityWarning.
+	CheckInfoDescription = "info-description"
+	// CheckTagDescription reports tags without a description.
+	// Its default severity is SeverityWarning.
+	CheckTagDescription = "tag-description"
+	// CheckParameterDescription reports parameters without a description.
+	// Its default severity is SeverityWarning.
+	CheckParameterDescription = "parameter-description"
+	// CheckUnknownField reports the fields that are neither defined by the specification
+	// nor extensions starting with "x-", see UnknownFields.
+	// Its default severity is SeverityWarning.
+	CheckUnknownField = "unknown-field"
+)
+
+// WithCheckSeverity sets the severity of the issues check reports in ValidateWithResult,
+// e.g. to promote CheckOperationID to SeverityError or to disable it with SeverityOff.
+// It has no effect on Validate.
+func WithCheckSeverity(check string, severity Severity) ValidationOption {
+	return func(options *ValidationOptions) {
+		severities := make(map[string]Severity, len(options.checkSeverities)+1)
+		for k, v := range options.checkSeverities {
+			severities[k] = v
+		}
+		severities[check] = severity
+		options.checkSeverities = severities
+	}
+}
+
+func (options *ValidationOptions) checkSeverity(check string) Severity {
+	if severity, ok := options.checkSeverities[check]; ok {
+		return severity
+	}
+	if check == CheckSpec {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// ValidationIssue is an issue found by ValidateWithResult.
+type ValidationIssue struct {
+	Check    string
+	Severity Severity
+	// Pointer is the JSON pointer to the part of the document with the issue,
+	// empty for the violations of the specification.
+	Pointer string
+	Err     error
+}
+
+func (issue ValidationIssue) Error() string {
+	if issue.Pointer == "" {
+		return issue.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", issue.Pointer, issue.Err)
+}
+
+// Unwrap returns the underlying error.
+func (issue ValidationIssue) Unwrap() error {
+	return issue.Err
+}
+
+// ValidationResult is the result of ValidateWithResult.
+type ValidationResult struct {
+	Issues []ValidationIssue
+}
+
+// Errors returns the issues of SeverityError.
+func (result *ValidationResult) Errors() []ValidationIssue {
+	return result.withSeverity(SeverityError)
+}
+
+// Warnings returns the issues of SeverityWarning.
+func (result *ValidationResult) Warnings() []ValidationIssue {
+	return result.withSeverity(SeverityWarning)
+}
+
+func (result *ValidationResult) withSeverity(severity Severity) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range result.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Err returns a MultiError of the issues of SeverityError, or nil if there are none.
+func (result *ValidationResult) Err() error {
+	var me MultiError
+	for _, issue := range result.Errors() {
+		me = append(me, issue)
+	}
+	if len(me) == 0 {
+		return nil
+	}
+	return me
+}
+
+// ValidateWithResult validates doc as Validate does with multi-error validation enabled,
+// along with checks of its quality, and returns all the issues found, each with
+// the severity of its check. See WithCheckSeverity.
+func (doc *T) ValidateWithResult(ctx context.Context, opts ...ValidationOption) *ValidationResult {
+	ctx = WithValidationOptions(ctx, opts...)
+	vo := *getValidationOptions(ctx)
+	vo.MultiErrorEnabled = true
+	ctx = context.WithValue(ctx, validationOptionsKey{}, &vo)
+
+	result := &ValidationResult{}
+	report := func(check, pointer string, err error) {
+		if severity := vo.checkSeverity(check); severity != SeverityOff {
+			result.Issues = append(result.Issues, ValidationIssue{Check: check, Severity: severity, Pointer: pointer, Err: err})
+		}
+	}
+
+	if err := doc.Validate(ctx); err != nil {
+		if me, ok := err.(MultiError); ok {
+			for _, e := range me {
+				report(CheckSpec, "", e)
+			}
+		} else {
+			report(CheckSpec, "", err)
+		}
+	}
+
+	_ = Walk(doc, func(pointer string, value, parent interface{}) error {
+		switch v := value.(type) {
+		case *Info:
+			if v.Description == "" {
+				report(CheckInfoDescription, pointer, errors.New("info has no description"))
+			}
+		case *Operation:
+			if v.OperationID == "" {
+				report(CheckOperationID, pointer, errors.New("operation has no operationId"))
+			}
+			if v.Summary == "" && v.Description == "" {
+				report(CheckOperationDescription, pointer, errors.New("operation has no summary nor description"))
+			}
+		case *Tag:
+			if v.Description == "" {
+				report(CheckTagDescription, pointer, fmt.Errorf("tag %q has no description", v.Name))
+			}
+		case *ParameterRef:
+			// References are visited where they are used, after their definition
+			if p := v.Value; p != nil && v.Ref == "" && p.Description == "" {
+				report(CheckParameterDescription, pointer, fmt.Errorf("parameter %q has no description", p.Name))
+			}
+		}
+		return nil
+	})
+
+	for _, pointer := range UnknownFields(doc) {
+		report(CheckUnknownField, pointer, errors.New("unknown field"))
+	}
+	return result
+}
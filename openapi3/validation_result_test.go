@@ -0,0 +1,82 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWithResult(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: An API
+  version: 1.0.0
+tags:
+- name: pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+      parameters:
+      - $ref: '#/components/parameters/limit'
+      responses:
+        '200':
+          description: The pets
+    post:
+      operationId: addPet
+      description: Add a pet
+      responses:
+        '201':
+          description: Added
+servers:
+- url: ''
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+`
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	issues := func(issues []ValidationIssue) []string {
+		var messages []string
+		for _, issue := range issues {
+			messages = append(messages, issue.Check+" "+issue.Error())
+		}
+		return messages
+	}
+
+	result := doc.ValidateWithResult(context.Background())
+	require.Equal(t, []string{
+		`spec invalid servers: value of url must be a non-empty string`,
+	}, issues(result.Errors()))
+	require.Equal(t, []string{
+		`parameter-description /components/parameters/limit: parameter "limit" has no description`,
+		`info-description /info: info has no description`,
+		`operation-id /paths/~1pets/get: operation has no operationId`,
+		`tag-description /tags/0: tag "pets" has no description`,
+	}, issues(result.Warnings()))
+	require.EqualError(t, result.Err(), `invalid servers: value of url must be a non-empty string`)
+
+	result = doc.ValidateWithResult(context.Background(),
+		WithCheckSeverity(CheckSpec, SeverityWarning),
+		WithCheckSeverity(CheckOperationID, SeverityError),
+		WithCheckSeverity(CheckInfoDescription, SeverityOff),
+		WithCheckSeverity(CheckParameterDescription, SeverityOff),
+		WithCheckSeverity(CheckTagDescription, SeverityOff),
+	)
+	require.Equal(t, []string{
+		`operation-id /paths/~1pets/get: operation has no operationId`,
+	}, issues(result.Errors()))
+	require.Equal(t, []string{
+		`spec invalid servers: value of url must be a non-empty string`,
+	}, issues(result.Warnings()))
+	require.Equal(t, "error", SeverityError.String())
+}
@@ -0,0 +1,126 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// openAPIVersion is a major.minor.patch version of the OpenAPI specification.
+type openAPIVersion struct {
+	major, minor, patch int
+}
+
+func parseOpenAPIVersion(s string) (openAPIVersion, error) {
+	var v openAPIVersion
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("%q is not a major.minor.patch version", s)
+	}
+	for i, target := range []*int{&v.major, &v.minor, &v.patch} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("%q is not a major.minor.patch version", s)
+		}
+		*target = n
+	}
+	return v, nil
+}
+
+func (v openAPIVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// versionProfile returns the version of the profile set with EnableVersionProfileValidation,
+// and false if none is set.
+func (options *ValidationOptions) versionProfile() (openAPIVersion, bool) {
+	if options.VersionProfile == "" {
+		return openAPIVersion{}, false
+	}
+	// The profile was checked by validateVersionProfile
+	v, err := parseOpenAPIVersion(options.VersionProfile)
+	return v, err == nil
+}
+
+// validateVersionProfile returns an error if the openapi field of doc does not match
+// the profile: it must have the same major and minor versions, and a patch version
+// no greater than that of the profile.
+func (doc *T) validateVersionProfile(profile string) error {
+	want, err := parseOpenAPIVersion(profile)
+	if err != nil {
+		return fmt.Errorf("invalid version profile: %w", err)
+	}
+	if want.major != 3 || want.minor > 1 {
+		return fmt.Errorf("invalid version profile: %s is not a supported version", profile)
+	}
+	got, err := parseOpenAPIVersion(doc.OpenAPI)
+	if err != nil {
+		return fmt.Errorf("value of openapi is invalid: %w", err)
+	}
+	if got.major != want.major || got.minor != want.minor || got.patch > want.patch {
+		return fmt.Errorf("value of openapi %q does not match the version profile %s", doc.OpenAPI, want)
+	}
+	return nil
+}
+
+// validateVersionFields returns an error if doc uses fields introduced
+// by a version of OpenAPI more recent than v.
+func (doc *T) validateVersionFields(v openAPIVersion) error {
+	if v.minor >= 1 {
+		return nil
+	}
+	if info := doc.Info; info != nil {
+		if info.Summary != "" {
+			return fmt.Errorf("invalid info: summary is not supported by OpenAPI %s", v)
+		}
+		if license := info.License; license != nil && license.Identifier != "" {
+			return fmt.Errorf("invalid info: license identifier is not supported by OpenAPI %s", v)
+		}
+	}
+	if len(doc.Components.PathItems) != 0 {
+		return fmt.Errorf("invalid components: pathItems are not supported by OpenAPI %s", v)
+	}
+	if len(doc.Webhooks) != 0 {
+		return fmt.Errorf("invalid webhooks: webhooks are not supported by OpenAPI %s", v)
+	}
+	return nil
+}
+
+// validateSchemaVersionProfile returns an error if schema uses keywords
+// that are not legal in the version profile v.
+func validateSchemaVersionProfile(schema *Schema, v openAPIVersion) error {
+	if v.minor < 1 && (schema.nullType || schema.Type == TypeNull) {
+		return fmt.Errorf(`a "null" type is not supported by OpenAPI %s, use nullable instead`, v)
+	}
+	if v.minor < 1 && schema.typeArray != noTypeArray {
+		return fmt.Errorf("a type array of several types is not supported by OpenAPI %s, use anyOf instead", v)
+	}
+	if v.minor < 1 && schema.HasConst() {
+		return fmt.Errorf("const is not supported by OpenAPI %s, use an enum of one value instead", v)
+	}
+	if v.minor < 1 && (schema.Contains != nil || schema.MinContains != nil || schema.MaxContains != nil) {
+		return fmt.Errorf("contains, minContains and maxContains are not supported by OpenAPI %s", v)
+	}
+	if v.minor < 1 && (schema.If != nil || schema.Then != nil || schema.Else != nil) {
+		return fmt.Errorf("if, then and else are not supported by OpenAPI %s", v)
+	}
+	if v.minor < 1 && len(schema.PatternProperties) != 0 {
+		return fmt.Errorf("patternProperties are not supported by OpenAPI %s", v)
+	}
+	if v.minor < 1 && (schema.ContentEncoding != "" || schema.ContentMediaType != "") {
+		return fmt.Errorf("contentEncoding and contentMediaType are not supported by OpenAPI %s", v)
+	}
+	if v.minor < 1 && schema.PropertyNames != nil {
+		return fmt.Errorf("propertyNames is not supported by OpenAPI %s", v)
+	}
+	if v.minor >= 1 {
+		if schema.Nullable && !schema.nullType {
+			return errors.New(`nullable is not supported by OpenAPI 3.1, use a "null" type instead`)
+		}
+		if schema.ExclusiveMin || schema.ExclusiveMax {
+			return errors.New("boolean exclusiveMinimum and exclusiveMaximum are not supported by OpenAPI 3.1, use numbers instead")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionProfileValidation(t *testing.T) {
+	load := func(version, paths, schema string) *T {
+		doc, err := NewLoader().LoadFromData([]byte(`
+openapi: ` + version + `
+info: {title: Profiles, version: 1.0.0}
+` + paths + `
+components:
+  schemas:
+    Pet:
+` + schema))
+		require.NoError(t, err)
+		return doc
+	}
+	const (
+		paths    = "paths: {}"
+		noPaths  = ""
+		nullable = "      type: string\n      nullable: true\n"
+		plain    = "      type: string\n"
+	)
+
+	tests := []struct {
+		name    string
+		doc     *T
+		profile string
+		err     string
+	}{
+		{name: "no profile", doc: load("3.0.3", paths, nullable)},
+		{name: "same version", doc: load("3.0.3", paths, nullable), profile: "3.0.3"},
+		{name: "older patch", doc: load("3.0.0", paths, nullable), profile: "3.0.3"},
+		{
+			name:    "newer patch",
+			doc:     load("3.0.3", paths, nullable),
+			profile: "3.0.0",
+			err:     `value of openapi "3.0.3" does not match the version profile 3.0.0`,
+		},
+		{
+			name:    "other minor",
+			doc:     load("3.0.3", paths, plain),
+			profile: "3.1.0",
+			err:     `value of openapi "3.0.3" does not match the version profile 3.1.0`,
+		},
+		{
+			name:    "malformed openapi",
+			doc:     load("'3.0'", paths, plain),
+			profile: "3.0.3",
+			err:     `value of openapi is invalid: "3.0" is not a major.minor.patch version`,
+		},
+		{
+			name:    "unsupported profile",
+			doc:     load("3.0.3", paths, plain),
+			profile: "2.0.0",
+			err:     "invalid version profile: 2.0.0 is not a supported version",
+		},
+		{name: "paths optional in 3.1", doc: load("3.1.0", noPaths, plain), profile: "3.1.0"},
+		{
+			name:    "paths required in 3.0",
+			doc:     load("3.0.3", noPaths, plain),
+			profile: "3.0.3",
+			err:     "invalid paths: must be an object",
+		},
+		{
+			name:    "nullable illegal in 3.1",
+			doc:     load("3.1.0", paths, nullable),
+			profile: "3.1.0",
+			err:     `invalid components: schema "Pet": nullable is not supported by OpenAPI 3.1, use a "null" type instead`,
+		},
+		{
+			name:    "boolean exclusiveMinimum illegal in 3.1",
+			doc:     load("3.1.0", paths, "      type: number\n      minimum: 1\n      exclusiveMinimum: true\n"),
+			profile: "3.1.0",
+			err:     `invalid components: schema "Pet": boolean exclusiveMinimum and exclusiveMaximum are not supported by OpenAPI 3.1, use numbers instead`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var opts []ValidationOption
+			if test.profile != "" {
+				opts = append(opts, EnableVersionProfileValidation(test.profile))
+			}
+			err := test.doc.Validate(context.Background(), opts...)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+
+	doc := load("3.1.0", paths, nullable)
+	err := doc.Validate(context.Background(), EnableVersionProfileValidation("3.1.0"), DisableVersionProfileValidation())
+	require.NoError(t, err)
+}
@@ -0,0 +1,83 @@
+package openapi3
+
+// Overrides are changes to a document made at runtime, e.g. for one of the tenants
+// of a service sharing a document, see T.View.
+type Overrides struct {
+	// Servers replace the servers of the document, and those of its path items, when not nil.
+	Servers Servers
+
+	// OperationEnabled tells whether the operation of the document at path for method is kept.
+	// All operations are kept if nil. The paths left without operations are removed.
+	OperationEnabled func(path, method string, operation *Operation) bool
+
+	// SecuritySchemes replace the security schemes of the components of the document
+	// with the same names, or are added to them.
+	SecuritySchemes SecuritySchemes
+}
+
+// View returns a document with overrides applied to doc, which is not modified.
+//
+// Only the parts of doc that overrides change are copied, shallowly: the view shares
+// the rest of doc, such as its operations and schemas, so creating it is cheap
+// and it is meant to be read, e.g. by routers and openapi3filter, but not modified.
+// A view of a frozen document shares its frozen state.
+func (doc *T) View(overrides Overrides) *T {
+	view := *doc
+	if overrides.Servers != nil {
+		view.Servers = overrides.Servers
+	}
+
+	if overrides.Servers != nil || overrides.OperationEnabled != nil {
+		view.Paths = make(Paths, len(doc.Paths))
+		for path, pathItem := range doc.Paths {
+			if pathItem = pathItem.view(path, overrides); pathItem != nil {
+				view.Paths[path] = pathItem
+			}
+		}
+	}
+
+	if len(overrides.SecuritySchemes) != 0 {
+		view.Components.SecuritySchemes = make(SecuritySchemes, len(doc.Components.SecuritySchemes)+len(overrides.SecuritySchemes))
+		for name, scheme := range doc.Components.SecuritySchemes {
+			view.Components.SecuritySchemes[name] = scheme
+		}
+		for name, scheme := range overrides.SecuritySchemes {
+			view.Components.SecuritySchemes[name] = scheme
+		}
+	}
+	return &view
+}
+
+// view returns pathItem with overrides applied, pathItem itself if they do not change it,
+// or nil if none of its operations is enabled.
+func (pathItem *PathItem) view(path string, overrides Overrides) *PathItem {
+	if pathItem == nil {
+		return nil
+	}
+	view := pathItem
+	copyOnce := func() {
+		if view == pathItem {
+			copied := *pathItem
+			view = &copied
+		}
+	}
+	if overrides.Servers != nil && pathItem.Servers != nil {
+		copyOnce()
+		view.Servers = nil
+	}
+	if enabled := overrides.OperationEnabled; enabled != nil {
+		operations := pathItem.Operations()
+		disabled := 0
+		for method, operation := range operations {
+			if !enabled(path, method, operation) {
+				copyOnce()
+				view.SetOperation(method, nil)
+				disabled++
+			}
+		}
+		if disabled != 0 && disabled == len(operations) {
+			return nil
+		}
+	}
+	return view
+}
@@ -0,0 +1,77 @@
+package openapi3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    bearer:
+      type: http
+      scheme: bearer
+paths:
+  /pets:
+    servers:
+      - url: https://pets.example.com
+    get:
+      responses:
+        "200":
+          description: pets
+    post:
+      responses:
+        "201":
+          description: pet created
+  /admin:
+    delete:
+      responses:
+        "204":
+          description: all pets deleted
+`[1:]
+	doc, err := NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	base, err := doc.MarshalJSON()
+	require.NoError(t, err)
+
+	tenantScheme := &SecuritySchemeRef{Value: NewSecurityScheme().WithType("apiKey").WithIn("query").WithName("key")}
+	view := doc.View(Overrides{
+		Servers: Servers{{URL: "https://tenant.example.com"}},
+		OperationEnabled: func(path, method string, operation *Operation) bool {
+			return method == http.MethodGet
+		},
+		SecuritySchemes: SecuritySchemes{"apiKey": tenantScheme},
+	})
+
+	require.Equal(t, "https://tenant.example.com", view.Servers[0].URL)
+	require.Len(t, view.Paths, 1)
+	pets := view.Paths["/pets"]
+	require.Nil(t, pets.Servers)
+	require.Nil(t, pets.Post)
+	require.Same(t, doc.Paths["/pets"].Get, pets.Get)
+	require.Equal(t, []string{"/pets"}, view.PathsInOrder())
+	require.Same(t, tenantScheme, view.Components.SecuritySchemes["apiKey"])
+	require.Same(t, doc.Components.SecuritySchemes["bearer"], view.Components.SecuritySchemes["bearer"])
+	require.Same(t, doc.Info, view.Info)
+
+	after, err := doc.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, string(base), string(after))
+
+	unchanged := doc.View(Overrides{})
+	require.Equal(t, doc.Paths, unchanged.Paths)
+	require.Same(t, doc.Paths["/pets"], unchanged.Paths["/pets"])
+}
@@ -0,0 +1,646 @@
+package openapi3
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrSkipChildren can be returned by a WalkFunc so that Walk does not visit
+// what the visited value contains.
+var ErrSkipChildren = errors.New("skip children")
+
+// ErrStopWalk can be returned by a WalkFunc to stop the walk. Walk then returns nil.
+var ErrStopWalk = errors.New("stop walk")
+
+// WalkFunc is called by Walk for every object of a document.
+// pointer is the JSON pointer to value within the document (e.g. "/paths/~1pets/get"),
+// parent is the closest visited object containing value (nil for the document itself).
+//
+// Values are pointers (*T, *Info, *PathItem, *Operation, *MediaType, *Encoding, *Server, ...)
+// except for SecurityRequirement. Objects that can be references are visited
+// through their XxxRef wrapper (*SchemaRef, *ParameterRef, *ResponseRef, ...).
+type WalkFunc func(pointer string, value, parent interface{}) error
+
+// Walk visits doc and all the objects it contains, calling walkFunc for each.
+//
+// The values referenced by XxxRef wrappers are walked only once, where they are
+// first reached: the components section is walked before the rest of the document
+// so that values defined there are visited at their definition.
+// Likewise, the contents of a PathItem with a local $ref are not walked.
+// Map entries are walked in the order of their sorted keys.
+//
+// If walkFunc returns ErrSkipChildren, the objects contained in the visited value are skipped.
+// If it returns ErrStopWalk, Walk returns nil. Any other error stops the walk and is returned.
+func Walk(doc *T, walkFunc WalkFunc) error {
+	w := &walker{
+		walkFunc: walkFunc,
+		walked:   make(map[interface{}]struct{}),
+	}
+	if err := w.walkT(doc); err != nil && err != ErrStopWalk {
+		return err
+	}
+	return nil
+}
+
+type walker struct {
+	walkFunc WalkFunc
+	// values of XxxRef already walked
+	walked map[interface{}]struct{}
+}
+
+// visit calls walkFunc and tells whether the children of value should be walked.
+func (w *walker) visit(pointer string, value, parent interface{}) (bool, error) {
+	switch err := w.walkFunc(pointer, value, parent); err {
+	case nil:
+		return true, nil
+	case ErrSkipChildren:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// firstWalk tells whether the value of an XxxRef was not walked yet.
+func (w *walker) firstWalk(value interface{}) bool {
+	if _, ok := w.walked[value]; ok {
+		return false
+	}
+	w.walked[value] = struct{}{}
+	return true
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func childPointer(pointer string, tokens ...string) string {
+	var sb strings.Builder
+	sb.WriteString(pointer)
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(escapeJSONPointerToken(token))
+	}
+	return sb.String()
+}
+
+// sortedMapKeys returns the sorted keys of m, a map with string keys.
+func sortedMapKeys(m interface{}) []string {
+	mapKeys := reflect.ValueOf(m).MapKeys()
+	keys := make([]string, 0, len(mapKeys))
+	for _, key := range mapKeys {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (w *walker) walkT(doc *T) error {
+	if ok, err := w.visit("", doc, nil); !ok {
+		return err
+	}
+	if err := w.walkComponents("/components", &doc.Components, doc); err != nil {
+		return err
+	}
+	if err := w.walkInfo("/info", doc.Info, doc); err != nil {
+		return err
+	}
+	if err := w.walkPaths("/paths", doc.Paths, doc); err != nil {
+		return err
+	}
+	for _, name := range sortedMapKeys(doc.Webhooks) {
+		if err := w.walkPathItem(childPointer("/webhooks", name), doc.Webhooks[name], doc); err != nil {
+			return err
+		}
+	}
+	if err := w.walkSecurityRequirements("/security", doc.Security, doc); err != nil {
+		return err
+	}
+	if err := w.walkServers("/servers", doc.Servers, doc); err != nil {
+		return err
+	}
+	for i, tag := range doc.Tags {
+		if err := w.walkTag(childPointer("/tags", strconv.Itoa(i)), tag, doc); err != nil {
+			return err
+		}
+	}
+	return w.walkExternalDocs("/externalDocs", doc.ExternalDocs, doc)
+}
+
+func (w *walker) walkComponents(pointer string, components *Components, parent interface{}) error {
+	if ok, err := w.visit(pointer, components, parent); !ok {
+		return err
+	}
+	if err := w.walkSchemas(childPointer(pointer, "schemas"), components.Schemas, components); err != nil {
+		return err
+	}
+	if err := w.walkParametersMap(childPointer(pointer, "parameters"), components.Parameters, components); err != nil {
+		return err
+	}
+	if err := w.walkHeaders(childPointer(pointer, "headers"), components.Headers, components); err != nil {
+		return err
+	}
+	p := childPointer(pointer, "requestBodies")
+	for _, name := range sortedMapKeys(components.RequestBodies) {
+		if err := w.walkRequestBodyRef(childPointer(p, name), components.RequestBodies[name], components); err != nil {
+			return err
+		}
+	}
+	if err := w.walkResponses(childPointer(pointer, "responses"), components.Responses, components); err != nil {
+		return err
+	}
+	p = childPointer(pointer, "securitySchemes")
+	for _, name := range sortedMapKeys(components.SecuritySchemes) {
+		if err := w.walkSecuritySchemeRef(childPointer(p, name), components.SecuritySchemes[name], components); err != nil {
+			return err
+		}
+	}
+	if err := w.walkExamples(childPointer(pointer, "examples"), components.Examples, components); err != nil {
+		return err
+	}
+	if err := w.walkLinks(childPointer(pointer, "links"), components.Links, components); err != nil {
+		return err
+	}
+	if err := w.walkCallbacks(childPointer(pointer, "callbacks"), components.Callbacks, components); err != nil {
+		return err
+	}
+	p = childPointer(pointer, "pathItems")
+	for _, name := range sortedMapKeys(components.PathItems) {
+		if err := w.walkPathItem(childPointer(p, name), components.PathItems[name], components); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkInfo(pointer string, info *Info, parent interface{}) error {
+	if info == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, info, parent); !ok {
+		return err
+	}
+	if contact := info.Contact; contact != nil {
+		if _, err := w.visit(childPointer(pointer, "contact"), contact, info); err != nil {
+			return err
+		}
+	}
+	if license := info.License; license != nil {
+		if _, err := w.visit(childPointer(pointer, "license"), license, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkPaths(pointer string, paths Paths, parent interface{}) error {
+	for _, path := range sortedMapKeys(paths) {
+		if err := w.walkPathItem(childPointer(pointer, path), paths[path], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkPathItem(pointer string, pathItem *PathItem, parent interface{}) error {
+	if pathItem == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, pathItem, parent); !ok {
+		return err
+	}
+	if strings.HasPrefix(pathItem.Ref, "#") {
+		// Contents are walked where they are defined
+		return nil
+	}
+	if err := w.walkServers(childPointer(pointer, "servers"), pathItem.Servers, pathItem); err != nil {
+		return err
+	}
+	if err := w.walkParameters(childPointer(pointer, "parameters"), pathItem.Parameters, pathItem); err != nil {
+		return err
+	}
+	operations := pathItem.Operations()
+	for _, method := range sortedMapKeys(operations) {
+		if err := w.walkOperation(childPointer(pointer, strings.ToLower(method)), operations[method], pathItem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkOperation(pointer string, operation *Operation, parent interface{}) error {
+	if ok, err := w.visit(pointer, operation, parent); !ok {
+		return err
+	}
+	if err := w.walkParameters(childPointer(pointer, "parameters"), operation.Parameters, operation); err != nil {
+		return err
+	}
+	if err := w.walkRequestBodyRef(childPointer(pointer, "requestBody"), operation.RequestBody, operation); err != nil {
+		return err
+	}
+	if err := w.walkResponses(childPointer(pointer, "responses"), operation.Responses, operation); err != nil {
+		return err
+	}
+	if err := w.walkCallbacks(childPointer(pointer, "callbacks"), operation.Callbacks, operation); err != nil {
+		return err
+	}
+	if security := operation.Security; security != nil {
+		if err := w.walkSecurityRequirements(childPointer(pointer, "security"), *security, operation); err != nil {
+			return err
+		}
+	}
+	if servers := operation.Servers; servers != nil {
+		if err := w.walkServers(childPointer(pointer, "servers"), *servers, operation); err != nil {
+			return err
+		}
+	}
+	return w.walkExternalDocs(childPointer(pointer, "externalDocs"), operation.ExternalDocs, operation)
+}
+
+func (w *walker) walkParameters(pointer string, parameters Parameters, parent interface{}) error {
+	for i, parameter := range parameters {
+		if err := w.walkParameterRef(childPointer(pointer, strconv.Itoa(i)), parameter, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkParametersMap(pointer string, parameters ParametersMap, parent interface{}) error {
+	for _, name := range sortedMapKeys(parameters) {
+		if err := w.walkParameterRef(childPointer(pointer, name), parameters[name], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkParameterRef(pointer string, ref *ParameterRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	if ref.Value == nil || !w.firstWalk(ref.Value) {
+		return nil
+	}
+	return w.walkParameter(pointer, ref.Value, ref)
+}
+
+func (w *walker) walkParameter(pointer string, parameter *Parameter, parent interface{}) error {
+	if err := w.walkSchemaRef(childPointer(pointer, "schema"), parameter.Schema, parent); err != nil {
+		return err
+	}
+	if err := w.walkExamples(childPointer(pointer, "examples"), parameter.Examples, parent); err != nil {
+		return err
+	}
+	return w.walkContent(childPointer(pointer, "content"), parameter.Content, parent)
+}
+
+func (w *walker) walkHeaders(pointer string, headers Headers, parent interface{}) error {
+	for _, name := range sortedMapKeys(headers) {
+		if err := w.walkHeaderRef(childPointer(pointer, name), headers[name], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkHeaderRef(pointer string, ref *HeaderRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	if ref.Value == nil || !w.firstWalk(ref.Value) {
+		return nil
+	}
+	return w.walkParameter(pointer, &ref.Value.Parameter, ref)
+}
+
+func (w *walker) walkRequestBodyRef(pointer string, ref *RequestBodyRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	if ref.Value == nil || !w.firstWalk(ref.Value) {
+		return nil
+	}
+	return w.walkContent(childPointer(pointer, "content"), ref.Value.Content, ref)
+}
+
+func (w *walker) walkResponses(pointer string, responses Responses, parent interface{}) error {
+	for _, status := range sortedMapKeys(responses) {
+		if err := w.walkResponseRef(childPointer(pointer, status), responses[status], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkResponseRef(pointer string, ref *ResponseRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	response := ref.Value
+	if response == nil || !w.firstWalk(response) {
+		return nil
+	}
+	if err := w.walkHeaders(childPointer(pointer, "headers"), response.Headers, ref); err != nil {
+		return err
+	}
+	if err := w.walkContent(childPointer(pointer, "content"), response.Content, ref); err != nil {
+		return err
+	}
+	return w.walkLinks(childPointer(pointer, "links"), response.Links, ref)
+}
+
+func (w *walker) walkContent(pointer string, content Content, parent interface{}) error {
+	for _, mime := range sortedMapKeys(content) {
+		if err := w.walkMediaType(childPointer(pointer, mime), content[mime], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkMediaType(pointer string, mediaType *MediaType, parent interface{}) error {
+	if mediaType == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, mediaType, parent); !ok {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "schema"), mediaType.Schema, mediaType); err != nil {
+		return err
+	}
+	if err := w.walkExamples(childPointer(pointer, "examples"), mediaType.Examples, mediaType); err != nil {
+		return err
+	}
+	p := childPointer(pointer, "encoding")
+	for _, name := range sortedMapKeys(mediaType.Encoding) {
+		encoding := mediaType.Encoding[name]
+		if encoding == nil {
+			continue
+		}
+		ep := childPointer(p, name)
+		ok, err := w.visit(ep, encoding, mediaType)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := w.walkHeaders(childPointer(ep, "headers"), encoding.Headers, encoding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkSchemas(pointer string, schemas Schemas, parent interface{}) error {
+	for _, name := range sortedMapKeys(schemas) {
+		if err := w.walkSchemaRef(childPointer(pointer, name), schemas[name], parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkSchemaRefs(pointer string, refs SchemaRefs, parent interface{}) error {
+	for i, ref := range refs {
+		if err := w.walkSchemaRef(childPointer(pointer, strconv.Itoa(i)), ref, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkSchemaRef(pointer string, ref *SchemaRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	schema := ref.Value
+	if schema == nil || !w.firstWalk(schema) {
+		return nil
+	}
+	if err := w.walkSchemaRefs(childPointer(pointer, "oneOf"), schema.OneOf, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRefs(childPointer(pointer, "anyOf"), schema.AnyOf, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRefs(childPointer(pointer, "allOf"), schema.AllOf, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "not"), schema.Not, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "items"), schema.Items, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "contains"), schema.Contains, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "if"), schema.If, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "then"), schema.Then, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "else"), schema.Else, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemas(childPointer(pointer, "properties"), schema.Properties, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemas(childPointer(pointer, "patternProperties"), schema.PatternProperties, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "propertyNames"), schema.PropertyNames, ref); err != nil {
+		return err
+	}
+	if err := w.walkSchemaRef(childPointer(pointer, "additionalProperties"), schema.AdditionalProperties.Schema, ref); err != nil {
+		return err
+	}
+	if discriminator := schema.Discriminator; discriminator != nil {
+		if _, err := w.visit(childPointer(pointer, "discriminator"), discriminator, ref); err != nil {
+			return err
+		}
+	}
+	if xml := schema.XML; xml != nil {
+		if _, err := w.visit(childPointer(pointer, "xml"), xml, ref); err != nil {
+			return err
+		}
+	}
+	return w.walkExternalDocs(childPointer(pointer, "externalDocs"), schema.ExternalDocs, ref)
+}
+
+func (w *walker) walkExamples(pointer string, examples Examples, parent interface{}) error {
+	for _, name := range sortedMapKeys(examples) {
+		ref := examples[name]
+		if ref == nil {
+			continue
+		}
+		if _, err := w.visit(childPointer(pointer, name), ref, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkLinks(pointer string, links Links, parent interface{}) error {
+	for _, name := range sortedMapKeys(links) {
+		ref := links[name]
+		if ref == nil {
+			continue
+		}
+		p := childPointer(pointer, name)
+		if ok, err := w.visit(p, ref, parent); !ok {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if ref.Value == nil || !w.firstWalk(ref.Value) {
+			continue
+		}
+		if server := ref.Value.Server; server != nil {
+			if err := w.walkServer(childPointer(p, "server"), server, ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkCallbacks(pointer string, callbacks Callbacks, parent interface{}) error {
+	for _, name := range sortedMapKeys(callbacks) {
+		ref := callbacks[name]
+		if ref == nil {
+			continue
+		}
+		p := childPointer(pointer, name)
+		if ok, err := w.visit(p, ref, parent); !ok {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		callback := ref.Value
+		if callback == nil || !w.firstWalk(callback) {
+			continue
+		}
+		for _, expression := range sortedMapKeys(*callback) {
+			if err := w.walkPathItem(childPointer(p, expression), (*callback)[expression], ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkSecuritySchemeRef(pointer string, ref *SecuritySchemeRef, parent interface{}) error {
+	if ref == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, ref, parent); !ok {
+		return err
+	}
+	if ref.Value == nil || !w.firstWalk(ref.Value) {
+		return nil
+	}
+	flows := ref.Value.Flows
+	if flows == nil {
+		return nil
+	}
+	p := childPointer(pointer, "flows")
+	if ok, err := w.visit(p, flows, ref); !ok {
+		return err
+	}
+	for _, flow := range []struct {
+		name string
+		flow *OAuthFlow
+	}{
+		{"implicit", flows.Implicit},
+		{"password", flows.Password},
+		{"clientCredentials", flows.ClientCredentials},
+		{"authorizationCode", flows.AuthorizationCode},
+	} {
+		if flow.flow == nil {
+			continue
+		}
+		if _, err := w.visit(childPointer(p, flow.name), flow.flow, flows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkSecurityRequirements(pointer string, security SecurityRequirements, parent interface{}) error {
+	for i, requirement := range security {
+		if _, err := w.visit(childPointer(pointer, strconv.Itoa(i)), requirement, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkServers(pointer string, servers Servers, parent interface{}) error {
+	for i, server := range servers {
+		if err := w.walkServer(childPointer(pointer, strconv.Itoa(i)), server, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkServer(pointer string, server *Server, parent interface{}) error {
+	if server == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, server, parent); !ok {
+		return err
+	}
+	p := childPointer(pointer, "variables")
+	for _, name := range sortedMapKeys(server.Variables) {
+		variable := server.Variables[name]
+		if variable == nil {
+			continue
+		}
+		if _, err := w.visit(childPointer(p, name), variable, server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkTag(pointer string, tag *Tag, parent interface{}) error {
+	if tag == nil {
+		return nil
+	}
+	if ok, err := w.visit(pointer, tag, parent); !ok {
+		return err
+	}
+	return w.walkExternalDocs(childPointer(pointer, "externalDocs"), tag.ExternalDocs, tag)
+}
+
+func (w *walker) walkExternalDocs(pointer string, externalDocs *ExternalDocs, parent interface{}) error {
+	if externalDocs == nil {
+		return nil
+	}
+	_, err := w.visit(pointer, externalDocs, parent)
+	return err
+}
@@ -0,0 +1,140 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var specToWalk = []byte(`
+openapi: 3.0.0
+info:
+  title: t
+  version: 1.0.0
+  contact: {name: me}
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/Id'
+    get:
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Rate:
+              schema: {type: integer}
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              responses:
+                default:
+                  $ref: '#/components/responses/Error'
+  /animals/{id}:
+    $ref: '#/paths/~1pets~1{id}'
+components:
+  parameters:
+    Id:
+      name: id
+      in: path
+      required: true
+      schema: {type: string}
+  responses:
+    Error:
+      description: error
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        parent:
+          $ref: '#/components/schemas/Pet'
+`[1:])
+
+func TestWalk(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(specToWalk)
+	require.NoError(t, err)
+
+	var visited []string
+	parents := make(map[string]interface{})
+	err = Walk(doc, func(pointer string, value, parent interface{}) error {
+		visited = append(visited, fmt.Sprintf("%s %T", pointer, value))
+		parents[pointer] = parent
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		" *openapi3.T",
+		"/components *openapi3.Components",
+		"/components/schemas/Pet *openapi3.SchemaRef",
+		"/components/schemas/Pet/properties/name *openapi3.SchemaRef",
+		"/components/schemas/Pet/properties/parent *openapi3.SchemaRef",
+		"/components/parameters/Id *openapi3.ParameterRef",
+		"/components/parameters/Id/schema *openapi3.SchemaRef",
+		"/components/responses/Error *openapi3.ResponseRef",
+		"/info *openapi3.Info",
+		"/info/contact *openapi3.Contact",
+		"/paths/~1animals~1{id} *openapi3.PathItem",
+		"/paths/~1pets~1{id} *openapi3.PathItem",
+		"/paths/~1pets~1{id}/parameters/0 *openapi3.ParameterRef",
+		"/paths/~1pets~1{id}/get *openapi3.Operation",
+		"/paths/~1pets~1{id}/get/responses/200 *openapi3.ResponseRef",
+		"/paths/~1pets~1{id}/get/responses/200/headers/X-Rate *openapi3.HeaderRef",
+		"/paths/~1pets~1{id}/get/responses/200/headers/X-Rate/schema *openapi3.SchemaRef",
+		"/paths/~1pets~1{id}/get/responses/200/content/application~1json *openapi3.MediaType",
+		"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema *openapi3.SchemaRef",
+		"/paths/~1pets~1{id}/get/callbacks/onEvent *openapi3.CallbackRef",
+		"/paths/~1pets~1{id}/get/callbacks/onEvent/{$request.body#~1url} *openapi3.PathItem",
+		"/paths/~1pets~1{id}/get/callbacks/onEvent/{$request.body#~1url}/post *openapi3.Operation",
+		"/paths/~1pets~1{id}/get/callbacks/onEvent/{$request.body#~1url}/post/responses/default *openapi3.ResponseRef",
+	}, visited)
+
+	require.Nil(t, parents[""])
+	require.Same(t, doc, parents["/paths/~1pets~1{id}"])
+	require.Same(t, doc.Paths["/pets/{id}"].Get, parents["/paths/~1pets~1{id}/get/responses/200"])
+	require.Same(t, doc.Components.Schemas["Pet"], parents["/components/schemas/Pet/properties/name"])
+	require.Same(t, doc.Paths["/pets/{id}"].Get.Responses["200"].Value.Content["application/json"], parents["/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema"])
+}
+
+func TestWalkSkipAndStop(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(specToWalk)
+	require.NoError(t, err)
+
+	var visited []string
+	err = Walk(doc, func(pointer string, value, parent interface{}) error {
+		visited = append(visited, pointer)
+		switch value.(type) {
+		case *Components, *Info:
+			return ErrSkipChildren
+		case *Operation:
+			return ErrStopWalk
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"",
+		"/components",
+		"/info",
+		"/paths/~1animals~1{id}",
+		"/paths/~1pets~1{id}",
+		"/paths/~1pets~1{id}/parameters/0",
+		"/paths/~1pets~1{id}/parameters/0/schema",
+		"/paths/~1pets~1{id}/get",
+	}, visited)
+
+	errFailed := errors.New("failed")
+	err = Walk(doc, func(pointer string, value, parent interface{}) error {
+		if _, ok := value.(*MediaType); ok {
+			return errFailed
+		}
+		return nil
+	})
+	require.Equal(t, errFailed, err)
+}
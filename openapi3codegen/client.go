@@ -0,0 +1,217 @@
+package openapi3codegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ClientOption configures a generated client.
+type ClientOption func(*Client)
+
+// HTTPClient sets the client sending requests, http.DefaultClient by default.
+func HTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// ResponseValidationOptions sets the options responses are validated with.
+func ResponseValidationOptions(options *openapi3filter.Options) ClientOption {
+	return func(c *Client) { c.options = options }
+}
+
+// SkipResponseValidation makes the client decode responses without validating them.
+func SkipResponseValidation() ClientOption {
+	return func(c *Client) { c.skipResponseValidation = true }
+}
+
+// Client sends requests to the operations of a document and validates their responses.
+// It is used by generated clients.
+type Client struct {
+	doc                    *openapi3.T
+	baseURL                string
+	httpClient             *http.Client
+	options                *openapi3filter.Options
+	skipResponseValidation bool
+}
+
+// NewClient returns a Client sending the requests to the operations of doc to baseURL.
+func NewClient(doc *openapi3.T, baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		doc:        doc,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Do sends a request to the operation of method and path, the path template of the operation.
+// params, if not nil, is a struct whose fields are tagged with the names of the operation's
+// parameters, serialized according to their style. body, if not nil, is the request body:
+// an io.Reader sent as is, or a value encoded as JSON.
+//
+// Unless SkipResponseValidation is set, the response is validated against the operation.
+// Its body is then decoded as JSON into the value newBody returns for its status code,
+// and set to what that value points to. When newBody is or returns nil, the body is set
+// to an io.Reader of the bytes of the response, or left nil when it is empty.
+func (c *Client) Do(ctx context.Context, method, path string, params, body interface{}, newBody func(statusCode int) interface{}) (*Response, error) {
+	pathItem := c.doc.Paths[path]
+	if pathItem == nil {
+		return nil, fmt.Errorf("no path %q in the document", path)
+	}
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil, fmt.Errorf("no operation %s %s in the document", method, path)
+	}
+	route := &routers.Route{Spec: c.doc, Path: path, PathItem: pathItem, Method: method, Operation: operation}
+
+	req, err := c.newRequest(ctx, route, params, body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	data, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.skipResponseValidation {
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request: req,
+				Route:   route,
+				Options: c.options,
+			},
+			Status:  httpResp.StatusCode,
+			Header:  httpResp.Header,
+			Options: c.options,
+		}
+		input.SetBodyBytes(data)
+		if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header}
+	if len(data) == 0 {
+		return resp, nil
+	}
+	var target interface{}
+	if newBody != nil {
+		target = newBody(httpResp.StatusCode)
+	}
+	if target == nil {
+		resp.Body = bytes.NewReader(data)
+		return resp, nil
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("decoding the body of the %d response of %s %s: %w", httpResp.StatusCode, method, path, err)
+	}
+	resp.Body = reflect.ValueOf(target).Elem().Interface()
+	return resp, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, route *routers.Route, params, body interface{}) (*http.Request, error) {
+	values := make(map[string]interface{})
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return nil, err
+		}
+	}
+
+	path := route.Path
+	query := make(url.Values)
+	header := make(http.Header)
+	var cookies []*http.Cookie
+	for _, parameter := range routeParameters(route) {
+		value, ok := values[parameter.Name]
+		if !ok {
+			continue
+		}
+		encoded, err := openapi3filter.EncodeParameter(parameter, value)
+		if err != nil {
+			return nil, err
+		}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			path = strings.Replace(path, "{"+parameter.Name+"}", escapePathValue(encoded.Get(parameter.Name)), -1)
+		case openapi3.ParameterInQuery:
+			for name, vs := range encoded {
+				query[name] = append(query[name], vs...)
+			}
+		case openapi3.ParameterInHeader:
+			header.Set(parameter.Name, encoded.Get(parameter.Name))
+		case openapi3.ParameterInCookie:
+			cookies = append(cookies, &http.Cookie{Name: parameter.Name, Value: encoded.Get(parameter.Name)})
+		}
+	}
+
+	var bodyReader io.Reader
+	if body != nil && !isNilPointer(body) {
+		if r, ok := body.(io.Reader); ok {
+			bodyReader = r
+			if requestBody := route.Operation.RequestBody; requestBody != nil && requestBody.Value != nil && len(requestBody.Value.Content) != 0 {
+				header.Set("Content-Type", firstMediaType(requestBody.Value.Content))
+			}
+		} else {
+			data, err := json.Marshal(body)
+			if err != nil {
+				return nil, err
+			}
+			bodyReader = bytes.NewReader(data)
+			header.Set("Content-Type", "application/json")
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, route.Method, target, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	for name, vs := range header {
+		req.Header[name] = vs
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	return req, nil
+}
+
+var pathValueUnescaper = strings.NewReplacer("%3B", ";", "%2C", ",", "%3D", "=")
+
+// escapePathValue escapes a serialized path parameter, keeping the delimiters
+// of the label and matrix styles.
+func escapePathValue(value string) string {
+	return pathValueUnescaper.Replace(url.PathEscape(value))
+}
+
+func isNilPointer(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
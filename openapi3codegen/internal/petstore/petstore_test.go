@@ -0,0 +1,161 @@
+package petstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3codegen"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+type server struct {
+	pets []Pet
+}
+
+func (s *server) ListPets(ctx context.Context, params ListPetsParams) (*ListPetsResponse, error) {
+	var pets []Pet
+	for _, pet := range s.pets {
+		if params.Kind == nil || pet.Kind == *params.Kind {
+			pets = append(pets, pet)
+		}
+	}
+	if len(pets) > int(*params.Limit) {
+		pets = pets[:*params.Limit]
+	}
+	return ListPets200Response(pets), nil
+}
+
+func (s *server) CreatePet(ctx context.Context, body NewPet) (*CreatePetResponse, error) {
+	if body.Name == "Garfield" {
+		return CreatePetDefaultResponse(http.StatusConflict, Error{Message: "already exists"}), nil
+	}
+	pet := Pet{NewPet: body, ID: int64(len(s.pets) + 1)}
+	s.pets = append(s.pets, pet)
+	return CreatePet201Response(pet), nil
+}
+
+func (s *server) GetPet(ctx context.Context, params GetPetParams) (*GetPetResponse, error) {
+	for _, pet := range s.pets {
+		if pet.ID == params.PetID {
+			return GetPet200Response(pet), nil
+		}
+	}
+	return GetPet404Response(), nil
+}
+
+func (s *server) DeletePetsPetID(ctx context.Context, params DeletePetsPetIDParams) (*DeletePetsPetIDResponse, error) {
+	return nil, errors.New("not allowed")
+}
+
+func (s *server) Health(ctx context.Context) (*HealthResponse, error) {
+	return Health200Response(strings.NewReader("OK")), nil
+}
+
+func TestHandler(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("petstore.yml")
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	options := &openapi3filter.Options{SchemaValidators: SchemaValidators}
+	handler := NewHandler(&server{}, router, openapi3codegen.ValidationOptions(options))
+
+	for _, tc := range []struct {
+		method, target, body string
+		status               int
+		response             string
+	}{
+		{method: http.MethodPost, target: "/pets", body: `{"name":"Tom","kind":"cat"}`, status: http.StatusCreated, response: `{"id":1,"name":"Tom","kind":"cat"}`},
+		{method: http.MethodPost, target: "/pets", body: `{"name":"Rex","kind":"dog","tags":["good"]}`, status: http.StatusCreated, response: `{"id":2,"name":"Rex","kind":"dog","tags":["good"]}`},
+		{method: http.MethodPost, target: "/pets", body: `{"name":"Garfield","kind":"cat"}`, status: http.StatusConflict, response: `{"message":"already exists"}`},
+		{method: http.MethodPost, target: "/pets", body: `{"name":"Nemo","kind":"fish"}`, status: http.StatusUnprocessableEntity},
+		{method: http.MethodGet, target: "/pets", status: http.StatusOK, response: `[{"id":1,"name":"Tom","kind":"cat"},{"id":2,"name":"Rex","kind":"dog","tags":["good"]}]`},
+		{method: http.MethodGet, target: "/pets?kind=dog", status: http.StatusOK, response: `[{"id":2,"name":"Rex","kind":"dog","tags":["good"]}]`},
+		{method: http.MethodGet, target: "/pets?limit=1", status: http.StatusOK, response: `[{"id":1,"name":"Tom","kind":"cat"}]`},
+		{method: http.MethodGet, target: "/pets?limit=0", status: http.StatusBadRequest},
+		{method: http.MethodGet, target: "/pets/2", status: http.StatusOK, response: `{"id":2,"name":"Rex","kind":"dog","tags":["good"]}`},
+		{method: http.MethodGet, target: "/pets/3", status: http.StatusNotFound},
+		{method: http.MethodGet, target: "/pets/rex", status: http.StatusNotFound},
+		{method: http.MethodDelete, target: "/pets/2", status: http.StatusInternalServerError},
+		{method: http.MethodGet, target: "/health", status: http.StatusOK, response: "OK"},
+		{method: http.MethodGet, target: "/owners", status: http.StatusNotFound},
+	} {
+		t.Run(tc.method+" "+tc.target, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			require.Equal(t, tc.status, w.Code, w.Body.String())
+			switch {
+			case tc.response == "OK":
+				require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+				require.Equal(t, tc.response, w.Body.String())
+			case tc.response != "":
+				require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				require.JSONEq(t, tc.response, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("petstore.yml")
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	ts := httptest.NewServer(NewHandler(&server{}, router))
+	defer ts.Close()
+	client := NewClient(doc, ts.URL)
+	ctx := context.Background()
+
+	created, err := client.CreatePet(ctx, NewPet{Name: "Tom", Kind: KindCat})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, created.StatusCode)
+	require.Equal(t, Pet{NewPet: NewPet{Name: "Tom", Kind: KindCat}, ID: 1}, created.Body)
+	_, err = client.CreatePet(ctx, NewPet{Name: "Rex", Kind: KindDog, Tags: []string{"good"}})
+	require.NoError(t, err)
+
+	conflict, err := client.CreatePet(ctx, NewPet{Name: "Garfield", Kind: KindCat})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, conflict.StatusCode)
+	require.Equal(t, Error{Message: "already exists"}, conflict.Body)
+
+	kind, limit := KindDog, int32(1)
+	listed, err := client.ListPets(ctx, ListPetsParams{Kind: &kind, Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []Pet{{NewPet: NewPet{Name: "Rex", Kind: KindDog, Tags: []string{"good"}}, ID: 2}}, listed.Body)
+
+	got, err := client.GetPet(ctx, GetPetParams{PetID: 1})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, got.StatusCode)
+	require.Equal(t, "Tom", got.Body.(Pet).Name)
+
+	missing, err := client.GetPet(ctx, GetPetParams{PetID: 3})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, missing.StatusCode)
+	require.Nil(t, missing.Body)
+
+	health, err := client.Health(ctx)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(health.Body.(io.Reader))
+	require.NoError(t, err)
+	require.Equal(t, "OK", string(data))
+
+	reason := "moved"
+	deleted, err := client.DeletePetsPetID(ctx, DeletePetsPetIDParams{PetID: 2, XReason: &reason})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, deleted.StatusCode)
+}
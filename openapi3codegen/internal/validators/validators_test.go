@@ -0,0 +1,87 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestSchemaValidators(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("validators.yml")
+	require.NoError(t, err)
+
+	values := []string{
+		`null`, `true`, `0`, `1.5`, `-2`, `4`, `"red"`, `"green"`, `"#00ff00"`, `"#00FF00"`, `""`, `"abcdef"`,
+		`[]`, `[2, 4]`, `[2, 3]`, `[2, 4, 6]`, `[2147483648, 2]`, `[null, 2]`,
+		`{}`, `{"radius": 1}`, `{"radius": 0}`, `{"radius": 100}`, `{"radius": 100.5}`, `{"radius": "1"}`,
+		`{"radius": 1, "color": "blue"}`, `{"radius": 1, "color": "green"}`, `{"radius": 1, "color": null}`, `{"radius": 1, "colour": "red"}`,
+		`{"points": [[0, 0], [2, 0], [0, 2]]}`, `{"points": [[0, 0], [2, 0]]}`, `{"points": [[0, 0], [2, 0], [0, 1]]}`,
+		`{"points": [[0, 0], [2, 0], [0, 2]], "tag": "abc"}`, `{"points": [[0, 0], [2, 0], [0, 2]], "tag": "abcd"}`,
+		`{"points": [[0, 0], [2, 0], [0, 2]], "radius": 1}`, `{"points": [[0, 0], [2, 0], [0, 2]], "color": "#0000ff"}`,
+		`{"text": "hello"}`, `{"text": "hello!"}`, `{"text": ""}`, `{"text": "héllo"}`, `{"bold": true, "weight": 1.5}`,
+		`{"weight": 1.25}`, `{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`, `{"id": "not a uuid"}`,
+		`{"tree": {"tree": {"text": "a"}}}`, `{"tree": {"tree": {"text": 1}}}`, `{"a": 1, "b": 2, "c": 3}`,
+	}
+	for name, validator := range SchemaValidators {
+		schema := doc.Components.Schemas[name[len("#/components/schemas/"):]].Value
+		for _, data := range values {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(data), &value))
+			expected := schema.VisitJSON(value) == nil
+			require.Equal(t, expected, validator(value), "%s %s", name, data)
+		}
+	}
+}
+
+func TestSchemaValidatorsAgreeUnderClock(t *testing.T) {
+	openapi3.RegisterTimeFormatValidator("test-future", func(value interface{}, now time.Time) error {
+		at, err := time.Parse(time.RFC3339, value.(string))
+		if err != nil {
+			return err
+		}
+		if !at.After(now) {
+			return errors.New("not in the future")
+		}
+		return nil
+	})
+	defer openapi3.UnregisterFormatValidator("test-future")
+	clock := func() time.Time { return time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("validators.yml")
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	validate := func(body string, options *openapi3filter.Options) error {
+		req := httptest.NewRequest(http.MethodPost, "/meetings", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return openapi3filter.ValidateRequest(context.Background(), input)
+	}
+
+	interpreted := &openapi3filter.Options{Clock: clock}
+	compiled := &openapi3filter.Options{Clock: clock, SchemaValidators: SchemaValidators}
+	for body, valid := range map[string]bool{
+		`{"at": "2099-06-01T00:00:00Z"}`: false,
+		`{"at": "2100-06-01T00:00:00Z"}`: true,
+		`{}`:                             false,
+	} {
+		require.Equal(t, valid, validate(body, interpreted) == nil, body)
+		require.Equal(t, valid, validate(body, compiled) == nil, body)
+	}
+}
@@ -0,0 +1,745 @@
+// Package openapi3codegen generates Go server interfaces, validating dispatchers
+// and typed clients from OpenAPI 3 documents.
+//
+// Generate emits, for a document:
+//   - a Go type per component schema,
+//   - an interface per tag, named after it, with a method per operation tagged
+//     with it first (untagged operations go to DefaultServer),
+//   - a Server interface embedding them,
+//   - a params struct, a response type and response constructors per operation,
+//   - a NewHandler function returning an http.Handler that routes requests,
+//     validates them with openapi3filter and calls the Server.
+//
+// With WithClient, it also emits a Client with a method per operation, which
+// serializes parameters according to their style, and validates and decodes responses.
+// With WithoutServer, the server interfaces and NewHandler are left out.
+// With WithSchemaValidators, component schemas are compiled into validators
+// openapi3filter uses instead of interpreting the schemas.
+//
+// The generated code depends on this package for its runtime support.
+package openapi3codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Option allows tweaking code generation
+type Option func(*generator)
+
+// PackageName sets the name of the package of the generated code, "api" by default.
+func PackageName(name string) Option {
+	return func(g *generator) { g.packageName = name }
+}
+
+// WithClient makes Generate emit a client of the operations as well.
+func WithClient() Option {
+	return func(g *generator) { g.client = true }
+}
+
+// WithoutServer makes Generate leave out the server interfaces and handler.
+func WithoutServer() Option {
+	return func(g *generator) { g.server = false }
+}
+
+// Generate returns the formatted Go source of the server interfaces and handler of doc,
+// and of its client if WithClient is set.
+func Generate(doc *openapi3.T, opts ...Option) ([]byte, error) {
+	g := &generator{
+		packageName: "api",
+		server:      true,
+		imports:     make(map[string]struct{}),
+		names:       make(map[string]struct{}),
+		typeNames:   make(map[string]string),
+		nilable:     make(map[string]bool),
+		visiting:    make(map[*openapi3.Schema]struct{}),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if err := g.generate(doc); err != nil {
+		return nil, err
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by openapi3codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.packageName)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		// Standard library packages come first
+		if iStd, jStd := !strings.Contains(imports[i], "."), !strings.Contains(imports[j], "."); iStd != jStd {
+			return iStd
+		}
+		return imports[i] < imports[j]
+	})
+	src.WriteString("import (\n")
+	std := true
+	for _, path := range imports {
+		if std && strings.Contains(path, ".") {
+			std = false
+			src.WriteString("\n")
+		}
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	src.WriteString(")\n")
+	src.Write(g.buf.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+const componentSchemaPrefix = "#/components/schemas/"
+
+type generator struct {
+	packageName    string
+	server, client bool
+	validators     bool
+	validatorNames []string
+	buf            bytes.Buffer
+	imports        map[string]struct{}
+	// names are the package level identifiers in use
+	names map[string]struct{}
+	// typeNames are the Go type names of component schemas
+	typeNames map[string]string
+	// nilable is set for the Go types of component schemas that can be nil
+	nilable  map[string]bool
+	visiting map[*openapi3.Schema]struct{}
+}
+
+type operation struct {
+	*openapi3.OperationInfo
+	name       string
+	group      string
+	params     []*param
+	body       string
+	bodyJSON   bool
+	responses  []*response
+	paramsType string
+	respType   string
+}
+
+type param struct {
+	field, goType string
+	parameter     *openapi3.Parameter
+}
+
+type response struct {
+	status    string
+	code      int
+	goType    string
+	json      bool
+	mediaType string
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) use(path string) {
+	g.imports[path] = struct{}{}
+}
+
+// identifier returns a package level identifier derived from name not yet in use.
+func (g *generator) identifier(name string) string {
+	id := name
+	for i := 2; ; i++ {
+		if _, ok := g.names[id]; !ok {
+			break
+		}
+		id = name + strconv.Itoa(i)
+	}
+	g.names[id] = struct{}{}
+	return id
+}
+
+func (g *generator) generate(doc *openapi3.T) error {
+	g.use("net/http")
+	g.use("github.com/getkin/kin-openapi/openapi3codegen")
+	if g.server || g.client {
+		g.use("context")
+	}
+	if g.server {
+		for _, name := range []string{"Server", "DefaultServer", "NewHandler"} {
+			g.names[name] = struct{}{}
+		}
+		g.use("github.com/getkin/kin-openapi/routers")
+	}
+	if g.client {
+		for _, name := range []string{"Client", "NewClient"} {
+			g.names[name] = struct{}{}
+		}
+		g.use("github.com/getkin/kin-openapi/openapi3")
+	}
+	if g.validators {
+		g.names["SchemaValidators"] = struct{}{}
+	}
+
+	schemaNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+	for _, name := range schemaNames {
+		g.typeNames[name] = g.identifier(goName(name))
+	}
+	for _, name := range schemaNames {
+		if ref := doc.Components.Schemas[name]; ref != nil && ref.Value != nil {
+			g.nilable[g.typeNames[name]] = isNilable(g.goType(&openapi3.SchemaRef{Value: ref.Value}))
+		}
+	}
+	for _, name := range schemaNames {
+		if ref := doc.Components.Schemas[name]; ref != nil && ref.Value != nil {
+			g.schemaType(name, ref.Value)
+		}
+	}
+	if g.validators {
+		if err := g.schemaValidators(doc); err != nil {
+			return err
+		}
+	}
+
+	operations, err := g.operations(doc)
+	if err != nil {
+		return err
+	}
+
+	if g.server {
+		groups := make(map[string][]*operation)
+		var groupNames []string
+		for _, op := range operations {
+			if _, ok := groups[op.group]; !ok {
+				groupNames = append(groupNames, op.group)
+			}
+			groups[op.group] = append(groups[op.group], op)
+		}
+		sort.Strings(groupNames)
+		for _, group := range groupNames {
+			g.serverInterface(group, groups[group])
+		}
+		g.printf("\n// Server is implemented by the operations of the document.\n")
+		g.printf("type Server interface {\n")
+		for _, group := range groupNames {
+			g.printf("%s\n", group)
+		}
+		g.printf("}\n")
+	}
+
+	for _, op := range operations {
+		g.operationTypes(op)
+	}
+	if g.server {
+		g.handler(operations)
+	}
+	if g.client {
+		g.clientType(operations)
+	}
+	return nil
+}
+
+func (g *generator) operations(doc *openapi3.T) ([]*operation, error) {
+	infos := doc.Operations()
+	operations := make([]*operation, 0, len(infos))
+	for _, info := range infos {
+		op := &operation{OperationInfo: info}
+		name := info.Operation.OperationID
+		if name == "" {
+			name = strings.ToLower(info.Method) + " " + info.Path
+		}
+		op.name = g.identifier(goName(name))
+		op.group = "DefaultServer"
+		if len(info.Operation.Tags) != 0 {
+			op.group = goName(info.Operation.Tags[0]) + "Server"
+		}
+
+		if len(info.Parameters) != 0 {
+			op.paramsType = g.identifier(op.name + "Params")
+			fields := make(map[string]struct{})
+			names := make(map[string]string)
+			for _, ref := range info.Parameters {
+				parameter := ref.Value
+				if parameter == nil {
+					continue
+				}
+				if in, ok := names[parameter.Name]; ok {
+					return nil, fmt.Errorf("operation %s %s: parameters %q in %s and %s share a name", info.Method, info.Path, parameter.Name, in, parameter.In)
+				}
+				names[parameter.Name] = parameter.In
+				schema := parameter.Schema
+				if schema == nil {
+					if mediaType := parameter.Content.Get("application/json"); mediaType != nil {
+						schema = mediaType.Schema
+					}
+				}
+				op.params = append(op.params, &param{
+					field:     uniqueField(goName(parameter.Name), fields),
+					goType:    g.fieldType(schema, parameter.Required),
+					parameter: parameter,
+				})
+			}
+		}
+
+		if ref := info.Operation.RequestBody; ref != nil && ref.Value != nil && len(ref.Value.Content) != 0 {
+			if mediaType := ref.Value.Content.Get("application/json"); mediaType != nil {
+				op.body = g.fieldType(mediaType.Schema, ref.Value.Required)
+				op.bodyJSON = true
+			} else {
+				g.use("io")
+				op.body = "io.Reader"
+			}
+		}
+
+		op.respType = g.identifier(op.name + "Response")
+		statuses := make([]string, 0, len(info.Operation.Responses))
+		for status := range info.Operation.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			// default comes last
+			if statuses[i] == "default" || statuses[j] == "default" {
+				return statuses[j] == "default" && statuses[i] != "default"
+			}
+			return statuses[i] < statuses[j]
+		})
+		for _, status := range statuses {
+			ref := info.Operation.Responses[status]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			resp := &response{status: status}
+			resp.code, _ = strconv.Atoi(status)
+			if len(ref.Value.Content) != 0 {
+				if mediaType := ref.Value.Content.Get("application/json"); mediaType != nil {
+					resp.goType = g.fieldType(mediaType.Schema, true)
+					resp.json = true
+				} else {
+					g.use("io")
+					resp.goType = "io.Reader"
+					resp.mediaType = firstMediaType(ref.Value.Content)
+				}
+			}
+			op.responses = append(op.responses, resp)
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+func (g *generator) serverInterface(group string, operations []*operation) {
+	g.printf("\n// %s is implemented by the operations", group)
+	if group == "DefaultServer" {
+		g.printf(" without tags.\n")
+	} else {
+		g.printf(" tagged with %s first.\n", operations[0].Operation.Tags[0])
+	}
+	g.printf("type %s interface {\n", group)
+	for _, op := range operations {
+		g.printf("// %s handles %s %s.\n", op.name, op.Method, op.Path)
+		if summary := op.Operation.Summary; summary != "" {
+			g.comment(summary)
+		}
+		g.printf("%s(ctx context.Context", op.name)
+		if op.paramsType != "" {
+			g.printf(", params %s", op.paramsType)
+		}
+		if op.body != "" {
+			g.printf(", body %s", op.body)
+		}
+		g.printf(") (*%s, error)\n", op.respType)
+	}
+	g.printf("}\n")
+}
+
+func (g *generator) operationTypes(op *operation) {
+	if op.paramsType != "" {
+		g.printf("\n// %s are the parameters of %s.\n", op.paramsType, op.name)
+		g.printf("type %s struct {\n", op.paramsType)
+		for _, p := range op.params {
+			if p.parameter.Description != "" {
+				g.comment(p.parameter.Description)
+			}
+			tag := p.parameter.Name
+			if !p.parameter.Required {
+				tag += ",omitempty"
+			}
+			g.printf("%s %s `json:%q`\n", p.field, p.goType, tag)
+		}
+		g.printf("}\n")
+	}
+
+	g.printf("\n// %s is a response of %s, returned by the %s* functions.\n", op.respType, op.name, op.name)
+	g.printf("type %s struct {\nopenapi3codegen.Response\n}\n", op.respType)
+	for _, resp := range op.responses {
+		var args []string
+		code := strconv.Itoa(resp.code)
+		if resp.code == 0 {
+			// default or a range of status codes
+			args = append(args, "statusCode int")
+			code = "statusCode"
+		}
+		if resp.goType != "" {
+			args = append(args, "body "+resp.goType)
+		}
+		suffix := strings.ToUpper(resp.status[:1]) + resp.status[1:]
+		fn := g.identifier(op.name + suffix + "Response")
+		g.printf("\n// %s returns the %s response of %s.\n", fn, resp.status, op.name)
+		if description := op.Operation.Responses[resp.status].Value.Description; description != nil && *description != "" {
+			g.comment(*description)
+		}
+		g.printf("func %s(%s) *%s {\n", fn, strings.Join(args, ", "), op.respType)
+		header := "make(http.Header)"
+		if resp.mediaType != "" {
+			header = fmt.Sprintf("http.Header{\"Content-Type\": {%q}}", resp.mediaType)
+		}
+		g.printf("return &%s{openapi3codegen.Response{StatusCode: %s, Header: %s", op.respType, code, header)
+		if resp.goType != "" {
+			g.printf(", Body: body")
+		}
+		g.printf("}}\n}\n")
+	}
+}
+
+func (g *generator) handler(operations []*operation) {
+	g.printf("\n// NewHandler returns an http.Handler routing requests with router, validating them\n")
+	g.printf("// and calling the operations of server.\n")
+	g.printf("func NewHandler(server Server, router routers.Router, opts ...openapi3codegen.HandlerOption) http.Handler {\n")
+	g.printf("return openapi3codegen.NewHandler(router, map[string]openapi3codegen.Operation{\n")
+	for _, op := range operations {
+		g.printf("%q: func(ctx context.Context, req *openapi3codegen.Request) (*openapi3codegen.Response, error) {\n", op.Method+" "+op.Path)
+		args := []string{"ctx"}
+		if op.paramsType != "" {
+			g.printf("var params %s\n", op.paramsType)
+			g.printf("if err := req.DecodeParams(&params); err != nil {\nreturn nil, err\n}\n")
+			args = append(args, "params")
+		}
+		if op.bodyJSON {
+			g.printf("var body %s\n", op.body)
+			g.printf("if err := req.DecodeBody(&body); err != nil {\nreturn nil, err\n}\n")
+			args = append(args, "body")
+		} else if op.body != "" {
+			args = append(args, "req.Input.Request.Body")
+		}
+		g.printf("resp, err := server.%s(%s)\n", op.name, strings.Join(args, ", "))
+		g.printf("if err != nil || resp == nil {\nreturn nil, err\n}\n")
+		g.printf("return &resp.Response, nil\n")
+		g.printf("},\n")
+	}
+	g.printf("}, opts...)\n}\n")
+}
+
+func (g *generator) clientType(operations []*operation) {
+	g.printf("\n// Client sends requests to the operations of the document.\n")
+	g.printf("type Client struct {\nclient *openapi3codegen.Client\n}\n")
+	g.printf("\n// NewClient returns a Client sending the requests to the operations of doc to baseURL,\n")
+	g.printf("// serializing parameters and validating responses according to doc.\n")
+	g.printf("func NewClient(doc *openapi3.T, baseURL string, opts ...openapi3codegen.ClientOption) *Client {\n")
+	g.printf("return &Client{client: openapi3codegen.NewClient(doc, baseURL, opts...)}\n}\n")
+
+	for _, op := range operations {
+		g.printf("\n// %s sends a request to %s %s.\n", op.name, op.Method, op.Path)
+		if summary := op.Operation.Summary; summary != "" {
+			g.comment(summary)
+		}
+		g.printf("func (c *Client) %s(ctx context.Context", op.name)
+		params, body := "nil", "nil"
+		if op.paramsType != "" {
+			g.printf(", params %s", op.paramsType)
+			params = "params"
+		}
+		if op.body != "" {
+			g.printf(", body %s", op.body)
+			body = "body"
+		}
+		g.printf(") (*%s, error) {\n", op.respType)
+		// Exact status codes are matched before ranges such as 2XX, then default
+		var exact, ranges []*response
+		var fallback *response
+		for _, resp := range op.responses {
+			switch {
+			case !resp.json:
+			case resp.code != 0:
+				exact = append(exact, resp)
+			case resp.status == "default":
+				fallback = resp
+			default:
+				ranges = append(ranges, resp)
+			}
+		}
+		if len(exact)+len(ranges) == 0 && fallback == nil {
+			g.printf("resp, err := c.client.Do(ctx, %q, %q, %s, %s, nil)\n", op.Method, op.Path, params, body)
+		} else {
+			g.printf("resp, err := c.client.Do(ctx, %q, %q, %s, %s, func(statusCode int) interface{} {\n", op.Method, op.Path, params, body)
+			g.printf("switch {\n")
+			for _, resp := range exact {
+				g.printf("case statusCode == %d:\nreturn new(%s)\n", resp.code, resp.goType)
+			}
+			for _, resp := range ranges {
+				g.printf("case statusCode/100 == %s:\nreturn new(%s)\n", resp.status[:1], resp.goType)
+			}
+			if fallback != nil {
+				g.printf("default:\nreturn new(%s)\n}\n})\n", fallback.goType)
+			} else {
+				g.printf("}\nreturn nil\n})\n")
+			}
+		}
+		g.printf("if err != nil {\nreturn nil, err\n}\n")
+		g.printf("return &%s{*resp}, nil\n}\n", op.respType)
+	}
+}
+
+func (g *generator) schemaType(name string, schema *openapi3.Schema) {
+	typeName := g.typeNames[name]
+	g.printf("\n// %s is the %s schema.\n", typeName, name)
+	if schema.Description != "" {
+		g.comment(schema.Description)
+	}
+	if schema.Type == "string" && len(schema.Enum) != 0 {
+		g.printf("type %s string\n", typeName)
+		g.printf("\n// Values of %s\nconst (\n", typeName)
+		for _, value := range schema.Enum {
+			if s, ok := value.(string); ok {
+				g.printf("%s %s = %q\n", g.identifier(typeName+goName(s)), typeName, s)
+			}
+		}
+		g.printf(")\n")
+		return
+	}
+	g.visiting[schema] = struct{}{}
+	defer delete(g.visiting, schema)
+	g.printf("type %s %s\n", typeName, g.goType(&openapi3.SchemaRef{Value: schema}))
+}
+
+// goType returns the Go type of values of ref.
+func (g *generator) goType(ref *openapi3.SchemaRef) string {
+	if ref == nil || ref.Value == nil {
+		return "interface{}"
+	}
+	if strings.HasPrefix(ref.Ref, componentSchemaPrefix) {
+		if name, ok := g.typeNames[ref.Ref[len(componentSchemaPrefix):]]; ok {
+			return name
+		}
+	}
+	schema := ref.Value
+	if ref.Ref != "" {
+		// Schemas of other documents are inlined
+		if _, ok := g.visiting[schema]; ok {
+			return "interface{}"
+		}
+		g.visiting[schema] = struct{}{}
+		defer delete(g.visiting, schema)
+	}
+
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.use("time")
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(schema.Items)
+	case "object", "":
+		if len(schema.Properties) != 0 || len(schema.AllOf) != 0 {
+			if t, ok := g.structType(schema); ok {
+				return t
+			}
+			return "interface{}"
+		}
+		if schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + g.goType(schema.AdditionalProperties.Schema)
+		}
+		if schema.Type == "object" {
+			return "map[string]interface{}"
+		}
+	}
+	return "interface{}"
+}
+
+// fieldType returns the Go type of a value of ref, a pointer if it may be missing or null.
+func (g *generator) fieldType(ref *openapi3.SchemaRef, required bool) string {
+	t := g.goType(ref)
+	if isNilable(t) || g.nilable[t] {
+		return t
+	}
+	if !required || (ref != nil && ref.Value != nil && ref.Value.Nullable) {
+		return "*" + t
+	}
+	return t
+}
+
+// structType returns the Go struct type of an object schema, embedding the
+// component schemas of its allOf, and false if schema is not such a schema.
+func (g *generator) structType(schema *openapi3.Schema) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	fields := make(map[string]struct{})
+	if !g.writeFields(&sb, schema, fields) {
+		return "", false
+	}
+	sb.WriteString("}")
+	return sb.String(), true
+}
+
+func (g *generator) writeFields(sb *strings.Builder, schema *openapi3.Schema, fields map[string]struct{}) bool {
+	if schema.Type != "" && schema.Type != "object" {
+		return false
+	}
+	for _, ref := range schema.AllOf {
+		if ref == nil || ref.Value == nil {
+			return false
+		}
+		if t := g.goType(ref); strings.HasPrefix(ref.Ref, componentSchemaPrefix) && t != "interface{}" {
+			if _, ok := g.visiting[ref.Value]; ok {
+				return false
+			}
+			g.visiting[ref.Value] = struct{}{}
+			_, ok := g.structType(ref.Value)
+			delete(g.visiting, ref.Value)
+			if !ok {
+				return false
+			}
+			fields[t] = struct{}{}
+			fmt.Fprintf(sb, "%s\n", t)
+			continue
+		}
+		if !g.writeFields(sb, ref.Value, fields) {
+			return false
+		}
+	}
+	if len(schema.OneOf) != 0 || len(schema.AnyOf) != 0 {
+		return false
+	}
+	for _, name := range sortedPropertyNames(schema) {
+		property := schema.Properties[name]
+		required := false
+		for _, r := range schema.Required {
+			if r == name {
+				required = true
+			}
+		}
+		tag := name
+		if !required {
+			tag += ",omitempty"
+		}
+		if property != nil && property.Value != nil && property.Value.Description != "" {
+			for _, line := range strings.Split(strings.TrimSpace(property.Value.Description), "\n") {
+				fmt.Fprintf(sb, "// %s\n", line)
+			}
+		}
+		fmt.Fprintf(sb, "%s %s `json:%q`\n", uniqueField(goName(name), fields), g.fieldType(property, required), tag)
+	}
+	return true
+}
+
+func (g *generator) comment(text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		g.printf("// %s\n", strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+func isNilable(t string) bool {
+	return t == "interface{}" || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[")
+}
+
+func uniqueField(name string, fields map[string]struct{}) string {
+	field := name
+	for i := 2; ; i++ {
+		if _, ok := fields[field]; !ok {
+			break
+		}
+		field = name + strconv.Itoa(i)
+	}
+	fields[field] = struct{}{}
+	return field
+}
+
+var initialisms = map[string]struct{}{
+	"api": {}, "html": {}, "http": {}, "https": {}, "id": {}, "ip": {},
+	"json": {}, "sql": {}, "uri": {}, "url": {}, "uuid": {}, "xml": {},
+}
+
+// goName returns an exported Go identifier for name, such as PetID for pet_id or petId.
+func goName(name string) string {
+	var parts []string
+	var part []rune
+	var prev rune
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(part) != 0 {
+				parts = append(parts, string(part))
+				part = nil
+			}
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) && len(part) != 0:
+			parts = append(parts, string(part))
+			part = []rune{r}
+		default:
+			part = append(part, r)
+		}
+		prev = r
+	}
+	if len(part) != 0 {
+		parts = append(parts, string(part))
+	}
+
+	var sb strings.Builder
+	for _, part := range parts {
+		if _, ok := initialisms[strings.ToLower(part)]; ok {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	id := sb.String()
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		id = "N" + id
+	}
+	return id
+}
+
+func sortedPropertyNames(schema *openapi3.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func firstMediaType(content openapi3.Content) string {
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names[0]
+}
@@ -0,0 +1,85 @@
+package openapi3codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "petstore", opts: []Option{WithClient(), WithSchemaValidators()}},
+		{name: "validators", opts: []Option{WithoutServer(), WithSchemaValidators()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := openapi3.NewLoader()
+			doc, err := loader.LoadFromFile(fmt.Sprintf("internal/%s/%s.yml", tc.name, tc.name))
+			require.NoError(t, err)
+			require.NoError(t, doc.Validate(loader.Context))
+
+			src, err := Generate(doc, append([]Option{PackageName(tc.name)}, tc.opts...)...)
+			require.NoError(t, err)
+			golden := fmt.Sprintf("internal/%s/%s.gen.go", tc.name, tc.name)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				require.NoError(t, ioutil.WriteFile(golden, src, 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(src))
+		})
+	}
+}
+
+func TestGenerateWithoutServer(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("internal/petstore/petstore.yml")
+	require.NoError(t, err)
+
+	src, err := Generate(doc, PackageName("petstore"), WithClient(), WithoutServer())
+	require.NoError(t, err)
+	require.Contains(t, string(src), "func NewClient(")
+	require.Contains(t, string(src), "func ListPets200Response(")
+	require.NotContains(t, string(src), "type Server interface")
+	require.NotContains(t, string(src), "func NewHandler(")
+	require.NotContains(t, string(src), `"github.com/getkin/kin-openapi/routers"`)
+}
+
+func TestWithSchemaValidators(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("internal/validators/validators.yml")
+	require.NoError(t, err)
+
+	src, err := Generate(doc, WithSchemaValidators("Circle", "Point"))
+	require.NoError(t, err)
+	require.Contains(t, string(src), `"#/components/schemas/Circle": validateCircle,`)
+	require.NotContains(t, string(src), `"#/components/schemas/Polygon"`)
+	// Circle refers to Color
+	require.Contains(t, string(src), "func validateColor(")
+
+	_, err = Generate(doc, WithSchemaValidators("WithDefault"))
+	require.EqualError(t, err, `component schema "WithDefault" cannot be compiled`)
+	_, err = Generate(doc, WithSchemaValidators("Square"))
+	require.EqualError(t, err, `no component schema "Square"`)
+}
+
+func TestGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"pet_id":           "PetID",
+		"petId":            "PetID",
+		"listPets":         "ListPets",
+		"X-Request-Id":     "XRequestID",
+		"get /pets/{name}": "GetPetsName",
+		"HTTPServer":       "HTTPServer",
+		"2fa":              "N2fa",
+	} {
+		require.Equal(t, expected, goName(name), name)
+	}
+}
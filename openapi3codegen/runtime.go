@@ -0,0 +1,217 @@
+package openapi3codegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Operation handles a validated request routed to an operation of the document
+// a handler was generated from.
+type Operation func(ctx context.Context, req *Request) (*Response, error)
+
+// Request is a request routed to an operation and validated by a generated handler.
+type Request struct {
+	Input *openapi3filter.RequestValidationInput
+}
+
+// DecodeParams sets params, a pointer to a struct whose fields are tagged with the
+// names of the operation's parameters, to the parameters of the request.
+func (req *Request) DecodeParams(params interface{}) error {
+	input := req.Input
+	values := make(map[string]interface{})
+	for _, parameter := range routeParameters(input.Route) {
+		value, _, err := openapi3filter.DecodeParameter(input, parameter)
+		if err != nil {
+			return &openapi3filter.RequestError{Input: input, Parameter: parameter, Err: err}
+		}
+		if value != nil {
+			values[parameter.Name] = value
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, params)
+}
+
+// DecodeBody sets body, a pointer, to the JSON body of the request.
+// body is left unchanged when the request has no body.
+func (req *Request) DecodeBody(body interface{}) error {
+	r := req.Input.Request
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, body); err != nil {
+		requestError := &openapi3filter.RequestError{Input: req.Input, Reason: "failed to decode request body", Err: err}
+		if ref := req.Input.Route.Operation.RequestBody; ref != nil {
+			requestError.RequestBody = ref.Value
+		}
+		return requestError
+	}
+	return nil
+}
+
+// routeParameters returns the parameters of the operation of route,
+// including those of its path item it does not override.
+func routeParameters(route *routers.Route) []*openapi3.Parameter {
+	var parameters []*openapi3.Parameter
+	for _, ref := range route.Operation.Parameters {
+		if ref != nil && ref.Value != nil {
+			parameters = append(parameters, ref.Value)
+		}
+	}
+	for _, ref := range route.PathItem.Parameters {
+		if ref != nil && ref.Value != nil && route.Operation.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) == nil {
+			parameters = append(parameters, ref.Value)
+		}
+	}
+	return parameters
+}
+
+// Response is a response of an operation.
+// Body is written as is when it is an io.Reader or a []byte, otherwise encoded as JSON.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       interface{}
+}
+
+// HandlerOption configures a generated handler.
+type HandlerOption func(*handler)
+
+// ValidationOptions sets the options requests are validated with.
+func ValidationOptions(options *openapi3filter.Options) HandlerOption {
+	return func(h *handler) { h.options = options }
+}
+
+// ErrorEncoder sets the function writing the routing and validation errors of requests
+// and the errors operations return.
+// By default these are written by an openapi3filter.ValidationErrorEncoder
+// wrapping openapi3filter.DefaultErrorEncoder.
+func ErrorEncoder(encoder openapi3filter.ErrorEncoder) HandlerOption {
+	return func(h *handler) { h.errorEncoder = encoder }
+}
+
+type handler struct {
+	router       routers.Router
+	operations   map[string]Operation
+	options      *openapi3filter.Options
+	errorEncoder openapi3filter.ErrorEncoder
+}
+
+// NewHandler returns an http.Handler routing requests with router, validating them
+// and calling operations, keyed by upper case method and path, for example "GET /pets".
+// It is called by generated NewHandler functions.
+func NewHandler(router routers.Router, operations map[string]Operation, opts ...HandlerOption) http.Handler {
+	h := &handler{
+		router:       router,
+		operations:   operations,
+		errorEncoder: (&openapi3filter.ValidationErrorEncoder{Encoder: openapi3filter.DefaultErrorEncoder}).Encode,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	route, pathParams, err := h.router.FindRoute(r)
+	if err != nil {
+		h.errorEncoder(ctx, err, w)
+		return
+	}
+	operation, ok := h.operations[strings.ToUpper(route.Method)+" "+route.Path]
+	if !ok || route.Operation == nil {
+		h.errorEncoder(ctx, &notImplementedError{method: route.Method, path: route.Path}, w)
+		return
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    h.options,
+	}
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		h.errorEncoder(ctx, err, w)
+		return
+	}
+
+	resp, err := operation(ctx, &Request{Input: input})
+	if err == nil && resp == nil {
+		err = fmt.Errorf("operation %s %s returned no response", route.Method, route.Path)
+	}
+	if err != nil {
+		h.errorEncoder(ctx, err, w)
+		return
+	}
+	if err := writeResponse(w, resp); err != nil {
+		h.errorEncoder(ctx, err, w)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, resp *Response) error {
+	var body io.Reader
+	switch v := resp.Body.(type) {
+	case nil:
+	case io.Reader:
+		body = v
+	case []byte:
+		body = bytes.NewReader(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+		if resp.Header.Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+	}
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	if body != nil {
+		// The response is already started
+		_, _ = io.Copy(w, body)
+	}
+	return nil
+}
+
+type notImplementedError struct {
+	method, path string
+}
+
+func (err *notImplementedError) Error() string {
+	return fmt.Sprintf("operation %s %s is not implemented", err.method, err.path)
+}
+
+func (err *notImplementedError) StatusCode() int { return http.StatusNotImplemented }
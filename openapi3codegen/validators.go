@@ -0,0 +1,424 @@
+package openapi3codegen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// WithSchemaValidators makes Generate compile the component schemas of names,
+// or all those that can be compiled when no name is given, into Go functions
+// reporting whether a value decoded from JSON matches them.
+// They are emitted in a SchemaValidators variable to set as the
+// SchemaValidators of the openapi3filter.Options requests and responses
+// are validated with.
+//
+// Schemas with defaults, readOnly or writeOnly properties, discriminators,
+// uniqueItems, string formats or contentEncoding and contentMediaType cannot be
+// compiled, nor those referring to such schemas: string formats and contents are
+// checked with settings of the validation, such as its clock, that compiled validators
+// cannot honor.
+func WithSchemaValidators(names ...string) Option {
+	return func(g *generator) {
+		g.validators = true
+		g.validatorNames = names
+	}
+}
+
+// validatorGenerator emits the functions of compiled schemas.
+type validatorGenerator struct {
+	*generator
+	// funcs are the names of the functions of the component schemas being compiled
+	funcs map[*openapi3.Schema]string
+	// compilable caches whether schemas can be compiled
+	compilable map[*openapi3.Schema]bool
+	pending    []pendingValidator
+	// patterns are the regular expressions of the function being emitted
+	patterns []pendingPattern
+}
+
+type pendingPattern struct {
+	name, pattern string
+}
+
+type pendingValidator struct {
+	name   string
+	schema *openapi3.Schema
+}
+
+func (g *generator) schemaValidators(doc *openapi3.T) error {
+	v := &validatorGenerator{
+		generator:  g,
+		funcs:      make(map[*openapi3.Schema]string),
+		compilable: make(map[*openapi3.Schema]bool),
+	}
+
+	names := g.validatorNames
+	if len(names) == 0 {
+		for name, ref := range doc.Components.Schemas {
+			if ref != nil && ref.Value != nil && v.canCompile(ref.Value) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	refs := make([]string, 0, len(names))
+	funcs := make(map[string]string, len(names))
+	for _, name := range names {
+		ref := doc.Components.Schemas[name]
+		if ref == nil || ref.Value == nil {
+			return fmt.Errorf("no component schema %q", name)
+		}
+		if !v.canCompile(ref.Value) {
+			return fmt.Errorf("component schema %q cannot be compiled", name)
+		}
+		refs = append(refs, componentSchemaPrefix+name)
+		funcs[componentSchemaPrefix+name] = v.function(ref.Value, g.typeNames[name])
+	}
+
+	g.use("github.com/getkin/kin-openapi/openapi3filter")
+	g.printf("\n// SchemaValidators are the validators compiled from the component schemas\n")
+	g.printf("// of the document, keyed by their reference.\n")
+	g.printf("var SchemaValidators = map[string]openapi3filter.SchemaValidator{\n")
+	for _, ref := range refs {
+		g.printf("%q: %s,\n", ref, funcs[ref])
+	}
+	g.printf("}\n")
+
+	for len(v.pending) != 0 {
+		p := v.pending[0]
+		v.pending = v.pending[1:]
+		v.emit(p.name, p.schema)
+	}
+	return nil
+}
+
+// canCompile reports whether schema and the schemas it refers to can be compiled.
+func (v *validatorGenerator) canCompile(schema *openapi3.Schema) bool {
+	if ok, found := v.compilable[schema]; found {
+		return ok
+	}
+	// Cycles are assumed compilable until proven otherwise
+	v.compilable[schema] = true
+	ok := v.canCompileNode(schema)
+	v.compilable[schema] = ok
+	return ok
+}
+
+func (v *validatorGenerator) canCompileNode(schema *openapi3.Schema) bool {
+	if schema.Default != nil || schema.ReadOnly || schema.WriteOnly ||
+		schema.Discriminator != nil || schema.UniqueItems || schema.HasConst() || schema.Contains != nil || schema.If != nil || len(schema.PatternProperties) != 0 || schema.PropertyNames != nil ||
+		(schema.ExclusiveMin && schema.Min == nil) || (schema.ExclusiveMax && schema.Max == nil) {
+		return false
+	}
+	// Validation checks the other formats and the content of strings with settings, such as
+	// its clock and EnableFormatValidation, that compiled validators cannot honor
+	switch schema.Format {
+	case "", "int32", "int64", "float", "double":
+	default:
+		return false
+	}
+	if schema.ContentEncoding != "" || schema.ContentMediaType != "" {
+		return false
+	}
+	if schema.Pattern != "" {
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			return false
+		}
+	}
+	for _, value := range schema.Enum {
+		switch value.(type) {
+		case nil, bool, float64, string:
+		default:
+			return false
+		}
+	}
+	refs := make([]*openapi3.SchemaRef, 0, len(schema.Properties)+len(schema.OneOf)+len(schema.AnyOf)+len(schema.AllOf)+3)
+	refs = append(refs, schema.Not, schema.Items, schema.AdditionalProperties.Schema)
+	for _, ref := range schema.Properties {
+		refs = append(refs, ref)
+	}
+	refs = append(refs, schema.OneOf...)
+	refs = append(refs, schema.AnyOf...)
+	refs = append(refs, schema.AllOf...)
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		if ref.Value == nil || !v.canCompile(ref.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// function returns the name of the function validating schema,
+// queuing it for emission if needed.
+func (v *validatorGenerator) function(schema *openapi3.Schema, typeName string) string {
+	if name, ok := v.funcs[schema]; ok {
+		return name
+	}
+	name := v.identifier("validate" + typeName)
+	v.funcs[schema] = name
+	v.pending = append(v.pending, pendingValidator{name: name, schema: schema})
+	return name
+}
+
+// subfunction returns the name of the function validating the schema of ref,
+// a schema nested at location, such as "Items", in the schema validated by parent.
+func (v *validatorGenerator) subfunction(ref *openapi3.SchemaRef, parent, location string) string {
+	if name, ok := v.funcs[ref.Value]; ok {
+		return name
+	}
+	if typeName, ok := v.typeNames[componentSchemaName(ref.Ref)]; ok {
+		return v.function(ref.Value, typeName)
+	}
+	name := v.identifier(parent + location)
+	v.funcs[ref.Value] = name
+	v.pending = append(v.pending, pendingValidator{name: name, schema: ref.Value})
+	return name
+}
+
+func componentSchemaName(ref string) string {
+	if len(ref) > len(componentSchemaPrefix) && ref[:len(componentSchemaPrefix)] == componentSchemaPrefix {
+		return ref[len(componentSchemaPrefix):]
+	}
+	return ""
+}
+
+// emit writes the function name, the compiled form of schema.VisitJSON.
+func (v *validatorGenerator) emit(name string, schema *openapi3.Schema) {
+	defer v.emitPatterns()
+	v.printf("\nfunc %s(v interface{}) bool {\n", name)
+	v.printf("if v == nil {\nreturn %t\n}\n", schema.Nullable)
+	if schema.IsEmpty() {
+		v.printf("return true\n}\n")
+		return
+	}
+
+	if len(schema.Enum) != 0 {
+		var values []string
+		seen := make(map[string]struct{}, len(schema.Enum))
+		for _, value := range schema.Enum {
+			var s string
+			switch value := value.(type) {
+			case nil:
+				continue
+			case float64:
+				s = "float64(" + formatFloat(value) + ")"
+			default:
+				s = fmt.Sprintf("%#v", value)
+			}
+			if _, ok := seen[s]; !ok {
+				seen[s] = struct{}{}
+				values = append(values, s)
+			}
+		}
+		if len(values) == 0 {
+			// Only null is allowed
+			v.printf("return false\n}\n")
+			return
+		}
+		v.printf("switch v {\ncase %s:\ndefault:\nreturn false\n}\n", strings.Join(values, ", "))
+	}
+	if ref := schema.Not; ref != nil {
+		v.printf("if %s(v) {\nreturn false\n}\n", v.subfunction(ref, name, "Not"))
+	}
+	if len(schema.OneOf) != 0 {
+		v.printf("matches := 0\n")
+		for i, ref := range schema.OneOf {
+			v.printf("if %s(v) {\nmatches++\n}\n", v.subfunction(ref, name, "OneOf"+strconv.Itoa(i)))
+		}
+		v.printf("if matches != 1 {\nreturn false\n}\n")
+	}
+	if len(schema.AnyOf) != 0 {
+		v.printf("if !(")
+		for i, ref := range schema.AnyOf {
+			if i != 0 {
+				v.printf(" || ")
+			}
+			v.printf("%s(v)", v.subfunction(ref, name, "AnyOf"+strconv.Itoa(i)))
+		}
+		v.printf(") {\nreturn false\n}\n")
+	}
+	for i, ref := range schema.AllOf {
+		v.printf("if !%s(v) {\nreturn false\n}\n", v.subfunction(ref, name, "AllOf"+strconv.Itoa(i)))
+	}
+
+	typ := schema.Type
+	numeric := typ == "" || typ == openapi3.TypeNumber || typ == openapi3.TypeInteger
+	numberChecks := numeric && hasNumberChecks(schema)
+	stringCase := typ == "" || typ == openapi3.TypeString
+	arrayCase := typ == "" || typ == openapi3.TypeArray
+	objectCase := typ == "" || typ == openapi3.TypeObject
+	if numberChecks {
+		v.printf("var n float64\n")
+	}
+	if numeric || (stringCase && hasStringChecks(schema)) || (arrayCase && hasArrayChecks(schema)) || (objectCase && hasObjectChecks(schema)) {
+		v.printf("switch v := v.(type) {\n")
+	} else {
+		v.printf("switch v.(type) {\n")
+	}
+	if typ == "" || typ == openapi3.TypeBoolean {
+		v.printf("case bool:\nreturn true\n")
+	}
+	if numeric {
+		v.use("math")
+		if numberChecks {
+			v.printf("case float64:\nif math.IsNaN(v) || math.IsInf(v, 0) {\nreturn false\n}\nn = v\n")
+			v.printf("case int:\nn = float64(v)\n")
+			v.printf("case int32:\nn = float64(v)\n")
+			v.printf("case int64:\nn = float64(v)\n")
+		} else {
+			v.printf("case float64:\nreturn !math.IsNaN(v) && !math.IsInf(v, 0)\n")
+			v.printf("case int, int32, int64:\nreturn true\n")
+		}
+	}
+	if stringCase {
+		v.printf("case string:\n")
+		v.stringChecks(name, schema)
+		v.printf("return true\n")
+	}
+	if arrayCase {
+		v.printf("case []interface{}:\n")
+		v.arrayChecks(name, schema)
+		v.printf("return true\n")
+	}
+	if objectCase {
+		v.printf("case map[string]interface{}:\n")
+		v.objectChecks(name, schema)
+		v.printf("return true\n")
+	}
+	v.printf("default:\nreturn false\n}\n")
+	if numberChecks {
+		v.numberChecks(schema)
+		v.printf("return true\n")
+	}
+	v.printf("}\n")
+}
+
+func hasNumberChecks(schema *openapi3.Schema) bool {
+	return schema.Type == openapi3.TypeInteger || schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil
+}
+
+func hasStringChecks(schema *openapi3.Schema) bool {
+	return schema.MinLength != 0 || schema.MaxLength != nil || schema.Pattern != ""
+}
+
+func hasArrayChecks(schema *openapi3.Schema) bool {
+	return schema.MinItems != 0 || schema.MaxItems != nil || (schema.Items != nil && !schema.Items.Value.IsEmpty())
+}
+
+func hasObjectChecks(schema *openapi3.Schema) bool {
+	return schema.MinProps != 0 || schema.MaxProps != nil || len(schema.Required) != 0 ||
+		len(schema.Properties) != 0 || schema.AdditionalProperties.Schema != nil || !schema.AdditionalProperties.Allowed()
+}
+
+func (v *validatorGenerator) numberChecks(schema *openapi3.Schema) {
+	if schema.Type == openapi3.TypeInteger {
+		v.printf("if n != math.Trunc(n) {\nreturn false\n}\n")
+		switch schema.Format {
+		case "int32":
+			v.printf("if n < math.MinInt32 || n > math.MaxInt32 {\nreturn false\n}\n")
+		case "int64":
+			v.printf("if n < math.MinInt64 || n > math.MaxInt64 {\nreturn false\n}\n")
+		}
+	}
+	if schema.ExclusiveMin {
+		v.printf("if n <= %s {\nreturn false\n}\n", formatFloat(*schema.Min))
+	}
+	if schema.ExclusiveMax {
+		v.printf("if n >= %s {\nreturn false\n}\n", formatFloat(*schema.Max))
+	}
+	if schema.Min != nil {
+		v.printf("if n < %s {\nreturn false\n}\n", formatFloat(*schema.Min))
+	}
+	if schema.Max != nil {
+		v.printf("if n > %s {\nreturn false\n}\n", formatFloat(*schema.Max))
+	}
+	if schema.MultipleOf != nil {
+		v.printf("if q := n / %s; q != math.Trunc(q) {\nreturn false\n}\n", formatFloat(*schema.MultipleOf))
+	}
+}
+
+func (v *validatorGenerator) stringChecks(name string, schema *openapi3.Schema) {
+	if schema.MinLength != 0 || schema.MaxLength != nil {
+		v.use("unicode/utf8")
+		v.printf("length := utf8.RuneCountInString(v)\n")
+		if schema.MinLength != 0 {
+			v.printf("if length < %d {\nreturn false\n}\n", schema.MinLength)
+		}
+		if schema.MaxLength != nil {
+			v.printf("if length > %d {\nreturn false\n}\n", *schema.MaxLength)
+		}
+	}
+	if schema.Pattern != "" {
+		v.use("regexp")
+		pattern := v.identifier(name + "Pattern")
+		v.patterns = append(v.patterns, pendingPattern{name: pattern, pattern: schema.Pattern})
+		v.printf("if !%s.MatchString(v) {\nreturn false\n}\n", pattern)
+	}
+}
+
+func (v *validatorGenerator) arrayChecks(name string, schema *openapi3.Schema) {
+	if schema.MinItems != 0 {
+		v.printf("if len(v) < %d {\nreturn false\n}\n", schema.MinItems)
+	}
+	if schema.MaxItems != nil {
+		v.printf("if len(v) > %d {\nreturn false\n}\n", *schema.MaxItems)
+	}
+	if ref := schema.Items; ref != nil && !ref.Value.IsEmpty() {
+		v.printf("for _, item := range v {\nif !%s(item) {\nreturn false\n}\n}\n", v.subfunction(ref, name, "Items"))
+	}
+}
+
+func (v *validatorGenerator) objectChecks(name string, schema *openapi3.Schema) {
+	if schema.MinProps != 0 {
+		v.printf("if len(v) < %d {\nreturn false\n}\n", schema.MinProps)
+	}
+	if schema.MaxProps != nil {
+		v.printf("if len(v) > %d {\nreturn false\n}\n", *schema.MaxProps)
+	}
+	for _, property := range schema.Required {
+		v.printf("if _, ok := v[%q]; !ok {\nreturn false\n}\n", property)
+	}
+
+	additional := schema.AdditionalProperties.Schema
+	allowed := schema.AdditionalProperties.Allowed()
+	if len(schema.Properties) == 0 {
+		switch {
+		case additional != nil:
+			v.printf("for _, value := range v {\nif !%s(value) {\nreturn false\n}\n}\n", v.subfunction(additional, name, "AdditionalProperties"))
+		case !allowed:
+			v.printf("if len(v) != 0 {\nreturn false\n}\n")
+		}
+		return
+	}
+	v.printf("for key, value := range v {\nswitch key {\n")
+	for _, property := range sortedPropertyNames(schema) {
+		v.printf("case %q:\nif !%s(value) {\nreturn false\n}\n", property, v.subfunction(schema.Properties[property], name, goName(property)))
+	}
+	switch {
+	case additional != nil:
+		v.printf("default:\nif !%s(value) {\nreturn false\n}\n", v.subfunction(additional, name, "AdditionalProperties"))
+	case !allowed:
+		v.printf("default:\nreturn false\n")
+	}
+	v.printf("}\n}\n")
+}
+
+func (v *validatorGenerator) emitPatterns() {
+	for _, p := range v.patterns {
+		v.printf("\nvar %s = regexp.MustCompile(%q)\n", p.name, p.pattern)
+	}
+	v.patterns = v.patterns[:0]
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,138 @@
+package openapi3filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// BodyRedaction is what the errors of ValidateRequest and ValidateResponse show
+// of the bodies they are about.
+type BodyRedaction int
+
+const (
+	// ShowBody keeps the values of bodies in errors.
+	ShowBody BodyRedaction = iota
+	// TruncateBody truncates the values of bodies the schema errors show
+	// to Options.MaxBodyErrorLength bytes of their JSON encoding.
+	TruncateBody
+	// RedactBody removes the values of bodies from errors: schema errors only tell
+	// where bodies do not match their schema and which keyword they fail,
+	// and the errors decoding bodies are replaced with errors wrapping them
+	// whose message is that of ErrBodyRedacted, see errors.Is.
+	RedactBody
+)
+
+// defaultMaxBodyErrorLength is the length values are truncated to with TruncateBody.
+const defaultMaxBodyErrorLength = 256
+
+// ErrBodyRedacted matches the errors decoding bodies redacted with RedactBody.
+var ErrBodyRedacted = redactedError{}
+
+// redactedError hides the message of an error about a body, wrapping it.
+type redactedError struct {
+	err error
+}
+
+func (err redactedError) Error() string {
+	return "details redacted"
+}
+
+func (err redactedError) Is(target error) bool {
+	_, ok := target.(redactedError)
+	return ok
+}
+
+func (err redactedError) Unwrap() error {
+	return err.err
+}
+
+// bodyRedaction returns the redaction of the bodies with header.
+func (options *Options) bodyRedaction(header http.Header) BodyRedaction {
+	if len(options.BodyRedactionByMediaType) != 0 {
+		if mediaType, _, err := mime.ParseMediaType(header.Get(headerCT)); err == nil {
+			if redaction, ok := options.BodyRedactionByMediaType[mediaType]; ok {
+				return redaction
+			}
+		}
+	}
+	return options.BodyRedaction
+}
+
+// bodySchemaValidationOptions returns the options of the validation of bodies with redaction.
+func bodySchemaValidationOptions(redaction BodyRedaction) []openapi3.SchemaValidationOption {
+	if redaction != RedactBody {
+		return nil
+	}
+	return []openapi3.SchemaValidationOption{openapi3.SetSchemaErrorMessageCustomizer(redactedSchemaErrorMessage)}
+}
+
+// redactBodyError applies redaction to err, an error decoding or validating a body.
+func (options *Options) redactBodyError(err error, redaction BodyRedaction) error {
+	switch redaction {
+	case TruncateBody:
+		max := options.MaxBodyErrorLength
+		if max <= 0 {
+			max = defaultMaxBodyErrorLength
+		}
+		visitSchemaErrors(err, func(e *openapi3.SchemaError) {
+			e.Value = truncatedValue(e.Value, max)
+		})
+	case RedactBody:
+		redacted := false
+		visitSchemaErrors(err, func(e *openapi3.SchemaError) {
+			e.Value, redacted = nil, true
+		})
+		if !redacted {
+			return redactedError{err: err}
+		}
+	}
+	return err
+}
+
+// visitSchemaErrors calls f with the schema errors err is made of.
+func visitSchemaErrors(err error, f func(*openapi3.SchemaError)) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, err := range e {
+			visitSchemaErrors(err, f)
+		}
+	case *openapi3.SchemaError:
+		f(e)
+		if e.Origin != nil {
+			visitSchemaErrors(e.Origin, f)
+		}
+	case interface{ Unwrap() error }:
+		if err := e.Unwrap(); err != nil {
+			visitSchemaErrors(err, f)
+		}
+	}
+}
+
+// truncatedValue returns value, or the first max bytes of its JSON encoding if longer.
+func truncatedValue(value interface{}, max int) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil || len(data) <= max {
+		return value
+	}
+	n := len(data)
+	data = data[:max]
+	for len(data) > 0 && !utf8.Valid(data) {
+		data = data[:len(data)-1]
+	}
+	return fmt.Sprintf("%s... (%d bytes)", data, n)
+}
+
+// redactedSchemaErrorMessage is the message of the schema errors of bodies redacted with RedactBody.
+func redactedSchemaErrorMessage(err *openapi3.SchemaError) string {
+	msg := fmt.Sprintf("Doesn't match schema %q", err.SchemaField)
+	if pointer := err.JSONPointer(); len(pointer) != 0 {
+		return fmt.Sprintf(`Error at "/%s": %s`, strings.Join(pointer, "/"), msg)
+	}
+	return msg
+}
@@ -0,0 +1,97 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestBodyRedaction(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Users
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                password: {type: string, pattern: '^[a-z]+$'}
+                bio: {type: string, maxLength: 10}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	validate := func(options *Options, contentType, body string) (requestErr, responseErr error) {
+		req, err := http.NewRequest(http.MethodPost, "http://example.com/users", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		requestErr = ValidateRequest(context.Background(), input)
+		responseErr = ValidateResponse(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 http.StatusCreated,
+			Header:                 http.Header{"Content-Type": {contentType}},
+			Body:                   ioutil.NopCloser(strings.NewReader(body)),
+			Options:                options,
+		})
+		return
+	}
+	body := `{"password": "Secret123", "bio": "` + strings.Repeat("x", 300) + `"}`
+
+	requestErr, responseErr := validate(&Options{MultiError: true}, "application/json", body)
+	require.Contains(t, requestErr.Error(), "Secret123")
+	require.Contains(t, responseErr.Error(), "Secret123")
+
+	requestErr, responseErr = validate(&Options{BodyRedaction: TruncateBody, MaxBodyErrorLength: 32, MultiError: true}, "application/json", body)
+	require.Contains(t, requestErr.Error(), `"Secret123"`)
+	require.Contains(t, requestErr.Error(), `xxx... (302 bytes)`)
+	require.NotContains(t, requestErr.Error(), strings.Repeat("x", 40))
+	require.Contains(t, responseErr.Error(), `... (333 bytes)`)
+
+	redacted := &Options{
+		BodyRedaction:            ShowBody,
+		BodyRedactionByMediaType: map[string]BodyRedaction{"application/json": RedactBody},
+		MultiError:               true,
+	}
+	requestErr, responseErr = validate(redacted, "application/json; charset=utf-8", body)
+	require.EqualError(t, requestErr, `request body has an error: doesn't match schema: `+
+		`Error at "/bio": Doesn't match schema "maxLength" | Error at "/password": Doesn't match schema "pattern"`)
+	var schemaErr *openapi3.SchemaError
+	require.ErrorAs(t, requestErr, &schemaErr)
+	require.Nil(t, schemaErr.Value)
+	require.EqualError(t, responseErr, `response body doesn't match schema: Error at "/id": Doesn't match schema "required"`)
+
+	requestErr, _ = validate(redacted, "application/json", `{"password": "Secret123"`)
+	require.EqualError(t, requestErr, "request body has an error: failed to decode request body: details redacted")
+	require.True(t, errors.Is(requestErr, ErrBodyRedacted))
+	var parseErr *ParseError
+	require.ErrorAs(t, requestErr, &parseErr)
+}
@@ -0,0 +1,94 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestClock(t *testing.T) {
+	openapi3.RegisterTimeFormatValidator("past-date-time", func(value interface{}, now time.Time) error {
+		s, _ := value.(string)
+		if at, err := time.Parse(time.RFC3339, s); err != nil || at.After(now) {
+			return errors.New("not in the past")
+		}
+		return nil
+	})
+	defer openapi3.UnregisterFormatValidator("past-date-time")
+
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Events
+paths:
+  /events:
+    post:
+      parameters:
+        - name: since
+          in: query
+          schema: {type: string, format: past-date-time}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                at: {type: string, format: past-date-time}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  at: {type: string, format: past-date-time}
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	options := &Options{Clock: func() time.Time { return now }}
+	validate := func(query, body string) error {
+		req, err := http.NewRequest(http.MethodPost, "http://example.com/events"+query, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		if err := ValidateRequest(context.Background(), input); err != nil {
+			return err
+		}
+		return ValidateResponse(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 http.StatusCreated,
+			Header:                 http.Header{"Content-Type": {"application/json"}},
+			Body:                   ioutil.NopCloser(strings.NewReader(body)),
+			Options:                options,
+		})
+	}
+
+	require.NoError(t, validate("?since=2019-12-31T00:00:00Z", `{"at": "2019-12-31T00:00:00Z"}`))
+	err = validate("?since=2020-06-01T00:00:00Z", `{}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `parameter "since" in query has an error`)
+	err = validate("", `{"at": "2020-06-01T00:00:00Z"}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "request body has an error")
+
+	// Time goes on
+	now = now.AddDate(1, 0, 0)
+	require.NoError(t, validate("?since=2020-06-01T00:00:00Z", `{"at": "2020-06-01T00:00:00Z"}`))
+}
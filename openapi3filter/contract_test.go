@@ -0,0 +1,110 @@
+package openapi3filter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestEnforceContract(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Contract'
+  version: 0.0.1
+paths:
+  /echo:
+    post:
+      x-max-request-bytes: 8
+      x-max-response-bytes: 8
+      x-timeout-ms: 50
+      requestBody:
+        content:
+          text/plain:
+            schema: {type: string}
+      responses:
+        '200':
+          description: The request body.
+          content:
+            text/plain:
+              schema: {type: string}
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	validate := func(body string, options *Options) (error, error) {
+		req, err := http.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "text/plain")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		reqErr := ValidateRequest(context.Background(), input)
+		respInput := &ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 http.StatusOK,
+			Header:                 http.Header{"Content-Type": {"text/plain"}},
+			Body:                   ioutil.NopCloser(strings.NewReader(body)),
+			Options:                options,
+		}
+		return reqErr, ValidateResponse(context.Background(), respInput)
+	}
+
+	reqErr, respErr := validate("too large", &Options{})
+	require.NoError(t, reqErr)
+	require.NoError(t, respErr)
+
+	options := &Options{EnforceContract: true}
+	reqErr, respErr = validate("small", options)
+	require.NoError(t, reqErr)
+	require.NoError(t, respErr)
+
+	reqErr, respErr = validate("too large", options)
+	require.EqualError(t, reqErr, "request body has an error: body of 9 bytes is larger than the 8 bytes allowed")
+	require.EqualError(t, respErr, "response body of 9 bytes is larger than the 8 bytes allowed")
+
+	var code ErrCode
+	handler := NewValidator(router, Strict(true), ValidationOptions(*options),
+		OnErr(func(w http.ResponseWriter, status int, c ErrCode, err error) {
+			code = c
+			http.Error(w, err.Error(), status)
+		}),
+		OnLog(func(string, error) {}),
+	).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			<-r.Context().Done()
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("hi"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok", rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/echo?slow=1", strings.NewReader("hi"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(rec, req)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, ErrCode(ErrCodeResponseInvalid), code)
+	require.Contains(t, rec.Body.String(), "handling the request took longer than the 50ms allowed")
+}
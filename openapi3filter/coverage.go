@@ -0,0 +1,328 @@
+package openapi3filter
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Coverage records which parts of a document validated traffic exercised:
+// operations, response statuses, request and response content types and
+// the oneOf and anyOf alternatives of schemas.
+//
+// Set it as Options.Coverage so that ValidateRequest and ValidateResponse
+// record what successfully validated. It is safe for concurrent use.
+type Coverage struct {
+	mu sync.Mutex
+
+	operations map[string]*operationCoverage
+	// operation keys in the order of the document
+	operationKeys []string
+
+	// JSON pointers of the schemas with alternatives
+	schemaPointers map[*openapi3.Schema]string
+	branches       map[string]int
+	branchKeys     []string
+}
+
+type operationCoverage struct {
+	method, path        string
+	requests            int
+	requestContentTypes map[string]int
+	responses           map[string]*responseCoverage
+}
+
+type responseCoverage struct {
+	count        int
+	contentTypes map[string]int
+}
+
+// NewCoverage returns a Coverage tracking the operations and schemas of doc.
+func NewCoverage(doc *openapi3.T) *Coverage {
+	c := &Coverage{
+		operations:     make(map[string]*operationCoverage),
+		schemaPointers: make(map[*openapi3.Schema]string),
+		branches:       make(map[string]int),
+	}
+	for _, info := range doc.Operations() {
+		operation := &operationCoverage{
+			method:              info.Method,
+			path:                info.Path,
+			requestContentTypes: make(map[string]int),
+			responses:           make(map[string]*responseCoverage),
+		}
+		if ref := info.Operation.RequestBody; ref != nil && ref.Value != nil {
+			for contentType := range ref.Value.Content {
+				operation.requestContentTypes[contentType] = 0
+			}
+		}
+		for status, ref := range info.Operation.Responses {
+			response := &responseCoverage{contentTypes: make(map[string]int)}
+			if ref != nil && ref.Value != nil {
+				for contentType := range ref.Value.Content {
+					response.contentTypes[contentType] = 0
+				}
+			}
+			operation.responses[status] = response
+		}
+		key := info.Method + " " + info.Path
+		c.operations[key] = operation
+		c.operationKeys = append(c.operationKeys, key)
+	}
+
+	// The walk function never fails
+	_ = openapi3.Walk(doc, func(pointer string, value, parent interface{}) error {
+		ref, ok := value.(*openapi3.SchemaRef)
+		if !ok || ref.Value == nil {
+			return nil
+		}
+		schema := ref.Value
+		if _, ok := c.schemaPointers[schema]; ok || (len(schema.OneOf) == 0 && len(schema.AnyOf) == 0) {
+			return nil
+		}
+		c.schemaPointers[schema] = pointer
+		for _, keyword := range []string{"oneOf", "anyOf"} {
+			alternatives := schema.OneOf
+			if keyword == "anyOf" {
+				alternatives = schema.AnyOf
+			}
+			for i := range alternatives {
+				key := branchKey(pointer, keyword, i)
+				c.branches[key] = 0
+				c.branchKeys = append(c.branchKeys, key)
+			}
+		}
+		return nil
+	})
+	return c
+}
+
+func branchKey(pointer, keyword string, index int) string {
+	return pointer + "/" + keyword + "/" + strconv.Itoa(index)
+}
+
+func (c *Coverage) operation(input *RequestValidationInput) *operationCoverage {
+	if input == nil || input.Route == nil {
+		return nil
+	}
+	return c.operations[input.Route.Method+" "+input.Route.Path]
+}
+
+func (c *Coverage) recordRequest(input *RequestValidationInput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	operation := c.operation(input)
+	if operation == nil {
+		return
+	}
+	operation.requests++
+	if ref := input.Route.Operation.RequestBody; ref != nil && ref.Value != nil && input.Request.Header.Get(headerCT) != "" {
+		content := ref.Value.Content
+		if contentType, ok := contentKey(content, content.Get(input.Request.Header.Get(headerCT))); ok {
+			operation.requestContentTypes[contentType]++
+		}
+	}
+}
+
+func (c *Coverage) recordResponse(input *ResponseValidationInput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	operation := c.operation(input.RequestValidationInput)
+	if operation == nil {
+		return
+	}
+	_, status := input.RequestValidationInput.Route.Operation.Responses.FindFor(input.Status)
+	response, ok := operation.responses[status]
+	if !ok {
+		return
+	}
+	response.count++
+	if ref := input.RequestValidationInput.Route.Operation.Responses[status]; ref != nil && ref.Value != nil && input.Header.Get(headerCT) != "" {
+		content := ref.Value.Content
+		if contentType, ok := contentKey(content, content.Get(input.Header.Get(headerCT))); ok {
+			response.contentTypes[contentType]++
+		}
+	}
+}
+
+func (c *Coverage) recordBranch(schema *openapi3.Schema, keyword string, index int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if pointer, ok := c.schemaPointers[schema]; ok {
+		c.branches[branchKey(pointer, keyword, index)]++
+	}
+}
+
+// schemaValidationOptions returns the options recording the alternatives matched during validation.
+func (c *Coverage) schemaValidationOptions() []openapi3.SchemaValidationOption {
+	if c == nil {
+		return nil
+	}
+	return []openapi3.SchemaValidationOption{openapi3.BranchMatched(c.recordBranch)}
+}
+
+// contentKey returns the key of mediaType in content.
+func contentKey(content openapi3.Content, mediaType *openapi3.MediaType) (string, bool) {
+	if mediaType == nil {
+		return "", false
+	}
+	for key, value := range content {
+		if value == mediaType {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// CoverageItem is a part of a document along with the number of times it was exercised.
+type CoverageItem struct {
+	Name  string
+	Count int
+}
+
+// OperationCoverage reports how an operation was exercised.
+type OperationCoverage struct {
+	Method, Path string
+	// Requests is the number of requests that validated.
+	Requests            int
+	RequestContentTypes []CoverageItem
+	Responses           []ResponseCoverage
+}
+
+// ResponseCoverage reports how the response documented for a status was exercised.
+type ResponseCoverage struct {
+	// Status is a status code or "default"
+	Status       string
+	Count        int
+	ContentTypes []CoverageItem
+}
+
+// CoverageReport is a snapshot of what a Coverage recorded.
+type CoverageReport struct {
+	// Operations are in the order of their path then method.
+	Operations []OperationCoverage
+	// Branches are named after the JSON pointer of the alternatives of
+	// oneOf and anyOf schemas, e.g. "/components/schemas/Pet/oneOf/1".
+	Branches []CoverageItem
+}
+
+// Report returns what was recorded so far.
+func (c *Coverage) Report() *CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := &CoverageReport{}
+	for _, key := range c.operationKeys {
+		operation := c.operations[key]
+		operationReport := OperationCoverage{
+			Method:              operation.method,
+			Path:                operation.path,
+			Requests:            operation.requests,
+			RequestContentTypes: coverageItems(operation.requestContentTypes),
+		}
+		statuses := make([]string, 0, len(operation.responses))
+		for status := range operation.responses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			response := operation.responses[status]
+			operationReport.Responses = append(operationReport.Responses, ResponseCoverage{
+				Status:       status,
+				Count:        response.count,
+				ContentTypes: coverageItems(response.contentTypes),
+			})
+		}
+		report.Operations = append(report.Operations, operationReport)
+	}
+	for _, key := range c.branchKeys {
+		report.Branches = append(report.Branches, CoverageItem{Name: key, Count: c.branches[key]})
+	}
+	return report
+}
+
+func coverageItems(counts map[string]int) []CoverageItem {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items := make([]CoverageItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, CoverageItem{Name: name, Count: counts[name]})
+	}
+	return items
+}
+
+// Uncovered lists the parts of the document that were not exercised, e.g.
+// "GET /pets", "POST /pets request application/xml", "GET /pets 404",
+// "GET /pets 200 application/xml" or "/components/schemas/Pet/oneOf/1".
+func (report *CoverageReport) Uncovered() []string {
+	var uncovered []string
+	for _, operation := range report.Operations {
+		name := operation.Method + " " + operation.Path
+		if operation.Requests == 0 {
+			uncovered = append(uncovered, name)
+		}
+		for _, item := range operation.RequestContentTypes {
+			if item.Count == 0 {
+				uncovered = append(uncovered, name+" request "+item.Name)
+			}
+		}
+		for _, response := range operation.Responses {
+			if response.Count == 0 {
+				uncovered = append(uncovered, name+" "+response.Status)
+			}
+			for _, item := range response.ContentTypes {
+				if item.Count == 0 {
+					uncovered = append(uncovered, name+" "+response.Status+" "+item.Name)
+				}
+			}
+		}
+	}
+	for _, item := range report.Branches {
+		if item.Count == 0 {
+			uncovered = append(uncovered, item.Name)
+		}
+	}
+	return uncovered
+}
+
+// Covered returns the number of parts of the document that were exercised
+// and the total number of parts, counting operations, request content types,
+// response statuses, response content types and branches.
+func (report *CoverageReport) Covered() (covered, total int) {
+	count := func(n int) {
+		total++
+		if n != 0 {
+			covered++
+		}
+	}
+	for _, operation := range report.Operations {
+		count(operation.Requests)
+		for _, item := range operation.RequestContentTypes {
+			count(item.Count)
+		}
+		for _, response := range operation.Responses {
+			count(response.Count)
+			for _, item := range response.ContentTypes {
+				count(item.Count)
+			}
+		}
+	}
+	for _, item := range report.Branches {
+		count(item.Count)
+	}
+	return
+}
+
+// Ratio returns the share of the parts of the document that were exercised,
+// between 0 and 1. It is 1 for documents without operations nor branches.
+func (report *CoverageReport) Ratio() float64 {
+	covered, total := report.Covered()
+	if total == 0 {
+		return 1
+	}
+	return float64(covered) / float64(total)
+}
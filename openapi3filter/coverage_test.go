@@ -0,0 +1,137 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestCoverage(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+          application/xml:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        '201':
+          description: created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+        default:
+          description: error
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - {$ref: '#/components/schemas/Dog'}
+        - {$ref: '#/components/schemas/Cat'}
+    Dog:
+      type: object
+      required: [barks]
+      properties:
+        barks: {type: boolean}
+      additionalProperties: false
+    Cat:
+      type: object
+      required: [meows]
+      properties:
+        meows: {type: boolean}
+      additionalProperties: false
+`[1:]
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	coverage := NewCoverage(doc)
+	options := &Options{Coverage: coverage}
+	covered, total := coverage.Report().Covered()
+	require.Equal(t, 0, covered)
+	require.Equal(t, 10, total)
+
+	exchange := func(body string, status int, responseBody string) error {
+		req, err := http.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		requestInput := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		if err := ValidateRequest(context.Background(), requestInput); err != nil {
+			return err
+		}
+		responseInput := &ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 status,
+			Header:                 http.Header{"Content-Type": {"application/json"}},
+			Options:                options,
+		}
+		responseInput.SetBodyBytes([]byte(responseBody))
+		return ValidateResponse(context.Background(), responseInput)
+	}
+
+	require.NoError(t, exchange(`{"barks": true}`, http.StatusCreated, `{"barks": false}`))
+	require.NoError(t, exchange(`{"barks": true}`, http.StatusBadRequest, ``))
+	// Invalid traffic is not recorded
+	require.Error(t, exchange(`{"meows": 1}`, http.StatusCreated, `{}`))
+
+	report := coverage.Report()
+	require.Equal(t, []OperationCoverage{
+		{
+			Method:              "GET",
+			Path:                "/pets",
+			RequestContentTypes: []CoverageItem{},
+			Responses: []ResponseCoverage{
+				{Status: "200", ContentTypes: []CoverageItem{}},
+			},
+		},
+		{
+			Method:   "POST",
+			Path:     "/pets",
+			Requests: 2,
+			RequestContentTypes: []CoverageItem{
+				{Name: "application/json", Count: 2},
+				{Name: "application/xml"},
+			},
+			Responses: []ResponseCoverage{
+				{Status: "201", Count: 1, ContentTypes: []CoverageItem{{Name: "application/json", Count: 1}}},
+				{Status: "default", Count: 1, ContentTypes: []CoverageItem{}},
+			},
+		},
+	}, report.Operations)
+	require.Equal(t, []CoverageItem{
+		{Name: "/components/schemas/Pet/oneOf/0", Count: 3},
+		{Name: "/components/schemas/Pet/oneOf/1"},
+	}, report.Branches)
+	require.Equal(t, []string{
+		"GET /pets",
+		"GET /pets 200",
+		"POST /pets request application/xml",
+		"/components/schemas/Pet/oneOf/1",
+	}, report.Uncovered())
+	covered, total = report.Covered()
+	require.Equal(t, 6, covered)
+	require.Equal(t, 10, total)
+	require.InDelta(t, 6.0/10, report.Ratio(), 1e-9)
+}
@@ -0,0 +1,139 @@
+package openapi3filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DeprecationKind is the kind of a deprecated part of a document.
+type DeprecationKind string
+
+const (
+	// DeprecatedOperation is a deprecated operation a request was made to.
+	DeprecatedOperation DeprecationKind = "operation"
+	// DeprecatedParameter is a deprecated parameter a request set.
+	DeprecatedParameter DeprecationKind = "parameter"
+	// DeprecatedProperty is a deprecated schema property a request set,
+	// in its body or in a parameter value.
+	DeprecatedProperty DeprecationKind = "property"
+)
+
+// Deprecation is a deprecated part of the document a request used.
+// See Options.ReportDeprecations.
+type Deprecation struct {
+	Kind DeprecationKind
+	// Method and Path of the operation
+	Method, Path string
+	// Parameter is the deprecated parameter, or the parameter a deprecated property
+	// was found in. It is nil for operations and for properties of the request body.
+	Parameter *openapi3.Parameter
+	// Pointer is the JSON pointer of a deprecated property within the request body
+	// or the parameter value, with "*" in place of array indexes.
+	Pointer string
+}
+
+func (d Deprecation) String() string {
+	operation := d.Method + " " + d.Path
+	var parameter string
+	if p := d.Parameter; p != nil {
+		parameter = fmt.Sprintf("%s parameter %q", p.In, p.Name)
+	}
+	switch d.Kind {
+	case DeprecatedOperation:
+		return "deprecated operation " + operation
+	case DeprecatedParameter:
+		return fmt.Sprintf("deprecated %s of %s", parameter, operation)
+	}
+	if parameter == "" {
+		return fmt.Sprintf("deprecated property %s of the request body of %s", d.Pointer, operation)
+	}
+	return fmt.Sprintf("deprecated property %s of %s of %s", d.Pointer, parameter, operation)
+}
+
+func (input *RequestValidationInput) addDeprecation(kind DeprecationKind, parameter *openapi3.Parameter, pointer string) {
+	d := Deprecation{Kind: kind, Parameter: parameter, Pointer: pointer}
+	if route := input.Route; route != nil {
+		d.Method, d.Path = route.Method, route.Path
+	}
+	for _, existing := range input.Deprecations {
+		if existing == d {
+			return
+		}
+	}
+	input.Deprecations = append(input.Deprecations, d)
+}
+
+// addDeprecatedProperties adds the deprecated properties of schema that value sets.
+// Null properties of parameter values are ignored as decoding sets the properties
+// missing from the parameter to null.
+func (input *RequestValidationInput) addDeprecatedProperties(parameter *openapi3.Parameter, schema *openapi3.Schema, value interface{}) {
+	walkDeprecatedProperties(schema, value, "", parameter != nil, func(pointer string) {
+		input.addDeprecation(DeprecatedProperty, parameter, pointer)
+	})
+}
+
+func walkDeprecatedProperties(schema *openapi3.Schema, value interface{}, pointer string, skipNull bool, found func(pointer string)) {
+	schemas := applicableSchemas(schema, value, nil)
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if skipNull && value[key] == nil {
+				continue
+			}
+			propertyPointer := pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+			for _, s := range schemas {
+				ref := s.Properties[key]
+				if ref == nil {
+					ref = s.AdditionalProperties.Schema
+				}
+				if ref == nil || ref.Value == nil {
+					continue
+				}
+				if ref.Value.Deprecated && s.Properties[key] != nil {
+					found(propertyPointer)
+				}
+				walkDeprecatedProperties(ref.Value, value[key], propertyPointer, skipNull, found)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			for _, s := range schemas {
+				if ref := s.Items; ref != nil && ref.Value != nil {
+					walkDeprecatedProperties(ref.Value, item, pointer+"/*", skipNull, found)
+				}
+			}
+		}
+	}
+}
+
+// applicableSchemas returns schema and the schemas of its allOf, and of its oneOf
+// and anyOf that value matches, that apply to value.
+func applicableSchemas(schema *openapi3.Schema, value interface{}, schemas []*openapi3.Schema) []*openapi3.Schema {
+	for _, s := range schemas {
+		if s == schema {
+			return schemas
+		}
+	}
+	schemas = append(schemas, schema)
+	for _, ref := range schema.AllOf {
+		if ref.Value != nil {
+			schemas = applicableSchemas(ref.Value, value, schemas)
+		}
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref.Value != nil && ref.Value.IsMatching(value) {
+				schemas = applicableSchemas(ref.Value, value, schemas)
+			}
+		}
+	}
+	return schemas
+}
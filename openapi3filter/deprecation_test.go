@@ -0,0 +1,168 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestReportDeprecations(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    post:
+      deprecated: true
+      parameters:
+        - name: legacy
+          in: query
+          deprecated: true
+          schema: {type: boolean}
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              name: {type: string}
+              old: {type: string, deprecated: true}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                nickname: {type: string, deprecated: true}
+                tags:
+                  type: array
+                  items:
+                    allOf:
+                      - type: object
+                        properties:
+                          label: {type: string, deprecated: true}
+      responses:
+        '201':
+          description: Created
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema: {type: integer}
+      responses:
+        '200':
+          description: Pet
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	validate := func(method, target, body string) *RequestValidationInput {
+		req, err := http.NewRequest(method, "http://example.com"+target, strings.NewReader(body))
+		require.NoError(t, err)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &Options{ReportDeprecations: true},
+		}
+		require.NoError(t, ValidateRequest(context.Background(), input))
+		return input
+	}
+
+	input := validate(http.MethodPost, "/pets?legacy=true&filter[name]=x&filter[old]=y",
+		`{"name": "Rex", "nickname": "R", "tags": [{"label": "a"}, {"label": "b"}]}`)
+	var reported []string
+	for _, d := range input.Deprecations {
+		reported = append(reported, d.String())
+	}
+	require.Equal(t, []string{
+		"deprecated operation POST /pets",
+		`deprecated query parameter "legacy" of POST /pets`,
+		`deprecated property /old of query parameter "filter" of POST /pets`,
+		"deprecated property /nickname of the request body of POST /pets",
+		"deprecated property /tags/*/label of the request body of POST /pets",
+	}, reported)
+	require.Equal(t, DeprecatedParameter, input.Deprecations[1].Kind)
+	require.Equal(t, "legacy", input.Deprecations[1].Parameter.Name)
+
+	input = validate(http.MethodPost, "/pets", `{"name": "Rex"}`)
+	require.Equal(t, []Deprecation{{Kind: DeprecatedOperation, Method: http.MethodPost, Path: "/pets"}}, input.Deprecations)
+
+	input = validate(http.MethodGet, "/pets/1", "")
+	require.Empty(t, input.Deprecations)
+}
+
+func TestDeprecationFunc(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      deprecated: true
+      parameters:
+        - name: limit
+          in: query
+          deprecated: true
+          schema: {type: integer}
+      responses:
+        '200':
+          description: Pets
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	var reported []string
+	options := &Options{
+		DeprecationFunc: func(ctx context.Context, input *RequestValidationInput, deprecation Deprecation) {
+			require.Equal(t, "/pets", input.Route.Path)
+			reported = append(reported, deprecation.String())
+		},
+	}
+	validate := func(target string) error {
+		req, err := http.NewRequest(http.MethodGet, "http://example.com"+target, nil)
+		require.NoError(t, err)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		return ValidateRequest(context.Background(), &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		})
+	}
+
+	require.NoError(t, validate("/pets?limit=3"))
+	require.Equal(t, []string{
+		"deprecated operation GET /pets",
+		`deprecated query parameter "limit" of GET /pets`,
+	}, reported)
+
+	// Invalid requests report their deprecations too
+	reported = nil
+	require.Error(t, validate("/pets?limit=three"))
+	require.Equal(t, []string{"deprecated operation GET /pets"}, reported)
+}
@@ -0,0 +1,452 @@
+package openapi3filter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// DriftKind classifies the disagreements between an implementation and its document.
+type DriftKind string
+
+const (
+	// DriftUndocumentedOperation is a request matching no operation of the document.
+	DriftUndocumentedOperation DriftKind = "undocumented operation"
+	// DriftInvalidRequest is a request failing validation.
+	DriftInvalidRequest DriftKind = "invalid request"
+	// DriftInvalidResponse is a response failing validation.
+	DriftInvalidResponse DriftKind = "invalid response"
+	// DriftUndocumentedStatus is a response whose status is not documented.
+	DriftUndocumentedStatus DriftKind = "undocumented status"
+	// DriftUndocumentedProperty is a body property that the schema does not declare.
+	DriftUndocumentedProperty DriftKind = "undocumented property"
+)
+
+// Drift is a disagreement between an implementation and its document,
+// along with the number of sampled exchanges it was seen in.
+type Drift struct {
+	Kind DriftKind
+	// Method and Path are the operation's, or the request's when it matches no operation.
+	Method, Path string
+	// Location tells where the disagreement is, e.g. "request body /items/*/name",
+	// "response 200 body /id", "query parameter limit" or a status code.
+	// Array indices are replaced by "*" in JSON pointers.
+	Location string
+	// Message describes the first occurrence
+	Message string
+	Count   int
+}
+
+func (d Drift) String() string {
+	s := d.Method + " " + d.Path + ": " + string(d.Kind)
+	if d.Location != "" {
+		s += " at " + d.Location
+	}
+	return s + " (" + strconv.Itoa(d.Count) + "x)"
+}
+
+// DriftReport is a snapshot of what a DriftDetector recorded.
+type DriftReport struct {
+	// Observed is the number of exchanges observed, Sampled the number checked.
+	Observed, Sampled int
+	// Drifts are sorted by path, method, kind then location.
+	Drifts []Drift
+}
+
+// DriftOption configures a DriftDetector.
+type DriftOption func(*DriftDetector)
+
+// SampleRate sets the share of exchanges a DriftDetector checks, between 0 and 1. It defaults to 1.
+func SampleRate(rate float64) DriftOption {
+	return func(d *DriftDetector) {
+		d.sampleRate = rate
+	}
+}
+
+// DriftValidationOptions sets the options exchanges are validated with.
+// By default errors are aggregated and security requirements are not checked.
+func DriftValidationOptions(options Options) DriftOption {
+	return func(d *DriftDetector) {
+		d.options = options
+	}
+}
+
+// DriftDetector records where traffic disagrees with a document: requests and
+// responses failing validation, undocumented operations, statuses and body properties.
+//
+// Unlike Validator it never alters exchanges, so that it can watch production traffic.
+// It is safe for concurrent use.
+type DriftDetector struct {
+	router     routers.Router
+	sampleRate float64
+	options    Options
+
+	mu       sync.Mutex
+	observed int
+	sampled  int
+	drifts   map[driftKey]*Drift
+}
+
+type driftKey struct {
+	kind                   DriftKind
+	method, path, location string
+}
+
+// NewDriftDetector returns a DriftDetector for the operations router finds.
+func NewDriftDetector(router routers.Router, options ...DriftOption) *DriftDetector {
+	d := &DriftDetector{
+		router:     router,
+		sampleRate: 1,
+		options: Options{
+			MultiError:         true,
+			AuthenticationFunc: NoopAuthenticationFunc,
+		},
+		drifts: make(map[driftKey]*Drift),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// Middleware returns an http.Handler which wraps the given handler and
+// checks the sampled requests it receives and the responses it sends.
+func (d *DriftDetector) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !d.sample() {
+			h.ServeHTTP(w, req)
+			return
+		}
+		var body []byte
+		if req.Body != nil && req.Body != http.NoBody {
+			var err error
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+			req.Body.Close()
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		wr := newWarnResponseWrapper(w)
+		h.ServeHTTP(wr, req)
+
+		status := wr.statusCode()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		d.check(req, body, status, wr.Header(), wr.bodyContents())
+	})
+}
+
+func (d *DriftDetector) sample() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observed++
+	if d.sampleRate < 1 && rand.Float64() >= d.sampleRate {
+		return false
+	}
+	d.sampled++
+	return true
+}
+
+// Observe checks an exchange, if sampled: a request along with its body and
+// the status, header and body of its response.
+func (d *DriftDetector) Observe(req *http.Request, requestBody []byte, status int, header http.Header, responseBody []byte) {
+	if d.sample() {
+		d.check(req, requestBody, status, header, responseBody)
+	}
+}
+
+func (d *DriftDetector) check(req *http.Request, requestBody []byte, status int, header http.Header, responseBody []byte) {
+	// Drifts are counted once per exchange
+	seen := make(map[driftKey]struct{})
+	route, pathParams, err := d.router.FindRoute(req)
+	if err != nil {
+		d.record(seen, DriftUndocumentedOperation, req.Method, req.URL.Path, "", err)
+		return
+	}
+	method, path := route.Method, route.Path
+	ctx := context.Background()
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
+	requestInput := &RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &d.options,
+	}
+	for _, err := range flattenDriftErrors(ValidateRequest(ctx, requestInput)) {
+		d.record(seen, DriftInvalidRequest, method, path, requestErrorLocation(err), err)
+	}
+	if ref := route.Operation.RequestBody; ref != nil && ref.Value != nil {
+		d.checkProperties(seen, method, path, "request body", ref.Value.Content, req.Header, requestBody)
+	}
+
+	response, _ := route.Operation.Responses.FindFor(status)
+	statusLocation := strconv.Itoa(status)
+	if response == nil {
+		d.record(seen, DriftUndocumentedStatus, method, path, statusLocation, nil)
+		return
+	}
+	responseInput := &ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 status,
+		Header:                 header,
+		Options:                &d.options,
+	}
+	responseInput.SetBodyBytes(responseBody)
+	for _, err := range flattenDriftErrors(ValidateResponse(ctx, responseInput)) {
+		d.record(seen, DriftInvalidResponse, method, path, responseErrorLocation(statusLocation, err), err)
+	}
+	if response.Value != nil {
+		d.checkProperties(seen, method, path, "response "+statusLocation+" body", response.Value.Content, header, responseBody)
+	}
+}
+
+// flattenDriftErrors returns the errors aggregated in err, each wrapped as err is.
+func flattenDriftErrors(err error) []error {
+	var errs []error
+	switch e := err.(type) {
+	case nil:
+	case openapi3.MultiError:
+		for _, err := range e {
+			errs = append(errs, flattenDriftErrors(err)...)
+		}
+	case *RequestError:
+		me, ok := e.Err.(openapi3.MultiError)
+		if !ok {
+			return []error{err}
+		}
+		for _, err := range flattenDriftErrors(me) {
+			wrapped := *e
+			wrapped.Err = err
+			errs = append(errs, &wrapped)
+		}
+	case *ResponseError:
+		me, ok := e.Err.(openapi3.MultiError)
+		if !ok {
+			return []error{err}
+		}
+		for _, err := range flattenDriftErrors(me) {
+			wrapped := *e
+			wrapped.Err = err
+			errs = append(errs, &wrapped)
+		}
+	default:
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func requestErrorLocation(err error) string {
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		if p := requestError.Parameter; p != nil {
+			return p.In + " parameter " + p.Name
+		}
+		if requestError.RequestBody != nil {
+			return "request body" + schemaErrorPointer(err)
+		}
+	}
+	var securityError *SecurityRequirementsError
+	if errors.As(err, &securityError) {
+		return "security"
+	}
+	return ""
+}
+
+func responseErrorLocation(status string, err error) string {
+	var responseError *ResponseError
+	if errors.As(err, &responseError) && strings.HasPrefix(responseError.Reason, "response header ") {
+		var name string
+		if _, err := fmt.Sscanf(responseError.Reason, "response header %q", &name); err != nil {
+			name = headerCT
+		}
+		return "response " + status + " header " + name
+	}
+	return "response " + status + " body" + schemaErrorPointer(err)
+}
+
+func schemaErrorPointer(err error) string {
+	var schemaError *openapi3.SchemaError
+	if !errors.As(err, &schemaError) {
+		return ""
+	}
+	return " " + driftPointer(schemaError.JSONPointer())
+}
+
+// driftPointer returns the JSON pointer of tokens with array indices replaced by "*".
+func driftPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		if _, err := strconv.ParseUint(token, 10, 64); err == nil {
+			token = "*"
+		} else {
+			token = strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+		}
+		sb.WriteString("/" + token)
+	}
+	if sb.Len() == 0 {
+		return "/"
+	}
+	return sb.String()
+}
+
+// checkProperties records the properties of a JSON body that its schema does not declare.
+func (d *DriftDetector) checkProperties(seen map[driftKey]struct{}, method, path, location string, content openapi3.Content, header http.Header, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	mediaType := content.Get(header.Get(headerCT))
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return
+	}
+	for _, pointer := range undocumentedProperties(nil, mediaType.Schema.Value, value, make(map[*openapi3.Schema]int)) {
+		d.record(seen, DriftUndocumentedProperty, method, path, location+" "+pointer, nil)
+	}
+}
+
+// undocumentedProperties returns the pointers of the properties of value that schema does not declare.
+// Objects whose schema allows additional properties explicitly are not checked.
+func undocumentedProperties(path []string, schema *openapi3.Schema, value interface{}, onStack map[*openapi3.Schema]int) []string {
+	if schema == nil || onStack[schema] > 0 {
+		return nil
+	}
+	onStack[schema]++
+	defer func() { onStack[schema]-- }()
+
+	var pointers []string
+	switch value := value.(type) {
+	case []interface{}:
+		items := itemSchemas(schema)
+		for i, item := range value {
+			for _, itemSchema := range items {
+				pointers = append(pointers, undocumentedProperties(append(path[:len(path):len(path)], strconv.Itoa(i)), itemSchema, item, onStack)...)
+			}
+		}
+	case map[string]interface{}:
+		properties, open := declaredProperties(schema)
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, propertySchema := range properties[name] {
+				pointers = append(pointers, undocumentedProperties(append(path[:len(path):len(path)], name), propertySchema, value[name], onStack)...)
+			}
+		}
+		if !open {
+			for _, name := range names {
+				if _, ok := properties[name]; !ok {
+					pointers = append(pointers, driftPointer(append(path[:len(path):len(path)], name)))
+				}
+			}
+		}
+	}
+	return pointers
+}
+
+// declaredProperties returns the schemas of the properties schema declares,
+// looking into allOf, oneOf and anyOf, and whether it allows additional properties.
+func declaredProperties(schema *openapi3.Schema) (map[string][]*openapi3.Schema, bool) {
+	properties := make(map[string][]*openapi3.Schema)
+	open := schema.AdditionalProperties.Schema != nil ||
+		(schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has) ||
+		// Free-form object
+		(len(schema.Properties) == 0 && len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0)
+	for name, ref := range schema.Properties {
+		if ref != nil && ref.Value != nil {
+			properties[name] = append(properties[name], ref.Value)
+		}
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			branchProperties, branchOpen := declaredProperties(ref.Value)
+			open = open || branchOpen
+			for name, schemas := range branchProperties {
+				properties[name] = append(properties[name], schemas...)
+			}
+		}
+	}
+	return properties, open
+}
+
+func itemSchemas(schema *openapi3.Schema) []*openapi3.Schema {
+	var schemas []*openapi3.Schema
+	if ref := schema.Items; ref != nil && ref.Value != nil {
+		schemas = append(schemas, ref.Value)
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref != nil && ref.Value != nil {
+				schemas = append(schemas, itemSchemas(ref.Value)...)
+			}
+		}
+	}
+	return schemas
+}
+
+func (d *DriftDetector) record(seen map[driftKey]struct{}, kind DriftKind, method, path, location string, err error) {
+	key := driftKey{kind: kind, method: method, path: path, location: location}
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	drift := d.drifts[key]
+	if drift == nil {
+		drift = &Drift{Kind: kind, Method: method, Path: path, Location: location}
+		if err != nil {
+			drift.Message = err.Error()
+		}
+		d.drifts[key] = drift
+	}
+	drift.Count++
+}
+
+// Report returns what was recorded so far.
+func (d *DriftDetector) Report() *DriftReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	report := &DriftReport{Observed: d.observed, Sampled: d.sampled}
+	for _, drift := range d.drifts {
+		report.Drifts = append(report.Drifts, *drift)
+	}
+	sort.Slice(report.Drifts, func(i, j int) bool {
+		a, b := report.Drifts[i], report.Drifts[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Location < b.Location
+	})
+	return report
+}
@@ -0,0 +1,131 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestDriftDetector(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: '#/components/schemas/Pet'}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        '201':
+          description: created
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        tags:
+          type: object
+          additionalProperties: {type: string}
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	detector := NewDriftDetector(router)
+	handler := detector.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/pets":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"name":"Rex","age":3,"tags":{"color":"brown"}},{"name":7,"age":2}]`))
+		}
+	}))
+
+	for _, exchange := range []struct{ method, target, body string }{
+		{http.MethodGet, "/pets", ""},
+		{http.MethodGet, "/pets?limit=ten", ""},
+		{http.MethodPost, "/pets", `{"name":"Rex","owner":"Ann"}`},
+		{http.MethodPost, "/pets", `{"owner":"Ann"}`},
+		{http.MethodDelete, "/pets/1", ""},
+	} {
+		req := httptest.NewRequest(exchange.method, exchange.target, strings.NewReader(exchange.body))
+		if exchange.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if exchange.method == http.MethodGet {
+			require.Contains(t, rec.Body.String(), "Rex")
+		}
+	}
+
+	report := detector.Report()
+	require.Equal(t, 5, report.Observed)
+	require.Equal(t, 5, report.Sampled)
+	var drifts []string
+	for _, drift := range report.Drifts {
+		drifts = append(drifts, drift.String())
+	}
+	require.Equal(t, []string{
+		"GET /pets: invalid request at query parameter limit (1x)",
+		"GET /pets: invalid response at response 200 body /*/name (2x)",
+		"GET /pets: undocumented property at response 200 body /*/age (2x)",
+		"POST /pets: invalid request at request body /name (1x)",
+		"POST /pets: undocumented property at request body /owner (2x)",
+		"POST /pets: undocumented status at 409 (2x)",
+		"DELETE /pets/1: undocumented operation (1x)",
+	}, drifts)
+	require.Contains(t, report.Drifts[0].Message, "parameter \"limit\" in query has an error")
+}
+
+func TestDriftDetectorSampling(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths: {}
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	detector := NewDriftDetector(router, SampleRate(0))
+	for i := 0; i < 10; i++ {
+		detector.Observe(httptest.NewRequest(http.MethodGet, "/", nil), nil, http.StatusOK, http.Header{}, nil)
+	}
+	report := detector.Report()
+	require.Equal(t, 10, report.Observed)
+	require.Equal(t, 0, report.Sampled)
+	require.Empty(t, report.Drifts)
+}
@@ -13,6 +13,17 @@ func parseMediaType(contentType string) string {
 	return contentType[:i]
 }
 
+// inMediaTypeRange tells whether the media type of contentType is of the type
+// of the range mediaRange, e.g. text/plain for text/*. The range */* is excluded.
+func inMediaTypeRange(contentType, mediaRange string) bool {
+	mediaRange = strings.TrimSpace(parseMediaType(mediaRange))
+	if !strings.HasSuffix(mediaRange, "/*") || mediaRange == "*/*" {
+		return false
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(parseMediaType(contentType)))
+	return strings.HasPrefix(mediaType, strings.ToLower(mediaRange[:len(mediaRange)-1]))
+}
+
 func isNilValue(value interface{}) bool {
 	if value == nil {
 		return true
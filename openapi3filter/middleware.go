@@ -2,6 +2,9 @@ package openapi3filter
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -118,12 +121,29 @@ func ValidationOptions(options Options) ValidatorOption {
 // request and response validation.
 func (v *Validator) Middleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		route, pathParams, err := v.router.FindRoute(r)
+		route, pathParams, err := v.findRoute(r)
 		if err != nil {
+			if skip := v.options.SkipRequest; skip != nil && skip(r, nil) {
+				h.ServeHTTP(w, r)
+				return
+			}
 			v.logFunc("validation error: failed to find route for "+r.URL.String(), err)
 			v.errFunc(w, http.StatusNotFound, ErrCodeCannotFindRoute, err)
 			return
 		}
+		if v.options.skipsRequest(r, route) && v.options.skipsResponse(r, route) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		timeout := route.Operation.Contract.Timeout
+		if !v.options.EnforceContract {
+			timeout = 0
+		}
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
 		requestValidationInput := &RequestValidationInput{
 			Request:    r,
 			PathParams: pathParams,
@@ -135,6 +155,12 @@ func (v *Validator) Middleware(h http.Handler) http.Handler {
 			v.errFunc(w, http.StatusBadRequest, ErrCodeRequestInvalid, err)
 			return
 		}
+		if ct := requestValidationInput.UnknownContentType; ct != "" {
+			v.logFunc("request body not validated", fmt.Errorf("%s %q", prefixInvalidCT, ct))
+		}
+		for _, d := range requestValidationInput.Deprecations {
+			v.logFunc("deprecated usage", errors.New(d.String()))
+		}
 
 		var wr responseWrapper
 		if v.strict {
@@ -145,6 +171,15 @@ func (v *Validator) Middleware(h http.Handler) http.Handler {
 
 		h.ServeHTTP(wr, r)
 
+		if timeout > 0 && r.Context().Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("handling the request took longer than the %s allowed", timeout)
+			v.logFunc("invalid response", err)
+			if v.strict {
+				v.errFunc(w, http.StatusServiceUnavailable, ErrCodeResponseInvalid, err)
+				return
+			}
+		}
+
 		if err = ValidateResponse(r.Context(), &ResponseValidationInput{
 			RequestValidationInput: requestValidationInput,
 			Status:                 wr.statusCode(),
@@ -165,6 +200,17 @@ func (v *Validator) Middleware(h http.Handler) http.Handler {
 	})
 }
 
+// findRoute finds the route of r, or that of the method r is a CORS preflight request for
+// when Options.PreflightRequests is set.
+func (v *Validator) findRoute(r *http.Request) (*routers.Route, map[string]string, error) {
+	if v.options.PreflightRequests && IsPreflightRequest(r) {
+		target := r.Clone(r.Context())
+		target.Method = r.Header.Get("Access-Control-Request-Method")
+		return v.router.FindRoute(target)
+	}
+	return v.router.FindRoute(r)
+}
+
 type responseWrapper interface {
 	http.ResponseWriter
 
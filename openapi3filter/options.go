@@ -1,6 +1,14 @@
 package openapi3filter
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
 
 // DefaultOptions do not set an AuthenticationFunc.
 // A spec with security schemes defined will not pass validation
@@ -28,9 +36,201 @@ type Options struct {
 	// request. If true, then they are not set
 	SkipSettingDefaults bool
 
+	// Set StripReadOnlyProperties so ValidateRequest removes the readOnly properties
+	// of request bodies instead of rejecting them, rewriting the bodies
+	// like when default values are set.
+	StripReadOnlyProperties bool
+
+	// Coverage, if set, records the parts of the document exercised by
+	// requests and responses that validate.
+	Coverage *Coverage
+
+	// Set ReportDeprecations so ValidateRequest lists in RequestValidationInput.Deprecations
+	// the deprecated operation, parameters and schema properties the request used
+	ReportDeprecations bool
+
+	// DeprecationFunc, if set, is called by ValidateRequest with each deprecated part
+	// of the document the request used, as listed in RequestValidationInput.Deprecations,
+	// whether the request is valid or not. Setting it implies ReportDeprecations.
+	DeprecationFunc DeprecationFunc
+
+	// MaxValidatedItems, if positive, limits the validation of arrays to their first
+	// MaxValidatedItems items, and that of objects to as many of their properties,
+	// for the values of requests and responses to be validated in bounded time.
+	// The arrays and objects not fully validated are listed in the TruncatedValidations
+	// of the RequestValidationInput or ResponseValidationInput.
+	// See openapi3.LimitItemsValidation
+	MaxValidatedItems int
+
+	// SchemaValidators, keyed by schema reference such as "#/components/schemas/Pet",
+	// are used to validate the request and response bodies of those schemas
+	// before validating them against the schema, which is then only needed
+	// when a validator rejects a body, to report why.
+	// They are not used when Coverage is set.
+	// See openapi3codegen.WithSchemaValidators
+	SchemaValidators map[string]SchemaValidator
+
+	// Set EnforceContract so the limits declared by the contract extensions
+	// of operations are enforced: ValidateRequest and ValidateResponse reject
+	// bodies larger than their MaxRequestBytes and MaxResponseBytes, and
+	// the Validator middleware handles requests within their Timeout.
+	// See openapi3.OperationContract
+	EnforceContract bool
+
+	// UnknownContentType sets what ValidateRequest does with request bodies
+	// of a content type the operation does not declare. They are rejected by default.
+	UnknownContentType UnknownContentType
+
+	// FallbackContentType is the media type of the content entry that request bodies
+	// of an unknown content type are validated against when UnknownContentType
+	// is FallbackUnknownContentType. Without it, such bodies are rejected.
+	FallbackContentType string
+
+	// SkipRequest, if set, makes ValidateRequest skip the validation of the requests
+	// it returns true for, such as health checks, CORS preflight OPTIONS requests
+	// or those of internal endpoints.
+	// The Validator middleware also passes the requests it returns true for
+	// to the handler it wraps when their route is not found, calling it with a nil route.
+	SkipRequest SkipFunc
+
+	// SkipResponse, if set, makes ValidateResponse skip the validation of the responses
+	// to the requests it returns true for.
+	// The Validator middleware does not buffer the responses to the requests
+	// both SkipRequest and SkipResponse return true for.
+	SkipResponse SkipFunc
+
+	// BodyRedaction sets what the errors of ValidateRequest and ValidateResponse show
+	// of the request and response bodies they are about, such as the values
+	// of schema errors. Bodies are shown as is by default.
+	BodyRedaction BodyRedaction
+
+	// BodyRedactionByMediaType overrides BodyRedaction for the bodies of media types
+	// such as "application/json", whatever the parameters of their Content-Type.
+	BodyRedactionByMediaType map[string]BodyRedaction
+
+	// MaxBodyErrorLength is the number of bytes, 256 by default, values are truncated to
+	// with TruncateBody.
+	MaxBodyErrorLength int
+
+	// Clock, if set, is the time source of the format validators registered with
+	// openapi3.RegisterTimeFormatValidator, instead of time.Now.
+	// See openapi3.WithClock
+	Clock func() time.Time
+
+	// Set PreflightRequests so CORS preflight requests, see IsPreflightRequest, are neither
+	// validated by ValidateRequest nor their responses by ValidateResponse.
+	// The Validator middleware then routes them as requests of the method they are made for,
+	// passing them to the handler it wraps if the document has an operation for that method
+	// and path, and to its ErrFunc otherwise, whether or not it has an OPTIONS operation.
+	PreflightRequests bool
+
 	customSchemaErrorFunc CustomSchemaErrorFunc
 }
 
+// IsPreflightRequest tells whether req is a CORS preflight request: an OPTIONS request
+// with an Access-Control-Request-Method header naming the method of the request it precedes.
+func IsPreflightRequest(req *http.Request) bool {
+	return req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// SkipFunc tells whether the validation of a request or of its response is skipped.
+// route is nil when the request matches no route of the document.
+type SkipFunc func(req *http.Request, route *routers.Route) bool
+
+// SkipMethods returns a SkipFunc returning true for the requests of methods,
+// such as http.MethodOptions.
+func SkipMethods(methods ...string) SkipFunc {
+	return func(req *http.Request, route *routers.Route) bool {
+		for _, method := range methods {
+			if req.Method == method {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipPathPrefixes returns a SkipFunc returning true for the requests whose URL path
+// starts with one of prefixes, such as "/healthz" or "/internal/".
+func SkipPathPrefixes(prefixes ...string) SkipFunc {
+	return func(req *http.Request, route *routers.Route) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(req.URL.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipAny returns a SkipFunc returning true for the requests one of skips returns true for.
+func SkipAny(skips ...SkipFunc) SkipFunc {
+	return func(req *http.Request, route *routers.Route) bool {
+		for _, skip := range skips {
+			if skip(req, route) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// skipsRequest tells whether the validation of req is skipped.
+func (options *Options) skipsRequest(req *http.Request, route *routers.Route) bool {
+	if options.PreflightRequests && IsPreflightRequest(req) {
+		return true
+	}
+	return options.SkipRequest != nil && options.SkipRequest(req, route)
+}
+
+// skipsResponse tells whether the validation of the response to req is skipped.
+func (options *Options) skipsResponse(req *http.Request, route *routers.Route) bool {
+	if options.PreflightRequests && IsPreflightRequest(req) {
+		return true
+	}
+	return options.SkipResponse != nil && options.SkipResponse(req, route)
+}
+
+// UnknownContentType is the handling of request bodies of a content type
+// not declared by their operation.
+type UnknownContentType int
+
+const (
+	// RejectUnknownContentType makes ValidateRequest return an error.
+	RejectUnknownContentType UnknownContentType = iota
+	// WarnUnknownContentType makes ValidateRequest skip the validation of the body
+	// and set the UnknownContentType of the RequestValidationInput, which
+	// the Validator middleware logs.
+	WarnUnknownContentType
+	// FallbackUnknownContentType makes ValidateRequest validate the body against
+	// the content entry of Options.FallbackContentType, and return an error
+	// if the operation does not declare it.
+	// The body is decoded as that media type or, when it is a range such as text/*,
+	// as its own media type if of that range and else as JSON.
+	FallbackUnknownContentType
+)
+
+// SchemaValidator reports whether a value decoded from a body matches a schema,
+// like a nil error of Schema.VisitJSON.
+type SchemaValidator func(value interface{}) bool
+
+// isAcceptedBySchemaValidator reports whether a validator of schema accepts value.
+func (options *Options) isAcceptedBySchemaValidator(schema *openapi3.SchemaRef, value interface{}) bool {
+	if options.Coverage != nil || schema.Ref == "" {
+		return false
+	}
+	validator := options.SchemaValidators[schema.Ref]
+	return validator != nil && validator(value)
+}
+
+// DeprecationFunc is called with a deprecated part of the document a request used.
+type DeprecationFunc func(ctx context.Context, input *RequestValidationInput, deprecation Deprecation)
+
+// reportsDeprecations tells whether ValidateRequest lists the deprecations of requests.
+func (options *Options) reportsDeprecations() bool {
+	return options.ReportDeprecations || options.DeprecationFunc != nil
+}
+
 // CustomSchemaErrorFunc allows for custom the schema error message.
 type CustomSchemaErrorFunc func(err *openapi3.SchemaError) string
 
@@ -0,0 +1,80 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestPreflightRequests(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {type: object}
+      responses:
+        '201':
+          description: Created
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	preflight := func(method, target string) *http.Request {
+		req := httptest.NewRequest(http.MethodOptions, target, nil)
+		req.Header.Set("Origin", "http://example.org")
+		req.Header.Set("Access-Control-Request-Method", method)
+		return req
+	}
+	require.True(t, IsPreflightRequest(preflight(http.MethodPost, "/pets")))
+	require.False(t, IsPreflightRequest(httptest.NewRequest(http.MethodOptions, "/pets", nil)))
+
+	serve := func(options Options, req *http.Request) int {
+		v := NewValidator(router, ValidationOptions(options), Strict(true), OnLog(func(string, error) {}))
+		h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodOptions, r.Method)
+			w.Header().Set("Access-Control-Allow-Methods", "POST")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusNotFound, serve(Options{}, preflight(http.MethodPost, "/pets")))
+	options := Options{PreflightRequests: true}
+	require.Equal(t, http.StatusNoContent, serve(options, preflight(http.MethodPost, "/pets")))
+	require.Equal(t, http.StatusNotFound, serve(options, preflight(http.MethodDelete, "/pets")))
+	require.Equal(t, http.StatusNotFound, serve(options, preflight(http.MethodPost, "/unknown")))
+
+	// Without a body nor a declared response
+	req := preflight(http.MethodPost, "/pets")
+	route, pathParams, err := router.FindRoute(httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader("")))
+	require.NoError(t, err)
+	input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: &options}
+	require.NoError(t, ValidateRequest(context.Background(), input))
+	require.NoError(t, ValidateResponse(context.Background(), &ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 http.StatusNoContent,
+		Header:                 http.Header{},
+		Options:                &options,
+	}))
+	input.Options = &Options{}
+	require.Error(t, ValidateRequest(context.Background(), input))
+}
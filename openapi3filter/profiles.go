@@ -0,0 +1,93 @@
+package openapi3filter
+
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Profile is a named configuration of the validation of requests and responses,
+// along with that of the document they are validated against.
+// The functions returning profiles return new values that may be modified,
+// e.g. to set Options.AuthenticationFunc.
+type Profile struct {
+	Name string
+
+	// Options are the options to validate requests and responses with.
+	Options *Options
+
+	// DocumentOptions are the options to validate the document with, see ValidateDocument.
+	DocumentOptions []openapi3.ValidationOption
+}
+
+// ValidateDocument validates doc with the DocumentOptions of the profile.
+func (profile *Profile) ValidateDocument(ctx context.Context, doc *openapi3.T) error {
+	return doc.Validate(ctx, profile.DocumentOptions...)
+}
+
+// StrictProfile validates documents, requests and responses as fully as possible:
+// all errors are reported, undeclared response statuses and request content types are
+// rejected, the contract extensions of operations are enforced, and the formats,
+// tags, discriminators and metadata of documents are validated.
+func StrictProfile() *Profile {
+	return &Profile{
+		Name: "strict",
+		Options: &Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+			EnforceContract:       true,
+			UnknownContentType:    RejectUnknownContentType,
+		},
+		DocumentOptions: []openapi3.ValidationOption{
+			openapi3.EnableSchemaFormatValidation(),
+			openapi3.EnableSchemaPatternValidation(),
+			openapi3.EnableExamplesValidation(),
+			openapi3.EnableDeclaredTagsValidation(),
+			openapi3.EnableDiscriminatorPropertyValidation(),
+			openapi3.EnableStrictMetadataValidation(),
+			openapi3.EnableMultiErrorValidation(),
+		},
+	}
+}
+
+// LenientGatewayProfile validates the requests a gateway forwards without rejecting
+// what services usually tolerate: response bodies are not validated, request bodies
+// of undeclared content types are let through with a warning, readOnly properties
+// are removed from request bodies, and authentication is left to the services.
+// The examples and tags of documents are not validated.
+func LenientGatewayProfile() *Profile {
+	return &Profile{
+		Name: "lenient-gateway",
+		Options: &Options{
+			ExcludeResponseBody:     true,
+			AuthenticationFunc:      NoopAuthenticationFunc,
+			StripReadOnlyProperties: true,
+			UnknownContentType:      WarnUnknownContentType,
+		},
+		DocumentOptions: []openapi3.ValidationOption{
+			openapi3.DisableExamplesValidation(),
+			openapi3.DisableDeclaredTagsValidation(),
+		},
+	}
+}
+
+// ContractTestProfile validates the requests and responses of tests checking
+// that a service conforms to its document: all errors are reported, undeclared
+// response statuses are rejected, deprecated parts in use are reported and
+// authentication is assumed to succeed.
+// The formats and examples of documents are validated.
+func ContractTestProfile() *Profile {
+	return &Profile{
+		Name: "contract-test",
+		Options: &Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+			AuthenticationFunc:    NoopAuthenticationFunc,
+			ReportDeprecations:    true,
+		},
+		DocumentOptions: []openapi3.ValidationOption{
+			openapi3.EnableSchemaFormatValidation(),
+			openapi3.EnableExamplesValidation(),
+		},
+	}
+}
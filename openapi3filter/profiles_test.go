@@ -0,0 +1,94 @@
+package openapi3filter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestProfiles(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Profiles'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      tags: [pets]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id: {type: string, readOnly: true}
+                name: {type: string}
+      responses:
+        '201':
+          description: Created.
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: string}
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router := setupTestRouter(t, spec)
+
+	validate := func(profile *Profile, contentType, body string, status int) (requestErr, responseErr error) {
+		req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: profile.Options}
+		requestErr = ValidateRequest(context.Background(), input)
+		responseErr = ValidateResponse(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 status,
+			Header:                 http.Header{"Content-Type": {"application/json"}},
+			Body:                   io.NopCloser(strings.NewReader(`{}`)),
+			Options:                profile.Options,
+		})
+		return
+	}
+
+	strict := StrictProfile()
+	require.Equal(t, "strict", strict.Name)
+	err = strict.ValidateDocument(loader.Context, doc)
+	require.EqualError(t, err, "invalid tags: tags used by operations are not declared: pets")
+	requestErr, responseErr := validate(strict, "application/json", `{"id":"a1"}`, 200)
+	require.Error(t, requestErr)
+	require.Contains(t, requestErr.Error(), `readOnly property "id" in request`)
+	require.EqualError(t, responseErr, "status is not supported")
+
+	gateway := LenientGatewayProfile()
+	require.NoError(t, gateway.ValidateDocument(loader.Context, doc))
+	requestErr, responseErr = validate(gateway, "text/plain", `{"id":"a1"}`, 201)
+	require.NoError(t, requestErr)
+	require.NoError(t, responseErr)
+	requestErr, _ = validate(gateway, "application/json", `{"id":"a1"}`, 201)
+	require.NoError(t, requestErr)
+
+	contract := ContractTestProfile()
+	require.NoError(t, contract.ValidateDocument(loader.Context, doc))
+	_, responseErr = validate(contract, "application/json", `{"name":"Rex"}`, 201)
+	require.Error(t, responseErr)
+	require.Contains(t, responseErr.Error(), `property "id" is missing`)
+
+	// Each call returns a new profile
+	StrictProfile().Options.MultiError = false
+	require.True(t, StrictProfile().Options.MultiError)
+}
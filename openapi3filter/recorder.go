@@ -0,0 +1,293 @@
+package openapi3filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// DefaultRedactedProperties are the default names of the properties a Recorder redacts.
+// A property is redacted when its name contains one of them, ignoring case.
+var DefaultRedactedProperties = []string{"password", "secret", "token", "apikey", "authorization", "credential"}
+
+// RedactedValue replaces the values of redacted properties in recorded examples.
+const RedactedValue = "REDACTED"
+
+// Recorder captures the bodies of requests and responses exchanged with the
+// operations of a document so that they can be written back into the document
+// as named examples with WriteExamples.
+//
+// Bodies are decoded with the registered body decoders and sanitized:
+// the values of properties whose name looks sensitive are redacted.
+// Headers, parameters and cookies are not recorded. It is safe for concurrent use.
+type Recorder struct {
+	router      routers.Router
+	redacted    []string
+	sanitize    func(value interface{}) interface{}
+	maxExamples int
+
+	mu sync.Mutex
+	// recorded examples by target, with their encoding
+	examples map[recordTarget][]recordedExample
+}
+
+// recordTarget is the media type an example is recorded for.
+type recordTarget struct {
+	method, path string
+	// status is empty for request bodies
+	status      string
+	contentType string
+}
+
+type recordedExample struct {
+	value   interface{}
+	encoded string
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// RedactProperties sets the names of the properties whose values are replaced by RedactedValue,
+// instead of DefaultRedactedProperties. A property is redacted when its name contains one of names, ignoring case.
+func RedactProperties(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.redacted = names
+	}
+}
+
+// SanitizeExamples sets a function further sanitizing recorded bodies after redaction.
+// It is given decoded bodies and returns the values to record, or nil to skip them.
+func SanitizeExamples(f func(value interface{}) interface{}) RecorderOption {
+	return func(r *Recorder) {
+		r.sanitize = f
+	}
+}
+
+// MaxExamples sets the maximum number of distinct examples recorded for
+// each request or response media type of each operation. It defaults to 1.
+func MaxExamples(n int) RecorderOption {
+	return func(r *Recorder) {
+		r.maxExamples = n
+	}
+}
+
+// NewRecorder returns a Recorder for the operations router finds.
+func NewRecorder(router routers.Router, options ...RecorderOption) *Recorder {
+	r := &Recorder{
+		router:      router,
+		redacted:    DefaultRedactedProperties,
+		maxExamples: 1,
+		examples:    make(map[recordTarget][]recordedExample),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Middleware returns an http.Handler which wraps the given handler and
+// records the requests it receives and the responses it sends.
+func (r *Recorder) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body []byte
+		if req.Body != nil && req.Body != http.NoBody {
+			var err error
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+			req.Body.Close()
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		wr := newWarnResponseWrapper(w)
+		h.ServeHTTP(wr, req)
+
+		status := wr.statusCode()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		r.Record(req, body, status, wr.Header(), wr.bodyContents())
+	})
+}
+
+// Record records an exchange with an operation: the request along with
+// its body and the status, header and body of its response.
+// Requests that do not target an operation are ignored.
+func (r *Recorder) Record(req *http.Request, requestBody []byte, status int, header http.Header, responseBody []byte) {
+	route, _, err := r.router.FindRoute(req)
+	if err != nil {
+		return
+	}
+	operation := route.Operation
+
+	if ref := operation.RequestBody; ref != nil && ref.Value != nil && len(requestBody) != 0 {
+		r.record(route, "", ref.Value.Content, req.Header, requestBody)
+	}
+
+	if operation.Responses == nil || len(responseBody) == 0 {
+		return
+	}
+	response, statusKey := operation.Responses.FindFor(status)
+	if response != nil && response.Value != nil {
+		r.record(route, statusKey, response.Value.Content, header, responseBody)
+	}
+}
+
+func (r *Recorder) record(route *routers.Route, status string, content openapi3.Content, header http.Header, body []byte) {
+	contentType, ok := contentKey(content, content.Get(header.Get(headerCT)))
+	if !ok {
+		return
+	}
+	mediaType := content[contentType]
+	encFn := func(name string) *openapi3.Encoding { return mediaType.Encoding[name] }
+	_, value, err := decodeBody(bytes.NewReader(body), header, mediaType.Schema, encFn)
+	if err != nil {
+		return
+	}
+	value = r.redact(value)
+	if r.sanitize != nil {
+		if value = r.sanitize(value); value == nil {
+			return
+		}
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	target := recordTarget{method: route.Method, path: route.Path, status: status, contentType: contentType}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	examples := r.examples[target]
+	if len(examples) >= r.maxExamples {
+		return
+	}
+	for _, example := range examples {
+		if example.encoded == string(encoded) {
+			return
+		}
+	}
+	r.examples[target] = append(examples, recordedExample{value: value, encoded: string(encoded)})
+}
+
+// redact returns value with the values of sensitive properties replaced.
+func (r *Recorder) redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, v := range value {
+			if r.isRedacted(name) {
+				value[name] = RedactedValue
+			} else {
+				value[name] = r.redact(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = r.redact(v)
+		}
+	}
+	return value
+}
+
+func (r *Recorder) isRedacted(name string) bool {
+	name = strings.ToLower(name)
+	for _, redacted := range r.redacted {
+		if strings.Contains(name, strings.ToLower(redacted)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteExamples adds the recorded examples to the media types of doc they were
+// recorded for, named "recorded", "recorded2", ... without replacing existing examples.
+// It returns the number of examples added.
+func (r *Recorder) WriteExamples(doc *openapi3.T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	targets := make([]recordTarget, 0, len(r.examples))
+	for target := range r.examples {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.status != b.status {
+			return a.status < b.status
+		}
+		return a.contentType < b.contentType
+	})
+
+	added := 0
+	for _, target := range targets {
+		mediaType := recordedMediaType(doc, target)
+		if mediaType == nil {
+			continue
+		}
+		if mediaType.Examples == nil {
+			mediaType.Examples = make(openapi3.Examples)
+		}
+		for _, example := range r.examples[target] {
+			if hasExampleValue(mediaType.Examples, example.encoded) {
+				continue
+			}
+			name := "recorded"
+			for i := 2; mediaType.Examples[name] != nil; i++ {
+				name = "recorded" + strconv.Itoa(i)
+			}
+			mediaType.Examples[name] = &openapi3.ExampleRef{Value: &openapi3.Example{
+				Summary: "Recorded from traffic",
+				Value:   example.value,
+			}}
+			added++
+		}
+	}
+	return added
+}
+
+func recordedMediaType(doc *openapi3.T, target recordTarget) *openapi3.MediaType {
+	pathItem := doc.Paths[target.path]
+	if pathItem == nil {
+		return nil
+	}
+	operation := pathItem.GetOperation(target.method)
+	if operation == nil {
+		return nil
+	}
+	var content openapi3.Content
+	if target.status == "" {
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			content = ref.Value.Content
+		}
+	} else if ref := operation.Responses[target.status]; ref != nil && ref.Value != nil {
+		content = ref.Value.Content
+	}
+	return content[target.contentType]
+}
+
+func hasExampleValue(examples openapi3.Examples, encoded string) bool {
+	for _, ref := range examples {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		if data, err := json.Marshal(ref.Value.Value); err == nil && string(data) == encoded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package openapi3filter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestRecorder(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Users
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object}
+      responses:
+        '201':
+          description: created
+          content:
+            application/json:
+              schema: {type: object}
+              examples:
+                existing:
+                  value: {id: 1, name: Ann}
+        default:
+          description: error
+          content:
+            application/json:
+              schema: {type: object}
+`[1:]
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	recorder := NewRecorder(router, MaxExamples(2))
+	id := 0
+	handler := recorder.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var user map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+		w.Header().Set("Content-Type", "application/json")
+		if user["name"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"empty name"}`))
+			return
+		}
+		id++
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "name": user["name"]}))
+	}))
+
+	for _, body := range []string{
+		`{"name":"Ann","password":"hunter2"}`,
+		`{"name":"Bob","accessToken":"abc"}`,
+		`{"name":"Cid"}`,
+		`{"name":""}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.NotEmpty(t, rec.Body.String())
+	}
+	req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Equal(t, 4, recorder.WriteExamples(doc))
+	operation := doc.Paths["/users"].Post
+
+	requestExamples := operation.RequestBody.Value.Content["application/json"].Examples
+	require.Len(t, requestExamples, 2)
+	require.Equal(t, map[string]interface{}{"name": "Ann", "password": RedactedValue}, requestExamples["recorded"].Value.Value)
+	require.Equal(t, map[string]interface{}{"name": "Bob", "accessToken": RedactedValue}, requestExamples["recorded2"].Value.Value)
+
+	// The first response is already an example
+	createdExamples := operation.Responses["201"].Value.Content["application/json"].Examples
+	require.Len(t, createdExamples, 2)
+	require.Equal(t, map[string]interface{}{"id": 2.0, "name": "Bob"}, createdExamples["recorded"].Value.Value)
+
+	errorExamples := operation.Responses["default"].Value.Content["application/json"].Examples
+	require.Len(t, errorExamples, 1)
+	require.Equal(t, map[string]interface{}{"error": "empty name"}, errorExamples["recorded"].Value.Value)
+
+	// Examples are written once
+	require.Equal(t, 0, recorder.WriteExamples(doc))
+	require.NoError(t, doc.Validate(loader.Context))
+}
+
+func TestRecorderKeepsRequestBody(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Echo
+paths:
+  /echo:
+    post:
+      requestBody:
+        content:
+          text/plain:
+            schema: {type: string}
+      responses:
+        '200':
+          description: ok
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	recorder := NewRecorder(router, SanitizeExamples(func(value interface{}) interface{} {
+		return strings.ToUpper(value.(string))
+	}))
+	handler := recorder.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Write(body)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("hello"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, "hello", rec.Body.String())
+
+	require.Equal(t, 1, recorder.WriteExamples(doc))
+	require.Equal(t, "HELLO", doc.Paths["/echo"].Post.RequestBody.Value.Content["text/plain"].Examples["recorded"].Value.Value)
+}
@@ -0,0 +1,144 @@
+package openapi3filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EncodeParameter serializes value according to the style and explode of parameter,
+// the opposite of the decoding done by ValidateRequest.
+// value is a value as decoded from JSON: nil, a bool, a float64, a json.Number, a string,
+// a []interface{} of those or a map[string]interface{} of those.
+//
+// The serialized values are returned under the names they are sent with. That is the
+// name of the parameter, except for query parameters holding objects in the form style
+// when exploded, sent as a value per property, and in the deepObject style,
+// sent as name[property]. Values are not escaped.
+func EncodeParameter(parameter *openapi3.Parameter, value interface{}) (url.Values, error) {
+	sm, err := parameter.SerializationMethod()
+	if err != nil {
+		return nil, err
+	}
+	name := parameter.Name
+	values := make(url.Values)
+
+	switch value := value.(type) {
+	case nil:
+		return values, nil
+
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for i, item := range value {
+			s, err := encodePrimitive(item)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: item %d: %w", name, i, err)
+			}
+			items = append(items, s)
+		}
+		switch {
+		case sm.Style == openapi3.SerializationSimple:
+			values.Set(name, strings.Join(items, ","))
+		case sm.Style == openapi3.SerializationLabel && sm.Explode:
+			values.Set(name, "."+strings.Join(items, "."))
+		case sm.Style == openapi3.SerializationLabel:
+			values.Set(name, "."+strings.Join(items, ","))
+		case sm.Style == openapi3.SerializationMatrix && sm.Explode:
+			values.Set(name, ";"+name+"="+strings.Join(items, ";"+name+"="))
+		case sm.Style == openapi3.SerializationMatrix:
+			values.Set(name, ";"+name+"="+strings.Join(items, ","))
+		case sm.Explode && (sm.Style == openapi3.SerializationForm ||
+			sm.Style == openapi3.SerializationSpaceDelimited ||
+			sm.Style == openapi3.SerializationPipeDelimited):
+			values[name] = items
+		case sm.Style == openapi3.SerializationForm:
+			values.Set(name, strings.Join(items, ","))
+		case sm.Style == openapi3.SerializationSpaceDelimited:
+			values.Set(name, strings.Join(items, " "))
+		case sm.Style == openapi3.SerializationPipeDelimited:
+			values.Set(name, strings.Join(items, "|"))
+		default:
+			return nil, fmt.Errorf("parameter %q: %w", name, invalidSerializationMethodErr(sm))
+		}
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		props := make([]string, 0, len(keys))
+		var pairs, flattened []string
+		for _, key := range keys {
+			s, err := encodePrimitive(value[key])
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: property %q: %w", name, key, err)
+			}
+			props = append(props, s)
+			pairs = append(pairs, key+"="+s)
+			flattened = append(flattened, key, s)
+		}
+		switch {
+		case sm.Style == openapi3.SerializationSimple && sm.Explode:
+			values.Set(name, strings.Join(pairs, ","))
+		case sm.Style == openapi3.SerializationSimple:
+			values.Set(name, strings.Join(flattened, ","))
+		case sm.Style == openapi3.SerializationLabel && sm.Explode:
+			values.Set(name, "."+strings.Join(pairs, "."))
+		case sm.Style == openapi3.SerializationLabel:
+			values.Set(name, "."+strings.Join(flattened, ","))
+		case sm.Style == openapi3.SerializationMatrix && sm.Explode:
+			values.Set(name, ";"+strings.Join(pairs, ";"))
+		case sm.Style == openapi3.SerializationMatrix:
+			values.Set(name, ";"+name+"="+strings.Join(flattened, ","))
+		case sm.Style == openapi3.SerializationForm && sm.Explode:
+			for i, key := range keys {
+				values.Set(key, props[i])
+			}
+		case sm.Style == openapi3.SerializationForm:
+			values.Set(name, strings.Join(flattened, ","))
+		case sm.Style == openapi3.SerializationDeepObject:
+			for i, key := range keys {
+				values.Set(name+"["+key+"]", props[i])
+			}
+		default:
+			return nil, fmt.Errorf("parameter %q: %w", name, invalidSerializationMethodErr(sm))
+		}
+
+	default:
+		s, err := encodePrimitive(value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		switch sm.Style {
+		case openapi3.SerializationLabel:
+			s = "." + s
+		case openapi3.SerializationMatrix:
+			s = ";" + name + "=" + s
+		}
+		values.Set(name, s)
+	}
+	return values, nil
+}
+
+func encodePrimitive(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case json.Number:
+		return value.String(), nil
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("value of type %T cannot be serialized", value)
+	}
+}
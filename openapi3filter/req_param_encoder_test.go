@@ -0,0 +1,160 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEncodeParameter(t *testing.T) {
+	explode, noExplode := true, false
+	integer := openapi3.NewIntegerSchema().NewRef()
+	array := openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema()).NewRef()
+	object := openapi3.NewObjectSchema().
+		WithProperty("role", openapi3.NewStringSchema()).
+		WithProperty("age", openapi3.NewIntegerSchema()).NewRef()
+	primitiveValue := float64(5)
+	arrayValue := []interface{}{float64(3), float64(4), float64(5)}
+	objectValue := map[string]interface{}{"role": "admin", "age": float64(42)}
+
+	for _, tc := range []struct {
+		name      string
+		parameter *openapi3.Parameter
+		value     interface{}
+		encoded   url.Values
+	}{
+		{
+			name:      "path simple primitive",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Schema: integer},
+			value:     primitiveValue,
+			encoded:   url.Values{"id": {"5"}},
+		},
+		{
+			name:      "path simple array",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3,4,5"}},
+		},
+		{
+			name:      "path simple exploded object",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Explode: &explode, Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"id": {"age=42,role=admin"}},
+		},
+		{
+			name:      "path label array",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Style: "label", Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {".3,4,5"}},
+		},
+		{
+			name:      "path label exploded object",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Style: "label", Explode: &explode, Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"id": {".age=42.role=admin"}},
+		},
+		{
+			name:      "path matrix primitive",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Style: "matrix", Schema: integer},
+			value:     primitiveValue,
+			encoded:   url.Values{"id": {";id=5"}},
+		},
+		{
+			name:      "path matrix exploded array",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Style: "matrix", Explode: &explode, Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {";id=3;id=4;id=5"}},
+		},
+		{
+			name:      "path matrix object",
+			parameter: &openapi3.Parameter{Name: "id", In: "path", Style: "matrix", Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"id": {";id=age,42,role,admin"}},
+		},
+		{
+			name:      "query form exploded array",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3", "4", "5"}},
+		},
+		{
+			name:      "query form array",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Explode: &noExplode, Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3,4,5"}},
+		},
+		{
+			name:      "query form exploded object",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"role": {"admin"}, "age": {"42"}},
+		},
+		{
+			name:      "query space delimited array",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Style: "spaceDelimited", Explode: &noExplode, Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3 4 5"}},
+		},
+		{
+			name:      "query pipe delimited array",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Style: "pipeDelimited", Explode: &noExplode, Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3|4|5"}},
+		},
+		{
+			name:      "query deep object",
+			parameter: &openapi3.Parameter{Name: "id", In: "query", Style: "deepObject", Explode: &explode, Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"id[role]": {"admin"}, "id[age]": {"42"}},
+		},
+		{
+			name:      "header object",
+			parameter: &openapi3.Parameter{Name: "X-Id", In: "header", Schema: object},
+			value:     objectValue,
+			encoded:   url.Values{"X-Id": {"age,42,role,admin"}},
+		},
+		{
+			name:      "cookie array",
+			parameter: &openapi3.Parameter{Name: "id", In: "cookie", Explode: &noExplode, Schema: array},
+			value:     arrayValue,
+			encoded:   url.Values{"id": {"3,4,5"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := EncodeParameter(tc.parameter, tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, encoded)
+
+			// What is encoded decodes back to the value
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			input := &RequestValidationInput{Request: req}
+			switch tc.parameter.In {
+			case openapi3.ParameterInPath:
+				input.PathParams = map[string]string{tc.parameter.Name: encoded.Get(tc.parameter.Name)}
+			case openapi3.ParameterInQuery:
+				req.URL.RawQuery = encoded.Encode()
+			case openapi3.ParameterInHeader:
+				req.Header.Set(tc.parameter.Name, encoded.Get(tc.parameter.Name))
+			case openapi3.ParameterInCookie:
+				req.AddCookie(&http.Cookie{Name: tc.parameter.Name, Value: encoded.Get(tc.parameter.Name)})
+			}
+			decoded, found, err := DecodeParameter(input, tc.parameter)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, tc.value, decoded)
+		})
+	}
+}
+
+func TestEncodeParameterErrors(t *testing.T) {
+	_, err := EncodeParameter(&openapi3.Parameter{Name: "id", In: "query", Style: "deepObject"}, []interface{}{"a"})
+	require.EqualError(t, err, `parameter "id": invalid serialization method: style="deepObject", explode=true`)
+
+	_, err = EncodeParameter(&openapi3.Parameter{Name: "id", In: "query"}, []interface{}{[]interface{}{"a"}})
+	require.EqualError(t, err, `parameter "id": item 0: value of type []interface {} cannot be serialized`)
+}
@@ -218,6 +218,36 @@ func defaultContentParameterDecoder(param *openapi3.Parameter, values []string)
 	return
 }
 
+// DecodeParameter returns the value of an operation's parameter from the request of input,
+// decoded according to the parameter's content or style, and whether the parameter is
+// supplied in the input. The default value of the parameter's schema is returned when
+// the parameter is not supplied.
+// The function returns ParseError when HTTP request contains an invalid value of a parameter.
+func DecodeParameter(input *RequestValidationInput, parameter *openapi3.Parameter) (interface{}, bool, error) {
+	if parameter.Schema == nil && parameter.Content == nil {
+		return nil, false, nil
+	}
+	value, schema, found, err := decodeParameter(parameter, input)
+	if err != nil {
+		return nil, false, err
+	}
+	if value == nil && schema != nil && schema.Default != nil {
+		value = schema.Default
+	}
+	return value, found, nil
+}
+
+// decodeParameter returns the value of param from the request of input, the schema
+// of the value and whether the parameter is supplied in the input.
+func decodeParameter(param *openapi3.Parameter, input *RequestValidationInput) (value interface{}, schema *openapi3.Schema, found bool, err error) {
+	// Validation will ensure that we either have content or schema.
+	if param.Content != nil {
+		return decodeContentParameter(param, input)
+	}
+	value, found, err = decodeStyledParameter(param, input)
+	return value, param.Schema.Value, found, err
+}
+
 type valueDecoder interface {
 	DecodePrimitive(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (interface{}, bool, error)
 	DecodeArray(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) ([]interface{}, bool, error)
@@ -938,7 +968,29 @@ func RegisteredBodyDecoder(contentType string) BodyDecoder {
 	return bodyDecoders[contentType]
 }
 
+// bodyDecoderFor returns the decoder registered for a media type, or else
+// that registered for its structured syntax suffix, e.g. application/json
+// for application/problem+json, or else that registered for its type, e.g. text/*.
+func bodyDecoderFor(mediaType string) (BodyDecoder, bool) {
+	if decoder, ok := bodyDecoders[mediaType]; ok {
+		return decoder, true
+	}
+	slash := strings.IndexByte(mediaType, '/')
+	if slash < 0 {
+		return nil, false
+	}
+	if plus := strings.LastIndexByte(mediaType, '+'); plus > slash {
+		if decoder, ok := bodyDecoders["application/"+mediaType[plus+1:]]; ok {
+			return decoder, true
+		}
+	}
+	decoder, ok := bodyDecoders[mediaType[:slash]+"/*"]
+	return decoder, ok
+}
+
 // RegisterBodyDecoder registers a request body's decoder for a content type.
+// The content type may be a wildcard such as text/*, used for the content types
+// of that type with no decoder of their own.
 //
 // If a decoder for the specified content type already exists, the function replaces
 // it with the specified decoder.
@@ -982,7 +1034,7 @@ func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef,
 		}
 	}
 	mediaType := parseMediaType(contentType)
-	decoder, ok := bodyDecoders[mediaType]
+	decoder, ok := bodyDecoderFor(mediaType)
 	if !ok {
 		return "", nil, &ParseError{
 			Kind:   KindUnsupportedFormat,
@@ -998,6 +1050,7 @@ func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef,
 
 func init() {
 	RegisterBodyDecoder("text/plain", plainBodyDecoder)
+	RegisterBodyDecoder("text/*", plainBodyDecoder)
 	RegisterBodyDecoder("application/json", jsonBodyDecoder)
 	RegisterBodyDecoder("application/json-patch+json", jsonBodyDecoder)
 	RegisterBodyDecoder("application/x-yaml", yamlBodyDecoder)
@@ -1013,6 +1066,13 @@ func plainBodyDecoder(body io.Reader, header http.Header, schema *openapi3.Schem
 	if err != nil {
 		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err}
 	}
+	// Plain text of a number or boolean schema is parsed as such
+	if schema != nil && schema.Value != nil {
+		switch schema.Value.Type {
+		case "integer", "number", "boolean":
+			return parsePrimitive(string(data), schema)
+		}
+	}
 	return string(data), nil
 }
 
@@ -1119,7 +1179,7 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 		var exists bool
 		valueSchema, exists = schema.Value.Properties[name]
 		if !exists {
-			anyProperties := schema.Value.AdditionalPropertiesAllowed
+			anyProperties := schema.Value.AdditionalProperties.Has
 			if anyProperties != nil {
 				switch *anyProperties {
 				case true:
@@ -1130,10 +1190,10 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 					return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
 				}
 			}
-			if schema.Value.AdditionalProperties == nil {
+			if schema.Value.AdditionalProperties.Schema == nil {
 				return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
 			}
-			valueSchema, exists = schema.Value.AdditionalProperties.Value.Properties[name]
+			valueSchema, exists = schema.Value.AdditionalProperties.Schema.Value.Properties[name]
 			if !exists {
 				return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
 			}
@@ -1156,8 +1216,8 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 	for k, v := range schema.Value.Properties {
 		allTheProperties[k] = v
 	}
-	if schema.Value.AdditionalProperties != nil {
-		for k, v := range schema.Value.AdditionalProperties.Value.Properties {
+	if schema.Value.AdditionalProperties.Schema != nil {
+		for k, v := range schema.Value.AdditionalProperties.Schema.Value.Properties {
 			allTheProperties[k] = v
 		}
 	}
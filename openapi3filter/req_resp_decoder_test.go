@@ -1361,6 +1361,16 @@ func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 	originalDecoder = RegisteredBodyDecoder(contentType)
 	require.Nil(t, originalDecoder)
 
+	// Without a decoder of its own, text/csv is decoded as text
+	body = strings.NewReader("foo,bar")
+	_, got, err = decodeBody(body, h, openapi3.NewStringSchema().NewRef(), encFn)
+	require.NoError(t, err)
+	require.Equal(t, "foo,bar", got)
+
+	// Unless the decoder of text/* is unregistered too
+	textDecoder := RegisteredBodyDecoder("text/*")
+	UnregisterBodyDecoder("text/*")
+	defer RegisterBodyDecoder("text/*", textDecoder)
 	_, _, err = decodeBody(body, h, schema, encFn)
 	require.Equal(t, &ParseError{
 		Kind:   KindUnsupportedFormat,
@@ -1368,6 +1378,38 @@ func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 	}, err)
 }
 
+func TestBodyDecoderFallbacks(t *testing.T) {
+	encFn := func(string) *openapi3.Encoding { return nil }
+	for _, tc := range []struct {
+		contentType string
+		body        string
+		schema      *openapi3.Schema
+		expected    interface{}
+	}{
+		{"application/vnd.api+json", `{"id":1}`, openapi3.NewObjectSchema(), map[string]interface{}{"id": 1.0}},
+		{"text/html; charset=utf-8", "<p>Hi</p>", openapi3.NewStringSchema(), "<p>Hi</p>"},
+		{"text/plain", "42", openapi3.NewIntegerSchema(), 42.0},
+		{"text/plain", "true", openapi3.NewBoolSchema(), true},
+		{"text/plain", "42", openapi3.NewStringSchema(), "42"},
+	} {
+		h := http.Header{headerCT: {tc.contentType}}
+		_, got, err := decodeBody(strings.NewReader(tc.body), h, tc.schema.NewRef(), encFn)
+		require.NoError(t, err, tc.contentType)
+		require.Equal(t, tc.expected, got, tc.contentType)
+	}
+
+	h := http.Header{headerCT: {"text/plain"}}
+	_, _, err := decodeBody(strings.NewReader("many"), h, openapi3.NewIntegerSchema().NewRef(), encFn)
+	require.Error(t, err)
+
+	h = http.Header{headerCT: {"application/vnd.api+xml"}}
+	_, _, err = decodeBody(strings.NewReader("<a/>"), h, openapi3.NewStringSchema().NewRef(), encFn)
+	require.Equal(t, &ParseError{
+		Kind:   KindUnsupportedFormat,
+		Reason: prefixUnsupportedCT + ` "application/vnd.api+xml"`,
+	}, err)
+}
+
 func matchParseError(got, want error) bool {
 	wErr, ok := want.(*ParseError)
 	if !ok {
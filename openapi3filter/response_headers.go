@@ -0,0 +1,129 @@
+package openapi3filter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ResponseHeader is a header declared by the response of an operation.
+type ResponseHeader struct {
+	Name string
+	*openapi3.Header
+}
+
+// ResponseHeaders lists the headers declared by the response of the route's operation
+// for the given status code, sorted by name. Content-Type is not listed as it
+// is described by the response content instead.
+// It returns nil when no response is declared for the status code.
+func ResponseHeaders(route *routers.Route, status int) []ResponseHeader {
+	responseRef, _ := route.Operation.Responses.FindFor(status)
+	if responseRef == nil || responseRef.Value == nil {
+		return nil
+	}
+	return declaredResponseHeaders(responseRef.Value)
+}
+
+func declaredResponseHeaders(response *openapi3.Response) []ResponseHeader {
+	names := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		if name != headerCT {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	headers := make([]ResponseHeader, 0, len(names))
+	for _, name := range names {
+		headers = append(headers, ResponseHeader{Name: name, Header: response.Headers[name].Value})
+	}
+	return headers
+}
+
+// ValidateResponseHeaders validates the headers of the given input the same way
+// ValidateResponse does, without reading the response body.
+// It can be used to check the headers before they are written.
+func ValidateResponseHeaders(ctx context.Context, input *ResponseValidationInput) error {
+	options := input.Options
+	if options == nil {
+		options = DefaultOptions
+	}
+	responseRef, _ := input.RequestValidationInput.Route.Operation.Responses.FindFor(input.Status)
+	if responseRef == nil {
+		if !options.IncludeResponseStatus {
+			return nil
+		}
+		return &ResponseError{Input: input, Reason: "status is not supported"}
+	}
+	if responseRef.Value == nil {
+		return &ResponseError{Input: input, Reason: "response has not been resolved"}
+	}
+	return validateResponseHeaders(input, responseRef.Value, responseSchemaValidationOptions(ctx, input, options))
+}
+
+// SetResponseHeaders sets the headers declared by the response of the route's operation
+// for the given status code.
+// Values are serialized according to the style of their header. Headers missing from values
+// are set to the default of their schema if any, unless they are already set.
+// A value for an undeclared header is an error.
+func SetResponseHeaders(route *routers.Route, status int, header http.Header, values map[string]interface{}) error {
+	declared := make(map[string]*openapi3.Header)
+	for _, h := range ResponseHeaders(route, status) {
+		declared[http.CanonicalHeaderKey(h.Name)] = h.Header
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if _, ok := declared[http.CanonicalHeaderKey(name)]; !ok {
+			return fmt.Errorf("response header %q is not declared", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := setResponseHeader(header, name, declared[http.CanonicalHeaderKey(name)], values[name]); err != nil {
+			return err
+		}
+	}
+	for _, h := range ResponseHeaders(route, status) {
+		if _, ok := values[h.Name]; ok || header.Get(h.Name) != "" {
+			continue
+		}
+		if h.Schema == nil || h.Schema.Value == nil || h.Schema.Value.Default == nil {
+			continue
+		}
+		if err := setResponseHeader(header, h.Name, h.Header, h.Schema.Value.Default); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setResponseHeader(header http.Header, name string, h *openapi3.Header, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("response header %q: %w", name, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return fmt.Errorf("response header %q: %w", name, err)
+	}
+	parameter := h.Parameter
+	parameter.Name = name
+	parameter.In = openapi3.ParameterInHeader
+	encoded, err := EncodeParameter(&parameter, decoded)
+	if err != nil {
+		return fmt.Errorf("response header %q: %w", name, err)
+	}
+	header.Del(name)
+	for _, v := range encoded[name] {
+		header.Add(name, v)
+	}
+	return nil
+}
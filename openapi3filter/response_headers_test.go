@@ -0,0 +1,90 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestResponseHeaders(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Headers'
+  version: 0.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: The pets.
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema: {type: integer, default: 100}
+            X-Tags:
+              schema: {type: array, items: {type: string}}
+            Content-Type:
+              schema: {type: string}
+        default:
+          description: An error.
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "/pets", nil)
+	require.NoError(t, err)
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	headers := ResponseHeaders(route, http.StatusOK)
+	require.Len(t, headers, 2)
+	require.Equal(t, "X-Rate-Limit", headers[0].Name)
+	require.True(t, headers[0].Required)
+	require.Equal(t, "integer", headers[0].Schema.Value.Type)
+	require.Equal(t, "X-Tags", headers[1].Name)
+	require.False(t, headers[1].Required)
+	require.Empty(t, ResponseHeaders(route, http.StatusInternalServerError))
+
+	validate := func(header http.Header) error {
+		return ValidateResponseHeaders(context.Background(), &ResponseValidationInput{
+			RequestValidationInput: &RequestValidationInput{Request: req, PathParams: pathParams, Route: route},
+			Status:                 http.StatusOK,
+			Header:                 header,
+		})
+	}
+
+	header := http.Header{}
+	err = validate(header)
+	require.EqualError(t, err, `response header "X-Rate-Limit" missing`)
+
+	// Defaults are set for missing headers
+	require.NoError(t, SetResponseHeaders(route, http.StatusOK, header, nil))
+	require.Equal(t, http.Header{"X-Rate-Limit": {"100"}}, header)
+	require.NoError(t, validate(header))
+
+	header = http.Header{}
+	err = SetResponseHeaders(route, http.StatusOK, header, map[string]interface{}{
+		"x-rate-limit": 10,
+		"X-Tags":       []string{"a", "b"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.Header{"X-Rate-Limit": {"10"}, "X-Tags": {"a,b"}}, header)
+	require.NoError(t, validate(header))
+
+	header.Set("X-Rate-Limit", "many")
+	err = validate(header)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `response header "X-Rate-Limit" doesn't match the schema`)
+
+	err = SetResponseHeaders(route, http.StatusOK, header, map[string]interface{}{"X-Unknown": 1})
+	require.EqualError(t, err, `response header "X-Unknown" is not declared`)
+}
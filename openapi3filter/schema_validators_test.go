@@ -0,0 +1,107 @@
+package openapi3filter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestSchemaValidators(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: The pet.
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string, minLength: 1}
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	var calls int
+	accept := false
+	options := &Options{
+		SchemaValidators: map[string]SchemaValidator{
+			"#/components/schemas/Pet": func(value interface{}) bool {
+				calls++
+				return accept
+			},
+		},
+	}
+	validate := func(body string) (error, error) {
+		req, err := http.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		reqErr := ValidateRequest(context.Background(), input)
+
+		respInput := &ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 http.StatusOK,
+			Header:                 http.Header{"Content-Type": {"application/json"}},
+			Body:                   ioutil.NopCloser(strings.NewReader(body)),
+			Options:                options,
+		}
+		return reqErr, ValidateResponse(context.Background(), respInput)
+	}
+
+	// Bodies the validator rejects are validated against the schema
+	reqErr, respErr := validate(`{"name":"Tom"}`)
+	require.NoError(t, reqErr)
+	require.NoError(t, respErr)
+	require.Equal(t, 2, calls)
+
+	reqErr, respErr = validate(`{"name":""}`)
+	require.Error(t, reqErr)
+	var schemaErr *openapi3.SchemaError
+	require.ErrorAs(t, reqErr, &schemaErr)
+	require.Equal(t, "minLength", schemaErr.SchemaField)
+	require.Error(t, respErr)
+	require.Equal(t, 4, calls)
+
+	// The schema is not used for bodies the validator accepts
+	accept = true
+	reqErr, respErr = validate(`{"name":""}`)
+	require.NoError(t, reqErr)
+	require.NoError(t, respErr)
+	require.Equal(t, 6, calls)
+
+	// Validators are not used when recording coverage
+	options.Coverage = NewCoverage(doc)
+	reqErr, _ = validate(`{"name":""}`)
+	require.Error(t, reqErr)
+	require.Equal(t, 6, calls)
+}
@@ -0,0 +1,85 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestSkipRequest(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema: {type: integer}
+      responses:
+        '200':
+          description: Pets
+          content:
+            application/json:
+              schema: {type: array}
+  /internal/stats:
+    get:
+      responses:
+        '200':
+          description: Stats
+          content:
+            application/json:
+              schema: {type: object}
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	options := Options{
+		SkipRequest: SkipAny(
+			SkipMethods(http.MethodOptions),
+			SkipPathPrefixes("/healthz", "/internal/"),
+			func(req *http.Request, route *routers.Route) bool {
+				return route != nil && req.Header.Get("X-Skip") != ""
+			},
+		),
+		SkipResponse: SkipPathPrefixes("/internal/"),
+	}
+	v := NewValidator(router, ValidationOptions(options), Strict(true), OnLog(func(string, error) {}))
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"not validated"`))
+	}))
+	serve := func(method, target string, header http.Header) int {
+		req := httptest.NewRequest(method, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Not found but skipped
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, serve(http.MethodOptions, "/pets", nil))
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/unknown", nil))
+
+	// Neither the request nor the response are validated
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, "/internal/stats", nil))
+
+	// Only the request is skipped, the invalid response is rejected
+	require.Equal(t, http.StatusInternalServerError, serve(http.MethodGet, "/pets", http.Header{"X-Skip": {"1"}}))
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/pets", nil))
+}
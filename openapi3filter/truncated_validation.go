@@ -0,0 +1,55 @@
+package openapi3filter
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// TruncatedValidation is a warning that only some of the items of an array,
+// or of the properties of an object, of a request or a response were validated.
+// See Options.MaxValidatedItems.
+type TruncatedValidation struct {
+	// Parameter is the parameter the array or object was found in.
+	// It is nil for bodies and response headers.
+	Parameter *openapi3.Parameter
+	// Schema of the array or object
+	Schema *openapi3.Schema
+	// Validated is the number of items or properties validated, out of Length.
+	Validated, Length int
+}
+
+func (t TruncatedValidation) String() string {
+	where := "a body"
+	if p := t.Parameter; p != nil {
+		where = fmt.Sprintf("%s parameter %q", p.In, p.Name)
+	}
+	return fmt.Sprintf("validated %d of %d items of a value of %s", t.Validated, t.Length, where)
+}
+
+// itemsLimitOptions returns the schema validation options limiting the validation
+// of arrays and objects to Options.MaxValidatedItems and calling add with the warnings.
+func (options *Options) itemsLimitOptions(parameter *openapi3.Parameter, add func(TruncatedValidation)) []openapi3.SchemaValidationOption {
+	n := options.MaxValidatedItems
+	if n <= 0 {
+		return nil
+	}
+	return []openapi3.SchemaValidationOption{openapi3.LimitItemsValidation(n, func(schema *openapi3.Schema, value interface{}) {
+		t := TruncatedValidation{Parameter: parameter, Schema: schema, Validated: n}
+		switch value := value.(type) {
+		case []interface{}:
+			t.Length = len(value)
+		case map[string]interface{}:
+			t.Length = len(value)
+		}
+		add(t)
+	})}
+}
+
+func (input *RequestValidationInput) addTruncatedValidation(t TruncatedValidation) {
+	input.TruncatedValidations = append(input.TruncatedValidations, t)
+}
+
+func (input *ResponseValidationInput) addTruncatedValidation(t TruncatedValidation) {
+	input.TruncatedValidations = append(input.TruncatedValidations, t)
+}
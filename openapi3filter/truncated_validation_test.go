@@ -0,0 +1,96 @@
+package openapi3filter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestMaxValidatedItems(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /points:
+    post:
+      parameters:
+        - name: ids
+          in: query
+          schema:
+            type: array
+            items: {type: integer, minimum: 1}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                points:
+                  type: array
+                  items: {type: number}
+                labels:
+                  type: object
+                  additionalProperties: {type: string}
+      responses:
+        '200':
+          description: The points.
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: number}
+`
+	router := setupTestRouter(t, spec)
+	options := &Options{MaxValidatedItems: 2}
+
+	validate := func(query, body string) (*RequestValidationInput, error) {
+		req, err := http.NewRequest(http.MethodPost, "/points?"+query, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return input, ValidateRequest(context.Background(), input)
+	}
+
+	input, err := validate("ids=1&ids=2", `{"points":[1,2],"labels":{"a":"x","b":"y"}}`)
+	require.NoError(t, err)
+	require.Empty(t, input.TruncatedValidations)
+
+	// Items past the first two are not validated
+	input, err = validate("ids=1&ids=2&ids=0", `{"points":[1,2,"three"],"labels":{"a":"x","b":"y","c":3}}`)
+	require.NoError(t, err)
+	require.Len(t, input.TruncatedValidations, 3)
+	require.Equal(t, `validated 2 of 3 items of a value of query parameter "ids"`, input.TruncatedValidations[0].String())
+	require.Equal(t, "validated 2 of 3 items of a value of a body", input.TruncatedValidations[1].String())
+	// labels come before points
+	require.Equal(t, openapi3.TypeObject, input.TruncatedValidations[1].Schema.Type)
+	require.Equal(t, openapi3.TypeArray, input.TruncatedValidations[2].Schema.Type)
+
+	_, err = validate("ids=0&ids=1&ids=2", `{}`)
+	require.Error(t, err)
+	_, err = validate("", `{"points":[1,"two",3]}`)
+	require.Error(t, err)
+	// Properties are validated in key order
+	_, err = validate("", `{"labels":{"a":"x","b":2,"c":"z"}}`)
+	require.Error(t, err)
+
+	respInput := &ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 http.StatusOK,
+		Header:                 http.Header{"Content-Type": {"application/json"}},
+		Body:                   ioutil.NopCloser(strings.NewReader(`[1,2,"three","four"]`)),
+		Options:                options,
+	}
+	require.NoError(t, ValidateResponse(context.Background(), respInput))
+	require.Len(t, respInput.TruncatedValidations, 1)
+	require.Equal(t, 4, respInput.TruncatedValidations[0].Length)
+}
@@ -0,0 +1,178 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownContentType(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Content types'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        '201':
+          description: Created.
+`
+	router := setupTestRouter(t, spec)
+
+	validate := func(body string, options *Options) (*RequestValidationInput, error) {
+		req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return input, ValidateRequest(context.Background(), input)
+	}
+
+	_, err := validate(`{"name":"Rex"}`, nil)
+	require.EqualError(t, err, `request body has an error: header Content-Type has unexpected value "text/plain"`)
+
+	input, err := validate(`not even JSON`, &Options{UnknownContentType: WarnUnknownContentType})
+	require.NoError(t, err)
+	require.Equal(t, "text/plain", input.UnknownContentType)
+
+	fallback := &Options{UnknownContentType: FallbackUnknownContentType, FallbackContentType: "application/json"}
+	input, err = validate(`{"name":"Rex"}`, fallback)
+	require.NoError(t, err)
+	require.Empty(t, input.UnknownContentType)
+	_, err = validate(`{}`, fallback)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `property "name" is missing`)
+
+	// There is no fallback content type
+	_, err = validate(`{"name":"Rex"}`, &Options{UnknownContentType: FallbackUnknownContentType})
+	require.EqualError(t, err, `request body has an error: header Content-Type has unexpected value "text/plain"`)
+
+	// The operation does not declare the fallback content type
+	_, err = validate(`{"name":"Rex"}`, &Options{UnknownContentType: FallbackUnknownContentType, FallbackContentType: "application/xml"})
+	require.EqualError(t, err, `request body has an error: header Content-Type has unexpected value "text/plain"`)
+
+	var logged []string
+	handler := NewValidator(router,
+		ValidationOptions(Options{UnknownContentType: WarnUnknownContentType}),
+		OnLog(func(message string, err error) { logged = append(logged, message+": "+err.Error()) }),
+	).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`<pet/>`))
+	req.Header.Set("Content-Type", "application/xml")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, []string{`request body not validated: header Content-Type has unexpected value "application/xml"`}, logged)
+}
+
+func TestUnknownContentTypeFallbackToRange(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Content types'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/*:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        '201':
+          description: Created.
+`
+	router := setupTestRouter(t, spec)
+
+	validate := func(contentType, body string, options *Options) error {
+		req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return ValidateRequest(context.Background(), input)
+	}
+
+	// The range entry matches without falling back
+	require.NoError(t, validate("application/json", `{"name":"Rex"}`, nil))
+
+	err := validate("image/png", `{"name":"Rex"}`, nil)
+	require.EqualError(t, err, `request body has an error: header Content-Type has unexpected value "image/png"`)
+
+	// Bodies are decoded as JSON when falling back to a range
+	fallback := &Options{UnknownContentType: FallbackUnknownContentType, FallbackContentType: "application/*"}
+	require.NoError(t, validate("image/png", `{"name":"Rex"}`, fallback))
+	err = validate("image/png", `{}`, fallback)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `property "name" is missing`)
+}
+
+func TestUnknownContentTypeFallbackToTextRange(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Content types'
+  version: 0.0.1
+paths:
+  /notes:
+    post:
+      requestBody:
+        required: true
+        content:
+          text/*; charset=utf-8:
+            schema:
+              type: string
+              maxLength: 5
+      responses:
+        '201':
+          description: Created.
+`
+	router := setupTestRouter(t, spec)
+
+	validate := func(contentType, body string, options *Options) error {
+		req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return ValidateRequest(context.Background(), input)
+	}
+
+	require.NoError(t, validate("text/plain; charset=utf-8", "hello", nil))
+	err := validate("text/plain; charset=latin1", "hello", nil)
+	require.EqualError(t, err, `request body has an error: header Content-Type has unexpected value "text/plain; charset=latin1"`)
+
+	// Bodies of the range are decoded as their own media type
+	fallback := &Options{UnknownContentType: FallbackUnknownContentType, FallbackContentType: "text/*; charset=utf-8"}
+	require.NoError(t, validate("text/plain; charset=latin1", "hello", fallback))
+	err = validate("text/plain; charset=latin1", "hello world", fallback)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum string length is 5")
+
+	// Others as JSON
+	require.NoError(t, validate("application/octet-stream", `"hello"`, fallback))
+	err = validate("application/octet-stream", "hello", fallback)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode request body")
+}
@@ -2,8 +2,10 @@ package openapi3filter
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -228,3 +230,85 @@ func TestReadOnlyWriteOnlyPropertiesValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestReadOnlyWriteOnlyDirections(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Accounts'
+  version: 0.0.1
+paths:
+  /accounts:
+    post:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              id: {type: string, readOnly: true}
+              name: {type: string}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                id: {type: string, readOnly: true}
+                name: {type: string}
+      responses:
+        '201':
+          description: Created.
+          headers:
+            X-Account:
+              schema:
+                type: object
+                properties:
+                  password: {type: string, writeOnly: true}
+`
+	router := setupTestRouter(t, spec)
+
+	validate := func(query, body string, options *Options) (*RequestValidationInput, error) {
+		req := httptest.NewRequest(http.MethodPost, "/accounts"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+		return input, ValidateRequest(context.Background(), input)
+	}
+
+	_, err := validate("", `{"id":"a1","name":"Alice"}`, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `readOnly property "id" in request`)
+	var schemaErr *openapi3.SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, "readOnly", schemaErr.SchemaField)
+
+	input, err := validate("", `{"id":"a1","name":"Alice"}`, &Options{StripReadOnlyProperties: true})
+	require.NoError(t, err)
+	body, err := io.ReadAll(input.Request.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Alice"}`, string(body))
+
+	_, err = validate("?filter[id]=a1", `{"name":"Alice"}`, &Options{StripReadOnlyProperties: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `parameter "filter" in query has an error`)
+	require.Contains(t, err.Error(), `readOnly property "id" in request`)
+
+	input, err = validate("", `{"name":"Alice"}`, nil)
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set("X-Account", "password,secret")
+	err = ValidateResponse(context.Background(), &ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 201,
+		Header:                 header,
+		Body:                   io.NopCloser(strings.NewReader("")),
+		Options:                &Options{ExcludeResponseBody: true},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `writeOnly property "password" in response`)
+}
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -36,27 +37,44 @@ func ValidateRequest(ctx context.Context, input *RequestValidationInput) (err er
 	if options == nil {
 		options = DefaultOptions
 	}
+	if options.skipsRequest(input.Request, input.Route) {
+		return nil
+	}
+	if options.Coverage != nil {
+		defer func() {
+			if err == nil {
+				options.Coverage.recordRequest(input)
+			}
+		}()
+	}
 	route := input.Route
 	operation := route.Operation
 	operationParameters := operation.Parameters
 	pathItemParameters := route.PathItem.Parameters
 
-	// Security
-	security := operation.Security
-	// If there aren't any security requirements for the operation
-	if security == nil {
-		// Use the global security requirements.
-		security = &route.Spec.Security
-	}
-	if security != nil {
-		if err = ValidateSecurityRequirements(ctx, input, *security); err != nil && !options.MultiError {
-			return
+	input.TruncatedValidations = nil
+	if options.reportsDeprecations() {
+		input.Deprecations = nil
+		if f := options.DeprecationFunc; f != nil {
+			defer func() {
+				for _, d := range input.Deprecations {
+					f(ctx, input, d)
+				}
+			}()
 		}
-		if err != nil {
-			me = append(me, err)
+		if operation.Deprecated {
+			input.addDeprecation(DeprecatedOperation, nil, "")
 		}
 	}
 
+	// Security, with the global security requirements if the operation has none
+	if err = ValidateSecurityRequirements(ctx, input, route.SecurityRequirements()); err != nil && !options.MultiError {
+		return
+	}
+	if err != nil {
+		me = append(me, err)
+	}
+
 	// For each parameter of the PathItem
 	for _, parameterRef := range pathItemParameters {
 		parameter := parameterRef.Value
@@ -119,21 +137,9 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 		options = DefaultOptions
 	}
 
-	var value interface{}
-	var err error
-	var found bool
-	var schema *openapi3.Schema
-
-	// Validation will ensure that we either have content or schema.
-	if parameter.Content != nil {
-		if value, schema, found, err = decodeContentParameter(parameter, input); err != nil {
-			return &RequestError{Input: input, Parameter: parameter, Err: err}
-		}
-	} else {
-		if value, found, err = decodeStyledParameter(parameter, input); err != nil {
-			return &RequestError{Input: input, Parameter: parameter, Err: err}
-		}
-		schema = parameter.Schema.Value
+	value, schema, found, err := decodeParameter(parameter, input)
+	if err != nil {
+		return &RequestError{Input: input, Parameter: parameter, Err: err}
 	}
 
 	// Set default value if needed
@@ -158,6 +164,15 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 		}
 	}
 
+	if options.reportsDeprecations() && found {
+		if parameter.Deprecated {
+			input.addDeprecation(DeprecatedParameter, parameter, "")
+		}
+		if schema != nil {
+			input.addDeprecatedProperties(parameter, schema, value)
+		}
+	}
+
 	// Validate a parameter's value and presence.
 	if parameter.Required && !found {
 		return &RequestError{Input: input, Parameter: parameter, Reason: ErrInvalidRequired.Error(), Err: ErrInvalidRequired}
@@ -182,9 +197,13 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 	if options.customSchemaErrorFunc != nil {
 		opts = append(opts, openapi3.SetSchemaErrorMessageCustomizer(options.customSchemaErrorFunc))
 	}
+	opts = append(opts, options.Coverage.schemaValidationOptions()...)
+	opts = append(opts, options.itemsLimitOptions(parameter, input.addTruncatedValidation)...)
+	opts = append(opts, openapi3.WithContext(ctx), openapi3.WithClock(options.Clock), openapi3.VisitAsRequest())
 	if err = schema.VisitJSON(value, opts...); err != nil {
 		return &RequestError{Input: input, Parameter: parameter, Err: err}
 	}
+	input.setParameterValue(parameter, value)
 	return nil
 }
 
@@ -232,6 +251,16 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		}
 	}
 
+	if options.EnforceContract && input.Route != nil {
+		if max := input.Route.Operation.Contract.MaxRequestBytes; max > 0 && int64(len(data)) > max {
+			return &RequestError{
+				Input:       input,
+				RequestBody: requestBody,
+				Reason:      fmt.Sprintf("body of %d bytes is larger than the %d bytes allowed", len(data), max),
+			}
+		}
+	}
+
 	if len(data) == 0 {
 		if requestBody.Required {
 			return &RequestError{Input: input, RequestBody: requestBody, Err: ErrInvalidRequired}
@@ -245,8 +274,26 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		return nil
 	}
 
-	inputMIME := req.Header.Get(headerCT)
+	header := req.Header
+	inputMIME := header.Get(headerCT)
 	contentType := requestBody.Content.Get(inputMIME)
+	if contentType == nil {
+		switch options.UnknownContentType {
+		case WarnUnknownContentType:
+			input.UnknownContentType = inputMIME
+			return nil
+		case FallbackUnknownContentType:
+			fallback := options.FallbackContentType
+			if contentType = content[fallback]; contentType != nil && !inMediaTypeRange(inputMIME, fallback) {
+				// Decode the body as the media type of the entry, and as JSON for ranges
+				if strings.Contains(fallback, "*") {
+					fallback = "application/json"
+				}
+				header = header.Clone()
+				header.Set(headerCT, fallback)
+			}
+		}
+	}
 	if contentType == nil {
 		return &RequestError{
 			Input:       input,
@@ -259,23 +306,44 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		// A JSON schema that describes the received data is not declared, so skip validation.
 		return nil
 	}
+	if _, err := contentType.Schema.Resolve(); err != nil {
+		return &RequestError{
+			Input:       input,
+			RequestBody: requestBody,
+			Reason:      "request body schema has not been resolved",
+			Err:         err,
+		}
+	}
 
+	redaction := options.bodyRedaction(header)
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
-	mediaType, value, err := decodeBody(bytes.NewReader(data), req.Header, contentType.Schema, encFn)
+	mediaType, value, err := decodeBody(bytes.NewReader(data), header, contentType.Schema, encFn)
 	if err != nil {
 		return &RequestError{
 			Input:       input,
 			RequestBody: requestBody,
 			Reason:      "failed to decode request body",
-			Err:         err,
+			Err:         options.redactBodyError(err, redaction),
 		}
 	}
 
-	defaultsSet := false
-	opts := make([]openapi3.SchemaValidationOption, 0, 3) // 3 potential opts here
+	if options.reportsDeprecations() {
+		input.addDeprecatedProperties(nil, contentType.Schema.Value, value)
+	}
+
+	if options.isAcceptedBySchemaValidator(contentType.Schema, value) {
+		input.Body = value
+		return nil
+	}
+
+	modified := false
+	opts := make([]openapi3.SchemaValidationOption, 0, 4) // 4 potential opts here
 	opts = append(opts, openapi3.VisitAsRequest())
 	if !options.SkipSettingDefaults {
-		opts = append(opts, openapi3.DefaultsSet(func() { defaultsSet = true }))
+		opts = append(opts, openapi3.DefaultsSet(func() { modified = true }))
+	}
+	if options.StripReadOnlyProperties {
+		opts = append(opts, openapi3.StripReadOnlyProperties(func() { modified = true }))
 	}
 	if options.MultiError {
 		opts = append(opts, openapi3.MultiErrors())
@@ -283,6 +351,10 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 	if options.customSchemaErrorFunc != nil {
 		opts = append(opts, openapi3.SetSchemaErrorMessageCustomizer(options.customSchemaErrorFunc))
 	}
+	opts = append(opts, options.Coverage.schemaValidationOptions()...)
+	opts = append(opts, options.itemsLimitOptions(nil, input.addTruncatedValidation)...)
+	opts = append(opts, openapi3.WithContext(ctx), openapi3.WithClock(options.Clock))
+	opts = append(opts, bodySchemaValidationOptions(redaction)...)
 
 	// Validate JSON with the schema
 	if err := contentType.Schema.Value.VisitJSON(value, opts...); err != nil {
@@ -292,11 +364,12 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 			Input:       input,
 			RequestBody: requestBody,
 			Reason:      fmt.Sprintf("doesn't match schema%s", schemaId),
-			Err:         err,
+			Err:         options.redactBodyError(err, redaction),
 		}
 	}
+	input.Body = value
 
-	if defaultsSet {
+	if modified {
 		var err error
 		if data, err = encodeBody(value, mediaType); err != nil {
 			return &RequestError{
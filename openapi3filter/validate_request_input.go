@@ -26,6 +26,39 @@ type RequestValidationInput struct {
 	Route        *routers.Route
 	Options      *Options
 	ParamDecoder ContentParameterDecoder
+
+	// Deprecations is set by ValidateRequest when Options.ReportDeprecations is set.
+	Deprecations []Deprecation
+
+	// TruncatedValidations is set by ValidateRequest when Options.MaxValidatedItems is set.
+	TruncatedValidations []TruncatedValidation
+
+	// UnknownContentType is set by ValidateRequest to the content type of a request body
+	// it did not validate, as the operation does not declare it, when Options.UnknownContentType
+	// is WarnUnknownContentType.
+	UnknownContentType string
+
+	// ParameterValues are set by ValidateRequest to the decoded values of the parameters
+	// of the request that validate, keyed by location ("path", "query", "header" or "cookie")
+	// and then name, with the defaults of the parameters and of their properties set.
+	ParameterValues map[string]map[string]interface{}
+
+	// Body is set by ValidateRequest to the decoded value of a request body that validates,
+	// with the defaults of its properties set, even when Options.SkipSettingDefaults
+	// keeps them out of the request.
+	Body interface{}
+}
+
+func (input *RequestValidationInput) setParameterValue(parameter *openapi3.Parameter, value interface{}) {
+	if input.ParameterValues == nil {
+		input.ParameterValues = make(map[string]map[string]interface{})
+	}
+	values := input.ParameterValues[parameter.In]
+	if values == nil {
+		values = make(map[string]interface{})
+		input.ParameterValues[parameter.In] = values
+	}
+	values[parameter.Name] = value
 }
 
 func (input *RequestValidationInput) GetQueryParams() url.Values {
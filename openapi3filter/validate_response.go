@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -19,7 +18,7 @@ import (
 //
 // Note: One can tune the behavior of uniqueItems: true verification
 // by registering a custom function with openapi3.RegisterArrayUniqueItemsChecker
-func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error {
+func ValidateResponse(ctx context.Context, input *ResponseValidationInput) (err error) {
 	req := input.RequestValidationInput.Request
 	switch req.Method {
 	case "HEAD":
@@ -41,16 +40,30 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 	if options == nil {
 		options = DefaultOptions
 	}
+	if options.skipsResponse(req, route) {
+		return nil
+	}
+	input.TruncatedValidations = nil
+	if options.Coverage != nil {
+		defer func() {
+			if err == nil {
+				options.Coverage.recordResponse(input)
+			}
+		}()
+	}
+
+	if max := route.Operation.Contract.MaxResponseBytes; options.EnforceContract && max > 0 {
+		if err := input.validateContractSize(max); err != nil {
+			return err
+		}
+	}
 
 	// Find input for the current status
 	responses := route.Operation.Responses
 	if len(responses) == 0 {
 		return nil
 	}
-	responseRef := responses.Get(status) // Response
-	if responseRef == nil {
-		responseRef = responses.Default() // Default input
-	}
+	responseRef, _ := responses.FindFor(status)
 	if responseRef == nil {
 		// By default, status that is not documented is allowed.
 		if !options.IncludeResponseStatus {
@@ -63,40 +76,9 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return &ResponseError{Input: input, Reason: "response has not been resolved"}
 	}
 
-	opts := make([]openapi3.SchemaValidationOption, 0, 2)
-	if options.MultiError {
-		opts = append(opts, openapi3.MultiErrors())
-	}
-	if options.customSchemaErrorFunc != nil {
-		opts = append(opts, openapi3.SetSchemaErrorMessageCustomizer(options.customSchemaErrorFunc))
-	}
-
-	headers := make([]string, 0, len(response.Headers))
-	for k := range response.Headers {
-		if k != headerCT {
-			headers = append(headers, k)
-		}
-	}
-	sort.Strings(headers)
-	for _, k := range headers {
-		s := response.Headers[k]
-		h := input.Header.Get(k)
-		if h == "" {
-			if s.Value.Required {
-				return &ResponseError{
-					Input:  input,
-					Reason: fmt.Sprintf("response header %q missing", k),
-				}
-			}
-			continue
-		}
-		if err := s.Value.Schema.Value.VisitJSON(h, opts...); err != nil {
-			return &ResponseError{
-				Input:  input,
-				Reason: fmt.Sprintf("response header %q doesn't match the schema", k),
-				Err:    err,
-			}
-		}
+	opts := responseSchemaValidationOptions(ctx, input, options)
+	if err := validateResponseHeaders(input, response, opts); err != nil {
+		return err
 	}
 
 	if options.ExcludeResponseBody {
@@ -123,6 +105,9 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		// An operation does not contains a validation schema for responses with this status code.
 		return nil
 	}
+	if _, err := contentType.Schema.Resolve(); err != nil {
+		return &ResponseError{Input: input, Reason: "response body schema has not been resolved", Err: err}
+	}
 
 	// Read response's body.
 	body := input.Body
@@ -148,24 +133,104 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 	// Put the data back into the response.
 	input.SetBodyBytes(data)
 
+	redaction := options.bodyRedaction(input.Header)
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
 	_, value, err := decodeBody(bytes.NewBuffer(data), input.Header, contentType.Schema, encFn)
 	if err != nil {
 		return &ResponseError{
 			Input:  input,
 			Reason: "failed to decode response body",
-			Err:    err,
+			Err:    options.redactBodyError(err, redaction),
 		}
 	}
 
+	if options.isAcceptedBySchemaValidator(contentType.Schema, value) {
+		return nil
+	}
+
 	// Validate data with the schema.
-	if err := contentType.Schema.Value.VisitJSON(value, append(opts, openapi3.VisitAsResponse())...); err != nil {
+	opts = append(opts[:len(opts):len(opts)], bodySchemaValidationOptions(redaction)...)
+	if err := contentType.Schema.Value.VisitJSON(value, opts...); err != nil {
 		schemaId := getSchemaIdentifier(contentType.Schema)
 		schemaId = prependSpaceIfNeeded(schemaId)
 		return &ResponseError{
 			Input:  input,
 			Reason: fmt.Sprintf("response body doesn't match schema%s", schemaId),
-			Err:    err,
+			Err:    options.redactBodyError(err, redaction),
+		}
+	}
+	return nil
+}
+
+// validateContractSize returns an error if the body of input is larger than max bytes.
+func (input *ResponseValidationInput) validateContractSize(max int64) error {
+	if input.Body == nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(input.Body)
+	input.Body.Close()
+	if err != nil {
+		return &ResponseError{Input: input, Reason: "failed to read response body", Err: err}
+	}
+	input.SetBodyBytes(data)
+	if int64(len(data)) > max {
+		return &ResponseError{
+			Input:  input,
+			Reason: fmt.Sprintf("response body of %d bytes is larger than the %d bytes allowed", len(data), max),
+		}
+	}
+	return nil
+}
+
+func responseSchemaValidationOptions(ctx context.Context, input *ResponseValidationInput, options *Options) []openapi3.SchemaValidationOption {
+	opts := make([]openapi3.SchemaValidationOption, 0, 2)
+	if options.MultiError {
+		opts = append(opts, openapi3.MultiErrors())
+	}
+	if options.customSchemaErrorFunc != nil {
+		opts = append(opts, openapi3.SetSchemaErrorMessageCustomizer(options.customSchemaErrorFunc))
+	}
+	opts = append(opts, options.Coverage.schemaValidationOptions()...)
+	opts = append(opts, options.itemsLimitOptions(nil, input.addTruncatedValidation)...)
+	opts = append(opts, openapi3.WithContext(ctx), openapi3.WithClock(options.Clock), openapi3.VisitAsResponse())
+	return opts
+}
+
+func validateResponseHeaders(input *ResponseValidationInput, response *openapi3.Response, opts []openapi3.SchemaValidationOption) error {
+	dec := &headerParamDecoder{header: input.Header}
+	for _, h := range declaredResponseHeaders(response) {
+		raw := input.Header.Get(h.Name)
+		if raw == "" {
+			if h.Required {
+				return &ResponseError{
+					Input:  input,
+					Reason: fmt.Sprintf("response header %q missing", h.Name),
+				}
+			}
+			continue
+		}
+		// Headers of a typed schema are decoded according to their style,
+		// others are validated as they are sent.
+		var value interface{} = raw
+		if h.Schema.Value.Type != "" {
+			sm, err := h.SerializationMethod()
+			if err != nil {
+				return &ResponseError{Input: input, Reason: fmt.Sprintf("response header %q", h.Name), Err: err}
+			}
+			if value, _, err = decodeValue(dec, h.Name, sm, h.Schema, h.Required); err != nil {
+				return &ResponseError{
+					Input:  input,
+					Reason: fmt.Sprintf("response header %q doesn't match the schema", h.Name),
+					Err:    err,
+				}
+			}
+		}
+		if err := h.Schema.Value.VisitJSON(value, opts...); err != nil {
+			return &ResponseError{
+				Input:  input,
+				Reason: fmt.Sprintf("response header %q doesn't match the schema", h.Name),
+				Err:    err,
+			}
 		}
 	}
 	return nil
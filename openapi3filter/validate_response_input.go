@@ -13,6 +13,9 @@ type ResponseValidationInput struct {
 	Header                 http.Header
 	Body                   io.ReadCloser
 	Options                *Options
+
+	// TruncatedValidations is set by ValidateResponse when Options.MaxValidatedItems is set.
+	TruncatedValidations []TruncatedValidation
 }
 
 func (input *ResponseValidationInput) SetBodyBytes(value []byte) *ResponseValidationInput {
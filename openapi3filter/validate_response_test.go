@@ -0,0 +1,157 @@
+package openapi3filter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestValidateResponseStatusRange(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+        '5XX':
+          description: server error
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [error]
+                properties:
+                  error: {type: string}
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	validate := func(status int, body string) error {
+		input := &ResponseValidationInput{
+			RequestValidationInput: &RequestValidationInput{Request: req, PathParams: pathParams, Route: route},
+			Status:                 status,
+			Header:                 http.Header{"Content-Type": []string{"application/json"}},
+			Options:                &Options{IncludeResponseStatus: true},
+		}
+		input.SetBodyBytes([]byte(body))
+		return ValidateResponse(context.Background(), input)
+	}
+	require.NoError(t, validate(503, `{"error":"unavailable"}`))
+	require.Error(t, validate(500, `{}`))
+	require.EqualError(t, validate(404, `{}`), "status is not supported")
+}
+
+func TestValidateResponseNonJSONBody(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets/count:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            text/plain:
+              schema: {type: integer, minimum: 0}
+            text/html:
+              schema: {type: string, pattern: '^<'}
+            application/hal+json:
+              schema:
+                type: object
+                required: [count]
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/pets/count", nil)
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	validate := func(contentType string, body string) error {
+		input := &ResponseValidationInput{
+			RequestValidationInput: &RequestValidationInput{Request: req, PathParams: pathParams, Route: route},
+			Status:                 http.StatusOK,
+			Header:                 http.Header{"Content-Type": []string{contentType}},
+		}
+		input.SetBodyBytes([]byte(body))
+		return ValidateResponse(context.Background(), input)
+	}
+	require.NoError(t, validate("text/plain", "3"))
+	require.Error(t, validate("text/plain", "-1"))
+	require.Error(t, validate("text/plain", "three"))
+	require.NoError(t, validate("text/html; charset=utf-8", "<p>3</p>"))
+	require.Error(t, validate("text/html", "3"))
+	require.NoError(t, validate("application/hal+json", `{"count":3}`))
+	require.Error(t, validate("application/hal+json", `{}`))
+}
+
+func TestValidateUnresolvedBodySchema(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {type: object}
+`[1:]
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	operation := doc.Paths["/pets"].Post
+	operation.RequestBody.Value.Content["application/json"].Schema = &openapi3.SchemaRef{Ref: "#/components/schemas/Pet"}
+	operation.Responses["200"].Value.Content["application/json"].Schema = &openapi3.SchemaRef{Ref: "#/components/schemas/Pet"}
+
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	requestInput := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+	err = ValidateRequest(context.Background(), requestInput)
+	require.EqualError(t, err, `request body has an error: request body schema has not been resolved: found unresolved ref: "#/components/schemas/Pet"`)
+
+	responseInput := &ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 200,
+		Header:                 http.Header{"Content-Type": []string{"application/json"}},
+	}
+	responseInput.SetBodyBytes([]byte(`{}`))
+	err = ValidateResponse(context.Background(), responseInput)
+	require.EqualError(t, err, `response body schema has not been resolved: found unresolved ref: "#/components/schemas/Pet"`)
+}
@@ -801,3 +801,52 @@ func TestValidateRequestBodyAndSetDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRequestDecodedValuesWithDefaults(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Defaults'
+  version: 0.0.1
+paths:
+  /pets/{id}:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema: {type: integer}
+        - name: limit
+          in: query
+          schema: {type: integer, default: 10}
+        - name: X-Trace
+          in: header
+          schema: {type: string}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                kind: {type: string, default: dog}
+      responses:
+        '201':
+          description: Created.
+`
+	router := setupTestRouter(t, spec)
+
+	req, err := http.NewRequest(http.MethodPost, "/pets/7", bytes.NewReader([]byte(`{"name":"Rex"}`)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+	input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+	require.NoError(t, ValidateRequest(req.Context(), input))
+
+	require.Equal(t, map[string]map[string]interface{}{
+		"path":  {"id": float64(7)},
+		"query": {"limit": float64(10)},
+	}, input.ParameterValues)
+	require.Equal(t, map[string]interface{}{"name": "Rex", "kind": "dog"}, input.Body)
+}
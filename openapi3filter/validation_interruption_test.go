@@ -0,0 +1,47 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequestContextCanceled(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Canceled validation'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: array
+              items: {type: string}
+      responses:
+        '201':
+          description: Created.
+`
+	router := setupTestRouter(t, spec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`["Rex","Fido"]`))
+	req.Header.Set("Content-Type", "application/json")
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+	input := &RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+
+	cancel()
+	err = ValidateRequest(ctx, input)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, context.Canceled))
+}
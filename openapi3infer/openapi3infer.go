@@ -0,0 +1,360 @@
+// Package openapi3infer drafts OpenAPI 3 documents from observed HTTP traffic.
+package openapi3infer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Option configures an Inferrer.
+type Option func(*Inferrer)
+
+// Info sets the title and version of the inferred document.
+func Info(title, version string) Option {
+	return func(inf *Inferrer) {
+		inf.title, inf.version = title, version
+	}
+}
+
+// ParameterSegments sets the function telling whether a path segment is a
+// parameter value, instead of IsIdentifier.
+func ParameterSegments(f func(segment string) bool) Option {
+	return func(inf *Inferrer) {
+		inf.isParameter = f
+	}
+}
+
+// Inferrer observes HTTP exchanges and drafts the document describing them.
+// It is safe for concurrent use.
+type Inferrer struct {
+	title, version string
+	isParameter    func(segment string) bool
+
+	mu         sync.Mutex
+	operations map[operationKey]*operationShape
+}
+
+type operationKey struct {
+	method string
+	// path is the path template, e.g. "/users/{userId}"
+	path string
+}
+
+type operationShape struct {
+	requests   int
+	pathParams map[string]*shape
+	// query parameters with the number of requests having them
+	query       map[string]*shape
+	queryCounts map[string]int
+	// requests with a body
+	bodies       int
+	requestBody  map[string]*shape
+	responses    map[int]map[string]*shape
+	contentTypes map[string]struct{}
+}
+
+// New returns an Inferrer.
+func New(options ...Option) *Inferrer {
+	inf := &Inferrer{
+		title:       "Inferred API",
+		version:     "0.0.0",
+		isParameter: IsIdentifier,
+		operations:  make(map[operationKey]*operationShape),
+	}
+	for _, option := range options {
+		option(inf)
+	}
+	return inf
+}
+
+var hexPattern = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+
+// IsIdentifier tells whether a path segment looks like an identifier:
+// a number, a UUID or a long hexadecimal string.
+func IsIdentifier(segment string) bool {
+	if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+		return true
+	}
+	return uuidPattern.MatchString(segment) || hexPattern.MatchString(segment)
+}
+
+// Middleware returns an http.Handler which wraps the given handler and
+// observes the requests it receives and the responses it sends.
+func (inf *Inferrer) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body []byte
+		if req.Body != nil && req.Body != http.NoBody {
+			var err error
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+			req.Body.Close()
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		wr := &responseRecorder{ResponseWriter: w}
+		h.ServeHTTP(wr, req)
+
+		status := wr.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		inf.Observe(req, body, status, w.Header(), wr.body.Bytes())
+	})
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (wr *responseRecorder) WriteHeader(status int) {
+	if wr.status == 0 {
+		wr.status = status
+	}
+	wr.ResponseWriter.WriteHeader(status)
+}
+
+func (wr *responseRecorder) Write(b []byte) (int, error) {
+	if wr.status == 0 {
+		wr.status = http.StatusOK
+	}
+	wr.body.Write(b)
+	return wr.ResponseWriter.Write(b)
+}
+
+// Flush implements the optional http.Flusher interface.
+func (wr *responseRecorder) Flush() {
+	if fl, ok := wr.ResponseWriter.(http.Flusher); ok {
+		fl.Flush()
+	}
+}
+
+// Observe adds an exchange: a request along with its body and the status,
+// header and body of its response.
+func (inf *Inferrer) Observe(req *http.Request, requestBody []byte, status int, header http.Header, responseBody []byte) {
+	path, pathParams := inf.template(req.URL.Path)
+	key := operationKey{method: req.Method, path: path}
+
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+	operation := inf.operations[key]
+	if operation == nil {
+		operation = &operationShape{
+			pathParams:   make(map[string]*shape),
+			query:        make(map[string]*shape),
+			queryCounts:  make(map[string]int),
+			requestBody:  make(map[string]*shape),
+			responses:    make(map[int]map[string]*shape),
+			contentTypes: make(map[string]struct{}),
+		}
+		inf.operations[key] = operation
+	}
+	operation.requests++
+
+	for name, value := range pathParams {
+		if operation.pathParams[name] == nil {
+			operation.pathParams[name] = newShape()
+		}
+		operation.pathParams[name].observeParameter(value)
+	}
+	for name, values := range req.URL.Query() {
+		if operation.query[name] == nil {
+			operation.query[name] = newShape()
+		}
+		operation.queryCounts[name]++
+		for _, value := range values {
+			operation.query[name].observeParameter(value)
+		}
+	}
+
+	if len(requestBody) != 0 {
+		operation.bodies++
+		observeBody(operation.requestBody, req.Header.Get("Content-Type"), requestBody)
+	}
+
+	content := operation.responses[status]
+	if content == nil {
+		content = make(map[string]*shape)
+		operation.responses[status] = content
+	}
+	if len(responseBody) != 0 {
+		observeBody(content, header.Get("Content-Type"), responseBody)
+	}
+}
+
+// template returns the path template of path with the values of its parameters.
+func (inf *Inferrer) template(path string) (string, map[string]string) {
+	segments := strings.Split(path, "/")
+	params := make(map[string]string)
+	for i, segment := range segments {
+		if segment == "" || !inf.isParameter(segment) {
+			continue
+		}
+		name := "id"
+		if i > 0 && segments[i-1] != "" && !strings.HasPrefix(segments[i-1], "{") {
+			name = singular(segments[i-1]) + "Id"
+		}
+		for n := 2; ; n++ {
+			if _, ok := params[name]; !ok {
+				break
+			}
+			name = strings.TrimRight(name, "0123456789") + strconv.Itoa(n)
+		}
+		params[name] = segment
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// singular returns the singular of an English plural noun, in the simplest cases.
+func singular(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ies") && len(noun) > 3:
+		return noun[:len(noun)-3] + "y"
+	case strings.HasSuffix(noun, "ses"), strings.HasSuffix(noun, "xes"):
+		return noun[:len(noun)-2]
+	case strings.HasSuffix(noun, "s") && !strings.HasSuffix(noun, "ss"):
+		return noun[:len(noun)-1]
+	default:
+		return noun
+	}
+}
+
+// observeBody adds a body to the shapes by media type.
+// Only JSON bodies have their schema inferred.
+func observeBody(content map[string]*shape, contentType string, body []byte) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+	s := content[mediaType]
+	if s == nil {
+		s = newShape()
+		content[mediaType] = s
+	}
+	if !isJSON(mediaType) {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		s.observe(value)
+	}
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Document returns the draft document describing the exchanges observed so far:
+// the operations on each path template, their path and query parameters,
+// request bodies and responses by status, with the schemas of JSON bodies.
+//
+// Path segments that look like identifiers become parameters named after the
+// preceding segment, e.g. "/users/42" becomes "/users/{userId}".
+// Query parameters and object properties are required when always present.
+func (inf *Inferrer) Document() *openapi3.T {
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: inf.title, Version: inf.version},
+		Paths:   make(openapi3.Paths),
+	}
+	keys := make([]operationKey, 0, len(inf.operations))
+	for key := range inf.operations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		return keys[i].method < keys[j].method
+	})
+	for _, key := range keys {
+		pathItem := doc.Paths[key.path]
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			doc.Paths[key.path] = pathItem
+		}
+		pathItem.SetOperation(key.method, inf.operations[key].operation())
+	}
+	return doc
+}
+
+func (s *operationShape) operation() *openapi3.Operation {
+	operation := openapi3.NewOperation()
+
+	for _, name := range sortedKeys(s.pathParams) {
+		operation.AddParameter(openapi3.NewPathParameter(name).WithSchema(s.pathParams[name].schema()))
+	}
+	for _, name := range sortedKeys(s.query) {
+		parameter := openapi3.NewQueryParameter(name).WithSchema(s.query[name].schema())
+		parameter.Required = s.queryCounts[name] == s.requests
+		operation.AddParameter(parameter)
+	}
+
+	if s.bodies != 0 {
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithRequired(s.bodies == s.requests).
+			WithContent(content(s.requestBody))}
+	}
+
+	statuses := make([]int, 0, len(s.responses))
+	for status := range s.responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	operation.Responses = make(openapi3.Responses, len(statuses))
+	for _, status := range statuses {
+		description := http.StatusText(status)
+		if description == "" {
+			description = "Observed response"
+		}
+		response := openapi3.NewResponse().WithDescription(description)
+		if c := content(s.responses[status]); len(c) != 0 {
+			response.Content = c
+		}
+		operation.AddResponse(status, response)
+	}
+	return operation
+}
+
+func content(shapes map[string]*shape) openapi3.Content {
+	c := make(openapi3.Content, len(shapes))
+	for _, mediaType := range sortedKeys(shapes) {
+		var schema *openapi3.Schema
+		switch s := shapes[mediaType]; {
+		case isJSON(mediaType):
+			schema = s.schema()
+		case strings.HasPrefix(mediaType, "text/"):
+			schema = openapi3.NewStringSchema()
+		default:
+			schema = openapi3.NewBytesSchema()
+		}
+		c[mediaType] = openapi3.NewMediaType().WithSchema(schema)
+	}
+	return c
+}
+
+func sortedKeys(m map[string]*shape) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package openapi3infer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestInferrer(t *testing.T) {
+	inf := New(Info("Users", "1.0.0"))
+	handler := inf.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":3,"name":"Cid","createdAt":"2020-01-02T03:04:05Z"}`))
+		case strings.HasSuffix(r.URL.Path, "/404"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		case r.URL.Path == "/users":
+			w.Write([]byte(`[{"id":1,"name":"Ann","email":"ann@example.com"},{"id":2,"name":"Bob","email":null}]`))
+		default:
+			w.Write([]byte(`{"id":1,"name":"Ann","score":1.5,"tags":["a"]}`))
+		}
+	}))
+
+	for _, exchange := range []struct{ method, target, body string }{
+		{http.MethodGet, "/users?limit=10", ""},
+		{http.MethodGet, "/users?limit=20&active=true", ""},
+		{http.MethodPost, "/users", `{"name":"Cid","password":"x"}`},
+		{http.MethodPost, "/users", `{"name":"Dan"}`},
+		{http.MethodGet, "/users/1", ""},
+		{http.MethodGet, "/users/404", ""},
+		{http.MethodGet, "/users/2/orders/00000000-0000-4000-8000-000000000000", ""},
+	} {
+		req := httptest.NewRequest(exchange.method, exchange.target, strings.NewReader(exchange.body))
+		if exchange.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	doc := inf.Document()
+	require.NoError(t, doc.Validate(context.Background()))
+	require.Equal(t, "Users", doc.Info.Title)
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	require.ElementsMatch(t, []string{"/users", "/users/{userId}", "/users/{userId}/orders/{orderId}"}, paths)
+
+	list := doc.Paths["/users"].Get
+	require.Len(t, list.Parameters, 2)
+	require.Equal(t, "active", list.Parameters[0].Value.Name)
+	require.False(t, list.Parameters[0].Value.Required)
+	require.Equal(t, "boolean", list.Parameters[0].Value.Schema.Value.Type)
+	require.Equal(t, "limit", list.Parameters[1].Value.Name)
+	require.True(t, list.Parameters[1].Value.Required)
+	require.Equal(t, "integer", list.Parameters[1].Value.Schema.Value.Type)
+	requireSchemaJSON(t, `{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {
+				"email": {"type": "string", "format": "email", "nullable": true},
+				"id": {"type": "integer"},
+				"name": {"type": "string"}
+			},
+			"required": ["email", "id", "name"]
+		}
+	}`, list.Responses["200"].Value.Content["application/json"].Schema.Value)
+
+	create := doc.Paths["/users"].Post
+	require.True(t, create.RequestBody.Value.Required)
+	requireSchemaJSON(t, `{
+		"type": "object",
+		"properties": {"name": {"type": "string"}, "password": {"type": "string"}},
+		"required": ["name"]
+	}`, create.RequestBody.Value.Content["application/json"].Schema.Value)
+	requireSchemaJSON(t, `{
+		"type": "object",
+		"properties": {
+			"createdAt": {"type": "string", "format": "date-time"},
+			"id": {"type": "integer"},
+			"name": {"type": "string"}
+		},
+		"required": ["createdAt", "id", "name"]
+	}`, create.Responses["201"].Value.Content["application/json"].Schema.Value)
+
+	get := doc.Paths["/users/{userId}"].Get
+	require.Len(t, get.Parameters, 1)
+	require.Equal(t, "path", get.Parameters[0].Value.In)
+	require.Equal(t, "integer", get.Parameters[0].Value.Schema.Value.Type)
+	require.Len(t, get.Responses, 2)
+	require.Equal(t, "Not Found", *get.Responses["404"].Value.Description)
+
+	orders := doc.Paths["/users/{userId}/orders/{orderId}"].Get
+	require.Len(t, orders.Parameters, 2)
+	require.Equal(t, "orderId", orders.Parameters[0].Value.Name)
+	require.Equal(t, "uuid", orders.Parameters[0].Value.Schema.Value.Format)
+}
+
+func requireSchemaJSON(t *testing.T, expected string, schema *openapi3.Schema) {
+	t.Helper()
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	require.JSONEq(t, expected, string(data))
+}
+
+func TestIsIdentifier(t *testing.T) {
+	for segment, expected := range map[string]bool{
+		"42":                                   true,
+		"00000000-0000-4000-8000-000000000000": true,
+		"507f1f77bcf86cd799439011":             true,
+		"users":                                false,
+		"me":                                   false,
+		"-1":                                   false,
+	} {
+		require.Equal(t, expected, IsIdentifier(segment), segment)
+	}
+}
@@ -0,0 +1,175 @@
+package openapi3infer
+
+import (
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// shape accumulates the values observed at a location to infer their schema.
+type shape struct {
+	nullable bool
+	// number of non-null values of each type
+	types map[string]int
+
+	// number of objects and of objects having each property
+	objects        int
+	properties     map[string]*shape
+	propertyCounts map[string]int
+
+	items *shape
+
+	// number of strings and of strings in each format
+	strings int
+	formats map[string]int
+}
+
+func newShape() *shape {
+	return &shape{types: make(map[string]int)}
+}
+
+// observe adds a value as decoded by encoding/json.
+func (s *shape) observe(value interface{}) {
+	switch value := value.(type) {
+	case nil:
+		s.nullable = true
+	case bool:
+		s.types[openapi3.TypeBoolean]++
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+			s.types[openapi3.TypeInteger]++
+		} else {
+			s.types[openapi3.TypeNumber]++
+		}
+	case string:
+		s.types[openapi3.TypeString]++
+		s.observeString(value)
+	case []interface{}:
+		s.types[openapi3.TypeArray]++
+		if s.items == nil {
+			s.items = newShape()
+		}
+		for _, item := range value {
+			s.items.observe(item)
+		}
+	case map[string]interface{}:
+		s.types[openapi3.TypeObject]++
+		if s.properties == nil {
+			s.properties = make(map[string]*shape)
+			s.propertyCounts = make(map[string]int)
+		}
+		s.objects++
+		for name, v := range value {
+			property := s.properties[name]
+			if property == nil {
+				property = newShape()
+				s.properties[name] = property
+			}
+			property.observe(v)
+			s.propertyCounts[name]++
+		}
+	}
+}
+
+// observeParameter adds the value of a parameter, which may hold a number or a boolean.
+func (s *shape) observeParameter(value string) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		s.types[openapi3.TypeInteger]++
+		return
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		s.types[openapi3.TypeNumber]++
+		return
+	}
+	if value == "true" || value == "false" {
+		s.types[openapi3.TypeBoolean]++
+		return
+	}
+	s.types[openapi3.TypeString]++
+	s.observeString(value)
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func (s *shape) observeString(value string) {
+	if s.formats == nil {
+		s.formats = make(map[string]int)
+	}
+	s.strings++
+	if format := stringFormat(value); format != "" {
+		s.formats[format]++
+	}
+}
+
+// stringFormat returns the format of value among the common ones, if any.
+func stringFormat(value string) string {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return "date-time"
+	}
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return "date"
+	}
+	if uuidPattern.MatchString(value) {
+		return "uuid"
+	}
+	if address, err := mail.ParseAddress(value); err == nil && address.Address == value {
+		return "email"
+	}
+	if u, err := url.Parse(value); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return "uri"
+	}
+	return ""
+}
+
+// schema returns the schema of the observed values. Values of mixed types,
+// other than integers and numbers, are left untyped.
+func (s *shape) schema() *openapi3.Schema {
+	schema := &openapi3.Schema{Nullable: s.nullable}
+	types := make([]string, 0, len(s.types))
+	for t := range s.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	switch {
+	case len(types) == 1:
+		schema.Type = types[0]
+	case len(types) == 2 && types[0] == openapi3.TypeInteger && types[1] == openapi3.TypeNumber:
+		schema.Type = openapi3.TypeNumber
+	default:
+		return schema
+	}
+
+	switch schema.Type {
+	case openapi3.TypeString:
+		for format, n := range s.formats {
+			if n == s.strings {
+				schema.Format = format
+			}
+		}
+	case openapi3.TypeArray:
+		schema.Items = openapi3.NewSchemaRef("", &openapi3.Schema{})
+		if s.items != nil && (len(s.items.types) != 0 || s.items.nullable) {
+			schema.Items.Value = s.items.schema()
+		}
+	case openapi3.TypeObject:
+		schema.Properties = make(openapi3.Schemas, len(s.properties))
+		names := make([]string, 0, len(s.properties))
+		for name := range s.properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			schema.Properties[name] = openapi3.NewSchemaRef("", s.properties[name].schema())
+			if s.propertyCounts[name] == s.objects {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+	return schema
+}
@@ -0,0 +1,323 @@
+package openapi3lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// example is an example of a document along with the schema it should match.
+type example struct {
+	// pointer is the JSON pointer to the value of the example
+	pointer string
+	value   interface{}
+	schema  *openapi3.SchemaRef
+	// schemaPointer is the JSON pointer, as a URI fragment, to the definition of schema
+	schemaPointer string
+	// opts are the options the example is validated with, e.g. openapi3.VisitAsRequest
+	opts []openapi3.SchemaValidationOption
+}
+
+// walkExamples calls f with the examples of doc with a value: those of schemas,
+// parameters, headers and media types. The examples of components are passed
+// where they are used, those of schemas where the schemas are defined.
+func walkExamples(doc *openapi3.T, f func(example)) {
+	visited := make(map[interface{}]struct{})
+	firstVisit := func(value interface{}) bool {
+		if _, ok := visited[value]; ok {
+			return false
+		}
+		visited[value] = struct{}{}
+		return true
+	}
+	_ = openapi3.Walk(doc, func(pointer string, value, parent interface{}) error {
+		switch v := value.(type) {
+		case *openapi3.SchemaRef:
+			if v.Value != nil && v.Value.Example != nil && firstVisit(v.Value) {
+				f(example{pointer: pointer + "/example", value: v.Value.Example, schema: v, schemaPointer: "#" + pointer})
+			}
+		case *openapi3.ParameterRef:
+			if v.Value != nil && v.Value.Schema != nil && firstVisit(v.Value) {
+				p := v.Value
+				exampleValues(pointer, p.Example, p.Examples, p.Schema, exampleOptions(pointer), f)
+			}
+		case *openapi3.HeaderRef:
+			if v.Value != nil && v.Value.Schema != nil && firstVisit(v.Value) {
+				h := v.Value
+				exampleValues(pointer, h.Example, h.Examples, h.Schema, exampleOptions(pointer), f)
+			}
+		case *openapi3.MediaType:
+			if v.Schema != nil {
+				exampleValues(pointer, v.Example, v.Examples, v.Schema, exampleOptions(pointer), f)
+			}
+		}
+		return nil
+	})
+}
+
+// exampleValues calls f with example and the values of examples, of the object at pointer.
+func exampleValues(pointer string, value interface{}, examples openapi3.Examples, schema *openapi3.SchemaRef, opts []openapi3.SchemaValidationOption, f func(example)) {
+	schemaPointer := schemaRefPointer("#"+pointer+"/schema", schema)
+	if value != nil {
+		f(example{pointer: pointer + "/example", value: value, schema: schema, schemaPointer: schemaPointer, opts: opts})
+	}
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := examples[name]
+		if ref == nil || ref.Value == nil || ref.Value.Value == nil {
+			continue
+		}
+		p := pointer + "/examples/" + escapePointerToken(name) + "/value"
+		f(example{pointer: p, value: ref.Value.Value, schema: schema, schemaPointer: schemaPointer, opts: opts})
+	}
+}
+
+// exampleOptions returns the options to validate the examples of the object at pointer with,
+// as part of a request or a response depending on what contains it most closely.
+func exampleOptions(pointer string) []openapi3.SchemaValidationOption {
+	request := -1
+	for _, token := range []string{"/parameters/", "/requestBody/", "/requestBodies/"} {
+		if i := strings.LastIndex(pointer, token); i > request {
+			request = i
+		}
+	}
+	response := -1
+	for _, token := range []string{"/responses/", "/headers/"} {
+		if i := strings.LastIndex(pointer, token); i > response {
+			response = i
+		}
+	}
+	switch {
+	case request > response:
+		return []openapi3.SchemaValidationOption{openapi3.VisitAsRequest()}
+	case response > request:
+		return []openapi3.SchemaValidationOption{openapi3.VisitAsResponse()}
+	}
+	return nil
+}
+
+func checkExamplesValid(doc *openapi3.T, report ReportFunc) {
+	walkExamples(doc, func(e example) {
+		if e.schema.Value == nil {
+			return
+		}
+		opts := append([]openapi3.SchemaValidationOption{openapi3.MultiErrors()}, e.opts...)
+		for _, err := range schemaErrors(e.schema.Value.VisitJSON(e.value, opts...)) {
+			schemaErr, ok := err.(*openapi3.SchemaError)
+			if !ok {
+				report(e.pointer, "example does not match its schema: "+err.Error())
+				continue
+			}
+			reason := schemaErr.Reason
+			switch {
+			case schemaErr.Origin != nil:
+				reason = schemaErr.Origin.Error()
+			case reason == "":
+				reason = fmt.Sprintf("does not match %q", schemaErr.SchemaField)
+			}
+			against := "its schema"
+			if pointer := schemaErr.SchemaPointer(doc); pointer != "" {
+				against = pointer
+			}
+			report(e.pointer+schemaErr.InstancePointer(), fmt.Sprintf("example does not match %s: %s", against, reason))
+		}
+	})
+}
+
+// schemaErrors returns the errors err is made of.
+func schemaErrors(err error) []error {
+	me, ok := err.(openapi3.MultiError)
+	if !ok {
+		if err == nil {
+			return nil
+		}
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range me {
+		errs = append(errs, schemaErrors(e)...)
+	}
+	return errs
+}
+
+func checkExamplesUndeclaredProperties(doc *openapi3.T, report ReportFunc) {
+	walkExamples(doc, func(e example) {
+		onStack := make(map[*openapi3.Schema]int)
+		undeclaredProperties(e.pointer, e.schemaPointer, e.schema, e.value, onStack, report)
+	})
+}
+
+// undeclaredProperties reports the properties of the objects of value, at pointer,
+// that schema, at schemaPointer, does not declare, unless it allows additional properties.
+func undeclaredProperties(pointer, schemaPointer string, schema *openapi3.SchemaRef, value interface{}, onStack map[*openapi3.Schema]int, report ReportFunc) {
+	if schema == nil || schema.Value == nil || onStack[schema.Value] > 0 {
+		return
+	}
+	onStack[schema.Value]++
+	defer func() { onStack[schema.Value]-- }()
+	if schema.Ref != "" {
+		schemaPointer = schema.Ref
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		items := itemSchemas(schemaPointer, schema.Value)
+		for i, item := range value {
+			for _, itemPointer := range sortedPointers(items) {
+				undeclaredProperties(pointer+"/"+strconv.Itoa(i), itemPointer, items[itemPointer], item, onStack, report)
+			}
+		}
+	case map[string]interface{}:
+		properties, open := declaredProperties(schemaPointer, schema.Value)
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := pointer + "/" + escapePointerToken(name)
+			propertySchemas, ok := properties[name]
+			if !ok && !open {
+				report(p, fmt.Sprintf("property %q of the example is not declared by %s, consider adding to its properties %q: %s",
+					name, schemaPointer, name, suggestedSchema(value[name])))
+			}
+			for _, propertyPointer := range sortedPointers(propertySchemas) {
+				undeclaredProperties(p, propertyPointer, propertySchemas[propertyPointer], value[name], onStack, report)
+			}
+		}
+	}
+}
+
+// declaredProperties returns the schemas of the properties the schema at pointer declares,
+// by their pointers, looking into allOf, oneOf and anyOf, and whether it allows additional properties.
+func declaredProperties(pointer string, schema *openapi3.Schema) (map[string]map[string]*openapi3.SchemaRef, bool) {
+	properties := make(map[string]map[string]*openapi3.SchemaRef)
+	declare := func(name, pointer string, ref *openapi3.SchemaRef) {
+		if properties[name] == nil {
+			properties[name] = make(map[string]*openapi3.SchemaRef)
+		}
+		properties[name][pointer] = ref
+	}
+	open := schema.AdditionalProperties.Schema != nil ||
+		(schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has) ||
+		// Free-form object
+		(len(schema.Properties) == 0 && len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0)
+	for name, ref := range schema.Properties {
+		if ref != nil && ref.Value != nil {
+			declare(name, schemaRefPointer(pointer+"/properties/"+escapePointerToken(name), ref), ref)
+		}
+	}
+	for keyword, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		for i, ref := range refs {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			branchPointer := schemaRefPointer(pointer+"/"+keyword+"/"+strconv.Itoa(i), ref)
+			branchProperties, branchOpen := declaredProperties(branchPointer, ref.Value)
+			open = open || branchOpen
+			for name, schemas := range branchProperties {
+				for p, ref := range schemas {
+					declare(name, p, ref)
+				}
+			}
+		}
+	}
+	return properties, open
+}
+
+// itemSchemas returns the schemas of the items of the schema at pointer by their pointers,
+// looking into allOf, oneOf and anyOf.
+func itemSchemas(pointer string, schema *openapi3.Schema) map[string]*openapi3.SchemaRef {
+	schemas := make(map[string]*openapi3.SchemaRef)
+	if ref := schema.Items; ref != nil && ref.Value != nil {
+		schemas[schemaRefPointer(pointer+"/items", ref)] = ref
+	}
+	for keyword, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		for i, ref := range refs {
+			if ref != nil && ref.Value != nil {
+				for p, item := range itemSchemas(schemaRefPointer(pointer+"/"+keyword+"/"+strconv.Itoa(i), ref), ref.Value) {
+					schemas[p] = item
+				}
+			}
+		}
+	}
+	return schemas
+}
+
+func sortedPointers(schemas map[string]*openapi3.SchemaRef) []string {
+	pointers := make([]string, 0, len(schemas))
+	for pointer := range schemas {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+	return pointers
+}
+
+// schemaRefPointer returns the pointer, as a URI fragment, to the definition of the schema of ref at pointer.
+func schemaRefPointer(pointer string, ref *openapi3.SchemaRef) string {
+	if ref.Ref != "" {
+		return ref.Ref
+	}
+	return pointer
+}
+
+// suggestedSchema returns the JSON of a schema value matches, giving its type
+// and, for arrays of values of a same type, the type of their items.
+func suggestedSchema(value interface{}) string {
+	schema := map[string]interface{}{}
+	if t := jsonType(value); t != "" {
+		schema["type"] = t
+	}
+	if items, ok := value.([]interface{}); ok && len(items) != 0 {
+		itemType := jsonType(items[0])
+		for _, item := range items[1:] {
+			if jsonType(item) != itemType {
+				itemType = ""
+				break
+			}
+		}
+		if itemType != "" {
+			schema["items"] = map[string]interface{}{"type": itemType}
+		}
+	}
+	if value == nil {
+		schema["nullable"] = true
+	}
+	data, _ := json.Marshal(schema)
+	return string(data)
+}
+
+// jsonType returns the schema type of value, or "" for null.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case bool:
+		return openapi3.TypeBoolean
+	case float64:
+		if value == math.Trunc(value) {
+			return openapi3.TypeInteger
+		}
+		return openapi3.TypeNumber
+	case int, int32, int64:
+		return openapi3.TypeInteger
+	case string:
+		return openapi3.TypeString
+	case []interface{}:
+		return openapi3.TypeArray
+	case map[string]interface{}:
+		return openapi3.TypeObject
+	}
+	return ""
+}
+
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
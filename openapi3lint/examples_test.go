@@ -0,0 +1,91 @@
+package openapi3lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestExamplesRules(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            examples:
+              rex:
+                value: {id: 2, name: Rex, nickname: Rexy, tags: [good, dog]}
+              felix:
+                $ref: '#/components/examples/Felix'
+      responses:
+        '200':
+          description: The pet
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+              example: many
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example: {id: 1, name: Rex, owner: {name: Alice, phone: '555'}, labels: {color: brown}}
+components:
+  examples:
+    Felix:
+      value: {name: Felix, weight: 4.5}
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      example: {name: Tom, age: 3}
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	var rules []Rule
+	for _, rule := range DefaultRules() {
+		if rule.Name == RuleExamplesValid || rule.Name == RuleExamplesUndeclaredProperties {
+			rules = append(rules, rule)
+		}
+	}
+	var reported []string
+	for _, v := range New(rules...).Lint(doc) {
+		reported = append(reported, v.String())
+	}
+	require.Equal(t, []string{
+		`warning /components/schemas/Pet/example/age: property "age" of the example is not declared by #/components/schemas/Pet, consider adding to its properties "age": {"type":"integer"} (examples-undeclared-properties)`,
+		`warning /paths/~1pets/post/requestBody/content/application~1json/examples/felix/value/weight: property "weight" of the example is not declared by #/components/schemas/Pet, consider adding to its properties "weight": {"type":"number"} (examples-undeclared-properties)`,
+		`error /paths/~1pets/post/requestBody/content/application~1json/examples/rex/value: example does not match #/components/schemas/Pet/readOnly: readOnly property "id" in request (examples-valid)`,
+		`warning /paths/~1pets/post/requestBody/content/application~1json/examples/rex/value/nickname: property "nickname" of the example is not declared by #/components/schemas/Pet, consider adding to its properties "nickname": {"type":"string"} (examples-undeclared-properties)`,
+		`warning /paths/~1pets/post/requestBody/content/application~1json/examples/rex/value/tags: property "tags" of the example is not declared by #/components/schemas/Pet, consider adding to its properties "tags": {"items":{"type":"string"},"type":"array"} (examples-undeclared-properties)`,
+		`warning /paths/~1pets/post/responses/200/content/application~1json/example/owner/phone: property "phone" of the example is not declared by #/components/schemas/Pet/properties/owner, consider adding to its properties "phone": {"type":"string"} (examples-undeclared-properties)`,
+		`error /paths/~1pets/post/responses/200/headers/X-Rate-Limit/example: example does not match #/paths/~1pets/post/responses/200/headers/X-Rate-Limit/schema/type: field must be set to integer or not be present (examples-valid)`,
+	}, reported)
+}
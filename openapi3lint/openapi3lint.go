@@ -0,0 +1,199 @@
+// Package openapi3lint checks OpenAPI 3 documents against rules of style and
+// completeness beyond the specification, reporting their violations with their locations.
+package openapi3lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Violation is a part of a document breaking a Rule.
+type Violation struct {
+	// Rule is the name of the rule broken.
+	Rule     string
+	Severity openapi3.Severity
+	// Pointer is the JSON pointer to the part of the document breaking the rule.
+	Pointer string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", v.Severity, v.Pointer, v.Message, v.Rule)
+}
+
+// ReportFunc reports a violation of the rule being checked at pointer.
+type ReportFunc func(pointer, message string)
+
+// Rule is a check of documents.
+type Rule struct {
+	Name string
+	// Severity is that of the violations of the rule, openapi3.SeverityOff disabling it.
+	Severity openapi3.Severity
+	Check    func(doc *openapi3.T, report ReportFunc)
+}
+
+// The names of the rules of DefaultRules.
+const (
+	// RuleOperationIDUnique reports operations with the operationId of another,
+	// in paths, webhooks and callbacks.
+	RuleOperationIDUnique = "operation-operationId-unique"
+	// RuleOperationTags reports operations without tags.
+	RuleOperationTags = "operation-tags"
+	// RuleOperation4xxResponse reports operations without a 4xx response,
+	// be it a status code such as 404 or the 4XX range.
+	RuleOperation4xxResponse = "operation-4xx-response"
+	// RuleNoEmptyDescriptions reports the info, tags, operations, parameters,
+	// request bodies, responses and headers without a description, or a summary
+	// for operations, or whose description is only made of whitespace.
+	RuleNoEmptyDescriptions = "no-empty-descriptions"
+	// RuleExamplesValid reports the examples of schemas, parameters, headers
+	// and media types that do not match their schema.
+	RuleExamplesValid = "examples-valid"
+	// RuleExamplesUndeclaredProperties reports the properties of examples that their
+	// schema does not declare nor allows as additional properties, suggesting
+	// a schema to declare them with.
+	RuleExamplesUndeclaredProperties = "examples-undeclared-properties"
+)
+
+// DefaultRules returns the rules documents are linted against by Lint.
+// The rules returned may be modified, e.g. to change their severity.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: RuleOperationIDUnique, Severity: openapi3.SeverityError, Check: checkOperationIDUnique},
+		{Name: RuleOperationTags, Severity: openapi3.SeverityWarning, Check: checkOperationTags},
+		{Name: RuleOperation4xxResponse, Severity: openapi3.SeverityWarning, Check: checkOperation4xxResponse},
+		{Name: RuleNoEmptyDescriptions, Severity: openapi3.SeverityWarning, Check: checkNoEmptyDescriptions},
+		{Name: RuleExamplesValid, Severity: openapi3.SeverityError, Check: checkExamplesValid},
+		{Name: RuleExamplesUndeclaredProperties, Severity: openapi3.SeverityWarning, Check: checkExamplesUndeclaredProperties},
+	}
+}
+
+// Linter lints documents against its rules.
+type Linter struct {
+	Rules []Rule
+}
+
+// New returns a Linter with rules, DefaultRules if none are given.
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{Rules: rules}
+}
+
+// Lint returns the violations of the rules of linter by doc, sorted by pointer
+// then in the order of the rules.
+func (linter *Linter) Lint(doc *openapi3.T) []Violation {
+	var violations []Violation
+	for _, rule := range linter.Rules {
+		if rule.Severity == openapi3.SeverityOff || rule.Check == nil {
+			continue
+		}
+		rule.Check(doc, func(pointer, message string) {
+			violations = append(violations, Violation{
+				Rule:     rule.Name,
+				Severity: rule.Severity,
+				Pointer:  pointer,
+				Message:  message,
+			})
+		})
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Pointer < violations[j].Pointer
+	})
+	return violations
+}
+
+// Lint returns the violations of DefaultRules by doc.
+func Lint(doc *openapi3.T) []Violation {
+	return New().Lint(doc)
+}
+
+// walkOperations calls f with the operations of doc and their JSON pointers.
+func walkOperations(doc *openapi3.T, f func(pointer string, operation *openapi3.Operation)) {
+	_ = openapi3.Walk(doc, func(pointer string, value, parent interface{}) error {
+		if operation, ok := value.(*openapi3.Operation); ok {
+			f(pointer, operation)
+		}
+		return nil
+	})
+}
+
+func checkOperationIDUnique(doc *openapi3.T, report ReportFunc) {
+	first := make(map[string]string)
+	walkOperations(doc, func(pointer string, operation *openapi3.Operation) {
+		id := operation.OperationID
+		if id == "" {
+			return
+		}
+		if other, ok := first[id]; ok {
+			report(pointer, fmt.Sprintf("operationId %q is already used by %s", id, other))
+			return
+		}
+		first[id] = pointer
+	})
+}
+
+func checkOperationTags(doc *openapi3.T, report ReportFunc) {
+	walkOperations(doc, func(pointer string, operation *openapi3.Operation) {
+		if len(operation.Tags) == 0 {
+			report(pointer, "operation has no tags")
+		}
+	})
+}
+
+func checkOperation4xxResponse(doc *openapi3.T, report ReportFunc) {
+	walkOperations(doc, func(pointer string, operation *openapi3.Operation) {
+		for status := range operation.Responses {
+			if len(status) == 3 && status[0] == '4' {
+				return
+			}
+		}
+		report(pointer, "operation has no 4xx response")
+	})
+}
+
+func checkNoEmptyDescriptions(doc *openapi3.T, report ReportFunc) {
+	empty := func(s string) bool { return strings.TrimSpace(s) == "" }
+	// References are visited where they are used, after their definition
+	_ = openapi3.Walk(doc, func(pointer string, value, parent interface{}) error {
+		var kind string
+		switch v := value.(type) {
+		case *openapi3.Info:
+			if empty(v.Description) {
+				kind = "info"
+			}
+		case *openapi3.Tag:
+			if empty(v.Description) {
+				kind = "tag"
+			}
+		case *openapi3.Operation:
+			if empty(v.Summary) && empty(v.Description) {
+				report(pointer, "operation has no summary nor description")
+			}
+		case *openapi3.ParameterRef:
+			if v.Ref == "" && v.Value != nil && empty(v.Value.Description) {
+				kind = "parameter"
+			}
+		case *openapi3.RequestBodyRef:
+			if v.Ref == "" && v.Value != nil && empty(v.Value.Description) {
+				kind = "request body"
+			}
+		case *openapi3.ResponseRef:
+			if v.Ref == "" && v.Value != nil && (v.Value.Description == nil || empty(*v.Value.Description)) {
+				kind = "response"
+			}
+		case *openapi3.HeaderRef:
+			if v.Ref == "" && v.Value != nil && empty(v.Value.Description) {
+				kind = "header"
+			}
+		}
+		if kind != "" {
+			report(pointer, kind+" has no description")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package openapi3lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestLint(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+  description: The pets API
+tags:
+- name: pets
+  description: '  '
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      tags: [pets]
+      parameters:
+      - $ref: '#/components/parameters/limit'
+      responses:
+        '200':
+          description: The pets
+        4XX:
+          description: Invalid request
+    post:
+      operationId: listPets
+      responses:
+        '201':
+          description: Added
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	var reported []string
+	for _, v := range Lint(doc) {
+		reported = append(reported, v.String())
+	}
+	require.Equal(t, []string{
+		"warning /components/parameters/limit: parameter has no description (no-empty-descriptions)",
+		"error /paths/~1pets/post: operationId \"listPets\" is already used by /paths/~1pets/get (operation-operationId-unique)",
+		"warning /paths/~1pets/post: operation has no tags (operation-tags)",
+		"warning /paths/~1pets/post: operation has no 4xx response (operation-4xx-response)",
+		"warning /paths/~1pets/post: operation has no summary nor description (no-empty-descriptions)",
+		"warning /tags/0: tag has no description (no-empty-descriptions)",
+	}, reported)
+
+	rules := DefaultRules()
+	for i := range rules {
+		switch rules[i].Name {
+		case RuleOperation4xxResponse:
+			rules[i].Severity = openapi3.SeverityError
+		case RuleNoEmptyDescriptions, RuleOperationIDUnique:
+			rules[i].Severity = openapi3.SeverityOff
+		}
+	}
+	rules = append(rules, Rule{
+		Name:     "info-title-length",
+		Severity: openapi3.SeverityWarning,
+		Check: func(doc *openapi3.T, report ReportFunc) {
+			if len(doc.Info.Title) < 5 {
+				report("/info/title", "title is too short")
+			}
+		},
+	})
+	require.Equal(t, []Violation{
+		{Rule: "info-title-length", Severity: openapi3.SeverityWarning, Pointer: "/info/title", Message: "title is too short"},
+		{Rule: RuleOperationTags, Severity: openapi3.SeverityWarning, Pointer: "/paths/~1pets/post", Message: "operation has no tags"},
+		{Rule: RuleOperation4xxResponse, Severity: openapi3.SeverityError, Pointer: "/paths/~1pets/post", Message: "operation has no 4xx response"},
+	}, New(rules...).Lint(doc))
+}
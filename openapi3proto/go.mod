@@ -0,0 +1,11 @@
+module github.com/getkin/kin-openapi/openapi3proto
+
+go 1.16
+
+require (
+	github.com/getkin/kin-openapi v0.0.0
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/protobuf v1.28.1
+)
+
+replace github.com/getkin/kin-openapi => ../
@@ -0,0 +1,281 @@
+// Package openapi3proto converts protobuf descriptors to OpenAPIv3 schemas
+// following the proto3 JSON mapping.
+// See https://protobuf.dev/programming-guides/proto3/#json
+package openapi3proto
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Option allows tweaking the conversion
+type Option func(*converterOpt)
+
+type converterOpt struct {
+	useProtoNames bool
+}
+
+// UseProtoNames names properties after the fields as they are declared in the
+// .proto files instead of their lowerCamelCase JSON names.
+func UseProtoNames() Option {
+	return func(x *converterOpt) { x.useProtoNames = true }
+}
+
+// NewSchemas returns the schemas of the messages and enums declared by the files of set,
+// named after their full names (e.g. "acme.pets.v1.Pet") as meant for components/schemas.
+// Messages reference one another with "#/components/schemas/<full name>".
+//
+// Well-known types (google.protobuf.Timestamp, Duration, wrappers, Struct, Any...)
+// are not converted to components but inlined as their JSON representation.
+// The fields of a oneof are properties of which at most one may be set.
+func NewSchemas(set *descriptorpb.FileDescriptorSet, opts ...Option) (openapi3.Schemas, error) {
+	c := &converter{schemas: make(openapi3.Schemas)}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file descriptor set: %w", err)
+	}
+	for _, file := range set.GetFile() {
+		fd, err := files.FindFileByPath(file.GetName())
+		if err != nil {
+			return nil, err
+		}
+		c.addEnums(fd.Enums())
+		c.addMessages(fd.Messages())
+	}
+	return c.schemas, nil
+}
+
+type converter struct {
+	opts    converterOpt
+	schemas openapi3.Schemas
+}
+
+func (c *converter) addEnums(enums protoreflect.EnumDescriptors) {
+	for i := 0; i < enums.Len(); i++ {
+		enum := enums.Get(i)
+		if _, ok := wellKnownEnums[enum.FullName()]; ok {
+			continue
+		}
+		c.schemas[string(enum.FullName())] = openapi3.NewSchemaRef("", c.enumSchema(enum))
+	}
+}
+
+func (c *converter) addMessages(messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		c.addEnums(message.Enums())
+		c.addMessages(message.Messages())
+		if message.IsMapEntry() {
+			continue
+		}
+		if _, ok := wellKnownTypes[message.FullName()]; ok {
+			continue
+		}
+		c.schemas[string(message.FullName())] = openapi3.NewSchemaRef("", c.messageSchema(message))
+	}
+}
+
+func (c *converter) enumSchema(enum protoreflect.EnumDescriptor) *openapi3.Schema {
+	schema := openapi3.NewStringSchema()
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+	}
+	schema.Description = comments(enum)
+	if options, ok := enum.Options().(*descriptorpb.EnumOptions); ok && options.GetDeprecated() {
+		schema.Deprecated = true
+	}
+	return schema
+}
+
+func (c *converter) messageSchema(message protoreflect.MessageDescriptor) *openapi3.Schema {
+	schema := openapi3.NewObjectSchema()
+	schema.Description = comments(message)
+	if options, ok := message.Options().(*descriptorpb.MessageOptions); ok && options.GetDeprecated() {
+		schema.Deprecated = true
+	}
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		property := c.fieldSchema(field)
+		if description := comments(field); description != "" && property.Ref == "" {
+			property.Value.Description = description
+		}
+		if options, ok := field.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+			if property.Ref != "" {
+				// Siblings of $ref are ignored
+				property = openapi3.NewSchemaRef("", &openapi3.Schema{
+					AllOf:      openapi3.SchemaRefs{property},
+					Deprecated: true,
+				})
+			} else {
+				property.Value.Deprecated = true
+			}
+		}
+		schema.WithPropertyRef(c.propertyName(field), property)
+	}
+
+	// At most one of the fields of a oneof may be set
+	var groups openapi3.SchemaRefs
+	oneofs := message.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() || oneof.Fields().Len() < 2 {
+			continue
+		}
+		group := &openapi3.Schema{}
+		none := &openapi3.Schema{}
+		for j := 0; j < oneof.Fields().Len(); j++ {
+			required := openapi3.NewSchemaRef("", &openapi3.Schema{Required: []string{c.propertyName(oneof.Fields().Get(j))}})
+			group.OneOf = append(group.OneOf, required)
+			none.AnyOf = append(none.AnyOf, required)
+		}
+		group.OneOf = append(group.OneOf, openapi3.NewSchemaRef("", &openapi3.Schema{Not: openapi3.NewSchemaRef("", none)}))
+		groups = append(groups, openapi3.NewSchemaRef("", group))
+	}
+	switch len(groups) {
+	case 0:
+	case 1:
+		schema.OneOf = groups[0].Value.OneOf
+	default:
+		schema.AllOf = groups
+	}
+	return schema
+}
+
+func (c *converter) propertyName(field protoreflect.FieldDescriptor) string {
+	if c.opts.useProtoNames {
+		return string(field.Name())
+	}
+	return field.JSONName()
+}
+
+func (c *converter) fieldSchema(field protoreflect.FieldDescriptor) *openapi3.SchemaRef {
+	if field.IsMap() {
+		return openapi3.NewSchemaRef("", &openapi3.Schema{
+			Type:                 openapi3.TypeObject,
+			AdditionalProperties: openapi3.AdditionalProperties{Schema: c.singularSchema(field.MapValue())},
+		})
+	}
+	if field.IsList() {
+		return openapi3.NewSchemaRef("", &openapi3.Schema{
+			Type:  openapi3.TypeArray,
+			Items: c.singularSchema(field),
+		})
+	}
+	return c.singularSchema(field)
+}
+
+func (c *converter) singularSchema(field protoreflect.FieldDescriptor) *openapi3.SchemaRef {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		enum := field.Enum()
+		if f, ok := wellKnownEnums[enum.FullName()]; ok {
+			return openapi3.NewSchemaRef("", f())
+		}
+		return openapi3.NewSchemaRef(componentRef(enum.FullName()), nil)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		message := field.Message()
+		if f, ok := wellKnownTypes[message.FullName()]; ok {
+			return openapi3.NewSchemaRef("", f())
+		}
+		return openapi3.NewSchemaRef(componentRef(message.FullName()), nil)
+	}
+	return openapi3.NewSchemaRef("", scalarSchema(field.Kind()))
+}
+
+func componentRef(name protoreflect.FullName) string {
+	return "#/components/schemas/" + string(name)
+}
+
+// scalarSchema returns the schema of the JSON representation of a scalar kind:
+// 64-bit integers are strings, bytes are base64 encoded strings.
+func scalarSchema(kind protoreflect.Kind) *openapi3.Schema {
+	switch kind {
+	case protoreflect.BoolKind:
+		return openapi3.NewBoolSchema()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return openapi3.NewInt32Schema()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return openapi3.NewInt64Schema().WithMin(0).WithMax(1<<32 - 1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return openapi3.NewStringSchema().WithFormat("int64")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return openapi3.NewStringSchema().WithFormat("uint64")
+	case protoreflect.FloatKind:
+		return openapi3.NewFloat64Schema().WithFormat("float")
+	case protoreflect.DoubleKind:
+		return openapi3.NewFloat64Schema().WithFormat("double")
+	case protoreflect.BytesKind:
+		return openapi3.NewBytesSchema()
+	default:
+		return openapi3.NewStringSchema()
+	}
+}
+
+func wrapper(kind protoreflect.Kind) func() *openapi3.Schema {
+	return func() *openapi3.Schema {
+		schema := scalarSchema(kind)
+		schema.Nullable = true
+		return schema
+	}
+}
+
+// wellKnownTypes are the schemas of the JSON representations of the well-known message types.
+var wellKnownTypes = map[protoreflect.FullName]func() *openapi3.Schema{
+	"google.protobuf.Timestamp": openapi3.NewDateTimeSchema,
+	"google.protobuf.Duration": func() *openapi3.Schema {
+		return openapi3.NewStringSchema().WithPattern(`^-?[0-9]+(\.[0-9]{1,9})?s$`)
+	},
+	"google.protobuf.FieldMask": openapi3.NewStringSchema,
+	"google.protobuf.Empty":     openapi3.NewObjectSchema,
+	"google.protobuf.Struct": func() *openapi3.Schema {
+		return openapi3.NewObjectSchema().WithAnyAdditionalProperties()
+	},
+	"google.protobuf.Value": func() *openapi3.Schema {
+		return &openapi3.Schema{Nullable: true}
+	},
+	"google.protobuf.ListValue": func() *openapi3.Schema {
+		return openapi3.NewArraySchema().WithItems(&openapi3.Schema{Nullable: true})
+	},
+	"google.protobuf.Any": func() *openapi3.Schema {
+		schema := openapi3.NewObjectSchema().
+			WithProperty("@type", openapi3.NewStringSchema()).
+			WithAnyAdditionalProperties()
+		schema.Required = []string{"@type"}
+		return schema
+	},
+	"google.protobuf.DoubleValue": wrapper(protoreflect.DoubleKind),
+	"google.protobuf.FloatValue":  wrapper(protoreflect.FloatKind),
+	"google.protobuf.Int64Value":  wrapper(protoreflect.Int64Kind),
+	"google.protobuf.UInt64Value": wrapper(protoreflect.Uint64Kind),
+	"google.protobuf.Int32Value":  wrapper(protoreflect.Int32Kind),
+	"google.protobuf.UInt32Value": wrapper(protoreflect.Uint32Kind),
+	"google.protobuf.BoolValue":   wrapper(protoreflect.BoolKind),
+	"google.protobuf.StringValue": wrapper(protoreflect.StringKind),
+	"google.protobuf.BytesValue":  wrapper(protoreflect.BytesKind),
+}
+
+// wellKnownEnums are the schemas of the JSON representations of the well-known enums.
+var wellKnownEnums = map[protoreflect.FullName]func() *openapi3.Schema{
+	"google.protobuf.NullValue": func() *openapi3.Schema {
+		return &openapi3.Schema{Nullable: true, Enum: []interface{}{nil}}
+	},
+}
+
+// comments returns the leading comments of descriptor in its .proto file, if known.
+func comments(descriptor protoreflect.Descriptor) string {
+	location := descriptor.ParentFile().SourceLocations().ByDescriptor(descriptor)
+	return strings.TrimSpace(location.LeadingComments)
+}
@@ -0,0 +1,181 @@
+package openapi3proto
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func petsFile() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			JsonName: proto.String(jsonName(name)),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	repeated := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	inOneof := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.OneofIndex = proto.Int32(0)
+		return f
+	}
+	deprecated := field("old", 12, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	deprecated.Options = &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("acme/pets/v1/pets.proto"),
+		Package:    proto.String("acme.pets.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Pet"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("display_name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("id", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				field("kind", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".acme.pets.v1.Pet.Kind"),
+				repeated(field("tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+				repeated(field("scores", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".acme.pets.v1.Pet.ScoresEntry")),
+				field("born_at", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				field("nickname", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				inOneof(field("person", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+				inOneof(field("shelter", 9, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+				field("parent", 10, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".acme.pets.v1.Pet"),
+				field("photo", 11, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+				deprecated,
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("ScoresEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+			EnumType: []*descriptorpb.EnumDescriptorProto{{
+				Name: proto.String("Kind"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("KIND_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("DOG"), Number: proto.Int32(1)},
+					{Name: proto.String("CAT"), Number: proto.Int32(2)},
+				},
+			}},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("owner")}},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{4, 0},
+				Span:            []int32{3, 0, 20, 1},
+				LeadingComments: proto.String(" A pet of the shelter.\n"),
+			}},
+		},
+	}
+}
+
+func TestNewSchemas(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+		petsFile(),
+	}}
+	schemas, err := NewSchemas(set)
+	require.NoError(t, err)
+	require.Len(t, schemas, 2)
+
+	pet := schemas["acme.pets.v1.Pet"].Value
+	require.Equal(t, "A pet of the shelter.", pet.Description)
+	require.Equal(t, "#/components/schemas/acme.pets.v1.Pet.Kind", pet.Properties["kind"].Ref)
+	require.Equal(t, "#/components/schemas/acme.pets.v1.Pet", pet.Properties["parent"].Ref)
+	require.Equal(t, "int64", pet.Properties["id"].Value.Format)
+	require.Equal(t, "date-time", pet.Properties["bornAt"].Value.Format)
+	require.True(t, pet.Properties["nickname"].Value.Nullable)
+	require.True(t, pet.Properties["old"].Value.Deprecated)
+	require.Equal(t, []interface{}{"KIND_UNSPECIFIED", "DOG", "CAT"}, schemas["acme.pets.v1.Pet.Kind"].Value.Enum)
+
+	// Round-trip the schemas through a document so that references resolve
+	doc := &openapi3.T{
+		OpenAPI:    "3.0.3",
+		Info:       &openapi3.Info{Title: "Pets", Version: "1.0.0"},
+		Paths:      openapi3.Paths{},
+		Components: openapi3.Components{Schemas: schemas},
+	}
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	doc, err = openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	schema := doc.Components.Schemas["acme.pets.v1.Pet"].Value
+
+	// Messages encoded by protojson conform to the schemas
+	files, err := protodesc.NewFiles(set)
+	require.NoError(t, err)
+	descriptor, err := files.FindDescriptorByName("acme.pets.v1.Pet")
+	require.NoError(t, err)
+	md := descriptor.(protoreflect.MessageDescriptor)
+	message := dynamicpb.NewMessage(md)
+	fields := md.Fields()
+	message.Set(fields.ByName("display_name"), protoreflect.ValueOfString("Rex"))
+	message.Set(fields.ByName("id"), protoreflect.ValueOfInt64(1<<40))
+	message.Set(fields.ByName("kind"), protoreflect.ValueOfEnum(1))
+	message.Mutable(fields.ByName("tags")).List().Append(protoreflect.ValueOfString("good"))
+	message.Mutable(fields.ByName("scores")).Map().Set(protoreflect.ValueOfString("agility").MapKey(), protoreflect.ValueOfInt32(9))
+	message.Set(fields.ByName("born_at"), protoreflect.ValueOfMessage(timestamppb.Now().ProtoReflect()))
+	message.Set(fields.ByName("nickname"), protoreflect.ValueOfMessage(wrapperspb.String("R").ProtoReflect()))
+	message.Set(fields.ByName("person"), protoreflect.ValueOfString("Ann"))
+	message.Set(fields.ByName("photo"), protoreflect.ValueOfBytes([]byte{0xff, 0x00}))
+	parent := dynamicpb.NewMessage(md)
+	parent.Set(fields.ByName("shelter"), protoreflect.ValueOfString("North"))
+	message.Set(fields.ByName("parent"), protoreflect.ValueOfMessage(parent))
+
+	encoded, err := protojson.Marshal(message)
+	require.NoError(t, err)
+	var value interface{}
+	require.NoError(t, json.Unmarshal(encoded, &value))
+	require.NoError(t, schema.VisitJSON(value), string(encoded))
+
+	// At most one field of a oneof
+	value.(map[string]interface{})["shelter"] = "South"
+	require.Error(t, schema.VisitJSON(value))
+
+	schemas, err = NewSchemas(set, UseProtoNames())
+	require.NoError(t, err)
+	require.Contains(t, schemas["acme.pets.v1.Pet"].Value.Properties, "display_name")
+}
+
+func jsonName(name string) string {
+	var b []byte
+	upper := false
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c == '_':
+			upper = true
+		case upper && 'a' <= c && c <= 'z':
+			b = append(b, c-'a'+'A')
+			upper = false
+		default:
+			b = append(b, c)
+			upper = false
+		}
+	}
+	return string(b)
+}
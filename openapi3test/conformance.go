@@ -0,0 +1,178 @@
+package openapi3test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// RequireResponseConforms checks resp, the response to req, as AssertResponseConforms does
+// and stops the test if it does not conform.
+func RequireResponseConforms(t testing.TB, doc *openapi3.T, req *http.Request, resp *http.Response) {
+	t.Helper()
+	if !AssertResponseConforms(t, doc, req, resp) {
+		t.FailNow()
+	}
+}
+
+// AssertResponseConforms finds the operation of doc that req targets and reports
+// an error if resp, the response to req, is not documented by that operation:
+// undocumented status, missing header or body not matching the schema.
+// Schema errors are listed with the JSON pointer of the offending value.
+//
+// The bodies of req and resp can still be read afterwards.
+// It returns whether the response conforms.
+func AssertResponseConforms(t testing.TB, doc *openapi3.T, req *http.Request, resp *http.Response) bool {
+	t.Helper()
+	if err := ResponseConforms(doc, req, resp); err != nil {
+		t.Errorf("%s %s: response does not conform to the document:\n%s", req.Method, req.URL.Path, DescribeValidationError(err))
+		return false
+	}
+	return true
+}
+
+// ResponseConforms returns an error if resp, the response to req, is not documented
+// by the operation of doc that req targets.
+func ResponseConforms(doc *openapi3.T, req *http.Request, resp *http.Response) error {
+	router, err := docRouter(doc)
+	if err != nil {
+		return err
+	}
+	route, pathParams, err := router.FindRoute(req)
+	if err != nil {
+		return fmt.Errorf("no operation of the document matches the request: %w", err)
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		if body, err = ioutil.ReadAll(resp.Body); err != nil {
+			return err
+		}
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	options := &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true}
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		},
+		Status:  resp.StatusCode,
+		Header:  resp.Header,
+		Options: options,
+	}
+	input.SetBodyBytes(body)
+	return openapi3filter.ValidateResponse(context.Background(), input)
+}
+
+// docRouters caches the router of each document, as building one compiles its paths.
+var docRouters sync.Map // *openapi3.T -> routers.Router
+
+func docRouter(doc *openapi3.T) (routers.Router, error) {
+	if router, ok := docRouters.Load(doc); ok {
+		return router.(routers.Router), nil
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	docRouters.Store(doc, router)
+	return router, nil
+}
+
+// DescribeValidationError formats a request or response validation error,
+// with a line per schema error giving the JSON pointer of the offending value,
+// the value and the schema keyword it does not satisfy.
+func DescribeValidationError(err error) string {
+	var sb strings.Builder
+	describeValidationError(&sb, err, "")
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func describeValidationError(sb *strings.Builder, err error, indent string) {
+	var multiError openapi3.MultiError
+	var responseError *openapi3filter.ResponseError
+	var requestError *openapi3filter.RequestError
+	var schemaError *openapi3.SchemaError
+	switch {
+	case errors.As(err, &multiError) && len(multiError) > 1:
+		for _, err := range multiError {
+			describeValidationError(sb, err, indent)
+		}
+	case errors.As(err, &responseError) && responseError.Err != nil:
+		sb.WriteString(indent + responseError.Reason + "\n")
+		describeValidationError(sb, responseError.Err, indent+"  ")
+	case errors.As(err, &requestError) && requestError.Err != nil:
+		sb.WriteString(indent + strings.TrimSuffix(requestError.Error(), ": "+requestError.Err.Error()) + "\n")
+		describeValidationError(sb, requestError.Err, indent+"  ")
+	case errors.As(err, &schemaError):
+		pointer := "/" + strings.Join(schemaError.JSONPointer(), "/")
+		reason := schemaError.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("doesn't match schema %q", schemaError.SchemaField)
+		}
+		sb.WriteString(fmt.Sprintf("%s%s: %s\n", indent, pointer, reason))
+		if typ, ok := schemaError.Value.(string); ok && schemaError.SchemaField == "type" {
+			// Type errors hold the type of the value rather than the value
+			sb.WriteString(fmt.Sprintf("%s  value type: %s\n", indent, typ))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s  value: %s\n", indent, jsonString(schemaError.Value)))
+		}
+		if keyword := schemaKeyword(schemaError.Schema, schemaError.SchemaField); keyword != "" {
+			sb.WriteString(fmt.Sprintf("%s  schema: {%q: %s}\n", indent, schemaError.SchemaField, keyword))
+		}
+	default:
+		sb.WriteString(indent + err.Error() + "\n")
+	}
+}
+
+// schemaKeyword returns the JSON value of a keyword of schema.
+func schemaKeyword(schema *openapi3.Schema, keyword string) string {
+	if schema == nil || keyword == "" {
+		return ""
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	value, ok := fields[keyword]
+	if !ok {
+		return ""
+	}
+	return abbreviate(string(value))
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return abbreviate(string(data))
+}
+
+func abbreviate(s string) string {
+	const maxLength = 200
+	if len(s) > maxLength {
+		return s[:maxLength] + "..."
+	}
+	return s
+}
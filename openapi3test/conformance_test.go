@@ -0,0 +1,55 @@
+package openapi3test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestAssertResponseConforms(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(operationsSpec))
+	require.NoError(t, err)
+
+	response := func(status int, body string) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		rec.WriteHeader(status)
+		rec.WriteString(body)
+		return rec.Result()
+	}
+	req := httptest.NewRequest(http.MethodGet, "/pets?limit=1&tags=a&tags=b", nil)
+	req.Header.Set("X-Request-Id", "abc")
+
+	resp := response(http.StatusOK, `[{"id":1,"name":"Rex"}]`)
+	RequireResponseConforms(t, doc, req, resp)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `[{"id":1,"name":"Rex"}]`, string(body))
+
+	rt := &recordingT{TB: t}
+	require.False(t, AssertResponseConforms(rt, doc, req, response(http.StatusOK, `[{"id":1,"name":42}]`)))
+	require.Len(t, rt.errors, 1)
+	require.Equal(t, strings.Join([]string{
+		"GET /pets: response does not conform to the document:",
+		"response body doesn't match schema",
+		`  /0/name: field must be set to string or not be present`,
+		`    value type: number, integer`,
+		`    schema: {"type": "string"}`,
+	}, "\n"), rt.errors[0])
+
+	rt = &recordingT{TB: t}
+	require.False(t, AssertResponseConforms(rt, doc, req, response(http.StatusNotFound, `{}`)))
+	require.Len(t, rt.errors, 1)
+	require.Contains(t, rt.errors[0], "status is not supported")
+
+	rt = &recordingT{TB: t}
+	require.False(t, AssertResponseConforms(rt, doc, httptest.NewRequest(http.MethodGet, "/unknown", nil), response(http.StatusOK, `[]`)))
+	require.Len(t, rt.errors, 1)
+	require.Contains(t, rt.errors[0], "no operation of the document matches the request")
+}
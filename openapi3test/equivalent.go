@@ -0,0 +1,256 @@
+package openapi3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Difference is a location where two documents differ.
+type Difference struct {
+	// Pointer is the JSON pointer of the location, e.g. "/paths/~1pets/get/summary"
+	Pointer string
+	// A and B are the values of each document at that location, nil when absent
+	A, B interface{}
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s != %s", d.Pointer, formatDifferenceValue(d.A), formatDifferenceValue(d.B))
+}
+
+func formatDifferenceValue(value interface{}) string {
+	if value == nil {
+		return "(absent)"
+	}
+	return jsonString(value)
+}
+
+// DocsDifferences compares two documents semantically and returns where they differ,
+// sorted by pointer.
+//
+// Key order is not significant, references are compared through the values they
+// resolve to, whether defined inline or not, and empty values (empty strings,
+// lists, maps or objects) are equal to absent ones.
+func DocsDifferences(a, b *openapi3.T) []Difference {
+	c := &docsComparer{visited: make(map[[2]uintptr]struct{})}
+	c.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	sort.SliceStable(c.differences, func(i, j int) bool { return c.differences[i].Pointer < c.differences[j].Pointer })
+	return c.differences
+}
+
+// AssertDocsEquivalent reports an error listing the differences between a and b,
+// as returned by DocsDifferences, if any. It returns whether the documents are equivalent.
+func AssertDocsEquivalent(t testing.TB, a, b *openapi3.T) bool {
+	t.Helper()
+	differences := DocsDifferences(a, b)
+	if len(differences) == 0 {
+		return true
+	}
+	lines := make([]string, 0, len(differences))
+	for _, difference := range differences {
+		lines = append(lines, "  "+difference.String())
+	}
+	t.Errorf("documents are not equivalent:\n%s", strings.Join(lines, "\n"))
+	return false
+}
+
+type docsComparer struct {
+	differences []Difference
+	// pointers already compared
+	visited map[[2]uintptr]struct{}
+}
+
+func (c *docsComparer) differ(pointer string, a, b reflect.Value) {
+	c.differences = append(c.differences, Difference{
+		Pointer: pointer,
+		A:       differenceValue(a),
+		B:       differenceValue(b),
+	})
+}
+
+func differenceValue(v reflect.Value) interface{} {
+	if !v.IsValid() || isEmptyValue(v) {
+		return nil
+	}
+	return v.Interface()
+}
+
+func (c *docsComparer) compare(pointer string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() {
+		if (a.IsValid() && !isEmptyValue(a)) || (b.IsValid() && !isEmptyValue(b)) {
+			c.differ(pointer, a, b)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if !isEmptyValue(a) || !isEmptyValue(b) {
+				c.differ(pointer, a, b)
+			}
+			return
+		}
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if _, ok := c.visited[key]; ok {
+			return
+		}
+		c.visited[key] = struct{}{}
+		c.compare(pointer, a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		t := a.Type()
+		if isRefStruct(t) {
+			// Compare what references resolve to
+			valueA, valueB := a.Field(1), b.Field(1)
+			if valueA.IsNil() && valueB.IsNil() {
+				c.compare(childPointer(pointer, "$ref"), a.Field(0), b.Field(0))
+				return
+			}
+			c.compare(pointer, valueA, valueB)
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if field.Type == reflect.TypeOf(openapi3.ExtensionProps{}) {
+				c.compare(pointer, a.Field(i).Field(0), b.Field(i).Field(0))
+				continue
+			}
+			if field.Name == "Ref" && field.Type.Kind() == reflect.String {
+				// PathItem references
+				continue
+			}
+			c.compare(childPointer(pointer, jsonFieldName(field)), a.Field(i), b.Field(i))
+		}
+
+	case reflect.Map:
+		keys := make(map[string]reflect.Value, a.Len()+b.Len())
+		for _, key := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[key.String()] = key
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := keys[name]
+			c.compare(childPointer(pointer, name), a.MapIndex(key), b.MapIndex(key))
+		}
+
+	case reflect.Slice:
+		n := a.Len()
+		if b.Len() > n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			var itemA, itemB reflect.Value
+			if i < a.Len() {
+				itemA = a.Index(i)
+			}
+			if i < b.Len() {
+				itemB = b.Index(i)
+			}
+			c.compare(childPointer(pointer, strconv.Itoa(i)), itemA, itemB)
+		}
+
+	case reflect.Interface:
+		// Examples, defaults, enums and extensions hold arbitrary JSON
+		if !reflect.DeepEqual(normalizeJSON(a.Interface()), normalizeJSON(b.Interface())) {
+			c.differ(pointer, a, b)
+		}
+
+	default:
+		if a.Interface() != b.Interface() {
+			c.differ(pointer, a, b)
+		}
+	}
+}
+
+// isRefStruct tells whether t is an XxxRef struct: a Ref string and a Value pointer.
+func isRefStruct(t reflect.Type) bool {
+	if t.NumField() != 2 {
+		return false
+	}
+	ref, value := t.Field(0), t.Field(1)
+	return ref.Name == "Ref" && ref.Type.Kind() == reflect.String &&
+		value.Name == "Value" && value.Type.Kind() == reflect.Ptr
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "multijson"} {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// normalizeJSON returns value as decoded by encoding/json into an interface{}.
+func normalizeJSON(value interface{}) interface{} {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return value
+		}
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	return isEmpty(v, make(map[uintptr]struct{}))
+}
+
+func isEmpty(v reflect.Value, visited map[uintptr]struct{}) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		if _, ok := visited[v.Pointer()]; ok {
+			return true
+		}
+		visited[v.Pointer()] = struct{}{}
+		return isEmpty(v.Elem(), visited)
+	case reflect.Interface:
+		// Arbitrary JSON: false, 0 and "" are values
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" && !isEmpty(v.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		return v.IsZero()
+	}
+}
+
+func childPointer(pointer string, token string) string {
+	return pointer + "/" + strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
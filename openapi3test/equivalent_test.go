@@ -0,0 +1,113 @@
+package openapi3test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestDocsDifferences(t *testing.T) {
+	load := func(spec string) *openapi3.T {
+		doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		return doc
+	}
+
+	a := load(`
+openapi: 3.0.0
+info: {title: Pets, version: 1.0.0, x-id: 1}
+tags: []
+paths:
+  /pets:
+    get:
+      parameters: []
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string, example: {a: 1}}
+`)
+	b := load(`{
+  "info": {"x-id": 1.0, "version": "1.0.0", "title": "Pets"},
+  "openapi": "3.0.0",
+  "paths": {"/pets": {"get": {"responses": {"200": {
+    "description": "ok",
+    "content": {"application/json": {"schema": {
+      "type": "object",
+      "properties": {"name": {"example": {"a": 1}, "type": "string"}}
+    }}}
+  }}}}},
+  "components": {"schemas": {"Pet": {"properties": {"name": {"type": "string", "example": {"a": 1}}}, "type": "object"}}}
+}`)
+	require.Empty(t, DocsDifferences(a, b))
+	require.True(t, AssertDocsEquivalent(t, a, b))
+
+	c := load(`
+openapi: 3.0.0
+info: {title: Pets, version: 2.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: integer}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string, example: {a: 1}}
+`)
+	differences := DocsDifferences(a, c)
+	var lines []string
+	for _, d := range differences {
+		lines = append(lines, d.String())
+	}
+	require.Equal(t, []string{
+		`/info/version: "1.0.0" != "2.0.0"`,
+		`/info/x-id: 1 != (absent)`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/properties/name/example: {"a":1} != (absent)`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/properties/name/type: "string" != "integer"`,
+	}, lines)
+
+	rt := &recordingT{TB: t}
+	require.False(t, AssertDocsEquivalent(rt, a, c))
+	require.Len(t, rt.errors, 1)
+	require.Contains(t, rt.errors[0], `/info/version: "1.0.0" != "2.0.0"`)
+}
+
+func TestDocsDifferencesRecursive(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: Tree, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items: {$ref: '#/components/schemas/Node'}
+`
+	a, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	b, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	require.Empty(t, DocsDifferences(a, b))
+}
@@ -0,0 +1,289 @@
+// Package openapi3test helps testing APIs against their OpenAPI 3 description.
+package openapi3test
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RequestExample returns a value that schema accepts in a request.
+//
+// The schema's example, default or first enum value is used when set.
+// Otherwise a value is built from the schema's type, format and constraints:
+// all properties of objects are filled in except readOnly ones and the first
+// alternative of oneOf and anyOf is used. Patterns are not honored.
+func RequestExample(schema *openapi3.Schema) interface{} {
+	g := &exampleGenerator{request: true, onStack: make(map[*openapi3.Schema]int)}
+	return g.generate(schema)
+}
+
+// ResponseExample returns a value that schema accepts in a response,
+// built as RequestExample does but skipping writeOnly properties instead of readOnly ones.
+func ResponseExample(schema *openapi3.Schema) interface{} {
+	g := &exampleGenerator{onStack: make(map[*openapi3.Schema]int)}
+	return g.generate(schema)
+}
+
+// maxExampleRecursion is the number of times a recursive schema is expanded.
+const maxExampleRecursion = 2
+
+type exampleGenerator struct {
+	request bool
+	// schemas being generated, with their expansion count
+	onStack map[*openapi3.Schema]int
+}
+
+func (g *exampleGenerator) generate(schema *openapi3.Schema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) != 0:
+		return schema.Enum[0]
+	}
+	g.onStack[schema]++
+	defer func() { g.onStack[schema]-- }()
+
+	value := g.generateType(schema)
+
+	for _, ref := range schema.AllOf {
+		if ref == nil {
+			continue
+		}
+		branch := g.generate(ref.Value)
+		object, isObject := value.(map[string]interface{})
+		branchObject, branchIsObject := branch.(map[string]interface{})
+		switch {
+		case isObject && branchIsObject:
+			for k, v := range branchObject {
+				if _, ok := object[k]; !ok {
+					object[k] = v
+				}
+			}
+		case value == nil:
+			value = branch
+		}
+	}
+	for _, alternatives := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) == 0 || alternatives[0] == nil {
+			continue
+		}
+		branch := g.generate(alternatives[0].Value)
+		object, isObject := value.(map[string]interface{})
+		branchObject, branchIsObject := branch.(map[string]interface{})
+		switch {
+		case isObject && branchIsObject:
+			for k, v := range branchObject {
+				object[k] = v
+			}
+		case value == nil || !branchIsObject:
+			value = branch
+		}
+		if object, ok := value.(map[string]interface{}); ok && schema.Discriminator != nil {
+			object[schema.Discriminator.PropertyName] = discriminatorValue(schema.Discriminator, alternatives[0].Ref)
+		}
+	}
+	return value
+}
+
+// discriminatorValue returns the discriminator value selecting the schema at ref.
+func discriminatorValue(discriminator *openapi3.Discriminator, ref string) string {
+	keys := make([]string, 0, len(discriminator.Mapping))
+	for key := range discriminator.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if mapped := discriminator.Mapping[key]; mapped == ref || "#/components/schemas/"+mapped == ref {
+			return key
+		}
+	}
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func (g *exampleGenerator) generateType(schema *openapi3.Schema) interface{} {
+	schemaType := schema.Type
+	if schemaType == "" {
+		switch {
+		case len(schema.Properties) != 0 || schema.AdditionalProperties.Schema != nil:
+			schemaType = openapi3.TypeObject
+		case schema.Items != nil:
+			schemaType = openapi3.TypeArray
+		case len(schema.AllOf) != 0 || len(schema.OneOf) != 0 || len(schema.AnyOf) != 0:
+			return nil
+		default:
+			return "example"
+		}
+	}
+
+	switch schemaType {
+	case openapi3.TypeObject:
+		return g.generateObject(schema)
+	case openapi3.TypeArray:
+		return g.generateArray(schema)
+	case openapi3.TypeString:
+		return exampleString(schema)
+	case openapi3.TypeInteger:
+		return int64(exampleNumber(schema, true))
+	case openapi3.TypeNumber:
+		return exampleNumber(schema, false)
+	case openapi3.TypeBoolean:
+		return true
+	default:
+		return nil
+	}
+}
+
+func (g *exampleGenerator) generateObject(schema *openapi3.Schema) interface{} {
+	object := make(map[string]interface{}, len(schema.Properties))
+	required := make(map[string]struct{}, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := schema.Properties[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		property := ref.Value
+		if (g.request && property.ReadOnly) || (!g.request && property.WriteOnly) {
+			continue
+		}
+		if g.onStack[property] >= maxExampleRecursion {
+			// Stop expanding recursive schemas
+			if _, ok := required[name]; ok {
+				object[name] = nil
+			}
+			continue
+		}
+		object[name] = g.generate(property)
+	}
+
+	for i := 1; uint64(len(object)) < schema.MinProps; i++ {
+		name := "property" + strconv.Itoa(i)
+		if _, ok := object[name]; ok {
+			continue
+		}
+		var value interface{} = "example"
+		if ref := schema.AdditionalProperties.Schema; ref != nil && ref.Value != nil && g.onStack[ref.Value] < maxExampleRecursion {
+			value = g.generate(ref.Value)
+		}
+		object[name] = value
+	}
+	return object
+}
+
+func (g *exampleGenerator) generateArray(schema *openapi3.Schema) interface{} {
+	count := schema.MinItems
+	if count == 0 && !schema.UniqueItems {
+		count = 1
+	}
+	if max := schema.MaxItems; max != nil && count > *max {
+		count = *max
+	}
+	items := make([]interface{}, 0, count)
+	if ref := schema.Items; ref != nil && ref.Value != nil {
+		if g.onStack[ref.Value] >= maxExampleRecursion {
+			return items
+		}
+		for i := uint64(0); i < count; i++ {
+			items = append(items, g.generate(ref.Value))
+		}
+	} else {
+		for i := uint64(0); i < count; i++ {
+			items = append(items, "example"+strconv.FormatUint(i, 10))
+		}
+	}
+	return items
+}
+
+func exampleString(schema *openapi3.Schema) string {
+	var value string
+	switch schema.Format {
+	case "date":
+		value = "2020-01-02"
+	case "date-time":
+		value = "2020-01-02T03:04:05Z"
+	case "time":
+		value = "03:04:05"
+	case "email":
+		value = "user@example.com"
+	case "uuid":
+		value = "00000000-0000-4000-8000-000000000000"
+	case "uri", "url":
+		value = "https://example.com"
+	case "hostname":
+		value = "example.com"
+	case "ipv4":
+		value = "192.0.2.1"
+	case "ipv6":
+		value = "2001:db8::1"
+	case "byte":
+		value = "ZXhhbXBsZQ=="
+	case "password":
+		value = "secret"
+	default:
+		value = "example"
+	}
+	if n := schema.MinLength; uint64(len(value)) < n {
+		value += strings.Repeat("x", int(n)-len(value))
+	}
+	if n := schema.MaxLength; n != nil && uint64(len(value)) > *n {
+		value = value[:*n]
+	}
+	return value
+}
+
+func exampleNumber(schema *openapi3.Schema, integer bool) float64 {
+	step := 0.5
+	if integer {
+		step = 1
+	}
+	var value float64
+	switch min, max := schema.Min, schema.Max; {
+	case min != nil && max != nil:
+		value = (*min + *max) / 2
+		if integer {
+			value = math.Ceil(value)
+			if value > *max || (schema.ExclusiveMax && value == *max) {
+				value = math.Floor((*min + *max) / 2)
+			}
+		}
+	case min != nil:
+		value = *min
+		if schema.ExclusiveMin {
+			value += step
+		}
+	case max != nil:
+		value = *max
+		if schema.ExclusiveMax {
+			value -= step
+		}
+	default:
+		value = 1
+		if !integer {
+			value = 1.5
+		}
+	}
+	if integer {
+		value = math.Ceil(value)
+	}
+	if m := schema.MultipleOf; m != nil && *m > 0 {
+		value = math.Ceil(value / *m) * *m
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+package openapi3test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestExamples(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Examples
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name, kind]
+      properties:
+        id: {type: integer, format: int64, minimum: 10, readOnly: true}
+        name: {type: string, minLength: 12, maxLength: 20}
+        kind: {type: string, enum: [dog, cat]}
+        tags:
+          type: array
+          minItems: 2
+          items: {type: string, format: email}
+        weight: {type: number, minimum: 0, exclusiveMinimum: true, multipleOf: 0.25}
+        password: {type: string, writeOnly: true}
+        parent: {$ref: '#/components/schemas/Pet'}
+        born: {type: string, format: date-time}
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: type
+        mapping:
+          doggo: '#/components/schemas/Dog'
+    Dog:
+      type: object
+      required: [type, barks]
+      properties:
+        type: {type: string}
+        barks: {type: boolean}
+    Cat:
+      type: object
+      properties:
+        type: {type: string}
+    Named:
+      allOf:
+        - $ref: '#/components/schemas/Cat'
+        - type: object
+          required: [name]
+          properties:
+            name: {type: string, example: Tom}
+`)
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+
+	for name, ref := range doc.Components.Schemas {
+		schema := ref.Value
+		request := RequestExample(schema)
+		require.NoError(t, schema.VisitJSON(request, openapi3.VisitAsRequest(), openapi3.EnableFormatValidation()), name)
+		response := ResponseExample(schema)
+		require.NoError(t, schema.VisitJSON(response, openapi3.VisitAsResponse(), openapi3.EnableFormatValidation()), name)
+	}
+
+	pet := RequestExample(doc.Components.Schemas["Pet"].Value).(map[string]interface{})
+	require.NotContains(t, pet, "id")
+	require.Contains(t, pet, "password")
+	require.Equal(t, "dog", pet["kind"])
+	require.Equal(t, 0.5, pet["weight"])
+	require.Len(t, pet["tags"], 2)
+	require.Contains(t, pet, "parent")
+	require.NotContains(t, pet["parent"], "parent")
+
+	pet = ResponseExample(doc.Components.Schemas["Pet"].Value).(map[string]interface{})
+	require.Equal(t, int64(10), pet["id"])
+	require.NotContains(t, pet, "password")
+
+	require.Equal(t, map[string]interface{}{"type": "doggo", "barks": true},
+		RequestExample(doc.Components.Schemas["Animal"].Value))
+	require.Equal(t, map[string]interface{}{"type": "example", "name": "Tom"},
+		RequestExample(doc.Components.Schemas["Named"].Value))
+}
@@ -0,0 +1,364 @@
+package openapi3test
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FuzzCase is a payload derived from a schema.
+type FuzzCase struct {
+	// Name describes how the payload was derived, e.g. "missing required property"
+	Name string
+	// Pointer is the JSON pointer of the mutated location within Value
+	Pointer string
+	Value   interface{}
+	// Valid tells whether the schema accepts Value in a request.
+	Valid bool
+}
+
+// FuzzCases returns payloads for schema: the example built by RequestExample,
+// boundary values and mutations of it (missing required properties, wrong types,
+// values out of range, too short or too long strings and arrays, ...).
+//
+// Each case is labeled valid or not by validating it against schema as a request.
+func FuzzCases(schema *openapi3.Schema) []FuzzCase {
+	example := RequestExample(schema)
+	f := &fuzzer{
+		schema: schema,
+		root:   example,
+		seen:   make(map[string]struct{}),
+	}
+	f.add("example", "", example)
+	f.mutate(nil, schema, example)
+	return f.cases
+}
+
+// FuzzCorpus returns the JSON encoding of the values of FuzzCases(schema),
+// suitable for seeding a fuzz test with testing.F.Add.
+func FuzzCorpus(schema *openapi3.Schema) ([][]byte, error) {
+	var corpus [][]byte
+	for _, fuzzCase := range FuzzCases(schema) {
+		data, err := json.Marshal(fuzzCase.Value)
+		if err != nil {
+			return nil, err
+		}
+		corpus = append(corpus, data)
+	}
+	return corpus, nil
+}
+
+type fuzzer struct {
+	schema *openapi3.Schema
+	root   interface{}
+	cases  []FuzzCase
+	// encoded values already added
+	seen map[string]struct{}
+}
+
+func (f *fuzzer) add(name, pointer string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if _, ok := f.seen[string(data)]; ok {
+		return
+	}
+	f.seen[string(data)] = struct{}{}
+	f.cases = append(f.cases, FuzzCase{
+		Name:    name,
+		Pointer: pointer,
+		Value:   value,
+		Valid:   f.schema.VisitJSON(cloneValue(value), openapi3.VisitAsRequest()) == nil,
+	})
+}
+
+// replace adds a case where the value at path is replaced.
+func (f *fuzzer) replace(name string, path []string, value interface{}) {
+	f.add(name, fuzzPointer(path), withValueAt(f.root, path, value, false))
+}
+
+// remove adds a case where the value at path is removed.
+func (f *fuzzer) remove(name string, path []string) {
+	f.add(name, fuzzPointer(path), withValueAt(f.root, path, nil, true))
+}
+
+func fuzzPointer(path []string) string {
+	var sb strings.Builder
+	for _, token := range path {
+		sb.WriteByte('/')
+		sb.WriteString(strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1))
+	}
+	return sb.String()
+}
+
+func (f *fuzzer) mutate(path []string, schema *openapi3.Schema, value interface{}) {
+	if schema == nil {
+		return
+	}
+	schemaType := effectiveType(schema)
+
+	// Wrong types
+	for _, wrong := range []interface{}{"example", 123.0, 1.5, true, map[string]interface{}{}, []interface{}{}} {
+		if !valueHasType(wrong, schemaType) {
+			f.replace("wrong type", path, wrong)
+			break
+		}
+	}
+	f.replace("null", path, nil)
+
+	if len(schema.Enum) != 0 {
+		f.replace("not in enum", path, "not-in-enum")
+	}
+
+	switch schemaType {
+	case openapi3.TypeString:
+		f.mutateString(path, schema)
+	case openapi3.TypeInteger, openapi3.TypeNumber:
+		f.mutateNumber(path, schema, schemaType == openapi3.TypeInteger)
+	case openapi3.TypeArray:
+		f.mutateArray(path, schema, value)
+	case openapi3.TypeObject:
+		f.mutateObject(path, schema, value)
+	}
+}
+
+func (f *fuzzer) mutateString(path []string, schema *openapi3.Schema) {
+	if n := schema.MinLength; n > 0 {
+		f.replace("minimum length", path, strings.Repeat("x", int(n)))
+		f.replace("too short", path, strings.Repeat("x", int(n)-1))
+	}
+	if n := schema.MaxLength; n != nil {
+		f.replace("maximum length", path, strings.Repeat("x", int(*n)))
+		f.replace("too long", path, strings.Repeat("x", int(*n)+1))
+	}
+	if schema.Format != "" {
+		f.replace("invalid format", path, "not a "+schema.Format)
+	}
+	if schema.Pattern != "" {
+		f.replace("pattern mismatch", path, "\x00")
+	}
+	f.replace("empty string", path, "")
+}
+
+func (f *fuzzer) mutateNumber(path []string, schema *openapi3.Schema, integer bool) {
+	step := 0.5
+	if integer {
+		step = 1
+	}
+	if min := schema.Min; min != nil {
+		f.replace("minimum", path, *min)
+		f.replace("below minimum", path, *min-step)
+	}
+	if max := schema.Max; max != nil {
+		f.replace("maximum", path, *max)
+		f.replace("above maximum", path, *max+step)
+	}
+	if m := schema.MultipleOf; m != nil && *m > 0 {
+		base := exampleNumber(schema, integer)
+		f.replace("not a multiple", path, base+*m/2)
+	}
+	if integer {
+		f.replace("fractional integer", path, 1.5)
+		f.replace("large integer", path, float64(math.MaxInt64))
+	}
+	f.replace("zero", path, 0.0)
+	f.replace("negative", path, -1.0)
+}
+
+func (f *fuzzer) mutateArray(path []string, schema *openapi3.Schema, value interface{}) {
+	items, _ := value.([]interface{})
+	var item interface{} = "example"
+	if len(items) != 0 {
+		item = items[0]
+	} else if ref := schema.Items; ref != nil && ref.Value != nil {
+		item = RequestExample(ref.Value)
+	}
+	repeat := func(n uint64) []interface{} {
+		values := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			values = append(values, cloneValue(item))
+		}
+		return values
+	}
+
+	f.replace("empty array", path, []interface{}{})
+	if n := schema.MinItems; n > 0 {
+		f.replace("too few items", path, repeat(n-1))
+	}
+	if n := schema.MaxItems; n != nil {
+		f.replace("maximum items", path, repeat(*n))
+		f.replace("too many items", path, repeat(*n+1))
+	}
+	if schema.UniqueItems {
+		f.replace("duplicate items", path, repeat(2))
+	}
+	if ref := schema.Items; ref != nil && ref.Value != nil && len(items) != 0 {
+		f.mutate(append(path[:len(path):len(path)], "0"), ref.Value, items[0])
+	}
+}
+
+func (f *fuzzer) mutateObject(path []string, schema *openapi3.Schema, value interface{}) {
+	object, _ := value.(map[string]interface{})
+	for _, name := range requiredProperties(schema) {
+		if _, ok := object[name]; ok {
+			f.remove("missing required property", append(path[:len(path):len(path)], name))
+		}
+	}
+	if !schema.AdditionalProperties.Allowed() {
+		f.replace("additional property", append(path[:len(path):len(path)], "unexpectedProperty"), "example")
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property := propertySchema(schema, name); property != nil {
+			f.mutate(append(path[:len(path):len(path)], name), property, object[name])
+		}
+	}
+}
+
+// effectiveType returns the type of schema, looking into allOf, oneOf and anyOf when it has none.
+func effectiveType(schema *openapi3.Schema) string {
+	if schema.Type != "" {
+		return schema.Type
+	}
+	if len(schema.Properties) != 0 {
+		return openapi3.TypeObject
+	}
+	if schema.Items != nil {
+		return openapi3.TypeArray
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		if len(refs) != 0 && refs[0] != nil && refs[0].Value != nil {
+			if t := effectiveType(refs[0].Value); t != "" {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+func requiredProperties(schema *openapi3.Schema) []string {
+	required := append([]string(nil), schema.Required...)
+	for _, ref := range schema.AllOf {
+		if ref != nil && ref.Value != nil {
+			required = append(required, requiredProperties(ref.Value)...)
+		}
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(refs) != 0 && refs[0] != nil && refs[0].Value != nil {
+			required = append(required, requiredProperties(refs[0].Value)...)
+		}
+	}
+	return required
+}
+
+// propertySchema returns the schema of the property name, looking into allOf
+// and the first alternative of oneOf and anyOf, as RequestExample does.
+func propertySchema(schema *openapi3.Schema, name string) *openapi3.Schema {
+	if ref := schema.Properties[name]; ref != nil {
+		return ref.Value
+	}
+	for _, ref := range schema.AllOf {
+		if ref != nil && ref.Value != nil {
+			if property := propertySchema(ref.Value, name); property != nil {
+				return property
+			}
+		}
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(refs) != 0 && refs[0] != nil && refs[0].Value != nil {
+			if property := propertySchema(refs[0].Value, name); property != nil {
+				return property
+			}
+		}
+	}
+	return nil
+}
+
+func valueHasType(value interface{}, schemaType string) bool {
+	switch value := value.(type) {
+	case string:
+		return schemaType == openapi3.TypeString || schemaType == ""
+	case float64:
+		return schemaType == openapi3.TypeNumber || schemaType == "" ||
+			(schemaType == openapi3.TypeInteger && value == math.Trunc(value))
+	case bool:
+		return schemaType == openapi3.TypeBoolean || schemaType == ""
+	case map[string]interface{}:
+		return schemaType == openapi3.TypeObject || schemaType == ""
+	case []interface{}:
+		return schemaType == openapi3.TypeArray || schemaType == ""
+	default:
+		return false
+	}
+}
+
+// withValueAt returns a copy of root where the value at path is replaced, or removed.
+func withValueAt(root interface{}, path []string, value interface{}, remove bool) interface{} {
+	if len(path) == 0 {
+		return cloneValue(value)
+	}
+	switch node := root.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(node)+1)
+		for k, v := range node {
+			object[k] = v
+		}
+		if len(path) == 1 {
+			if remove {
+				delete(object, path[0])
+			} else {
+				object[path[0]] = cloneValue(value)
+			}
+		} else {
+			object[path[0]] = withValueAt(node[path[0]], path[1:], value, remove)
+		}
+		return cloneValue(object)
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index >= len(node) {
+			return cloneValue(root)
+		}
+		items := append([]interface{}(nil), node...)
+		if len(path) == 1 {
+			if remove {
+				items = append(items[:index], items[index+1:]...)
+			} else {
+				items[index] = cloneValue(value)
+			}
+		} else {
+			items[index] = withValueAt(node[index], path[1:], value, remove)
+		}
+		return cloneValue(items)
+	default:
+		return cloneValue(root)
+	}
+}
+
+func cloneValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			object[k] = cloneValue(v)
+		}
+		return object
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, v := range value {
+			items[i] = cloneValue(v)
+		}
+		return items
+	default:
+		return value
+	}
+}
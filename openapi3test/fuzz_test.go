@@ -0,0 +1,85 @@
+package openapi3test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFuzzCases(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info:
+  title: Fuzz
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, quantity, items]
+      additionalProperties: false
+      properties:
+        id: {type: string, minLength: 3, maxLength: 8}
+        quantity: {type: integer, minimum: 1, maximum: 10}
+        status: {type: string, enum: [new, shipped]}
+        items:
+          type: array
+          minItems: 1
+          maxItems: 2
+          uniqueItems: true
+          items:
+            type: object
+            required: [sku]
+            properties:
+              sku: {type: string}
+`)
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	schema := doc.Components.Schemas["Order"].Value
+
+	cases := FuzzCases(schema)
+	require.Equal(t, "example", cases[0].Name)
+	require.True(t, cases[0].Valid)
+
+	byName := make(map[string]FuzzCase)
+	for _, c := range cases {
+		require.Equal(t, c.Valid, schema.VisitJSON(c.Value, openapi3.VisitAsRequest()) == nil, c.Name+" "+c.Pointer)
+		byName[c.Name+" "+c.Pointer] = c
+	}
+
+	for name, valid := range map[string]bool{
+		"missing required property /id":           false,
+		"missing required property /items":        false,
+		"additional property /unexpectedProperty": false,
+		"wrong type ":                            false,
+		"too short /id":                          false,
+		"too long /id":                           false,
+		"maximum length /id":                     true,
+		"minimum /quantity":                      true,
+		"below minimum /quantity":                false,
+		"above maximum /quantity":                false,
+		"fractional integer /quantity":           false,
+		"not in enum /status":                    false,
+		"too many items /items":                  false,
+		"empty array /items":                     false,
+		"missing required property /items/0/sku": false,
+		"wrong type /items/0/sku":                false,
+	} {
+		c, ok := byName[name]
+		require.True(t, ok, name)
+		require.Equal(t, valid, c.Valid, name)
+	}
+	require.Equal(t, map[string]interface{}{"quantity": int64(6), "status": "new", "items": []interface{}{map[string]interface{}{"sku": "example"}}},
+		byName["missing required property /id"].Value)
+
+	corpus, err := FuzzCorpus(schema)
+	require.NoError(t, err)
+	require.Len(t, corpus, len(cases))
+	var example interface{}
+	require.NoError(t, json.Unmarshal(corpus[0], &example))
+	require.Contains(t, example, "id")
+}
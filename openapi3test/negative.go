@@ -0,0 +1,280 @@
+package openapi3test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ErrorKind is the kind of validation error the request of a negative test case causes.
+type ErrorKind string
+
+const (
+	// ErrorKindMissingParameter is a required parameter missing from the request.
+	ErrorKindMissingParameter ErrorKind = "missing parameter"
+	// ErrorKindInvalidParameter is a parameter whose value does not match its schema,
+	// e.g. of a wrong type or not in its enum.
+	ErrorKindInvalidParameter ErrorKind = "invalid parameter"
+	// ErrorKindMissingBody is a required request body missing from the request.
+	ErrorKindMissingBody ErrorKind = "missing body"
+	// ErrorKindInvalidBody is a request body that does not match its schema.
+	ErrorKindInvalidBody ErrorKind = "invalid body"
+)
+
+// NegativeTestCase is a request to an operation that does not conform to the document,
+// along with the kind of validation error it causes.
+type NegativeTestCase struct {
+	// Name is the operation's method and path followed by what makes the request invalid,
+	// e.g. `GET /pets: query parameter "limit": wrong type`
+	Name string
+
+	Route      *routers.Route
+	PathParams map[string]string
+
+	// Request targets the operation's path, without the base path of any server.
+	Request *http.Request
+	Body    []byte
+
+	Kind ErrorKind
+	// Parameter is the missing or invalid parameter, nil for request body cases.
+	Parameter *openapi3.Parameter
+	// Pointer is the JSON pointer of the invalid value within the value of the parameter
+	// or of the request body, empty for the whole value.
+	Pointer string
+}
+
+// NewNegativeTestCases returns requests to operation, an operation of doc, each
+// made invalid in one way from the request of its test case (see NewTestCases):
+// a required parameter or request body is missing, or the value of a parameter
+// or of a JSON request body is mutated as FuzzCases does (wrong type, value not
+// in enum, out of range, ...).
+//
+// Each request is checked with openapi3filter.ValidateRequest: only the mutations
+// that the validator rejects because of the mutated parameter or body are returned,
+// labeled with the kind of the error.
+func NewNegativeTestCases(doc *openapi3.T, operation *openapi3.OperationInfo) ([]*NegativeTestCase, error) {
+	g := &negativeGenerator{
+		doc:       doc,
+		operation: operation,
+		inputs:    exampleInputs(operation),
+		seen:      make(map[string]struct{}),
+	}
+	for i, input := range g.inputs.parameters {
+		if err := g.parameterCases(i, input); err != nil {
+			return nil, fmt.Errorf("%s %s: parameter %q: %w", operation.Method, operation.Path, input.parameter.Name, err)
+		}
+	}
+	if err := g.bodyCases(); err != nil {
+		return nil, fmt.Errorf("%s %s: request body: %w", operation.Method, operation.Path, err)
+	}
+	return g.cases, nil
+}
+
+// RunNegativeTestCases runs the negative test cases of each operation of doc against handler,
+// each in a subtest named after it.
+func RunNegativeTestCases(t *testing.T, doc *openapi3.T, handler http.Handler) {
+	t.Helper()
+	for _, operation := range doc.Operations() {
+		testCases, err := NewNegativeTestCases(doc, operation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, testCase := range testCases {
+			testCase := testCase
+			t.Run(testCase.Name, func(t *testing.T) {
+				testCase.Run(t, handler)
+			})
+		}
+	}
+}
+
+// Run sends the request of the test case to handler and reports an error
+// if the response status is not a client error (4XX), or if the response
+// has a documented status but does not conform to the document.
+func (testCase *NegativeTestCase) Run(t testing.TB, handler http.Handler) {
+	t.Helper()
+	ctx := context.Background()
+	req := testCase.request(ctx)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code < 400 || rec.Code >= 500 {
+		t.Errorf("%s: got status %d, expected a client error", testCase.Name, rec.Code)
+		return
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: testCase.PathParams,
+			Route:      testCase.Route,
+		},
+		Status: rec.Code,
+		Header: rec.Header(),
+	}
+	input.SetBodyBytes(rec.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		t.Errorf("%s: response does not conform to the document:\n%s", testCase.Name, DescribeValidationError(err))
+	}
+}
+
+// Matches reports whether err, as returned by openapi3filter.ValidateRequest,
+// is the validation error the request of the test case causes.
+func (testCase *NegativeTestCase) Matches(err error) bool {
+	kind, parameter, ok := classifyRequestError(err)
+	return ok && kind == testCase.Kind && parameter == testCase.Parameter
+}
+
+func (testCase *NegativeTestCase) request(ctx context.Context) *http.Request {
+	req := testCase.Request.Clone(ctx)
+	req.Body = ioutil.NopCloser(bytes.NewReader(testCase.Body))
+	return req
+}
+
+// classifyRequestError returns the kind of a request validation error and its parameter.
+func classifyRequestError(err error) (ErrorKind, *openapi3.Parameter, bool) {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return "", nil, false
+	}
+	missing := errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired)
+	switch {
+	case requestErr.Parameter != nil && missing:
+		return ErrorKindMissingParameter, requestErr.Parameter, true
+	case requestErr.Parameter != nil:
+		return ErrorKindInvalidParameter, requestErr.Parameter, true
+	case requestErr.RequestBody != nil && missing:
+		return ErrorKindMissingBody, nil, true
+	case requestErr.RequestBody != nil:
+		return ErrorKindInvalidBody, nil, true
+	default:
+		return "", nil, false
+	}
+}
+
+type negativeGenerator struct {
+	doc       *openapi3.T
+	operation *openapi3.OperationInfo
+	inputs    *requestInputs
+	cases     []*NegativeTestCase
+	// requests already added, by name of the invalid part and encoding
+	seen map[string]struct{}
+}
+
+func (g *negativeGenerator) parameterCases(i int, input parameterInput) error {
+	parameter := input.parameter
+	location := fmt.Sprintf("%s parameter %q", parameter.In, parameter.Name)
+
+	if parameter.Required && parameter.In != openapi3.ParameterInPath {
+		inputs := *g.inputs
+		inputs.parameters = append(append([]parameterInput(nil), g.inputs.parameters[:i]...), g.inputs.parameters[i+1:]...)
+		if err := g.add(location+": missing", ErrorKindMissingParameter, parameter, "", &inputs); err != nil {
+			return err
+		}
+	}
+
+	if parameter.Schema == nil || parameter.Schema.Value == nil {
+		return nil
+	}
+	for _, fuzzCase := range FuzzCases(parameter.Schema.Value) {
+		if fuzzCase.Valid {
+			continue
+		}
+		if parameter.In == openapi3.ParameterInPath && formatParameterValue(fuzzCase.Value) == "" {
+			// Requests with an empty path segment do not target the operation
+			continue
+		}
+		inputs := *g.inputs
+		inputs.parameters = append([]parameterInput(nil), g.inputs.parameters...)
+		inputs.parameters[i].value = fuzzCase.Value
+		name := location + ": " + fuzzCase.Name
+		if fuzzCase.Pointer != "" {
+			name += " at " + fuzzCase.Pointer
+		}
+		if err := g.add(name, ErrorKindInvalidParameter, parameter, fuzzCase.Pointer, &inputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *negativeGenerator) bodyCases() error {
+	ref := g.operation.Operation.RequestBody
+	if g.inputs.mediaType == "" {
+		return nil
+	}
+
+	if ref.Value.Required {
+		inputs := *g.inputs
+		inputs.mediaType, inputs.body = "", nil
+		if err := g.add("request body: missing", ErrorKindMissingBody, nil, "", &inputs); err != nil {
+			return err
+		}
+	}
+
+	mediaType := ref.Value.Content[g.inputs.mediaType]
+	if !isJSONMediaType(concreteMediaType(g.inputs.mediaType)) || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return nil
+	}
+	for _, fuzzCase := range FuzzCases(mediaType.Schema.Value) {
+		if fuzzCase.Valid {
+			continue
+		}
+		inputs := *g.inputs
+		inputs.body = fuzzCase.Value
+		name := "request body: " + fuzzCase.Name
+		if fuzzCase.Pointer != "" {
+			name += " at " + fuzzCase.Pointer
+		}
+		if err := g.add(name, ErrorKindInvalidBody, nil, fuzzCase.Pointer, &inputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds a test case for inputs if the request they make is rejected with an error of kind
+// because of parameter, or of the request body when parameter is nil.
+func (g *negativeGenerator) add(name string, kind ErrorKind, parameter *openapi3.Parameter, pointer string, inputs *requestInputs) error {
+	testCase, err := buildTestCase(g.doc, g.operation, inputs)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s %p %s %v\n%s", kind, parameter, testCase.Request.URL.RequestURI(), testCase.Request.Header, testCase.Body)
+	if _, ok := g.seen[key]; ok {
+		return nil
+	}
+	g.seen[key] = struct{}{}
+
+	negative := &NegativeTestCase{
+		Name:       testCase.Name + ": " + name,
+		Route:      testCase.Route,
+		PathParams: testCase.PathParams,
+		Request:    testCase.Request,
+		Body:       testCase.Body,
+		Kind:       kind,
+		Parameter:  parameter,
+		Pointer:    pointer,
+	}
+	ctx := context.Background()
+	err = openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+		Request:    negative.request(ctx),
+		PathParams: negative.PathParams,
+		Route:      negative.Route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	})
+	if negative.Matches(err) {
+		g.cases = append(g.cases, negative)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package openapi3test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestNewNegativeTestCases(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(operationsSpec))
+	require.NoError(t, err)
+	operations := doc.Operations()
+	require.Equal(t, "/pets", operations[0].Path)
+	require.Equal(t, http.MethodGet, operations[0].Method)
+
+	get, err := NewNegativeTestCases(doc, operations[0])
+	require.NoError(t, err)
+	byName := make(map[string]*NegativeTestCase, len(get))
+	for _, testCase := range get {
+		byName[testCase.Name] = testCase
+	}
+
+	missing := byName[`GET /pets: query parameter "limit": missing`]
+	require.NotNil(t, missing)
+	require.Equal(t, ErrorKindMissingParameter, missing.Kind)
+	require.Equal(t, "limit", missing.Parameter.Name)
+	require.Equal(t, "/pets?tags=example&tags=example", missing.Request.URL.RequestURI())
+	require.Equal(t, "abc", missing.Request.Header.Get("X-Request-Id"))
+
+	wrongType := byName[`GET /pets: query parameter "limit": wrong type`]
+	require.NotNil(t, wrongType)
+	require.Equal(t, ErrorKindInvalidParameter, wrongType.Kind)
+	require.Equal(t, "/pets?limit=example&tags=example&tags=example", wrongType.Request.URL.RequestURI())
+
+	aboveMaximum := byName[`GET /pets: query parameter "limit": above maximum`]
+	require.NotNil(t, aboveMaximum)
+	require.Equal(t, "/pets?limit=101&tags=example&tags=example", aboveMaximum.Request.URL.RequestURI())
+
+	require.NotNil(t, byName[`GET /pets: header parameter "X-Request-Id": missing`])
+	// The optional parameter is not set, nor mutated
+	for name := range byName {
+		require.NotContains(t, name, "optional")
+	}
+
+	post, err := NewNegativeTestCases(doc, operations[1])
+	require.NoError(t, err)
+	require.Equal(t, "POST /pets: request body: missing", post[0].Name)
+	require.Equal(t, ErrorKindMissingBody, post[0].Kind)
+	require.Nil(t, post[0].Parameter)
+	require.Empty(t, post[0].Body)
+	var missingProperty *NegativeTestCase
+	for _, testCase := range post[1:] {
+		require.Equal(t, ErrorKindInvalidBody, testCase.Kind)
+		if testCase.Name == "POST /pets: request body: missing required property at /name" {
+			missingProperty = testCase
+		}
+	}
+	require.NotNil(t, missingProperty)
+	require.Equal(t, "/name", missingProperty.Pointer)
+	require.JSONEq(t, `{}`, string(missingProperty.Body))
+
+	// Cases match the errors of the validator
+	ctx := context.Background()
+	err = openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+		Request:    missingProperty.request(ctx),
+		PathParams: missingProperty.PathParams,
+		Route:      missingProperty.Route,
+	})
+	require.Error(t, err)
+	require.True(t, missingProperty.Matches(err))
+	require.False(t, missing.Matches(err))
+	require.False(t, missing.Matches(nil))
+}
+
+func TestRunNegativeTestCases(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(operationsSpec))
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	RunNegativeTestCases(t, doc, openapi3filter.NewValidator(router).Middleware(ok))
+
+	// A handler accepting invalid requests fails the test cases
+	testCases, err := NewNegativeTestCases(doc, doc.Operations()[1])
+	require.NoError(t, err)
+	rt := &recordingT{TB: t}
+	for _, testCase := range testCases {
+		testCase.Run(rt, ok)
+	}
+	require.Len(t, rt.errors, len(testCases))
+	require.Equal(t, "POST /pets: request body: missing: got status 201, expected a client error", rt.errors[0])
+}
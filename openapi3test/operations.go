@@ -0,0 +1,472 @@
+package openapi3test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// TestCase is a request to an operation built from the examples and schemas
+// of the document, along with the statuses its response is expected to have.
+type TestCase struct {
+	// Name is the operation's method and path, e.g. "GET /pets/{id}"
+	Name string
+
+	Route      *routers.Route
+	PathParams map[string]string
+
+	// Request targets the operation's path, without the base path of any server.
+	// Headers may be added to it before running the test case, e.g. for authentication.
+	Request *http.Request
+	Body    []byte
+
+	// ExpectedStatus lists the documented success statuses (e.g. "200", "2XX"),
+	// or else all documented statuses. It is empty when any status is expected.
+	ExpectedStatus []string
+}
+
+// NewTestCases returns a test case for each operation of doc, sorted by path then method.
+//
+// Required parameters and request bodies are set from the examples given in the
+// document or, when there are none, from values built by RequestExample.
+// JSON content is used for request bodies when the operation accepts it.
+func NewTestCases(doc *openapi3.T) ([]*TestCase, error) {
+	var testCases []*TestCase
+	for _, operation := range doc.Operations() {
+		testCase, err := newTestCase(doc, operation)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", operation.Method, operation.Path, err)
+		}
+		testCases = append(testCases, testCase)
+	}
+	return testCases, nil
+}
+
+func newTestCase(doc *openapi3.T, operation *openapi3.OperationInfo) (*TestCase, error) {
+	return buildTestCase(doc, operation, exampleInputs(operation))
+}
+
+// requestInputs are the values the request of a test case is built from.
+type requestInputs struct {
+	parameters []parameterInput
+	// mediaType is that of the body, empty when the request has no body
+	mediaType string
+	body      interface{}
+}
+
+type parameterInput struct {
+	parameter *openapi3.Parameter
+	value     interface{}
+}
+
+// exampleInputs returns the required parameters of operation and its request body
+// set from examples.
+func exampleInputs(operation *openapi3.OperationInfo) *requestInputs {
+	inputs := &requestInputs{}
+	for _, ref := range operation.Parameters {
+		parameter := ref.Value
+		if parameter == nil || !(parameter.Required || parameter.In == openapi3.ParameterInPath) {
+			continue
+		}
+		inputs.parameters = append(inputs.parameters, parameterInput{parameter: parameter, value: parameterExample(parameter)})
+	}
+	if ref := operation.Operation.RequestBody; ref != nil && ref.Value != nil && len(ref.Value.Content) != 0 {
+		var mediaType *openapi3.MediaType
+		inputs.mediaType, mediaType = pickMediaType(ref.Value.Content)
+		inputs.body = mediaTypeExample(mediaType)
+	}
+	return inputs
+}
+
+func buildTestCase(doc *openapi3.T, operation *openapi3.OperationInfo, inputs *requestInputs) (*TestCase, error) {
+	testCase := &TestCase{
+		Name: operation.Method + " " + operation.Path,
+		Route: &routers.Route{
+			Spec:      doc,
+			Path:      operation.Path,
+			PathItem:  operation.PathItem,
+			Method:    operation.Method,
+			Operation: operation.Operation,
+		},
+		PathParams: make(map[string]string),
+	}
+	if len(operation.Servers) != 0 {
+		testCase.Route.Server = operation.Servers[0]
+	}
+
+	path := operation.Path
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+	for _, input := range inputs.parameters {
+		parameter := input.parameter
+		values, err := parameterValues(parameter, input.value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", parameter.Name, err)
+		}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			value := values.Get(parameter.Name)
+			testCase.PathParams[parameter.Name] = value
+			path = strings.Replace(path, "{"+parameter.Name+"}", url.PathEscape(value), -1)
+		case openapi3.ParameterInQuery:
+			for name, v := range values {
+				query[name] = append(query[name], v...)
+			}
+		case openapi3.ParameterInHeader:
+			header.Set(parameter.Name, values.Get(parameter.Name))
+		case openapi3.ParameterInCookie:
+			cookies = append(cookies, &http.Cookie{Name: parameter.Name, Value: values.Get(parameter.Name)})
+		}
+	}
+	target := path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+
+	var contentType string
+	if inputs.mediaType != "" {
+		var err error
+		if testCase.Body, contentType, err = encodeExample(inputs.mediaType, inputs.body); err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+	}
+
+	req := httptest.NewRequest(operation.Method, target, bytes.NewReader(testCase.Body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	testCase.Request = req
+
+	testCase.ExpectedStatus = expectedStatus(operation.Operation.Responses)
+	return testCase, nil
+}
+
+// RunTestCases runs the test cases of doc against handler, each in a subtest named after it.
+func RunTestCases(t *testing.T, doc *openapi3.T, handler http.Handler) {
+	t.Helper()
+	testCases, err := NewTestCases(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			testCase.Run(t, handler)
+		})
+	}
+}
+
+// Run sends the request of the test case to handler and reports an error
+// if the response status is not expected or if the response does not conform
+// to the document.
+func (testCase *TestCase) Run(t testing.TB, handler http.Handler) {
+	t.Helper()
+	ctx := context.Background()
+	req := testCase.Request.Clone(ctx)
+	req.Body = ioutil.NopCloser(bytes.NewReader(testCase.Body))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !testCase.expects(rec.Code) {
+		t.Errorf("%s: got status %d, expected one of %s", testCase.Name, rec.Code, strings.Join(testCase.ExpectedStatus, ", "))
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: testCase.PathParams,
+			Route:      testCase.Route,
+		},
+		Status:  rec.Code,
+		Header:  rec.Header(),
+		Options: &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	input.SetBodyBytes(rec.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		t.Errorf("%s: response does not conform to the document:\n%s", testCase.Name, DescribeValidationError(err))
+	}
+}
+
+func (testCase *TestCase) expects(status int) bool {
+	if len(testCase.ExpectedStatus) == 0 {
+		return true
+	}
+	code := strconv.Itoa(status)
+	for _, expected := range testCase.ExpectedStatus {
+		if expected == code || (len(expected) == 3 && strings.ToUpper(expected[1:]) == "XX" && expected[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func expectedStatus(responses openapi3.Responses) []string {
+	var success, documented []string
+	for status := range responses {
+		if status == "default" {
+			continue
+		}
+		documented = append(documented, status)
+		if strings.HasPrefix(status, "2") {
+			success = append(success, status)
+		}
+	}
+	if len(success) != 0 {
+		documented = success
+	} else if _, ok := responses["default"]; ok {
+		return nil
+	}
+	sort.Strings(documented)
+	return documented
+}
+
+func parameterExample(parameter *openapi3.Parameter) interface{} {
+	if parameter.Example != nil {
+		return parameter.Example
+	}
+	if value := firstExample(parameter.Examples); value != nil {
+		return value
+	}
+	if parameter.Schema != nil {
+		return RequestExample(parameter.Schema.Value)
+	}
+	if len(parameter.Content) != 0 {
+		_, mediaType := pickMediaType(parameter.Content)
+		return mediaTypeExample(mediaType)
+	}
+	return nil
+}
+
+func mediaTypeExample(mediaType *openapi3.MediaType) interface{} {
+	if mediaType.Example != nil {
+		return mediaType.Example
+	}
+	if value := firstExample(mediaType.Examples); value != nil {
+		return value
+	}
+	if mediaType.Schema != nil {
+		return RequestExample(mediaType.Schema.Value)
+	}
+	return nil
+}
+
+func firstExample(examples openapi3.Examples) interface{} {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ref := examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value
+		}
+	}
+	return nil
+}
+
+// pickMediaType returns the JSON media type of content if any, otherwise its first one.
+func pickMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if isJSONMediaType(name) {
+			return name, content[name]
+		}
+	}
+	return names[0], content[names[0]]
+}
+
+func isJSONMediaType(name string) bool {
+	name = strings.TrimSpace(strings.SplitN(name, ";", 2)[0])
+	return name == "application/json" || strings.HasSuffix(name, "+json")
+}
+
+// parameterValues serializes value according to the style of parameter, by name.
+func parameterValues(parameter *openapi3.Parameter, value interface{}) (url.Values, error) {
+	name := parameter.Name
+	if len(parameter.Content) != 0 {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return url.Values{name: {string(data)}}, nil
+	}
+
+	sm, err := parameter.SerializationMethod()
+	if err != nil {
+		return nil, err
+	}
+	separator := ","
+	switch sm.Style {
+	case openapi3.SerializationSpaceDelimited:
+		separator = " "
+	case openapi3.SerializationPipeDelimited:
+		separator = "|"
+	}
+	prefix := ""
+	switch sm.Style {
+	case openapi3.SerializationLabel:
+		prefix = "."
+		if sm.Explode {
+			separator = "."
+		}
+	case openapi3.SerializationMatrix:
+		prefix = ";" + name + "="
+	}
+
+	values := url.Values{}
+	switch value := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, formatParameterValue(item))
+		}
+		if sm.Style == openapi3.SerializationForm && sm.Explode {
+			values[name] = items
+		} else {
+			values.Set(name, prefix+strings.Join(items, separator))
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var pairs []string
+		for _, key := range keys {
+			v := formatParameterValue(value[key])
+			switch {
+			case sm.Style == openapi3.SerializationDeepObject:
+				values.Set(name+"["+key+"]", v)
+			case sm.Style == openapi3.SerializationForm && sm.Explode:
+				values.Set(key, v)
+			case sm.Explode:
+				pairs = append(pairs, key+"="+v)
+			default:
+				pairs = append(pairs, key, v)
+			}
+		}
+		if pairs != nil {
+			values.Set(name, prefix+strings.Join(pairs, separator))
+		}
+	default:
+		values.Set(name, prefix+formatParameterValue(value))
+	}
+	return values, nil
+}
+
+func formatParameterValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(data)
+	}
+}
+
+// encodeExample returns the body encoding value as mediaType, along with its content type.
+func encodeExample(mediaType string, value interface{}) ([]byte, string, error) {
+	contentType := concreteMediaType(mediaType)
+	name := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	switch {
+	case isJSONMediaType(name):
+		data, err := json.Marshal(value)
+		return data, contentType, err
+	case name == "application/x-www-form-urlencoded":
+		form := url.Values{}
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, v := range object {
+				if items, ok := v.([]interface{}); ok {
+					for _, item := range items {
+						form.Add(key, formatParameterValue(item))
+					}
+					continue
+				}
+				form.Set(key, formatParameterValue(v))
+			}
+		}
+		return []byte(form.Encode()), contentType, nil
+	case name == "multipart/form-data":
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		if object, ok := value.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(object))
+			for key := range object {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := writer.WriteField(key, formatParameterValue(object[key])); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), writer.FormDataContentType(), nil
+	default:
+		switch value := value.(type) {
+		case string:
+			return []byte(value), contentType, nil
+		case []byte:
+			return value, contentType, nil
+		}
+		if strings.HasPrefix(name, "text/") {
+			return []byte(formatParameterValue(value)), contentType, nil
+		}
+		data, err := json.Marshal(value)
+		return data, contentType, err
+	}
+}
+
+// concreteMediaType replaces the wildcards of a media type range.
+func concreteMediaType(mediaType string) string {
+	switch {
+	case mediaType == "*/*" || mediaType == "application/*":
+		return "application/json"
+	case mediaType == "text/*":
+		return "text/plain"
+	case strings.HasSuffix(mediaType, "/*"):
+		return strings.TrimSuffix(mediaType, "*") + "octet-stream"
+	default:
+		return mediaType
+	}
+}
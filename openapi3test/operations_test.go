@@ -0,0 +1,160 @@
+package openapi3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const operationsSpec = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema: {type: integer, minimum: 1, maximum: 100}
+        - name: tags
+          in: query
+          required: true
+          schema: {type: array, items: {type: string}, minItems: 2}
+        - name: X-Request-Id
+          in: header
+          required: true
+          example: abc
+          schema: {type: string}
+        - name: optional
+          in: query
+          schema: {type: string}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: '#/components/schemas/Pet'}
+    post:
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema: {$ref: '#/components/schemas/Pet'}
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        '201':
+          description: created
+        '400':
+          description: bad request
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema: {type: string, format: uuid}
+    delete:
+      responses:
+        default:
+          description: any
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string}
+`
+
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewTestCases(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(operationsSpec))
+	require.NoError(t, err)
+
+	testCases, err := NewTestCases(doc)
+	require.NoError(t, err)
+	require.Len(t, testCases, 3)
+
+	get := testCases[0]
+	require.Equal(t, "GET /pets", get.Name)
+	require.Equal(t, "/pets?limit=51&tags=example&tags=example", get.Request.URL.RequestURI())
+	require.Equal(t, "abc", get.Request.Header.Get("X-Request-Id"))
+	require.Equal(t, []string{"200"}, get.ExpectedStatus)
+
+	post := testCases[1]
+	require.Equal(t, "POST /pets", post.Name)
+	require.Equal(t, "application/json", post.Request.Header.Get("Content-Type"))
+	require.JSONEq(t, `{"name":"example"}`, string(post.Body))
+	require.Equal(t, []string{"201"}, post.ExpectedStatus)
+
+	del := testCases[2]
+	require.Equal(t, "DELETE /pets/{id}", del.Name)
+	require.Equal(t, "/pets/00000000-0000-4000-8000-000000000000", del.Request.URL.Path)
+	require.Equal(t, map[string]string{"id": "00000000-0000-4000-8000-000000000000"}, del.PathParams)
+	require.Empty(t, del.ExpectedStatus)
+}
+
+func TestRunTestCases(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(operationsSpec))
+	require.NoError(t, err)
+
+	handler := func(broken bool) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				w.Header().Set("Content-Type", "application/json")
+				pet := map[string]interface{}{"id": 1, "name": "Rex"}
+				if broken {
+					pet["name"] = 42
+				}
+				require.NoError(t, json.NewEncoder(w).Encode([]interface{}{pet}))
+			case http.MethodPost:
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"name":"example"}`, string(body))
+				if broken {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+	}
+
+	RunTestCases(t, doc, handler(false))
+
+	testCases, err := NewTestCases(doc)
+	require.NoError(t, err)
+	rt := &recordingT{TB: t}
+	for _, testCase := range testCases {
+		testCase.Run(rt, handler(true))
+	}
+	require.Len(t, rt.errors, 3)
+	require.Contains(t, rt.errors[0], "GET /pets: response does not conform to the document")
+	require.Contains(t, rt.errors[1], "POST /pets: got status 200, expected one of 201")
+	require.Contains(t, rt.errors[2], "POST /pets: response does not conform to the document")
+}
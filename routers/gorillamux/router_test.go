@@ -295,6 +295,54 @@ func TestServerOverrideAtPathLevel(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRouterOfView(t *testing.T) {
+	helloGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	helloDELETE := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info: &openapi3.Info{
+			Title:   "rel",
+			Version: "1",
+		},
+		Servers: openapi3.Servers{
+			&openapi3.Server{
+				URL: "https://example.com",
+			},
+		},
+		Paths: openapi3.Paths{
+			"/hello": &openapi3.PathItem{
+				Get:    helloGET,
+				Delete: helloDELETE,
+			},
+		},
+	}
+	err := doc.Validate(context.Background())
+	require.NoError(t, err)
+	router, err := NewRouter(doc.View(openapi3.Overrides{
+		Servers: openapi3.Servers{&openapi3.Server{URL: "https://tenant.example.com"}},
+		OperationEnabled: func(path, method string, operation *openapi3.Operation) bool {
+			return method != http.MethodDelete
+		},
+	}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://tenant.example.com/hello", nil)
+	require.NoError(t, err)
+	route, _, err := router.FindRoute(req)
+	require.NoError(t, err)
+	require.Same(t, helloGET, route.Operation)
+
+	req, err = http.NewRequest(http.MethodDelete, "https://tenant.example.com/hello", nil)
+	require.NoError(t, err)
+	_, _, err = router.FindRoute(req)
+	require.Equal(t, routers.ErrMethodNotAllowed, err)
+
+	req, err = http.NewRequest(http.MethodGet, "https://example.com/hello", nil)
+	require.NoError(t, err)
+	_, _, err = router.FindRoute(req)
+	require.Equal(t, routers.ErrPathNotFound, err)
+}
+
 func TestRelativeURL(t *testing.T) {
 	helloGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
 	doc := &openapi3.T{
@@ -81,7 +81,7 @@ func Example() {
 	p, err := json.Marshal(map[string]interface{}{
 		"pet_type": "Cat",
 		"breed":    "Dingo",
-		"bark":     true,
+		"age":      "three",
 	})
 	if err != nil {
 		panic(err)
@@ -107,6 +107,6 @@ func Example() {
 		fmt.Println(err)
 	}
 	// Output:
-	// request body has an error: doesn't match schema: input matches more than one oneOf schemas
+	// request body has an error: doesn't match schema: Error at "/age": field must be set to integer or not be present
 
 }
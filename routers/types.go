@@ -28,6 +28,46 @@ type Route struct {
 	Operation *openapi3.Operation
 }
 
+// SecurityRequirements returns the security requirements of the operation of the route,
+// or those of the document when the operation does not declare any.
+// Requests satisfying any one of them are authorized: no requirement means
+// the operation is not secured.
+func (route *Route) SecurityRequirements() openapi3.SecurityRequirements {
+	if operation := route.Operation; operation != nil && operation.Security != nil {
+		return *operation.Security
+	}
+	if route.Spec == nil {
+		return nil
+	}
+	return route.Spec.Security
+}
+
+// SecuritySchemes returns the security schemes of the document named by
+// the SecurityRequirements of the route, keyed by name.
+// Names without a security scheme in the components of the document are left out.
+func (route *Route) SecuritySchemes() map[string]*openapi3.SecurityScheme {
+	requirements := route.SecurityRequirements()
+	if len(requirements) == 0 || route.Spec == nil {
+		return nil
+	}
+	schemes := make(map[string]*openapi3.SecurityScheme)
+	for _, requirement := range requirements {
+		for name := range requirement {
+			if ref := route.Spec.Components.SecuritySchemes[name]; ref != nil && ref.Value != nil {
+				schemes[name] = ref.Value
+			}
+		}
+	}
+	return schemes
+}
+
+// Idempotency returns the idempotency of the operation of the route,
+// so that clients can tell whether its requests can be retried.
+// See openapi3.Operation.Idempotency.
+func (route *Route) Idempotency() openapi3.Idempotency {
+	return route.Operation.Idempotency(route.Method)
+}
+
 // ErrPathNotFound is returned when no route match is found
 var ErrPathNotFound error = &RouteError{"no matching operation was found"}
 
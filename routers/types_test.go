@@ -0,0 +1,111 @@
+package routers_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+func TestRouteSecurity(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: Pets, version: v1}
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        '200': {description: Pets.}
+    post:
+      security:
+        - oauth: [write]
+        - apiKey: []
+          basic: []
+      responses:
+        '201': {description: Created.}
+  /health:
+    get:
+      security: []
+      responses:
+        '200': {description: Healthy.}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+    basic: {type: http, scheme: basic}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {write: Write pets.}
+`)
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	schemes := doc.Components.SecuritySchemes
+
+	findRoute := func(method, path string) *routers.Route {
+		route, _, err := router.FindRoute(httptest.NewRequest(method, path, nil))
+		require.NoError(t, err)
+		return route
+	}
+
+	route := findRoute("GET", "/pets")
+	require.Equal(t, doc.Security, route.SecurityRequirements())
+	require.Equal(t, map[string]*openapi3.SecurityScheme{"apiKey": schemes["apiKey"].Value}, route.SecuritySchemes())
+
+	route = findRoute("POST", "/pets")
+	require.Equal(t, openapi3.SecurityRequirements{
+		{"oauth": {"write"}},
+		{"apiKey": {}, "basic": {}},
+	}, route.SecurityRequirements())
+	require.Equal(t, map[string]*openapi3.SecurityScheme{
+		"oauth":  schemes["oauth"].Value,
+		"apiKey": schemes["apiKey"].Value,
+		"basic":  schemes["basic"].Value,
+	}, route.SecuritySchemes())
+
+	route = findRoute("GET", "/health")
+	require.Empty(t, route.SecurityRequirements())
+	require.Nil(t, route.SecuritySchemes())
+}
+
+func TestRouteIdempotency(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: Orders, version: v1}
+paths:
+  /orders:
+    get:
+      responses:
+        '200': {description: Orders.}
+    post:
+      x-idempotency-key-header: Idempotency-Key
+      responses:
+        '201': {description: Created.}
+`)
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	route, _, err := router.FindRoute(req)
+	require.NoError(t, err)
+	require.Equal(t, openapi3.Idempotency{Safe: true, Idempotent: true}, route.Idempotency())
+	require.True(t, route.Idempotency().Retryable(req.Header))
+
+	req = httptest.NewRequest("POST", "/orders", nil)
+	route, _, err = router.FindRoute(req)
+	require.NoError(t, err)
+	require.False(t, route.Idempotency().Retryable(req.Header))
+	req.Header.Set("Idempotency-Key", "8e03978e")
+	require.True(t, route.Idempotency().Retryable(req.Header))
+}